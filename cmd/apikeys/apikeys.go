@@ -0,0 +1,18 @@
+package apikeys
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewAPIKeysCmd creates the apikeys command group
+func NewAPIKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikeys",
+		Short: "API key commands",
+		Long:  `Commands for inspecting the API keys of your Blimu workspace`,
+	}
+
+	cmd.AddCommand(NewListCmd())
+
+	return cmd
+}
@@ -0,0 +1,194 @@
+package apikeys
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// ListCommand represents the list API keys command
+type ListCommand struct {
+	WorkspaceID     string
+	EnvironmentID   string
+	AllEnvironments bool
+}
+
+// NewListCmd creates the list command
+func NewListCmd() *cobra.Command {
+	cmd := &ListCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API keys",
+		Long: `List the API keys of an environment. Keys are never shown, only their names and IDs.
+
+API keys are stored per workspace; with --all-environments every key in the workspace is
+listed, grouped by environment. Inactive keys are flagged.
+
+Examples:
+  # Keys of the current environment
+  blimu apikeys list
+
+  # Keys of every environment in the workspace
+  blimu apikeys list --all-environments`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.AllEnvironments, "all-environments", false, "List keys of every environment in the workspace")
+
+	return cobraCmd
+}
+
+// apiKey is an entry of the API key list
+type apiKey struct {
+	ID            string
+	Name          string
+	EnvironmentID string
+	Active        bool
+	CreatedAt     string
+}
+
+// Run executes the list API keys command
+func (c *ListCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	c.WorkspaceID, c.EnvironmentID = sc.IDs()
+	if c.WorkspaceID == "" {
+		return fmt.Errorf("workspace-id is required. Provide --workspace-id or select an environment with 'blimu env switch'")
+	}
+	if !c.AllEnvironments && c.EnvironmentID == "" {
+		return fmt.Errorf("environment-id is required. Provide --environment-id, or use --all-environments to list the whole workspace")
+	}
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.ApiKeys.List(c.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	// Group keys by environment; the list is workspace-scoped
+	groups := map[string][]apiKey{}
+	for _, data := range result.Data {
+		key := parseAPIKey(data)
+		if !c.AllEnvironments && key.EnvironmentID != c.EnvironmentID {
+			continue
+		}
+		groups[key.EnvironmentID] = append(groups[key.EnvironmentID], key)
+	}
+
+	if len(groups) == 0 {
+		if c.AllEnvironments {
+			fmt.Printf("No API keys found in workspace %s.\n", c.WorkspaceID)
+		} else {
+			fmt.Printf("No API keys found in environment %s.\n", c.EnvironmentID)
+		}
+		return nil
+	}
+
+	names := map[string]string{}
+	if c.AllEnvironments {
+		names = c.environmentNames(client)
+	}
+
+	envIDs := make([]string, 0, len(groups))
+	for envID := range groups {
+		envIDs = append(envIDs, envID)
+	}
+	sort.Slice(envIDs, func(i, j int) bool {
+		return environmentLabel(envIDs[i], names) < environmentLabel(envIDs[j], names)
+	})
+
+	total, inactive := 0, 0
+	for _, envID := range envIDs {
+		keys := groups[envID]
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+		fmt.Printf("\n🌍 %s\n", environmentLabel(envID, names))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tID\tSTATUS\tCREATED")
+		for _, key := range keys {
+			status := "active"
+			if !key.Active {
+				status = "⚠️  inactive"
+				inactive++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", key.Name, key.ID, status, key.CreatedAt)
+		}
+		w.Flush()
+		total += len(keys)
+	}
+
+	fmt.Printf("\n📊 %d API key(s) in %d environment(s)", total, len(groups))
+	if inactive > 0 {
+		fmt.Printf(", %d inactive", inactive)
+	}
+	fmt.Printf("\n")
+
+	return nil
+}
+
+// environmentNames maps the workspace's environment IDs to their names; names are only used for
+// display, so a failed lookup falls back to IDs
+func (c *ListCommand) environmentNames(client *blimu.Client) map[string]string {
+	names := map[string]string{}
+	environments, err := client.Environments.List(c.WorkspaceID, nil)
+	if err != nil {
+		fmt.Printf("⚠️  Could not fetch environment names: %v\n", err)
+		return names
+	}
+	for _, env := range environments.Data {
+		names[getStringFromMap(env, "id")] = getStringFromMap(env, "name")
+	}
+	return names
+}
+
+// environmentLabel names an environment group for display
+func environmentLabel(envID string, names map[string]string) string {
+	if envID == "" {
+		return "(no environment)"
+	}
+	if name := names[envID]; name != "" {
+		return fmt.Sprintf("%s (%s)", name, envID)
+	}
+	return envID
+}
+
+// parseAPIKey reads a list entry; the environment is either an environmentId field or an
+// embedded environment object
+func parseAPIKey(data map[string]interface{}) apiKey {
+	key := apiKey{
+		ID:            getStringFromMap(data, "id"),
+		Name:          getStringFromMap(data, "name"),
+		EnvironmentID: getStringFromMap(data, "environmentId"),
+		Active:        true,
+		CreatedAt:     getStringFromMap(data, "createdAt"),
+	}
+	if active, ok := data["isActive"].(bool); ok {
+		key.Active = active
+	}
+	if env, ok := data["environment"].(map[string]interface{}); ok && key.EnvironmentID == "" {
+		key.EnvironmentID = getStringFromMap(env, "id")
+	}
+	return key
+}
+
+// getStringFromMap safely extracts a string value from a map[string]interface{}
+func getStringFromMap(data map[string]interface{}, key string) string {
+	if val, ok := data[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
@@ -2,8 +2,12 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/blimu-dev/blimu-cli/pkg/blimu"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +26,7 @@ func NewAuthCmd() *cobra.Command {
 	cobraCmd.AddCommand(NewTestAuthCmd())
 	cobraCmd.AddCommand(NewPushAuthCmd())
 	cobraCmd.AddCommand(NewLoginCmd())
+	cobraCmd.AddCommand(NewTokenCmd())
 
 	return cobraCmd
 }
@@ -39,47 +44,52 @@ func NewTestAuthCmd() *cobra.Command {
 		Long: `Test your OAuth authentication credentials with the Blimu API.
 Requires authentication via 'blimu auth login'.`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
-			return cmd.Run()
+			return cmd.Run(cobraCmd)
 		},
 	}
 }
 
 // Run executes the test auth command
-func (c *TestAuthCommand) Run() error {
+func (c *TestAuthCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
 	// Get current environment info
-	cliConfig, currentEnv, err := shared.GetCurrentEnvironmentInfo()
+	cliConfig, currentEnv, err := sc.EnvironmentInfo()
 	if err != nil {
 		return err
 	}
 
 	// Determine API URL
-	apiURL := currentEnv.APIURL
-	if apiURL == "" {
-		apiURL = cliConfig.DefaultAPIURL
-	}
+	apiURL := shared.PlatformURL(cliConfig, currentEnv, sc.DevMode)
 
 	fmt.Printf("🔐 Testing authentication for environment '%s' with %s...\n", currentEnv.ID, apiURL)
 
 	// Check if OAuth authenticated
 	if !currentEnv.IsOAuthAuthenticated() {
-		return fmt.Errorf("no OAuth authentication found. Please run 'blimu auth login' to authenticate")
+		return shared.Errorf(shared.ErrAuth, "no OAuth authentication found. Please run 'blimu auth login' to authenticate")
 	}
 
 	// Get authenticated client (this will automatically refresh tokens if needed)
-	client, err := shared.GetAuthClient()
+	client, err := sc.Client()
 	if err != nil {
 		return fmt.Errorf("failed to get authenticated client: %w", err)
 	}
 
-	// Test authentication
-	if err := client.ValidateAuth(); err != nil {
+	// Test authentication by fetching the user's access
+	if _, err := client.Me.GetAccessWithContext(cmd.Context()); err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 
 	fmt.Println("✅ Authentication successful!")
 	fmt.Printf("   Environment: %s\n", currentEnv.ID)
 	fmt.Printf("   API URL: %s\n", apiURL)
-	fmt.Printf("   Authentication: OAuth (Clerk)\n")
+	if currentEnv.IsServiceAccount() {
+		fmt.Printf("   Authentication: service account (%s)\n", currentEnv.ClientID)
+	} else if currentEnv.IsGitHubOIDC() {
+		fmt.Printf("   Authentication: GitHub Actions OIDC\n")
+	} else {
+		fmt.Printf("   Authentication: OAuth (Clerk)\n")
+	}
 	if currentEnv.ExpiresAt != nil {
 		fmt.Printf("   Token expires: %s\n", currentEnv.ExpiresAt.Format(time.RFC3339))
 	}
@@ -89,8 +99,10 @@ func (c *TestAuthCommand) Run() error {
 
 // PushAuthCommand represents the push auth command
 type PushAuthCommand struct {
-	Directory string
-	EnvName   string
+	Directory       string
+	SkipBranchCheck bool
+	ChangedOnly     bool
+	Yes             bool
 }
 
 // NewPushAuthCmd creates the push auth command
@@ -108,47 +120,89 @@ The command will:
 2. Convert it to the API format
 3. Push it to your Blimu environment
 
-By default, uses the current environment. Use --env to specify a different environment.`,
+By default, uses the current environment. Use --env to specify a different environment, or
+--workspace-id and --environment-id (or BLIMU_WORKSPACE_ID and BLIMU_ENVIRONMENT_ID) to push
+somewhere else, like 'blimu push'.`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				cmd.Directory = args[0]
 			} else {
 				cmd.Directory = "."
 			}
-			return cmd.Run()
+			return cmd.Run(cobraCmd)
 		},
 		Args: cobra.MaximumNArgs(1),
 	}
 
-	cobraCmd.Flags().StringVar(&cmd.EnvName, "env", "", "Environment to push to (uses current environment if not specified)")
+	cobraCmd.Flags().BoolVar(&cmd.SkipBranchCheck, "skip-branch-check", false, "Push even if the git branch is not mapped to the environment in .blimu/project.yml")
+	cobraCmd.Flags().BoolVar(&cmd.ChangedOnly, "changed-only", false, "Only push sections that changed since the last pull or push of this environment")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Push to a protected environment without asking for confirmation")
 
 	return cobraCmd
 }
 
 // Run executes the push auth command
-func (c *PushAuthCommand) Run() error {
-	// Get SDK client
-	_, err := shared.GetSDKClient()
+func (c *PushAuthCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	cliConfig, err := sc.Config()
 	if err != nil {
 		return err
 	}
-
-	// Get current environment info
-	_, currentEnv, err := shared.GetCurrentEnvironmentInfo()
+	workspaceID, environmentID, err := sc.RequireIDs("push")
 	if err != nil {
 		return err
 	}
+	envName := environmentID
+	if name, ok := cliConfig.FindEnvironment(environmentID); ok {
+		envName = name
+	}
 
-	fmt.Printf("🚀 Pushing .blimu configuration from '%s' to environment '%s'...\n", c.Directory, currentEnv.ID)
+	if err := shared.ConfirmProtectedEnvironment(cliConfig, environmentID, "push definitions to", c.Yes); err != nil {
+		return err
+	}
 
-	// TODO: Implement the push logic using the SDK client
-	// This would involve:
-	// 1. Loading the .blimu configuration from the directory
-	// 2. Validating the configuration
-	// 3. Converting to API format
-	// 4. Pushing via the SDK client
+	fmt.Printf("🚀 Pushing .blimu configuration from '%s' to environment '%s'...\n", c.Directory, envName)
+
+	// Validate locally before touching the API
+	blimuConfig, err := config.LoadBlimuConfig(c.Directory)
+	if err != nil {
+		return fmt.Errorf("failed to load .blimu configuration: %w", err)
+	}
+	result := blimu.ValidateConfig(blimuConfig)
+	if !result.Valid {
+		fmt.Printf("❌ Configuration is invalid:\n")
+		for _, validationErr := range result.Errors {
+			fmt.Printf("  - %s\n", validationErr.Error())
+		}
+		return fmt.Errorf("configuration has %d error(s); nothing was pushed", len(result.Errors))
+	}
+	fmt.Printf("✅ Configuration is valid\n")
+
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return fmt.Errorf("authentication required for push. Run 'blimu auth login' first: %w", err)
+	}
+
+	pushResult, err := runner.Push(cmd.Context(), cli.PushOptions{
+		Directory:       c.Directory,
+		WorkspaceID:     workspaceID,
+		EnvironmentID:   environmentID,
+		EnvironmentKeys: cliConfig.EnvironmentKeys(environmentID),
+		SkipBranchGuard: c.SkipBranchCheck,
+		ChangedOnly:     c.ChangedOnly,
+	})
+	if err != nil {
+		return err
+	}
+	if len(pushResult.Sections) == 0 {
+		return nil
+	}
 
 	fmt.Println("✅ Configuration pushed successfully!")
+	fmt.Printf("   Workspace: %s\n", pushResult.WorkspaceID)
+	fmt.Printf("   Environment: %s (%s)\n", envName, pushResult.EnvironmentID)
+	fmt.Printf("   Sections: %s\n", strings.Join(pushResult.Sections, ", "))
 
 	return nil
 }
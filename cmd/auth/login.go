@@ -1,22 +1,42 @@
 package auth
 
 import (
+	"bufio"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/blimu-dev/blimu-cli/internal/oauth"
-	platform "github.com/blimu-dev/blimu-cli/internal/sdk"
 	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	platform "github.com/blimu-dev/blimu-cli/platform"
 	"github.com/spf13/cobra"
 )
 
+// defaultOIDCAudience is the audience of CI ID tokens unless --oidc-audience is given
+const defaultOIDCAudience = "blimu"
+
 // LoginCommand represents the login command
 type LoginCommand struct {
-	APIURL string
+	APIURL       string
+	Region       string
+	CallbackPort int
+	CallbackHost string
+	NoBrowser    bool
+	ClientID     string
+	ClientSecret string
+	OIDC         string
+	OIDCAudience string
+	OAuth        config.OAuthSettings
 }
 
 // NewLoginCmd creates the login command
@@ -26,13 +46,58 @@ func NewLoginCmd() *cobra.Command {
 	cobraCmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with Blimu using OAuth",
-		Long:  "Start the OAuth authentication flow to log in to your Blimu account",
+		Long: `Start the OAuth authentication flow to log in to your Blimu account.
+
+The browser is redirected to a callback server of the CLI, on http://127.0.0.1:8080/callback
+(or the next free port). When the OAuth app only allows a specific redirect URI, pass its host
+and port with --callback-host and --callback-port; a fixed port fails instead of moving to
+another one when it is busy.
+
+After logging in, the environment is picked from the ones the account has access to: the one
+matching the global --workspace-id and --environment-id flags, else the one the local environment
+already points at, else the only one, else the one chosen in a picker. The login is stored under
+the local name given with the global --env flag (default: the environment ID). Logging in again
+keeps the local environment's other settings, and never changes the current environment once
+one is set.
+
+Without a browser on this machine, --no-browser prints the authorization URL to open elsewhere,
+then asks for the URL the browser was redirected to (or just its code) to be pasted back. The
+page at that URL may fail to load; its address still holds the code.
+
+In CI, log in as a service account with --client-id and --client-secret (or BLIMU_CLIENT_ID and
+BLIMU_CLIENT_SECRET, which keep the secret out of the command line). No browser is involved; the
+secret is stored in the credentials file and requests a new access token whenever it expires.
+
+In GitHub Actions, --oidc github exchanges an ID token of the job for a platform token, so no
+secret needs to be stored at all. The job needs the 'id-token: write' permission. The token is
+short-lived: later commands of the same job exchange a new ID token when it expires, while
+outside the job an expired token needs another login.`,
+		Example: `  blimu auth login
+  blimu --env staging --workspace-id ws_123 --environment-id staging auth login
+  blimu auth login --callback-port 8123
+  blimu auth login --callback-host localhost --callback-port 8123
+  blimu auth login --no-browser
+  BLIMU_CLIENT_ID=sa_123 BLIMU_CLIENT_SECRET=... blimu auth login
+  blimu auth login --oidc github`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd)
 		},
 	}
 
-	cobraCmd.Flags().StringVar(&cmd.APIURL, "api-url", "", "Platform API URL for OAuth (defaults to https://app-api-42118893108.us-central1.run.app)")
+	cobraCmd.Flags().IntVar(&cmd.CallbackPort, "callback-port", 0, "Port of the OAuth callback server, matching the OAuth app's redirect URI (default: 8080, or the next free port)")
+	cobraCmd.Flags().StringVar(&cmd.CallbackHost, "callback-host", oauth.DefaultCallbackHost, "Host of the OAuth redirect URI; must resolve to this machine, e.g. localhost")
+	cobraCmd.Flags().BoolVar(&cmd.NoBrowser, "no-browser", false, "Print the authorization URL and read the redirected URL or code from the terminal instead of opening a browser")
+	cobraCmd.Flags().StringVar(&cmd.ClientID, "client-id", "", "Log in as the service account with this client ID (default: $BLIMU_CLIENT_ID)")
+	cobraCmd.Flags().StringVar(&cmd.ClientSecret, "client-secret", "", "Secret of the service account of --client-id (default: $BLIMU_CLIENT_SECRET)")
+	cobraCmd.Flags().StringVar(&cmd.OIDC, "oidc", "", "Log in with an OIDC ID token of the CI system instead of a browser; only 'github' (GitHub Actions) is supported")
+	cobraCmd.Flags().StringVar(&cmd.OIDCAudience, "oidc-audience", defaultOIDCAudience, "Audience of the OIDC ID token, as trusted by the platform")
+	cobraCmd.Flags().StringVar(&cmd.OAuth.ClientID, "oauth-client-id", "", "OAuth client ID of the CLI (default: "+shared.OAuthClientIDEnv+", the CLI config's oauth.client_id, or "+oauth.DefaultClientID+")")
+	cobraCmd.Flags().StringVar(&cmd.OAuth.AuthURL, "oauth-auth-url", "", "OAuth authorization endpoint (default: "+shared.OAuthAuthURLEnv+", the CLI config's oauth.auth_url, or <api-url>/oauth/authorize)")
+	cobraCmd.Flags().StringVar(&cmd.OAuth.TokenURL, "oauth-token-url", "", "OAuth token endpoint (default: "+shared.OAuthTokenURLEnv+", the CLI config's oauth.token_url, or <api-url>/oauth/token)")
+	cobraCmd.Flags().StringVar(&cmd.APIURL, "api-url", "", "Platform API URL of the environment, overriding its region's (kept with the environment)")
+	cobraCmd.Flags().StringVar(&cmd.Region, "region", "", "Region whose endpoints the environment uses (default: the CLI config's region, or "+config.DefaultRegion+"; kept with the environment)")
+	cobraCmd.MarkFlagsMutuallyExclusive("oidc", "client-id")
+	cobraCmd.MarkFlagsMutuallyExclusive("oidc", "no-browser")
 
 	return cobraCmd
 }
@@ -45,64 +110,253 @@ func (c *LoginCommand) Run(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to load CLI config: %w", err)
 	}
 
-	// Check if dev mode is enabled
-	devMode, _ := cmd.Flags().GetBool("dev")
+	sc := shared.FromContext(cmd.Context())
+	devMode := sc.DevMode
 
-	// Use platform API OAuth endpoints (which proxy to Clerk internally)
-	platformURL := "https://app-api-42118893108.us-central1.run.app"
-	if devMode {
-		platformURL = "http://localhost:3010"
-	} else if c.APIURL != "" {
-		platformURL = c.APIURL
+	if c.Region != "" && !cliConfig.HasRegion(c.Region) {
+		return fmt.Errorf("unknown region '%s' (known: %s)", c.Region, strings.Join(cliConfig.RegionNames(), ", "))
 	}
 
-	fmt.Printf("🔐 Starting OAuth authentication via platform API...\n")
+	// Log in against the endpoints of the local environment, with --region and --api-url applied
+	target := cliConfig.Environments[shared.EnvironmentOverride()]
+	if c.Region != "" {
+		target.Region = c.Region
+	}
+	if c.APIURL != "" {
+		target.APIURL = c.APIURL
+	}
+	platformURL := shared.PlatformURL(cliConfig, &target, devMode)
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	clientID, clientSecret := c.clientCredentials()
+	var envConfig config.Environment
+	// --oauth-* flags win over the BLIMU_OAUTH_* variables and the CLI config, and are kept with
+	// the environment so its token refreshes use the same client
+	oauthConfig := shared.OAuthConfig(cliConfig, nil, platformURL)
+	if c.OAuth != (config.OAuthSettings{}) {
+		flags := c.OAuth
+		envConfig.OAuth = &flags
+		if flags.ClientID != "" {
+			oauthConfig.ClientID = flags.ClientID
+		}
+		if flags.AuthURL != "" {
+			oauthConfig.AuthURL = flags.AuthURL
+		}
+		if flags.TokenURL != "" {
+			oauthConfig.TokenURL = flags.TokenURL
+		}
+	}
+
+	var tokenResp *oauth.TokenResponse
+	if c.OIDC != "" {
+		if c.OIDC != "github" {
+			return fmt.Errorf("unsupported --oidc provider '%s'; only 'github' is supported", c.OIDC)
+		}
+		fmt.Printf("🔐 Exchanging the GitHub Actions ID token...\n")
+		tokenResp, err = shared.ExchangeGitHubOIDCToken(ctx, oauthConfig, c.OIDCAudience)
+		if err != nil {
+			return shared.Errorf(shared.ErrAuth, "failed to authenticate with GitHub Actions OIDC: %v", err)
+		}
+		envConfig.AuthType = config.AuthTypeGitHubOIDC
+		envConfig.OIDCAudience = c.OIDCAudience
+	} else if clientID != "" {
+		if clientSecret == "" {
+			return fmt.Errorf("--client-id needs a client secret: pass --client-secret or set %s", shared.ClientSecretEnv)
+		}
+		fmt.Printf("🔐 Authenticating service account %s...\n", clientID)
+		serviceAccount := oauthConfig
+		serviceAccount.ClientID = clientID
+		tokenResp, err = oauth.NewClient(serviceAccount).ClientCredentialsToken(ctx, clientSecret)
+		if err != nil {
+			return shared.Errorf(shared.ErrAuth, "failed to authenticate service account: %v", err)
+		}
+		envConfig.AuthType = config.AuthTypeClientCredentials
+		envConfig.ClientID = clientID
+		envConfig.ClientSecret = clientSecret
+	} else {
+		if tokenResp, err = c.authorize(ctx, oauthConfig); err != nil {
+			return err
+		}
+	}
 
-	// Create callback server
-	server, err := oauth.NewCallbackServer()
+	// Calculate expiry time
+	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	// Fill in the initial environment config
+	envConfig.APIURL = c.APIURL
+	envConfig.Region = c.Region
+	envConfig.AccessToken = tokenResp.AccessToken
+	envConfig.RefreshToken = tokenResp.RefreshToken
+	envConfig.ExpiresAt = &expiresAt
+	envConfig.TokenType = "Bearer"
+
+	// Pick the workspace and environment the login is for
+	logging.Info("🔍 Fetching workspace and environment information...")
+	candidates, err := fetchAccessibleEnvironments(ctx, tokenResp.AccessToken, platformURL)
 	if err != nil {
-		return fmt.Errorf("failed to create callback server: %w", err)
+		return fmt.Errorf("failed to fetch workspace/environment information: %w", err)
+	}
+	name := shared.EnvironmentOverride()
+	existing, exists := cliConfig.Environments[name]
+	selected, err := selectLoginEnvironment(candidates, sc.WorkspaceID, sc.EnvironmentID, existing)
+	if err != nil {
+		return err
+	}
+	logging.Debug("selected environment", "workspace", selected.WorkspaceID, "id", selected.ID)
+	envConfig.WorkspaceID = selected.WorkspaceID
+	envConfig.ID = selected.ID
+	if name == "" {
+		name = envConfig.ID
+		existing, exists = cliConfig.Environments[name]
 	}
 
-	// Start callback server
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
+	// Logging in again keeps the local settings of the environment, e.g. its labels
+	if exists {
+		if envConfig.APIURL != "" {
+			existing.APIURL = envConfig.APIURL
+		}
+		if envConfig.Region != "" {
+			existing.Region = envConfig.Region
+		}
+		existing.ID = envConfig.ID
+		existing.WorkspaceID = envConfig.WorkspaceID
+		existing.AccessToken = envConfig.AccessToken
+		existing.RefreshToken = envConfig.RefreshToken
+		existing.ExpiresAt = envConfig.ExpiresAt
+		existing.TokenType = envConfig.TokenType
+		existing.ReauthRequired = false
+		existing.AuthType = envConfig.AuthType
+		existing.ClientID = envConfig.ClientID
+		existing.ClientSecret = envConfig.ClientSecret
+		existing.OIDCAudience = envConfig.OIDCAudience
+		if envConfig.OAuth != nil {
+			existing.OAuth = envConfig.OAuth
+		}
+		envConfig = existing
+	}
+	if err := cliConfig.SaveEnvironment(name, envConfig); err != nil {
+		return fmt.Errorf("failed to save authentication: %w", err)
+	}
+	renewed, expired, err := renewExpiredEnvironments(cliConfig, name, envConfig)
+	if err != nil {
+		fmt.Printf("⚠️  Could not update other environments: %v\n", err)
+	} else if renewed > 0 {
+		fmt.Printf("🔑 Renewed the expired session of %d other environment(s) of the same account\n", renewed)
+	}
+	if len(expired) > 0 {
+		fmt.Printf("⚠️  These environments still need to log in again: %s\n", strings.Join(expired, ", "))
+		fmt.Printf("   Run 'blimu --env <name> auth login' for each of them\n")
+	}
 
-	if err := server.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start callback server: %w", err)
+	if envConfig.IsServiceAccount() {
+		fmt.Printf("✅ Service account authentication successful!\n")
+	} else if envConfig.IsGitHubOIDC() {
+		fmt.Printf("✅ GitHub Actions OIDC authentication successful!\n")
+	} else {
+		fmt.Printf("✅ OAuth authentication successful!\n")
+	}
+	fmt.Printf("   Environment: %s\n", name)
+	fmt.Printf("   Platform API: %s\n", platformURL)
+	if envConfig.WorkspaceID != "" {
+		fmt.Printf("   Workspace ID: %s\n", envConfig.WorkspaceID)
+	}
+	if envConfig.ID != "" {
+		fmt.Printf("   Environment ID: %s\n", envConfig.ID)
+	}
+	fmt.Printf("   Token expires: %s\n", expiresAt.Format(time.RFC3339))
+	if cliConfig.CurrentEnvironment != name {
+		fmt.Printf("   The current environment is still '%s'; use 'blimu env switch %s' to change it\n", cliConfig.CurrentEnvironment, name)
+	}
+
+	// Show available environments
+	fmt.Printf("\n🌍 Fetching your available environments...\n")
+	if environments, err := shared.FetchUserEnvironments(ctx, devMode); err != nil {
+		fmt.Printf("⚠️  Could not fetch environments: %v\n", err)
+	} else if len(environments) > 1 {
+		fmt.Printf("\nYou have access to %d environments:\n", len(environments))
+		shared.DisplayEnvironments(environments)
+		fmt.Printf("\nUse 'blimu env switch' to switch between environments.\n")
+	} else if len(environments) == 1 {
+		fmt.Printf("You have access to 1 environment: %s\n", environments[0].Name)
+	}
+
+	return nil
+}
+
+// clientCredentials returns the service account to log in as, from the flags or else the
+// environment variables; an empty client ID means an interactive login
+func (c *LoginCommand) clientCredentials() (clientID, clientSecret string) {
+	clientID, clientSecret = c.ClientID, c.ClientSecret
+	if clientID == "" {
+		clientID = os.Getenv(shared.ClientIDEnv)
+	}
+	if clientSecret == "" {
+		clientSecret = os.Getenv(shared.ClientSecretEnv)
 	}
-	defer server.Shutdown(context.Background())
+	return clientID, clientSecret
+}
+
+// authorize runs the interactive OAuth flow in a browser and exchanges its code for tokens
+func (c *LoginCommand) authorize(ctx context.Context, oauthConfig oauth.Config) (*oauth.TokenResponse, error) {
+	fmt.Printf("🔐 Starting OAuth authentication via platform API...\n")
 
-	// Show callback server info
-	fmt.Printf("📡 Callback server started on port %d\n", server.GetPort())
-	if server.GetPort() != 8080 {
-		fmt.Printf("⚠️  Using alternative port %d (8080 was busy)\n", server.GetPort())
-		fmt.Printf("   Make sure %s is configured in your OAuth app\n", server.GetRedirectURI())
+	var err error
+	var server *oauth.CallbackServer
+	var redirectURI string
+	if c.NoBrowser {
+		// The code is pasted back, so the redirect URI only has to match the OAuth app
+		if !shared.IsInteractive() {
+			return nil, fmt.Errorf("--no-browser needs a terminal to paste the callback URL into")
+		}
+		port := c.CallbackPort
+		if port == 0 {
+			port = 8080
+		}
+		redirectURI = oauth.RedirectURI(c.CallbackHost, port)
+	} else {
+		// Create callback server
+		server, err = oauth.NewCallbackServerWithOptions(oauth.CallbackOptions{Host: c.CallbackHost, Port: c.CallbackPort})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create callback server: %w", err)
+		}
+
+		// Start callback server
+		if err := server.Start(ctx); err != nil {
+			return nil, fmt.Errorf("failed to start callback server: %w", err)
+		}
+		defer server.Shutdown(context.Background())
+		redirectURI = server.GetRedirectURI()
+
+		// Show callback server info
+		fmt.Printf("📡 Callback server started on port %d\n", server.GetPort())
+		if c.CallbackPort != 0 || c.CallbackHost != oauth.DefaultCallbackHost {
+			fmt.Printf("   Redirect URI: %s\n", redirectURI)
+		} else if server.GetPort() != 8080 {
+			fmt.Printf("⚠️  Using alternative port %d (8080 was busy)\n", server.GetPort())
+			fmt.Printf("   Make sure %s is configured in your OAuth app\n", redirectURI)
+		}
 	}
 
 	// Generate PKCE challenge
 	pkce, err := oauth.GeneratePKCEChallenge()
 	if err != nil {
-		return fmt.Errorf("failed to generate PKCE challenge: %w", err)
+		return nil, fmt.Errorf("failed to generate PKCE challenge: %w", err)
 	}
 
 	// Generate state parameter
 	state, err := oauth.GenerateRandomString(32)
 	if err != nil {
-		return fmt.Errorf("failed to generate state: %w", err)
-	}
-
-	// Create OAuth client using platform API endpoints (which proxy to Clerk)
-	oauthConfig := oauth.Config{
-		ClientID:    "blimu_cli", // Platform API OAuth client ID
-		AuthURL:     fmt.Sprintf("%s/oauth/authorize", platformURL),
-		TokenURL:    fmt.Sprintf("%s/oauth/token", platformURL),
-		RedirectURI: server.GetRedirectURI(),
-		Scopes: []string{
-			"openid",
-			"profile",
-			"email",
-		},
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	// OAuth client of the platform API endpoints (which proxy to Clerk), unless configured otherwise
+	oauthConfig.RedirectURI = redirectURI
+	oauthConfig.Scopes = []string{
+		"openid",
+		"profile",
+		"email",
 	}
 
 	oauthClient := oauth.NewClient(oauthConfig)
@@ -110,29 +364,38 @@ func (c *LoginCommand) Run(cmd *cobra.Command) error {
 	// Generate authorization URL
 	authURL := oauthClient.GetAuthorizationURL(state, pkce.Challenge)
 
-	// Open browser
-	fmt.Printf("🌐 Opening browser for authentication...\n")
-	fmt.Printf("If the browser doesn't open automatically, visit: %s\n\n", authURL)
+	var result oauth.CallbackResult
+	if c.NoBrowser {
+		result, err = readPastedCallback(authURL)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Open browser
+		fmt.Printf("🌐 Opening browser for authentication...\n")
+		fmt.Printf("If the browser doesn't open automatically, visit: %s\n\n", authURL)
 
-	if err := openBrowser(authURL); err != nil {
-		fmt.Printf("⚠️  Failed to open browser automatically: %v\n", err)
-		fmt.Printf("Please manually visit the URL above.\n\n")
-	}
+		if err := openBrowser(authURL); err != nil {
+			fmt.Printf("⚠️  Failed to open browser automatically: %v\n", err)
+			fmt.Printf("Please manually visit the URL above.\n\n")
+		}
 
-	fmt.Printf("⏳ Waiting for authentication callback...\n")
+		fmt.Printf("⏳ Waiting for authentication callback...\n")
 
-	// Wait for callback
-	result, err := server.WaitForCallback(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to receive callback: %w", err)
+		// Wait for callback
+		result, err = server.WaitForCallback(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive callback: %w", err)
+		}
 	}
 
 	if result.Error != "" {
-		return fmt.Errorf("authentication failed: %s", result.Error)
+		return nil, fmt.Errorf("authentication failed: %s", result.Error)
 	}
 
-	if result.State != state {
-		return fmt.Errorf("invalid state parameter")
+	// A pasted bare code has no state; PKCE still ties it to this login
+	if result.State != state && !(c.NoBrowser && result.State == "") {
+		return nil, fmt.Errorf("invalid state parameter")
 	}
 
 	fmt.Printf("✅ Received authorization callback\n")
@@ -142,73 +405,104 @@ func (c *LoginCommand) Run(cmd *cobra.Command) error {
 
 	tokenResp, err := oauthClient.ExchangeCodeForTokens(ctx, result.Code, pkce.Verifier)
 	if err != nil {
-		return fmt.Errorf("failed to exchange code for tokens: %w", err)
+		return nil, fmt.Errorf("failed to exchange code for tokens: %w", err)
 	}
 
-	// Calculate expiry time
-	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-
-	// Platform API URL is already determined above
-	// No need to redetermine it here since we're using platform API throughout
+	return tokenResp, nil
+}
 
-	// Create initial environment config
-	envConfig := config.Environment{
-		APIURL:       platformURL,
-		AccessToken:  tokenResp.AccessToken,
-		RefreshToken: tokenResp.RefreshToken,
-		ExpiresAt:    &expiresAt,
-		TokenType:    "Bearer",
+// renewExpiredEnvironments gives the tokens of the new session to the environments marked as
+// needing re-authentication that belong to the same identity: the same platform and workspace,
+// and the same service account or token subject. Secrets are never copied. It returns the number
+// of renewed environments and the names of the expired ones left alone.
+func renewExpiredEnvironments(cliConfig *config.CLIConfig, sessionName string, session config.Environment) (int, []string, error) {
+	names := make([]string, 0, len(cliConfig.Environments))
+	for name := range cliConfig.Environments {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	// Try to fetch workspace and environment information using the new token
-	fmt.Printf("🔍 Fetching workspace and environment information...\n")
-	if workspaceID, environmentID, err := fetchUserWorkspaceAndEnvironment(tokenResp.AccessToken, platformURL); err != nil {
-		fmt.Printf("⚠️  Could not fetch workspace/environment information: %v\n", err)
-		return fmt.Errorf("failed to fetch workspace/environment information: %w", err)
-	} else {
-		if workspaceID != "" {
-			envConfig.WorkspaceID = workspaceID
-			fmt.Printf("📋 Found workspace ID: %s\n", workspaceID)
-		} else {
-			return fmt.Errorf("failed to fetch workspace information: %w", err)
+	renewed := 0
+	var expired []string
+	for _, name := range names {
+		env := cliConfig.Environments[name]
+		if !env.ReauthRequired || name == sessionName {
+			continue
 		}
-
-		if environmentID != "" {
-			envConfig.ID = environmentID
-			fmt.Printf("📋 Found environment ID: %s\n", environmentID)
-		} else {
-			return fmt.Errorf("failed to fetch environment information: %w", err)
+		if !sameIdentity(cliConfig, env, session) {
+			expired = append(expired, name)
+			continue
+		}
+		env.AccessToken = session.AccessToken
+		env.RefreshToken = session.RefreshToken
+		env.ExpiresAt = session.ExpiresAt
+		env.TokenType = session.TokenType
+		env.ReauthRequired = false
+		if err := cliConfig.UpdateEnvironment(name, env); err != nil {
+			return renewed, expired, err
 		}
+		renewed++
 	}
+	return renewed, expired, nil
+}
 
-	if err := cliConfig.AddEnvironment(envConfig); err != nil {
-		return fmt.Errorf("failed to save authentication: %w", err)
+// sameIdentity reports whether the tokens of session act as the same identity as those env had:
+// same platform, workspace and kind of authentication, and the same service account client or,
+// for other logins, the same subject in the access tokens
+func sameIdentity(cliConfig *config.CLIConfig, env, session config.Environment) bool {
+	if shared.PlatformURL(cliConfig, &env, false) != shared.PlatformURL(cliConfig, &session, false) {
+		return false
 	}
-
-	fmt.Printf("✅ OAuth authentication successful!\n")
-	fmt.Printf("   Environment: %s\n", envConfig.ID)
-	fmt.Printf("   Platform API: %s\n", platformURL)
-	if envConfig.WorkspaceID != "" {
-		fmt.Printf("   Workspace ID: %s\n", envConfig.WorkspaceID)
+	if env.WorkspaceID == "" || env.WorkspaceID != session.WorkspaceID || env.AuthType != session.AuthType {
+		return false
 	}
-	if envConfig.ID != "" {
-		fmt.Printf("   Environment ID: %s\n", envConfig.ID)
+	if env.IsServiceAccount() {
+		return env.ClientID != "" && env.ClientID == session.ClientID
 	}
-	fmt.Printf("   Token expires: %s\n", expiresAt.Format(time.RFC3339))
+	subject := tokenSubject(env.AccessToken)
+	return subject != "" && subject == tokenSubject(session.AccessToken)
+}
 
-	// Show available environments
-	fmt.Printf("\n🌍 Fetching your available environments...\n")
-	if environments, err := shared.FetchUserEnvironments(devMode); err != nil {
-		fmt.Printf("⚠️  Could not fetch environments: %v\n", err)
-	} else if len(environments) > 1 {
-		fmt.Printf("\nYou have access to %d environments:\n", len(environments))
-		shared.DisplayEnvironments(environments)
-		fmt.Printf("\nUse 'blimu env switch' to switch between environments.\n")
-	} else if len(environments) == 1 {
-		fmt.Printf("You have access to 1 environment: %s\n", environments[0].Name)
+// tokenSubject returns the sub claim of a JWT access token, or "" for tokens that are not JWTs.
+// The signature is not checked: the claim only tells whether two tokens name the same subject.
+func tokenSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
 	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
 
-	return nil
+// readPastedCallback prints the authorization URL and reads the URL the browser was redirected
+// to, or its code, from the terminal
+func readPastedCallback(authURL string) (oauth.CallbackResult, error) {
+	fmt.Printf("🌐 Open this URL in a browser and log in:\n\n   %s\n\n", authURL)
+	fmt.Printf("The browser is then redirected to a page that may not load. Copy its whole URL from\n")
+	fmt.Printf("the address bar (or just the value of its code parameter) and paste it here.\n\n")
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("Callback URL or code: ")
+		line, err := reader.ReadString('\n')
+		if err != nil && strings.TrimSpace(line) == "" {
+			return oauth.CallbackResult{}, fmt.Errorf("failed to read the callback URL: %w", err)
+		}
+		result, err := oauth.ParseCallback(line)
+		if err == nil {
+			return result, nil
+		}
+		fmt.Printf("❌ %v\n", err)
+	}
 }
 
 func openBrowser(url string) error {
@@ -228,90 +522,110 @@ func openBrowser(url string) error {
 	return exec.Command(cmd, args...).Start()
 }
 
-// fetchUserWorkspaceAndEnvironment attempts to fetch the user's workspace and environment IDs using the access token
-func fetchUserWorkspaceAndEnvironment(accessToken, platformURL string) (workspaceID, environmentID string, err error) {
+// fetchAccessibleEnvironments lists the workspaces' environments the access token has access to
+func fetchAccessibleEnvironments(ctx context.Context, accessToken, platformURL string) ([]shared.EnvironmentInfo, error) {
 	// Create a temporary platform client with the new access token
 	client := platform.NewClient(
 		platform.WithBaseURL(platformURL),
 		platform.WithBearer(accessToken),
+		platform.WithHTTPClient(telemetry.HTTPClient()),
+		platform.WithContext(ctx),
 	)
 
 	// Get user's active resources
 	userAccess, err := client.Me.GetAccess()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get active resources: %w", err)
+		return nil, fmt.Errorf("failed to get active resources: %w", err)
 	}
 
-	fmt.Printf("🔍 Found %d workspaces for user\n", len(userAccess.Workspaces))
+	logging.Debug("fetched user access", "workspaces", len(userAccess.Workspaces))
 
 	if len(userAccess.Workspaces) == 0 {
-		return "", "", fmt.Errorf("no workspaces found for user")
+		return nil, fmt.Errorf("no workspaces found for user")
 	}
 
-	// Look for workspace and environment resources
+	var environments []shared.EnvironmentInfo
 	for i, workspaceData := range userAccess.Workspaces {
 		wsID := getStringFromMap(workspaceData, "id")
 		wsName := getStringFromMap(workspaceData, "name")
 		wsType := getStringFromMap(workspaceData, "type")
 
-		fmt.Printf("   Workspace %d: id=%s, name=%s, type=%s\n", i+1, wsID, wsName, wsType)
-
-		// Extract workspace ID if we haven't found one yet
-		if workspaceID == "" && wsID != "" && wsType == "workspace" {
-			workspaceID = wsID
-			fmt.Printf("   ✅ Found workspace ID: %s\n", workspaceID)
-		}
+		logging.Debug("workspace", "index", i+1, "id", wsID, "name", wsName, "type", wsType)
 
-		// Extract environments from this workspace
-		envsRaw, exists := workspaceData["environments"]
-		if !exists {
-			fmt.Printf("      No environments found in workspace\n")
-			continue
-		}
-
-		envsArray, ok := envsRaw.([]interface{})
+		envsArray, ok := workspaceData["environments"].([]interface{})
 		if !ok {
-			fmt.Printf("      ⚠️  Environments field is not an array\n")
+			logging.Debug("no environments in workspace", "workspace", wsID)
 			continue
 		}
 
-		// Look for environment ID if we haven't found one yet
-		if environmentID == "" {
-			for j, envRaw := range envsArray {
-				envData, ok := envRaw.(map[string]interface{})
-				if !ok {
-					fmt.Printf("      ⚠️  Environment %d: invalid format\n", j+1)
-					continue
-				}
-
-				envID := getStringFromMap(envData, "id")
-				envName := getStringFromMap(envData, "name")
-				envType := getStringFromMap(envData, "type")
-
-				fmt.Printf("      Environment %d: id=%s, name=%s, type=%s\n", j+1, envID, envName, envType)
-
-				if envType == "environment" && envID != "" {
-					environmentID = envID
-					fmt.Printf("      ✅ Found environment ID: %s\n", environmentID)
-					// If we found an environment, also use its workspace ID
-					if workspaceID == "" && wsID != "" {
-						workspaceID = wsID
-						fmt.Printf("      ✅ Using workspace ID from environment's workspace: %s\n", workspaceID)
-					}
-					break
-				}
+		for j, envRaw := range envsArray {
+			envData, ok := envRaw.(map[string]interface{})
+			if !ok {
+				logging.Debug("skipped environment with invalid format", "workspace", wsID, "index", j+1)
+				continue
+			}
+
+			envID := getStringFromMap(envData, "id")
+			envName := getStringFromMap(envData, "name")
+			envType := getStringFromMap(envData, "type")
+
+			logging.Debug("environment", "index", j+1, "id", envID, "name", envName, "type", envType)
+
+			if envType != "environment" || envID == "" || wsID == "" {
+				continue
 			}
+			if envName == "" {
+				envName = envID
+			}
+			environments = append(environments, shared.EnvironmentInfo{ID: envID, Name: envName, WorkspaceID: wsID})
 		}
 	}
 
-	// Return what we found, even if incomplete
-	fmt.Printf("🔍 Final results: workspaceID='%s', environmentID='%s'\n", workspaceID, environmentID)
+	if len(environments) == 0 {
+		return nil, fmt.Errorf("no workspace or environment found in active resources")
+	}
+
+	return environments, nil
+}
+
+// selectLoginEnvironment picks the environment to log in to among candidates: the one matching
+// --workspace-id and --environment-id (an ID or name), else the one the local environment
+// already points at, else the only one, else the user's choice. Without a terminal to choose on,
+// several candidates are an error listing them.
+func selectLoginEnvironment(candidates []shared.EnvironmentInfo, workspaceID, environmentID string, existing config.Environment) (*shared.EnvironmentInfo, error) {
+	var matches []shared.EnvironmentInfo
+	for _, candidate := range candidates {
+		if workspaceID != "" && candidate.WorkspaceID != workspaceID {
+			continue
+		}
+		if environmentID != "" && candidate.ID != environmentID && candidate.Name != environmentID {
+			continue
+		}
+		matches = append(matches, candidate)
+	}
+	if len(matches) == 0 {
+		return nil, shared.Errorf(shared.ErrNotFound, "no environment matches --workspace-id '%s' and --environment-id '%s' among the %d you have access to", workspaceID, environmentID, len(candidates))
+	}
+	if len(matches) == 1 {
+		return &matches[0], nil
+	}
 
-	if workspaceID == "" && environmentID == "" {
-		return "", "", fmt.Errorf("no workspace or environment found in active resources")
+	// Logging in again keeps the workspace and environment of the local environment
+	for i, match := range matches {
+		if existing.ID != "" && match.ID == existing.ID && match.WorkspaceID == existing.WorkspaceID {
+			return &matches[i], nil
+		}
 	}
 
-	return workspaceID, environmentID, nil
+	if !shared.IsInteractive() {
+		var listed strings.Builder
+		for _, match := range matches {
+			fmt.Fprintf(&listed, "\n  - %s (workspace %s, environment %s)", match.Name, match.WorkspaceID, match.ID)
+		}
+		return nil, fmt.Errorf("you have access to %d environments and there is no terminal to choose one; pass --workspace-id and --environment-id to pick one of:%s", len(matches), listed.String())
+	}
+	fmt.Printf("You have access to %d environments.\n", len(matches))
+	return shared.PromptEnvironmentSelection(matches)
 }
 
 // getStringFromMap safely extracts a string value from a map[string]interface{}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of the token command
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// tokenInfo is the JSON output of the token command
+type tokenInfo struct {
+	AccessToken   string     `json:"access_token"`
+	TokenType     string     `json:"token_type"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	WorkspaceID   string     `json:"workspace_id,omitempty"`
+	EnvironmentID string     `json:"environment_id,omitempty"`
+	APIURL        string     `json:"api_url"`
+}
+
+// TokenCommand represents the token command
+type TokenCommand struct {
+	Output string
+}
+
+// NewTokenCmd creates the token command
+func NewTokenCmd() *cobra.Command {
+	cmd := &TokenCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "token",
+		Short: "Print a valid access token of the environment",
+		Long: `Print the access token of the current environment (or the one selected with --env),
+refreshing it first when it is about to expire, so scripts can call the platform API with the
+CLI's login instead of implementing OAuth themselves.
+
+Only the token is written to stdout. --output json adds its expiry, the workspace and environment
+IDs and the platform API URL.`,
+		Example: `  curl -H "Authorization: Bearer $(blimu auth token)" "$API_URL/v1/me"
+  blimu auth token --env staging -o json | jq -r .expires_at`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", outputText, "Output format: text or json")
+
+	return cobraCmd
+}
+
+// Run executes the token command
+func (c *TokenCommand) Run(cmd *cobra.Command) error {
+	if c.Output != outputText && c.Output != outputJSON {
+		return fmt.Errorf("unknown output format '%s'; use text or json", c.Output)
+	}
+
+	sc := shared.FromContext(cmd.Context())
+	env, err := sc.AccessToken()
+	if err != nil {
+		return err
+	}
+
+	if c.Output == outputText {
+		fmt.Println(env.AccessToken)
+		return nil
+	}
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tokenInfo{
+		AccessToken:   env.AccessToken,
+		TokenType:     env.TokenType,
+		ExpiresAt:     env.ExpiresAt,
+		WorkspaceID:   env.WorkspaceID,
+		EnvironmentID: env.ID,
+		APIURL:        shared.PlatformURL(cliConfig, env, sc.DevMode),
+	})
+}
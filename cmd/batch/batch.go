@@ -0,0 +1,159 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// BatchCommand represents the batch command
+type BatchCommand struct {
+	ScriptPath      string
+	ContinueOnError bool
+	JSON            bool
+}
+
+// NewBatchCmd creates the batch command
+func NewBatchCmd() *cobra.Command {
+	cmd := &BatchCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "batch <script|->",
+		Short: "Run a sequence of operations from a YAML/JSON script",
+		Long: `Run a sequence of push, pull, validate and generate operations from a YAML or JSON
+script. Authentication and the workspace/environment context are resolved once and shared
+by every step. Pass "-" to read the script from stdin.
+
+By default the first failing step stops the run and the remaining steps are reported as
+skipped. Set continue_on_error in the script (or --continue-on-error) to run every step.
+
+Script format:
+  workspace_id: ws_123        # optional, defaults to current environment
+  environment_id: env_456     # optional, defaults to current environment
+  directory: .                # optional, default directory for every step
+  continue_on_error: false
+  steps:
+    - op: validate
+    - op: push
+      fail_on_breaking: true
+    - op: generate
+      if_changed: true
+      no_post_commands: true    # skip sdk.yml pre/post commands
+      clean: true               # remove stale generated files
+      bump: auto                # bump SDK versions from the spec changes
+    - name: pull-staging
+      op: pull
+      environment_id: env_staging
+      directory: ./staging
+
+Examples:
+  # Run a script file
+  blimu batch release.yml
+
+  # Read the script from stdin and print a JSON report
+  cat release.json | blimu batch - --json`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.ScriptPath = args[0]
+			return cmd.Run(cobraCmd)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.ContinueOnError, "continue-on-error", false, "Run every step even if an earlier step fails")
+	cobraCmd.Flags().BoolVar(&cmd.JSON, "json", false, "Print the final report as JSON")
+
+	return cobraCmd
+}
+
+// Run executes the batch command
+func (c *BatchCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	var data []byte
+	var err error
+	if c.ScriptPath == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(c.ScriptPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read batch script: %w", err)
+	}
+
+	script, err := cli.ParseBatchScript(data)
+	if err != nil {
+		return err
+	}
+
+	// --workspace-id and --environment-id override the IDs of the script
+	if sc.WorkspaceID != "" {
+		script.WorkspaceID = sc.WorkspaceID
+	}
+	if sc.EnvironmentID != "" {
+		script.EnvironmentID = sc.EnvironmentID
+	}
+	if c.ContinueOnError {
+		script.ContinueOnError = true
+	}
+
+	// Progress goes to stderr in JSON mode so stdout only carries the report
+	out := io.Writer(os.Stdout)
+	if c.JSON {
+		out = os.Stderr
+	}
+
+	// The script's own IDs override those of the current environment
+	workspaceID, environmentID := sc.IDs()
+	if script.WorkspaceID == "" {
+		script.WorkspaceID = workspaceID
+	}
+	if script.EnvironmentID == "" {
+		script.EnvironmentID = environmentID
+	}
+
+	runner, err := cli.NewFromContext(sc, cli.WithOutput(out))
+	if err != nil {
+		return fmt.Errorf("authentication required for batch. Run 'blimu auth login' first: %w", err)
+	}
+
+	fmt.Fprintf(out, "🔧 Running %d batch step(s)\n", len(script.Steps))
+	report := runner.RunBatch(cmd.Context(), script)
+
+	if c.JSON {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode batch report: %w", err)
+		}
+		fmt.Println(string(encoded))
+	} else {
+		printReport(report)
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("batch failed: %d step(s) failed, %d skipped", report.Failed, report.Skipped)
+	}
+
+	return nil
+}
+
+// printReport prints a per-step summary of a batch run
+func printReport(report *cli.BatchReport) {
+	fmt.Printf("\n📋 Batch summary:\n")
+	for _, step := range report.Steps {
+		switch step.Status {
+		case cli.BatchStatusSucceeded:
+			fmt.Printf("  ✅ %s (%s)\n", step.Name, step.Duration.Round(time.Millisecond))
+		case cli.BatchStatusFailed:
+			fmt.Printf("  ❌ %s: %s\n", step.Name, step.Error)
+		default:
+			fmt.Printf("  ⏭️  %s (skipped)\n", step.Name)
+		}
+	}
+	fmt.Printf("\n  %d succeeded, %d failed, %d skipped\n", report.Succeeded, report.Failed, report.Skipped)
+}
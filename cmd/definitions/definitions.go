@@ -13,6 +13,8 @@ func NewDefinitionsCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewLockCmd())
+	cmd.AddCommand(NewUnlockCmd())
 
 	return cmd
 }
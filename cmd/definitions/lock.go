@@ -0,0 +1,129 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// LockCommand represents the definitions lock and unlock commands
+type LockCommand struct {
+	WorkspaceID   string
+	EnvironmentID string
+	Reason        string
+	Force         bool
+}
+
+// NewLockCmd creates the definitions lock command
+func NewLockCmd() *cobra.Command {
+	cmd := &LockCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Lock an environment's definitions while you edit them",
+		Long: `Take an advisory lock on the environment's definitions so nobody else pushes to it while you
+are editing. Pushes, 'definitions update' and 'env copy-definitions' by anyone else fail with an
+error naming the lock holder until you run 'blimu definitions unlock'.
+
+The lock is tied to this machine: pushes from here keep working while you hold it.
+
+Examples:
+  blimu definitions lock --reason "reworking billing plans"`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunLock(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Reason, "reason", "", "Why the definitions are locked, shown to anyone blocked by the lock")
+
+	return cobraCmd
+}
+
+// NewUnlockCmd creates the definitions unlock command
+func NewUnlockCmd() *cobra.Command {
+	cmd := &LockCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Release the lock on an environment's definitions",
+		Long: `Release the advisory lock taken with 'blimu definitions lock'.
+
+A lock taken by someone else is only released with --force, e.g. when its holder is away.
+
+Examples:
+  blimu definitions unlock
+  blimu definitions unlock --force`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunUnlock(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Force, "force", false, "Release a lock taken by someone else")
+
+	return cobraCmd
+}
+
+// RunLock executes the definitions lock command
+func (c *LockCommand) RunLock(cmd *cobra.Command) error {
+	runner, err := c.runner(cmd)
+	if err != nil {
+		return err
+	}
+
+	lock, err := runner.LockDefinitions(cmd.Context(), c.WorkspaceID, c.EnvironmentID, c.Reason)
+	if errors.Is(err, cli.ErrLocksUnsupported) {
+		return fmt.Errorf("cannot lock definitions: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔒 Definitions of environment %s are locked by %s\n", c.EnvironmentID, lock.LockedBy)
+	if lock.Reason != "" {
+		fmt.Printf("  📝 Reason: %s\n", lock.Reason)
+	}
+	fmt.Printf("💡 Run 'blimu definitions unlock' when you are done\n")
+	return nil
+}
+
+// RunUnlock executes the definitions unlock command
+func (c *LockCommand) RunUnlock(cmd *cobra.Command) error {
+	runner, err := c.runner(cmd)
+	if err != nil {
+		return err
+	}
+
+	lock, err := runner.UnlockDefinitions(cmd.Context(), c.WorkspaceID, c.EnvironmentID, c.Force)
+	if errors.Is(err, cli.ErrLocksUnsupported) {
+		return fmt.Errorf("cannot unlock definitions: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if lock == nil {
+		fmt.Printf("ℹ️  Definitions of environment %s are not locked\n", c.EnvironmentID)
+		return nil
+	}
+	fmt.Printf("🔓 Released the lock of %s on environment %s\n", lock.LockedBy, c.EnvironmentID)
+	return nil
+}
+
+// runner resolves the workspace and environment and returns an authenticated runner
+func (c *LockCommand) runner(cmd *cobra.Command) (*cli.Runner, error) {
+	sc := shared.FromContext(cmd.Context())
+
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("definitions locks"); err != nil {
+		return nil, err
+	}
+
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return nil, fmt.Errorf("authentication required. Run 'blimu auth login' first: %w", err)
+	}
+	return runner, nil
+}
@@ -0,0 +1,203 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/demo"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// DemoCommand represents the demo command
+type DemoCommand struct {
+	Dataset       string
+	Directory     string
+	WorkspaceID   string
+	EnvironmentID string
+	Force         bool
+	Yes           bool
+}
+
+// NewDemoCmd creates the demo command
+func NewDemoCmd() *cobra.Command {
+	cmd := &DemoCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Seed a sandbox environment with an example model",
+		Long: `Seed an environment with a bundled example model and print a tour of commands to try on it.
+
+The 'saas' dataset models organizations that own projects, with owner/admin/member roles on
+organizations inherited by project roles, and free/pro/enterprise plans. Its definition files are
+written to --dir/.blimu so you can inspect and edit them, then pushed to the environment, and a few
+organizations and projects are created.
+
+Users and their roles are created by your application's sign-in and backend; the tour shows
+example users but the CLI does not create them.
+
+Use a sandbox environment: the dataset replaces the environment's definitions. An environment
+that already has resource definitions is refused unless --force is given.
+
+Examples:
+  # Switch to a sandbox environment and seed it
+  blimu env switch sandbox
+  blimu demo
+
+  # Seed another environment and keep the files somewhere else
+  blimu demo --environment-id env_456 --dir ./demo`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Dataset, "dataset", demo.DefaultDataset, fmt.Sprintf("Dataset to seed (%s)", strings.Join(demo.Names(), ", ")))
+	cobraCmd.Flags().StringVar(&cmd.Directory, "dir", "blimu-demo", "Directory to write the dataset's .blimu files to")
+	cobraCmd.Flags().BoolVar(&cmd.Force, "force", false, "Seed an environment that already has definitions and overwrite existing files in --dir")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Seed a protected environment without asking for confirmation")
+
+	return cobraCmd
+}
+
+// Run executes the demo command
+func (c *DemoCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	dataset, err := demo.Load(c.Dataset)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("demo"); err != nil {
+		return err
+	}
+
+	if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "seed demo data into", c.Yes); err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return fmt.Errorf("authentication required. Run 'blimu auth login' first: %w", err)
+	}
+
+	if !c.Force {
+		current, err := runner.API().GetDefinitions(ctx, c.WorkspaceID, c.EnvironmentID)
+		if err != nil {
+			return fmt.Errorf("failed to get current definitions: %w", err)
+		}
+		if len(current.Resources) > 0 {
+			return fmt.Errorf("environment %s already has resource definitions that the demo would replace; seed a sandbox environment or use --force", c.EnvironmentID)
+		}
+	}
+
+	fmt.Printf("🌱 Seeding the '%s' dataset into environment %s\n", dataset.Name, c.EnvironmentID)
+
+	if err := dataset.WriteFiles(c.Directory, c.Force); err != nil {
+		return err
+	}
+	fmt.Printf("📝 Wrote the definition files to %s/.blimu\n", c.Directory)
+
+	if _, err := runner.Push(ctx, cli.PushOptions{
+		Directory:       c.Directory,
+		WorkspaceID:     c.WorkspaceID,
+		EnvironmentID:   c.EnvironmentID,
+		EnvironmentKeys: cliConfig.EnvironmentKeys(c.EnvironmentID),
+		SkipBranchGuard: true,
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Definitions pushed\n")
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+	created, existing, err := c.createResources(ctx, client, dataset.Resources)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Created %d resource(s)", created)
+	if existing > 0 {
+		fmt.Printf(", %d already existed", existing)
+	}
+	fmt.Printf("\n")
+
+	c.printTour(dataset)
+	return nil
+}
+
+// createResources creates the dataset's resources in order, counting those that already exist
+// so that seeding twice is harmless
+func (c *DemoCommand) createResources(ctx context.Context, client *blimu.Client, resources []demo.Resource) (created, existing int, err error) {
+	for _, resource := range resources {
+		body := blimu.ResourceCreateDto{
+			Id:      resource.ID,
+			Type:    resource.Type,
+			Name:    resource.Name,
+			Parents: []map[string]interface{}{},
+		}
+		for _, parent := range resource.Parents {
+			body.Parents = append(body.Parents, map[string]interface{}{"type": parent.Type, "id": parent.ID})
+		}
+
+		_, err := client.Resources.CreateWithContext(ctx, c.WorkspaceID, c.EnvironmentID, body)
+		var apiErr *blimu.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			existing++
+			continue
+		}
+		if err != nil {
+			return created, existing, fmt.Errorf("failed to create resource %s:%s: %w", resource.Type, resource.ID, err)
+		}
+		created++
+	}
+	return created, existing, nil
+}
+
+// printTour prints commands to explore the seeded environment
+func (c *DemoCommand) printTour(dataset *demo.Dataset) {
+	fmt.Printf("\n🧭 Try these next:\n\n")
+	fmt.Printf("  # List the seeded organizations and the projects of one of them\n")
+	fmt.Printf("  blimu resources list --type organization\n")
+	if parent := firstParent(dataset.Resources); parent != nil {
+		fmt.Printf("  blimu resources list --type project --parent %s\n", parent.ID)
+	}
+	fmt.Printf("\n  # Export every resource as JSON lines\n")
+	fmt.Printf("  blimu resources export --format jsonl\n")
+	fmt.Printf("\n  # Inspect and lint the model, then edit it and push your changes\n")
+	fmt.Printf("  blimu validate --offline %s\n", c.Directory)
+	fmt.Printf("  blimu lint %s\n", c.Directory)
+	fmt.Printf("  blimu push %s\n", c.Directory)
+	fmt.Printf("\n  # Serve a local mock of the environment's API\n")
+	fmt.Printf("  blimu mock\n")
+
+	if len(dataset.Users) > 0 {
+		fmt.Printf("\n👥 Example users (created by your application's sign-in, not by the CLI):\n")
+		for _, user := range dataset.Users {
+			fmt.Printf("  %s  %s\n", user.ID, user.Role)
+		}
+	}
+}
+
+// firstParent returns the first parent referenced by the resources, or nil
+func firstParent(resources []demo.Resource) *demo.Parent {
+	for _, resource := range resources {
+		if len(resource.Parents) > 0 {
+			return &resource.Parents[0]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package doctor
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets in the bundle; a secret that is not set stays empty, so support can
+// tell the two apart
+const redacted = "[redacted]"
+
+// secretKeyPattern matches the config keys and environment variables whose values are secrets
+var secretKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|api_?key|authorization|private_key|headers)$`)
+
+// bundleEnvPrefixes select the environment variables described in the bundle
+var bundleEnvPrefixes = []string{"BLIMU_", "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy", "XDG_CONFIG_HOME"}
+
+// bundleFile is a file of the support bundle
+type bundleFile struct {
+	Name        string
+	Description string
+	Data        []byte
+}
+
+// SupportBundleCommand represents the support-bundle command
+type SupportBundleCommand struct {
+	Output  string
+	Offline bool
+	Yes     bool
+}
+
+// NewSupportBundleCmd creates the support-bundle command
+func NewSupportBundleCmd() *cobra.Command {
+	cmd := &SupportBundleCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect diagnostics into a zip to attach to a support ticket",
+		Long: `Collect what support needs to debug a problem into a zip file:
+
+- metadata.json: the CLI version and build, the OS, the active profile and the environment
+  variables of the CLI and of proxies
+- config.yml and credentials.yml: the CLI config of the active profile; tokens and other secrets
+  are redacted, only whether they are set and when they expire is kept
+- doctor.txt: the output of 'blimu doctor'
+- history.jsonl: the last 100 commands run, with their exit codes, errors and request IDs; flag
+  values and arguments are never recorded
+
+Before writing the zip, you can view each file and leave files out. --yes, or running without a
+terminal, writes the bundle without the review.`,
+		Example: `  blimu support-bundle
+  blimu support-bundle -o ticket-1234.zip --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "Path of the zip file (default: blimu-support-<time>.zip)")
+	cobraCmd.Flags().BoolVar(&cmd.Offline, "offline", false, "Skip the doctor checks that contact the platform")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Write the bundle without reviewing its files")
+
+	return cobraCmd
+}
+
+// Run executes the support-bundle command
+func (c *SupportBundleCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	fmt.Printf("📦 Collecting the support bundle...\n")
+	files := []bundleFile{
+		{Name: "metadata.json", Description: "CLI version, OS and environment variables", Data: metadata()},
+	}
+	for _, file := range []struct{ name, description string }{
+		{"config.yml", "CLI config, secrets redacted"},
+		{"credentials.yml", "token expiry, tokens redacted"},
+	} {
+		if data, ok := sanitizedConfigFile(file.name); ok {
+			files = append(files, bundleFile{Name: file.name, Description: file.description, Data: data})
+		}
+	}
+	files = append(files, bundleFile{Name: "doctor.txt", Description: "output of 'blimu doctor'", Data: c.doctorOutput(cmd.Context(), sc)})
+	if data := history(); len(data) > 0 {
+		files = append(files, bundleFile{Name: "history.jsonl", Description: "recent commands", Data: data})
+	}
+
+	if !c.Yes && shared.IsInteractive() {
+		var err error
+		if files, err = review(files); err != nil {
+			return err
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("every file was left out, no bundle written")
+	}
+
+	output := c.Output
+	if output == "" {
+		output = fmt.Sprintf("blimu-support-%s.zip", time.Now().Format("20060102-150405"))
+	}
+	if err := writeBundle(output, files); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Wrote %s (%d files)\n", output, len(files))
+	fmt.Printf("💡 Attach it to your support ticket\n")
+	return nil
+}
+
+// doctorOutput runs the doctor checks of the current directory and returns what they print
+func (c *SupportBundleCommand) doctorOutput(ctx context.Context, sc *shared.Context) []byte {
+	var out bytes.Buffer
+	doctor := &DoctorCommand{Directory: ".", Offline: c.Offline}
+	if err := doctor.diagnose(ctx, sc, &out); err != nil {
+		fmt.Fprintf(&out, "%v\n", err)
+	}
+	return out.Bytes()
+}
+
+// metadata describes the CLI build, the OS and the environment variables of the CLI
+func metadata() []byte {
+	configDir, _ := config.GetConfigDir()
+	profiles, _ := config.ListProfiles()
+	meta := struct {
+		CreatedAt  time.Time         `json:"created_at"`
+		Build      version.Info      `json:"build"`
+		SDKGen     string            `json:"sdk_gen,omitempty"`
+		BaseConfig string            `json:"base_config"`
+		OS         string            `json:"os"`
+		CPUs       int               `json:"cpus"`
+		ConfigDir  string            `json:"config_dir"`
+		Profile    string            `json:"profile"`
+		Profiles   []string          `json:"profiles"`
+		Terminal   bool              `json:"terminal"`
+		Env        map[string]string `json:"env"`
+	}{
+		CreatedAt:  time.Now().UTC(),
+		Build:      version.Build(),
+		SDKGen:     version.Dependency(cli.SDKGenModule),
+		BaseConfig: cli.BaseConfigVersion(),
+		OS:         runtime.GOOS + "/" + runtime.GOARCH,
+		CPUs:       runtime.NumCPU(),
+		ConfigDir:  configDir,
+		Profile:    config.ActiveProfile(),
+		Profiles:   profiles,
+		Terminal:   shared.IsInteractive(),
+		Env:        environmentVariables(),
+	}
+
+	data, _ := json.MarshalIndent(meta, "", "  ")
+	return append(data, '\n')
+}
+
+// environmentVariables returns the environment variables of the CLI and of proxies, with secrets
+// redacted and credentials removed from proxy URLs
+func environmentVariables() map[string]string {
+	vars := make(map[string]string)
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if !hasAnyPrefix(name, bundleEnvPrefixes) {
+			continue
+		}
+		switch {
+		case secretKeyPattern.MatchString(name) && value != "":
+			value = redacted
+		case strings.Contains(strings.ToLower(name), "proxy"):
+			value = redactURL(value)
+		}
+		vars[name] = value
+	}
+	return vars
+}
+
+// redactURL removes the password of a URL, e.g. of an authenticating proxy
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	}
+	return u.String()
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizedConfigFile returns the named file of the active profile (config.yml or
+// credentials.yml) with its secrets redacted, or a note when it cannot be read. ok is false when
+// the file does not exist.
+func sanitizedConfigFile(name string) ([]byte, bool) {
+	var path string
+	var err error
+	if name == "credentials.yml" {
+		path, err = config.GetCredentialsPath()
+	} else {
+		path, err = config.GetCLIConfigPath()
+	}
+	if err != nil {
+		return []byte(fmt.Sprintf("# %v\n", err)), true
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false
+	}
+	if err != nil {
+		return []byte(fmt.Sprintf("# %v\n", err)), true
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		// Never include a file that cannot be sanitized
+		return []byte(fmt.Sprintf("# %s is not valid YAML and was left out: %v\n", path, err)), true
+	}
+	redactNode(&doc)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return []byte(fmt.Sprintf("# failed to encode %s: %v\n", path, err)), true
+	}
+	return append([]byte(fmt.Sprintf("# %s\n", path)), out...), true
+}
+
+// redactNode replaces the values of secret keys in a YAML document, at any depth
+func redactNode(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if secretKeyPattern.MatchString(key.Value) && value.Kind == yaml.ScalarNode && value.Value != "" {
+				value.Value = redacted
+				value.Tag = "!!str"
+				value.Style = 0
+				continue
+			}
+			redactNode(value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		redactNode(child)
+	}
+}
+
+// history returns the recorded commands as JSON lines, empty when none were recorded
+func history() []byte {
+	entries, err := config.LoadHistory()
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\": %q}\n", err.Error()))
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	for _, entry := range entries {
+		encoder.Encode(entry)
+	}
+	return out.Bytes()
+}
+
+// review lists the files of the bundle and lets the user view them and leave some out, until
+// they press Enter
+func review(files []bundleFile) ([]bundleFile, error) {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("\nThe bundle will contain:\n")
+		for i, file := range files {
+			fmt.Printf("  %d. %-16s %8s  %s\n", i+1, file.Name, formatSize(len(file.Data)), file.Description)
+		}
+		if len(files) == 0 {
+			fmt.Printf("  (nothing)\n")
+		}
+		fmt.Printf("Enter a number to view a file, -<number> to leave it out, or press Enter to write the bundle: ")
+
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("failed to read the answer: %w", err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			return files, nil
+		}
+
+		remove := strings.HasPrefix(answer, "-")
+		n, convErr := strconv.Atoi(strings.TrimPrefix(answer, "-"))
+		if convErr != nil || n < 1 || n > len(files) {
+			fmt.Printf("❌ Enter a number between 1 and %d\n", len(files))
+			continue
+		}
+
+		if remove {
+			fmt.Printf("🗑️  Left out %s\n", files[n-1].Name)
+			files = append(files[:n-1], files[n:]...)
+			continue
+		}
+		fmt.Printf("\n----- %s -----\n%s", files[n-1].Name, files[n-1].Data)
+		if !bytes.HasSuffix(files[n-1].Data, []byte("\n")) {
+			fmt.Println()
+		}
+		fmt.Printf("----- end of %s -----\n", files[n-1].Name)
+	}
+}
+
+// formatSize formats a file size for the review, e.g. "1.2 KB"
+func formatSize(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
+
+// writeBundle writes the files into a zip readable by the user only, as it describes their setup
+func writeBundle(path string, files []bundleFile) error {
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer out.Close()
+
+	archive := zip.NewWriter(out)
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if _, err := w.Write(file.Data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return out.Close()
+}
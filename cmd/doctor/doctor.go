@@ -0,0 +1,332 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/blimu"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/schema"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// checkTimeout limits each request made to the platform
+const checkTimeout = 10 * time.Second
+
+// Clock skew beyond these is reported: tokens are judged expired or not yet valid by the clock
+const (
+	skewWarning = 30 * time.Second
+	skewFailure = 5 * time.Minute
+)
+
+// status is the outcome of a check
+type status int
+
+const (
+	statusOK status = iota
+	statusWarning
+	statusFailed
+)
+
+// result is the outcome of a check and, unless it passed, how to fix it
+type result struct {
+	Name   string
+	Status status
+	Detail string
+	Fix    string
+}
+
+// DoctorCommand represents the doctor command
+type DoctorCommand struct {
+	Directory string
+	Offline   bool
+
+	results []result
+}
+
+// NewDoctorCmd creates the doctor command
+func NewDoctorCmd() *cobra.Command {
+	cmd := &DoctorCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "doctor [directory]",
+		Short: "Diagnose common setup problems",
+		Long: `Check the CLI setup and print how to fix what is wrong:
+
+- the CLI config and credentials files
+- the current environment and the expiry of its tokens
+- connectivity to the platform API and the clock skew against it
+- the .blimu configuration of the directory (default: current directory)
+- the sdk.yml clients and the SDK generator
+
+Exits with an error when a check fails; warnings do not fail.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.Directory = "."
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			}
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Offline, "offline", false, "Skip the checks that contact the platform")
+
+	return cobraCmd
+}
+
+// Run executes the doctor command
+func (c *DoctorCommand) Run(cmd *cobra.Command) error {
+	return c.diagnose(cmd.Context(), shared.FromContext(cmd.Context()), os.Stdout)
+}
+
+// diagnose runs the checks and writes their results to w
+func (c *DoctorCommand) diagnose(ctx context.Context, sc *shared.Context, w io.Writer) error {
+	fmt.Fprintf(w, "🩺 Checking your Blimu setup...\n\n")
+
+	cliConfig, env := c.checkConfig(sc)
+	if env != nil {
+		c.checkTokens(env)
+		if !c.Offline {
+			c.checkPlatform(ctx, sc, cliConfig, env)
+		}
+	}
+	c.checkBlimuFiles()
+	c.checkSDKGenerator()
+
+	failed, warnings := 0, 0
+	for _, r := range c.results {
+		icon := "✅"
+		switch r.Status {
+		case statusWarning:
+			icon = "⚠️ "
+			warnings++
+		case statusFailed:
+			icon = "❌"
+			failed++
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", icon, r.Name, r.Detail)
+		if r.Status != statusOK && r.Fix != "" {
+			fmt.Fprintf(w, "   💡 %s\n", r.Fix)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed, %d warning(s)", failed, warnings)
+	}
+	if warnings > 0 {
+		fmt.Fprintf(w, "✅ No problems found, %d warning(s)\n", warnings)
+		return nil
+	}
+	fmt.Fprintf(w, "✅ No problems found\n")
+	return nil
+}
+
+func (c *DoctorCommand) add(name string, s status, detail, fix string) {
+	c.results = append(c.results, result{Name: name, Status: s, Detail: detail, Fix: fix})
+}
+
+// checkConfig checks the CLI config and credentials files and returns the active environment,
+// nil when there is none
+func (c *DoctorCommand) checkConfig(sc *shared.Context) (*config.CLIConfig, *config.Environment) {
+	configPath, err := config.GetCLIConfigPath()
+	if err != nil {
+		c.add("CLI config", statusFailed, err.Error(), "Set "+config.ConfigDirEnv+" to a writable directory")
+		return nil, nil
+	}
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		c.add("CLI config", statusFailed, err.Error(), fmt.Sprintf("Fix the YAML of %s, or move it away and run 'blimu auth login'", configPath))
+		return nil, nil
+	}
+	detail := configPath
+	if profile := config.ActiveProfile(); profile != config.DefaultProfile {
+		detail += fmt.Sprintf(" (profile %s)", profile)
+	}
+	c.add("CLI config", statusOK, detail, "")
+
+	if credentialsPath, err := config.GetCredentialsPath(); err == nil {
+		if info, err := os.Stat(credentialsPath); err == nil && info.Mode().Perm()&0077 != 0 {
+			c.add("Credentials file", statusWarning, fmt.Sprintf("%s is readable by other users (%s)", credentialsPath, info.Mode().Perm()),
+				fmt.Sprintf("Run 'chmod 600 %s'", credentialsPath))
+		}
+	}
+
+	_, env, err := sc.EnvironmentInfo()
+	if err != nil {
+		c.add("Environment", statusFailed, err.Error(), "Run 'blimu auth login', or switch to an environment with 'blimu env switch'")
+		return cliConfig, nil
+	}
+	name := shared.ActiveEnvironmentName(cliConfig)
+	if name == "" {
+		name = "from " + shared.AccessTokenEnv
+	}
+	c.add("Environment", statusOK, fmt.Sprintf("%s (workspace %s, environment %s)", name, orNone(env.WorkspaceID), orNone(env.ID)), "")
+	if env.ID == "" || env.WorkspaceID == "" {
+		c.add("Environment IDs", statusWarning, "the environment has no workspace or environment ID",
+			"Run 'blimu auth login' again, or pass --workspace-id and --environment-id")
+	}
+
+	return cliConfig, env
+}
+
+// checkTokens checks that the environment has tokens and when they expire
+func (c *DoctorCommand) checkTokens(env *config.Environment) {
+	switch {
+	case env.ReauthRequired:
+		c.add("Tokens", statusFailed, "the session expired and the refresh token was rejected", "Run 'blimu auth login'")
+	case env.AccessToken == "":
+		c.add("Tokens", statusFailed, "not logged in", "Run 'blimu auth login', or set "+shared.AccessTokenEnv)
+	case env.ExpiresAt == nil:
+		c.add("Tokens", statusOK, "access token without expiry", "")
+	case time.Now().After(*env.ExpiresAt) && env.RefreshToken == "":
+		c.add("Tokens", statusFailed, fmt.Sprintf("access token expired at %s and cannot be refreshed", env.ExpiresAt.Format(time.RFC3339)), "Run 'blimu auth login'")
+	case time.Now().After(*env.ExpiresAt):
+		c.add("Tokens", statusOK, "access token expired; it is refreshed on the next request", "")
+	default:
+		c.add("Tokens", statusOK, fmt.Sprintf("access token valid until %s", env.ExpiresAt.Local().Format("2006-01-02 15:04")), "")
+	}
+}
+
+// checkPlatform checks that the platform API can be reached, the clock agrees with it and the
+// tokens are accepted
+func (c *DoctorCommand) checkPlatform(ctx context.Context, sc *shared.Context, cliConfig *config.CLIConfig, env *config.Environment) {
+	apiURL := shared.PlatformURL(cliConfig, env, sc.DevMode)
+
+	httpClient, err := shared.HTTPClient(cliConfig, env)
+	if err != nil {
+		c.add("Platform API", statusFailed, err.Error(), "Fix the http settings of the CLI config")
+		return
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		c.add("Platform API", statusFailed, err.Error(), "Fix the api_url of the environment in the CLI config")
+		return
+	}
+	sent := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.add("Platform API", statusFailed, fmt.Sprintf("cannot reach %s: %v", apiURL, err),
+			"Check your network and the HTTPS_PROXY/NO_PROXY variables; behind a TLS-intercepting proxy, pass its CA with --ca-cert")
+		return
+	}
+	resp.Body.Close()
+	elapsed := time.Since(sent)
+	c.add("Platform API", statusOK, fmt.Sprintf("%s reachable (%s)", apiURL, elapsed.Round(time.Millisecond)), "")
+
+	if serverTime, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		// The server's clock is compared with the middle of the request
+		skew := sent.Add(elapsed / 2).Sub(serverTime).Round(time.Second)
+		magnitude := skew
+		if magnitude < 0 {
+			magnitude = -magnitude
+		}
+		fix := "Synchronize the system clock, e.g. enable NTP"
+		switch {
+		case magnitude > skewFailure:
+			c.add("Clock", statusFailed, fmt.Sprintf("differs from the platform by %s", skew), fix)
+		case magnitude > skewWarning:
+			c.add("Clock", statusWarning, fmt.Sprintf("differs from the platform by %s", skew), fix)
+		default:
+			c.add("Clock", statusOK, "in sync with the platform", "")
+		}
+	}
+
+	if env.AccessToken == "" {
+		return
+	}
+	client, err := sc.Client()
+	if err != nil {
+		c.add("Authentication", statusFailed, err.Error(), "Run 'blimu auth login'")
+		return
+	}
+	if _, err := client.Me.GetAccessWithContext(reqCtx); err != nil {
+		fix := "Run 'blimu auth login'"
+		if shared.ExitCode(err) != shared.ExitAuth {
+			fix = "Retry later; include the request ID when contacting support"
+		}
+		c.add("Authentication", statusFailed, err.Error(), fix)
+		return
+	}
+	c.add("Authentication", statusOK, "tokens accepted by the platform", "")
+}
+
+// checkBlimuFiles checks the .blimu configuration of the directory
+func (c *DoctorCommand) checkBlimuFiles() {
+	blimuDir := filepath.Join(c.Directory, ".blimu")
+	if info, err := os.Stat(blimuDir); err != nil || !info.IsDir() {
+		c.add(".blimu files", statusWarning, fmt.Sprintf("no .blimu directory in %s", c.Directory),
+			"Run 'blimu init' to create one, or run doctor in your project directory")
+		return
+	}
+
+	problems, err := schema.ValidateDirectory(blimuDir)
+	if err != nil {
+		c.add(".blimu files", statusFailed, err.Error(), "Run 'blimu validate' for details")
+		return
+	}
+	if len(problems) > 0 {
+		c.add(".blimu files", statusFailed, fmt.Sprintf("%d schema error(s), e.g. %s", len(problems), problems[0]), "Run 'blimu validate' for details")
+		return
+	}
+
+	blimuConfig, err := config.LoadBlimuConfig(c.Directory)
+	if err != nil {
+		c.add(".blimu files", statusFailed, err.Error(), "Run 'blimu validate' for details")
+		return
+	}
+	if result := blimu.ValidateConfig(blimuConfig); !result.Valid {
+		c.add(".blimu files", statusFailed, fmt.Sprintf("%d validation error(s), e.g. %s", len(result.Errors), result.Errors[0]), "Run 'blimu validate' for details")
+		return
+	}
+	c.add(".blimu files", statusOK, fmt.Sprintf("%s is valid", blimuDir), "")
+}
+
+// checkSDKGenerator checks sdk.yml and that the SDK generator supports its clients
+func (c *DoctorCommand) checkSDKGenerator() {
+	generator := strings.TrimSpace("sdk-gen " + version.Dependency(cli.SDKGenModule))
+
+	supported, err := cli.SDKClientTypes()
+	if err != nil {
+		c.add("SDK generator", statusFailed, fmt.Sprintf("%s: %v", generator, err), "Reinstall the CLI")
+		return
+	}
+	c.add("SDK generator", statusOK, fmt.Sprintf("%s (%d client types)", generator, len(supported)), "")
+
+	sdkPath := filepath.Join(c.Directory, ".blimu", "sdk.yml")
+	if _, err := os.Stat(sdkPath); err != nil {
+		return
+	}
+	report, err := cli.ValidateSDKConfigFile(sdkPath)
+	if err != nil {
+		c.add("sdk.yml", statusFailed, err.Error(), "Run 'blimu sdk validate' for details")
+		return
+	}
+	if errs := report.Errors(); errs > 0 {
+		c.add("sdk.yml", statusFailed, fmt.Sprintf("%d error(s)", errs), "Run 'blimu sdk validate' for details")
+		return
+	}
+	c.add("sdk.yml", statusOK, fmt.Sprintf("%s is valid", sdkPath), "")
+}
+
+// orNone returns value, or "none" when it is empty
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
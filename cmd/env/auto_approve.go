@@ -0,0 +1,102 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// AutoApproveCommand represents the auto-approve environment command
+type AutoApproveCommand struct {
+	EnvName string
+	Setting string
+}
+
+// NewAutoApproveCmd creates the auto-approve command
+func NewAutoApproveCmd() *cobra.Command {
+	cmd := &AutoApproveCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "auto-approve <environment-name> [true | false | unset]",
+		Short: "Show or change whether confirmation prompts are skipped for an environment",
+		Long: `Commands that change an environment (push, resources delete, protected environment checks,
+...) ask for confirmation first. Whether they ask is decided, in order, by:
+
+  1. the command's --yes flag or the global --auto-approve flag
+  2. the environment's auto_approve setting, changed with this command
+  3. the ` + shared.AutoApproveEnv + ` environment variable
+
+Set auto_approve to false to keep an environment prompting in automation that sets
+` + shared.AutoApproveEnv + `, or to true to stop a sandbox environment from asking.
+
+Environments matching a protected_environments selector ignore --auto-approve and
+` + shared.AutoApproveEnv + `: their protection check is only skipped by --yes or by setting
+auto_approve to true here.
+
+Examples:
+  # Show the setting of an environment
+  blimu env auto-approve prod
+
+  # Always ask before changing prod, even when BLIMU_AUTO_APPROVE is set
+  blimu env auto-approve prod false
+
+  # Go back to following BLIMU_AUTO_APPROVE
+  blimu env auto-approve prod unset`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.EnvName = args[0]
+			if len(args) > 1 {
+				cmd.Setting = args[1]
+			}
+			return cmd.Run()
+		},
+	}
+
+	return cobraCmd
+}
+
+// Run executes the auto-approve command
+func (c *AutoApproveCommand) Run() error {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load CLI config: %w", err)
+	}
+
+	env, exists := cliConfig.Environments[c.EnvName]
+	if !exists {
+		return shared.Errorf(shared.ErrNotFound, "environment '%s' not found. Use 'blimu env list' to see configured environments", c.EnvName)
+	}
+
+	switch c.Setting {
+	case "":
+		fmt.Printf("auto_approve: %s\n", describeAutoApprove(env.AutoApprove))
+		return nil
+	case "true", "false":
+		approve := c.Setting == "true"
+		env.AutoApprove = &approve
+	case "unset":
+		env.AutoApprove = nil
+	default:
+		return fmt.Errorf("invalid setting '%s': use true, false or unset", c.Setting)
+	}
+
+	if err := cliConfig.UpdateEnvironment(c.EnvName, env); err != nil {
+		return fmt.Errorf("failed to save auto_approve: %w", err)
+	}
+	fmt.Printf("✅ auto_approve of environment '%s': %s\n", c.EnvName, describeAutoApprove(env.AutoApprove))
+	return nil
+}
+
+// describeAutoApprove formats an environment's auto_approve setting
+func describeAutoApprove(setting *bool) string {
+	switch {
+	case setting == nil:
+		return "unset (follows " + shared.AutoApproveEnv + ")"
+	case *setting:
+		return "true (confirmation prompts are skipped)"
+	default:
+		return "false (always ask)"
+	}
+}
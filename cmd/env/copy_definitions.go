@@ -0,0 +1,208 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	platform "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// definitionSections lists the definition sections that can be copied between environments
+var definitionSections = []string{"resources", "entitlements", "features", "plans"}
+
+// CopyDefinitionsCommand represents the copy-definitions command
+type CopyDefinitionsCommand struct {
+	WorkspaceID     string
+	FromEnvironment string
+	ToEnvironment   string
+	ToWorkspaceID   string
+	Sections        []string
+	DryRun          bool
+	Yes             bool
+}
+
+// NewCopyDefinitionsCmd creates the copy-definitions command
+func NewCopyDefinitionsCmd() *cobra.Command {
+	cmd := &CopyDefinitionsCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "copy-definitions",
+		Short: "Copy selected definition sections between environments",
+		Long: `Copy only the selected definition sections (resources, entitlements, features, plans)
+from one environment to another. Sections that are not selected are left untouched
+in the target environment.
+
+Examples:
+  # Sync plans and features from production into the current environment
+  blimu env copy-definitions --from env_prod --sections plans,features
+
+  # Copy resources into an explicit target environment
+  blimu env copy-definitions --from env_prod --to env_staging --sections resources
+
+  # Environments can also be given by lookup key or name
+  blimu env copy-definitions --from production --to staging --sections plans`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.FromEnvironment, "from", "", "Source environment ID, lookup key or name (required)")
+	cobraCmd.Flags().StringVar(&cmd.ToEnvironment, "to", "", "Target environment ID, lookup key or name (uses current environment ID if not specified)")
+	cobraCmd.Flags().StringVar(&cmd.ToWorkspaceID, "to-workspace-id", "", "Workspace ID of the target environment (defaults to the source workspace)")
+	cobraCmd.Flags().StringSliceVar(&cmd.Sections, "sections", nil, "Comma-separated sections to copy (resources, entitlements, features, plans)")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Show what would be copied without updating the target environment")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Copy into a protected environment without asking for confirmation")
+	cobraCmd.MarkFlagRequired("from")
+	cobraCmd.MarkFlagRequired("sections")
+
+	return cobraCmd
+}
+
+// Run executes the copy-definitions command
+func (c *CopyDefinitionsCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	sections, err := parseSections(c.Sections)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+
+	// The target defaults to the current environment
+	var environmentID string
+	c.WorkspaceID, environmentID = sc.IDs()
+	if c.ToEnvironment == "" && environmentID != "" {
+		c.ToEnvironment = environmentID
+		fmt.Printf("📋 Using current environment as target: %s\n", c.ToEnvironment)
+	}
+	if c.ToWorkspaceID == "" {
+		c.ToWorkspaceID = c.WorkspaceID
+	}
+
+	if c.WorkspaceID == "" {
+		return fmt.Errorf("workspace-id is required for copy-definitions. Provide --workspace-id flag")
+	}
+	if c.ToEnvironment == "" {
+		return fmt.Errorf("target environment is required. Provide --to flag")
+	}
+
+	// Get platform SDK client
+	sdk, err := sc.Client()
+	if err != nil {
+		return fmt.Errorf("authentication required for copy-definitions. Run 'blimu auth login' first: %w", err)
+	}
+
+	// --from and --to also take the lookup key or name of an environment
+	if c.FromEnvironment, err = shared.ResolveEnvironmentID(cmd.Context(), sdk, c.WorkspaceID, c.FromEnvironment); err != nil {
+		return err
+	}
+	if c.ToEnvironment, err = shared.ResolveEnvironmentID(cmd.Context(), sdk, c.ToWorkspaceID, c.ToEnvironment); err != nil {
+		return err
+	}
+	if c.FromEnvironment == c.ToEnvironment && c.WorkspaceID == c.ToWorkspaceID {
+		return fmt.Errorf("source and target environments are the same")
+	}
+
+	fmt.Printf("📥 Reading definitions from environment '%s'...\n", c.FromEnvironment)
+	source, err := sdk.Definitions.Get(c.WorkspaceID, c.FromEnvironment)
+	if err != nil {
+		return fmt.Errorf("failed to read source definitions: %w", err)
+	}
+
+	// Only selected sections are populated; empty sections are preserved by the API
+	request := platform.DefinitionUpdateDto{
+		Resources:    make(map[string]interface{}),
+		Entitlements: make(map[string]interface{}),
+		Features:     make(map[string]interface{}),
+		Plans:        make(map[string]interface{}),
+	}
+
+	for _, section := range sections {
+		var data map[string]interface{}
+		switch section {
+		case "resources":
+			data = source.Resources
+			request.Resources = data
+		case "entitlements":
+			data = source.Entitlements
+			request.Entitlements = data
+		case "features":
+			data = source.Features
+			request.Features = data
+		case "plans":
+			data = source.Plans
+			request.Plans = data
+		}
+
+		if len(data) == 0 {
+			fmt.Printf("⚠️  Section '%s' is empty in the source environment and will not change the target\n", section)
+		} else {
+			fmt.Printf("  📋 %s: %d item(s)\n", section, len(data))
+		}
+	}
+
+	if c.DryRun {
+		fmt.Printf("🔍 Dry run: no changes made to environment '%s'\n", c.ToEnvironment)
+		return nil
+	}
+
+	if err := shared.ConfirmProtectedEnvironment(cliConfig, c.ToEnvironment, "copy definitions to", c.Yes); err != nil {
+		return err
+	}
+
+	if err := cli.New(cli.NewPlatformAPI(sdk)).CheckDefinitionsLock(cmd.Context(), c.ToWorkspaceID, c.ToEnvironment); err != nil {
+		return err
+	}
+
+	fmt.Printf("📤 Copying %s to environment '%s'...\n", strings.Join(sections, ", "), c.ToEnvironment)
+	if _, err := sdk.Definitions.Update(c.ToWorkspaceID, c.ToEnvironment, request); err != nil {
+		return fmt.Errorf("failed to update target definitions: %w", err)
+	}
+
+	fmt.Printf("✅ Definitions copied successfully!\n")
+	fmt.Printf("  📥 From: %s\n", c.FromEnvironment)
+	fmt.Printf("  📤 To: %s\n", c.ToEnvironment)
+	fmt.Printf("  📋 Sections: %s\n", strings.Join(sections, ", "))
+
+	return nil
+}
+
+// parseSections normalizes and validates the requested section names
+func parseSections(requested []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var sections []string
+
+	for _, raw := range requested {
+		section := strings.ToLower(strings.TrimSpace(raw))
+		if section == "" || seen[section] {
+			continue
+		}
+
+		valid := false
+		for _, known := range definitionSections {
+			if section == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown section '%s'. Valid sections: %s", section, strings.Join(definitionSections, ", "))
+		}
+
+		seen[section] = true
+		sections = append(sections, section)
+	}
+
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("at least one section must be specified with --sections")
+	}
+
+	return sections, nil
+}
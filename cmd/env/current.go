@@ -3,6 +3,7 @@ package env
 import (
 	"fmt"
 
+	"github.com/blimu-dev/blimu-cli/pkg/config"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
 	"github.com/spf13/cobra"
 )
@@ -18,22 +19,38 @@ func NewCurrentCmd() *cobra.Command {
 }
 
 func runCurrent(cmd *cobra.Command, args []string) error {
+	sc := shared.FromContext(cmd.Context())
+
 	// Get current environment info
-	cliConfig, currentEnv, err := shared.GetCurrentEnvironmentInfo()
+	cliConfig, currentEnv, err := sc.EnvironmentInfo()
 	if err != nil {
+		if shared.EnvironmentOverride() != "" {
+			return err
+		}
 		fmt.Println("No current environment set.")
-		fmt.Println("Use 'blimu env create <name>' to create an environment.")
+		fmt.Println("Run 'blimu auth login' to add an environment.")
 		return nil
 	}
 
 	// Show local configuration
-	fmt.Printf("Current environment: %s\n", cliConfig.CurrentEnvironment)
+	if name := shared.ActiveEnvironmentName(cliConfig); name != cliConfig.CurrentEnvironment {
+		fmt.Printf("Environment: %s (selected with --env; current is %s)\n", name, cliConfig.CurrentEnvironment)
+	} else {
+		fmt.Printf("Current environment: %s\n", cliConfig.CurrentEnvironment)
+	}
 
-	apiURL := currentEnv.APIURL
-	if apiURL == "" {
-		apiURL = cliConfig.DefaultAPIURL
+	if profile := config.ActiveProfile(); profile != config.DefaultProfile {
+		fmt.Printf("  Profile: %s\n", profile)
 	}
-	fmt.Printf("  API URL: %s\n", apiURL)
+
+	endpoints := shared.Endpoints(cliConfig, currentEnv, sc.DevMode)
+	fmt.Printf("  API environment: %s\n", shared.APIEnvironment())
+	if shared.APIEnvironment() == config.APIEnvProd {
+		fmt.Printf("  Region: %s\n", cliConfig.EnvironmentRegion(currentEnv))
+	}
+	fmt.Printf("  API URL: %s\n", endpoints.PlatformAPI)
+	fmt.Printf("  Runtime API URL: %s\n", endpoints.RuntimeAPI)
+	fmt.Printf("  Docs: %s\n", endpoints.Docs)
 
 	if currentEnv.LookupKey != "" {
 		fmt.Printf("  Lookup Key: %s\n", currentEnv.LookupKey)
@@ -48,7 +65,9 @@ func runCurrent(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show authentication status
-	if currentEnv.IsOAuthAuthenticated() {
+	if currentEnv.ReauthRequired {
+		fmt.Printf("  Authentication: Expired (run 'blimu auth login')\n")
+	} else if currentEnv.IsOAuthAuthenticated() {
 		fmt.Printf("  Authentication: OAuth")
 		if currentEnv.ExpiresAt != nil {
 			fmt.Printf(" (expires: %s)", currentEnv.ExpiresAt.Format("2006-01-02 15:04:05"))
@@ -58,11 +77,5 @@ func runCurrent(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Authentication: None (run 'blimu auth login')\n")
 	}
 
-	// Note about platform API requirements
-	if currentEnv.ID != "" {
-		fmt.Printf("\n⚠️  Note: Platform API environment details require workspace ID.\n")
-		fmt.Printf("Use 'blimu env create --workspace-id <id>' for full API integration.\n")
-	}
-
 	return nil
 }
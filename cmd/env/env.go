@@ -15,6 +15,11 @@ func NewEnvCmd() *cobra.Command {
 	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewSwitchCmd())
 	cmd.AddCommand(NewCurrentCmd())
+	cmd.AddCommand(NewLabelCmd())
+	cmd.AddCommand(NewProtectCmd())
+	cmd.AddCommand(NewAutoApproveCmd())
+	cmd.AddCommand(NewCopyDefinitionsCmd())
+	cmd.AddCommand(NewGCCmd())
 
 	return cmd
 }
@@ -0,0 +1,125 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// GCCommand represents the env gc command
+type GCCommand struct {
+	Yes    bool
+	DryRun bool
+}
+
+// NewGCCmd creates the env gc command
+func NewGCCmd() *cobra.Command {
+	cmd := &GCCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove local environments that no longer work",
+		Long: `Check every locally configured environment against the platform and remove the ones
+that are dead: environments that no longer exist in the cloud, and environments whose tokens
+are expired and cannot be refreshed.
+
+Environments that cannot be checked (for example when the platform is unreachable or the
+environment was never authenticated) are reported but never removed.
+
+Examples:
+  # Review dead environments and confirm removal
+  blimu env gc
+
+  # Only report, never remove
+  blimu env gc --dry-run
+
+  # Remove without prompting
+  blimu env gc --yes`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), shared.FromContext(cobraCmd.Context()).DevMode)
+		},
+	}
+
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Remove dead environments without prompting")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Only report dead environments")
+
+	return cobraCmd
+}
+
+// Run executes the env gc command
+func (c *GCCommand) Run(ctx context.Context, devMode bool) error {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load CLI config: %w", err)
+	}
+
+	if len(cliConfig.Environments) == 0 {
+		fmt.Println("No local environments configured.")
+		return nil
+	}
+
+	names := make([]string, 0, len(cliConfig.Environments))
+	for name := range cliConfig.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("🔍 Checking %d local environment(s)...\n\n", len(names))
+
+	var dead []shared.EnvironmentHealth
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATE\tREASON")
+	for _, name := range names {
+		health := shared.CheckEnvironmentHealth(ctx, cliConfig, name, devMode)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, health.State, health.Reason)
+		if health.Dead() {
+			dead = append(dead, health)
+		}
+	}
+	w.Flush()
+	fmt.Println()
+
+	if len(dead) == 0 {
+		fmt.Println("✅ No dead environments found")
+		return nil
+	}
+
+	if c.DryRun {
+		fmt.Printf("🔍 Dry run: %d dead environment(s) would be removed\n", len(dead))
+		return nil
+	}
+
+	approved, err := shared.AutoApproved(nil, "", c.Yes)
+	if err != nil {
+		return err
+	}
+	confirmed, err := shared.Confirm(fmt.Sprintf("Remove %d dead environment(s)?", len(dead)), fmt.Sprintf("remove %d dead environment(s)", len(dead)), approved)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Aborted; no environments were removed.")
+		return nil
+	}
+
+	for _, health := range dead {
+		if err := cliConfig.RemoveEnvironment(health.Name); err != nil {
+			return fmt.Errorf("failed to remove environment '%s': %w", health.Name, err)
+		}
+		fmt.Printf("🗑️  Removed '%s' (%s)\n", health.Name, health.State)
+	}
+
+	if cliConfig.CurrentEnvironment != "" {
+		fmt.Printf("\nCurrent environment: %s\n", cliConfig.CurrentEnvironment)
+	} else {
+		fmt.Printf("\nNo environments left. Run 'blimu auth login' to add one.\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// LabelCommand represents the label environment command
+type LabelCommand struct {
+	EnvName string
+	Args    []string
+}
+
+// NewLabelCmd creates the label command
+func NewLabelCmd() *cobra.Command {
+	cmd := &LabelCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "label <environment-name> [key=value | key-]...",
+		Short: "Show or change the labels of an environment",
+		Long: `Attach labels to a configured environment, e.g. team=payments or tier=prod. Labels are
+stored in the local CLI config and can be used to filter 'blimu env list --selector' and
+to protect environments with 'blimu env protect'.
+
+Examples:
+  # Show the labels of an environment
+  blimu env label prod
+
+  # Set labels
+  blimu env label prod team=payments tier=prod
+
+  # Remove a label
+  blimu env label prod team-`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.EnvName = args[0]
+			cmd.Args = args[1:]
+			return cmd.Run()
+		},
+	}
+
+	return cobraCmd
+}
+
+// Run executes the label command
+func (c *LabelCommand) Run() error {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load CLI config: %w", err)
+	}
+
+	env, exists := cliConfig.Environments[c.EnvName]
+	if !exists {
+		return shared.Errorf(shared.ErrNotFound, "environment '%s' not found. Use 'blimu env list' to see configured environments", c.EnvName)
+	}
+
+	if len(c.Args) == 0 {
+		if len(env.Labels) == 0 {
+			fmt.Printf("Environment '%s' has no labels.\n", c.EnvName)
+			return nil
+		}
+		keys := make([]string, 0, len(env.Labels))
+		for key := range env.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("%s=%s\n", key, env.Labels[key])
+		}
+		return nil
+	}
+
+	set, remove, err := config.ParseLabelArgs(c.Args)
+	if err != nil {
+		return err
+	}
+
+	if env.Labels == nil {
+		env.Labels = make(map[string]string)
+	}
+	for key, value := range set {
+		env.Labels[key] = value
+	}
+	for _, key := range remove {
+		delete(env.Labels, key)
+	}
+
+	if err := cliConfig.UpdateEnvironment(c.EnvName, env); err != nil {
+		return fmt.Errorf("failed to save labels: %w", err)
+	}
+
+	if len(env.Labels) == 0 {
+		fmt.Printf("✅ Removed all labels from environment '%s'\n", c.EnvName)
+	} else {
+		fmt.Printf("✅ Labels of environment '%s': %s\n", c.EnvName, config.FormatLabels(env.Labels))
+	}
+	return nil
+}
@@ -3,8 +3,10 @@ package env
 import (
 	"fmt"
 	"os"
+	"sort"
 	"text/tabwriter"
 
+	"github.com/blimu-dev/blimu-cli/pkg/config"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +14,7 @@ import (
 // ListCommand represents the list environments command
 type ListCommand struct {
 	WorkspaceID string
+	Selector    string
 }
 
 // NewListCmd creates the list command
@@ -21,26 +24,49 @@ func NewListCmd() *cobra.Command {
 	cobraCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List environments from API",
-		Long:  `List all environments from the API and show which one is currently active locally`,
+		Long: `List all environments from the API and show which one is currently active locally.
+
+Use --selector to show only environments whose local labels match, e.g.
+'blimu env list --selector team=payments,tier!=dev'. Labels are set with 'blimu env label'.`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
-			return cmd.Run()
+			return cmd.Run(cobraCmd)
 		},
 	}
 
-	cobraCmd.Flags().StringVar(&cmd.WorkspaceID, "workspace-id", "", "Workspace ID (required for platform API)")
+	cobraCmd.Flags().StringVarP(&cmd.Selector, "selector", "l", "", "Only list environments whose labels match this selector (e.g. team=payments,tier!=dev)")
 
 	return cobraCmd
 }
 
-func (c *ListCommand) Run() error {
-	cliConfig, currentEnv, err := shared.GetCurrentEnvironmentInfo()
+func (c *ListCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	var selector config.Selector
+	if c.Selector != "" {
+		parsed, err := config.ParseSelector(c.Selector)
+		if err != nil {
+			return err
+		}
+		selector = parsed
+	}
+
+	cliConfig, currentEnv, err := sc.EnvironmentInfo()
 	if err != nil {
 		fmt.Println("No current environment configured.")
-		fmt.Println("Use 'blimu env create <name>' to create an environment.")
+		fmt.Println("Run 'blimu auth login' to add an environment.")
 		return nil
 	}
 
+	// Labels are local, so remote environments are matched through the local environment with their ID
+	labelsByID := make(map[string]map[string]string)
+	for _, env := range cliConfig.Environments {
+		if env.ID != "" {
+			labelsByID[env.ID] = env.Labels
+		}
+	}
+
 	// Check if workspace ID is provided
+	c.WorkspaceID = sc.WorkspaceID
 	if c.WorkspaceID == "" {
 		fmt.Printf("⚠️  Workspace ID is required for listing environments.\n")
 		fmt.Printf("Use --workspace-id flag or run 'blimu workspaces list' to find your workspace ID.\n")
@@ -53,25 +79,35 @@ func (c *ListCommand) Run() error {
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME\tCURRENT\tAUTH\tAPI URL")
+		fmt.Fprintln(w, "NAME\tCURRENT\tAUTH\tAPI URL\tLABELS")
+
+		names := make([]string, 0, len(cliConfig.Environments))
+		for name := range cliConfig.Environments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			env := cliConfig.Environments[name]
+			if selector != nil && !selector.Matches(env.Labels) {
+				continue
+			}
 
-		for name, env := range cliConfig.Environments {
 			current := ""
 			if name == cliConfig.CurrentEnvironment {
 				current = "*"
 			}
 
 			authType := "None"
-			if env.IsOAuthAuthenticated() {
+			if env.ReauthRequired {
+				authType = "Expired"
+			} else if env.IsOAuthAuthenticated() {
 				authType = "OAuth"
 			}
 
-			apiURL := env.APIURL
-			if apiURL == "" {
-				apiURL = cliConfig.DefaultAPIURL
-			}
+			apiURL := shared.PlatformURL(cliConfig, &env, sc.DevMode)
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, current, authType, apiURL)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, current, authType, apiURL, config.FormatLabels(env.Labels))
 		}
 
 		w.Flush()
@@ -79,7 +115,7 @@ func (c *ListCommand) Run() error {
 	}
 
 	// Get platform SDK client
-	client, err := shared.GetSDKClient()
+	client, err := sc.Client()
 	if err != nil {
 		return fmt.Errorf("failed to get API client: %w", err)
 	}
@@ -92,28 +128,33 @@ func (c *ListCommand) Run() error {
 
 	if len(apiEnvironments.Data) == 0 {
 		fmt.Printf("No environments found in workspace %s.\n", c.WorkspaceID)
-		fmt.Println("Create environments via the Blimu dashboard or 'blimu env create'.")
+		fmt.Println("Create environments in the Blimu dashboard, then select them with 'blimu env switch'.")
 		return nil
 	}
 
 	// Display environments in a table
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NAME\tID\tLOOKUP KEY\tWORKSPACE ID\tCREATED")
+	fmt.Fprintln(w, "NAME\tID\tLOOKUP KEY\tWORKSPACE ID\tCREATED\tLABELS")
 
 	for _, envData := range apiEnvironments.Data {
 		// Extract fields from map[string]interface{}
 		name := getStringFromMap(envData, "name")
 		id := getStringFromMap(envData, "id")
+		labels := labelsByID[id]
+		if selector != nil && !selector.Matches(labels) {
+			continue
+		}
 		lookupKey := getStringFromMap(envData, "lookupKey")
 		workspaceId := getStringFromMap(envData, "workspaceId")
 		createdAt := getStringFromMap(envData, "createdAt")
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
 			name,
 			id,
 			lookupKey,
 			workspaceId,
 			createdAt,
+			config.FormatLabels(labels),
 		)
 	}
 
@@ -0,0 +1,136 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// ProtectCommand represents the protect environments command
+type ProtectCommand struct {
+	Selector string
+	Remove   bool
+}
+
+// NewProtectCmd creates the protect command
+func NewProtectCmd() *cobra.Command {
+	cmd := &ProtectCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "protect [selector]",
+		Short: "Require confirmation before changing environments matching a label selector",
+		Long: `Protect every environment whose labels match a selector. Pushing or copying definitions
+into a protected environment asks you to type its name first, and fails without a terminal
+unless the change is approved with the command's --yes or the environment's own auto_approve
+setting (see 'blimu env auto-approve'). --auto-approve and BLIMU_AUTO_APPROVE do not apply.
+
+Selectors are comma-separated requirements that must all hold: key=value, key!=value,
+key (label is set) and !key (label is not set).
+
+Examples:
+  # List protection rules and the environments they protect
+  blimu env protect
+
+  # Protect production environments
+  blimu env protect tier=prod
+
+  # Remove a protection rule
+  blimu env protect tier=prod --remove`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Selector = args[0]
+			}
+			return cmd.Run()
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Remove, "remove", false, "Remove the protection rule instead of adding it")
+
+	return cobraCmd
+}
+
+// Run executes the protect command
+func (c *ProtectCommand) Run() error {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load CLI config: %w", err)
+	}
+
+	if c.Selector == "" {
+		if c.Remove {
+			return fmt.Errorf("a selector is required with --remove")
+		}
+		return listProtection(cliConfig)
+	}
+
+	if _, err := config.ParseSelector(c.Selector); err != nil {
+		return err
+	}
+
+	index := -1
+	for i, existing := range cliConfig.ProtectedEnvironments {
+		if existing == c.Selector {
+			index = i
+		}
+	}
+
+	if c.Remove {
+		if index < 0 {
+			return fmt.Errorf("no protection rule '%s'", c.Selector)
+		}
+		cliConfig.ProtectedEnvironments = append(cliConfig.ProtectedEnvironments[:index], cliConfig.ProtectedEnvironments[index+1:]...)
+		if err := cliConfig.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Removed protection rule '%s'\n", c.Selector)
+		return nil
+	}
+
+	if index >= 0 {
+		fmt.Printf("Protection rule '%s' already exists\n", c.Selector)
+		return nil
+	}
+	cliConfig.ProtectedEnvironments = append(cliConfig.ProtectedEnvironments, c.Selector)
+	if err := cliConfig.Save(); err != nil {
+		return err
+	}
+	fmt.Printf("🔒 Added protection rule '%s'\n", c.Selector)
+	return listProtection(cliConfig)
+}
+
+// listProtection prints the protection rules and the environments each one matches
+func listProtection(cliConfig *config.CLIConfig) error {
+	if len(cliConfig.ProtectedEnvironments) == 0 {
+		fmt.Println("No protection rules configured. Add one with 'blimu env protect <selector>'.")
+		return nil
+	}
+
+	names := make([]string, 0, len(cliConfig.Environments))
+	for name := range cliConfig.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, raw := range cliConfig.ProtectedEnvironments {
+		selector, err := config.ParseSelector(raw)
+		if err != nil {
+			return err
+		}
+		var matched []string
+		for _, name := range names {
+			if selector.Matches(cliConfig.Environments[name].Labels) {
+				matched = append(matched, name)
+			}
+		}
+		if len(matched) == 0 {
+			fmt.Printf("🔒 %s: no environments\n", raw)
+			continue
+		}
+		fmt.Printf("🔒 %s: %s\n", raw, strings.Join(matched, ", "))
+	}
+	return nil
+}
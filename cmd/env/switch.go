@@ -1,6 +1,7 @@
 package env
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/blimu-dev/blimu-cli/pkg/config"
@@ -18,19 +19,19 @@ func NewSwitchCmd() *cobra.Command {
 	cmd := &SwitchCommand{}
 
 	cobraCmd := &cobra.Command{
-		Use:   "switch [environment-name]",
+		Use:   "switch [environment]",
 		Short: "Switch to a different environment",
 		Long: `Switch the current active environment to the specified environment.
 
-If no environment name is provided, you'll be prompted to select from available environments.`,
+The environment is its local name, or the ID, lookup key or name of an environment of the
+current workspace, which is added to the local configuration if needed. If no environment is
+provided, you'll be prompted to select from available environments.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				cmd.EnvName = args[0]
 			}
-			// Check if dev mode is enabled
-			devMode, _ := cobraCmd.Flags().GetBool("dev")
-			return cmd.Run(devMode)
+			return cmd.Run(cobraCmd)
 		},
 	}
 
@@ -38,7 +39,10 @@ If no environment name is provided, you'll be prompted to select from available
 }
 
 // Run executes the switch environment command
-func (c *SwitchCommand) Run(devMode bool) error {
+func (c *SwitchCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+	devMode := sc.DevMode
+
 	cliConfig, err := config.LoadCLIConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load CLI config: %w", err)
@@ -48,9 +52,12 @@ func (c *SwitchCommand) Run(devMode bool) error {
 
 	// If no environment name provided, show selection
 	if c.EnvName == "" {
+		if !shared.IsInteractive() {
+			return fmt.Errorf("no environment given and prompts are disabled; run 'blimu env switch <environment>' with its name, ID or lookup key")
+		}
 		fmt.Println("🔍 Fetching available environments...")
 
-		environments, err := shared.FetchUserEnvironments(devMode)
+		environments, err := shared.FetchUserEnvironments(cmd.Context(), devMode)
 		if err != nil {
 			return fmt.Errorf("failed to fetch environments: %w", err)
 		}
@@ -83,7 +90,10 @@ func (c *SwitchCommand) Run(devMode bool) error {
 			}
 		}
 	} else {
-		targetEnvName = c.EnvName
+		targetEnvName, err = c.localName(cmd.Context(), sc, cliConfig)
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := cliConfig.SetCurrentEnvironment(targetEnvName); err != nil {
@@ -107,3 +117,51 @@ func (c *SwitchCommand) Run(devMode bool) error {
 
 	return nil
 }
+
+// localName returns the local name of the environment given to switch to: a local name, the ID
+// of a local environment, or else the ID, lookup key or name of an environment of the current
+// workspace, which is added to the local configuration
+func (c *SwitchCommand) localName(ctx context.Context, sc *shared.Context, cliConfig *config.CLIConfig) (string, error) {
+	if _, exists := cliConfig.Environments[c.EnvName]; exists {
+		return c.EnvName, nil
+	}
+	for name, env := range cliConfig.Environments {
+		if env.ID != "" && env.ID == c.EnvName {
+			return name, nil
+		}
+	}
+
+	// Without a workspace to look in, SetCurrentEnvironment reports the unknown name
+	workspaceID := sc.WorkspaceID
+	if _, currentEnv, err := sc.EnvironmentInfo(); err == nil && workspaceID == "" {
+		workspaceID = currentEnv.WorkspaceID
+	}
+	if workspaceID == "" {
+		return c.EnvName, nil
+	}
+	client, err := sc.Client()
+	if err != nil {
+		return "", fmt.Errorf("failed to look up environment '%s': %w", c.EnvName, err)
+	}
+	remoteEnv, err := shared.ResolveEnvironment(ctx, client, workspaceID, c.EnvName)
+	if err != nil {
+		return "", err
+	}
+
+	for name, env := range cliConfig.Environments {
+		if env.ID == remoteEnv.ID {
+			return name, nil
+		}
+	}
+
+	fmt.Printf("📥 Adding environment '%s' to local configuration...\n", remoteEnv.Name)
+	envConfig := config.Environment{
+		ID:          remoteEnv.ID,
+		WorkspaceID: remoteEnv.WorkspaceID,
+	}
+	if err := cliConfig.AddEnvironment(envConfig); err != nil {
+		return "", fmt.Errorf("failed to add environment to local config: %w", err)
+	}
+	// AddEnvironment stores environments by ID
+	return remoteEnv.ID, nil
+}
@@ -0,0 +1,18 @@
+package export
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewExportCmd creates the export command group
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export definitions for other tooling",
+		Long:  `Commands for exporting Blimu definitions into formats consumed by other tools`,
+	}
+
+	cmd.AddCommand(NewK8sCmd())
+
+	return cmd
+}
@@ -0,0 +1,225 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// k8sAPIVersion is the API group/version of the exported custom resources
+const k8sAPIVersion = "blimu.dev/v1alpha1"
+
+// Annotations mapping manifests back to their Blimu workspace and environment
+const (
+	annotationWorkspaceID   = "blimu.dev/workspace-id"
+	annotationEnvironmentID = "blimu.dev/environment-id"
+	annotationName          = "blimu.dev/name"
+)
+
+// K8sCommand represents the export k8s command
+type K8sCommand struct {
+	Directory     string
+	Remote        bool
+	Namespace     string
+	Output        string
+	WorkspaceID   string
+	EnvironmentID string
+}
+
+// k8sManifest is a single custom resource manifest
+type k8sManifest struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   k8sMetadata `yaml:"metadata"`
+	Spec       interface{} `yaml:"spec"`
+}
+
+// k8sMetadata is the metadata of an exported manifest
+type k8sMetadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// NewK8sCmd creates the export k8s command
+func NewK8sCmd() *cobra.Command {
+	cmd := &K8sCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "k8s [directory]",
+		Short: "Export definitions as Kubernetes custom resource manifests",
+		Long: `Export definitions as CRD-style Kubernetes manifests (BlimuResource, BlimuEntitlement,
+BlimuFeature and BlimuPlan in the blimu.dev/v1alpha1 group) that an operator or GitOps
+tooling such as Argo CD can apply.
+
+Every manifest is annotated with blimu.dev/workspace-id and blimu.dev/environment-id so
+it can be mapped back to its environment. Definition names that are not valid Kubernetes
+names are normalized; the original name is kept in the blimu.dev/name annotation.
+
+By default the local .blimu files are exported; use --remote to export the environment's
+definitions from the cloud instead.
+
+Examples:
+  # Print manifests for the local definitions
+  blimu export k8s
+
+  # Write the deployed definitions into a GitOps repository
+  blimu export k8s --remote --namespace blimu --output ./deploy/blimu.yaml`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run(cobraCmd)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Remote, "remote", false, "Export the environment's definitions from the cloud instead of local files")
+	cobraCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Kubernetes namespace to set on the manifests")
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "Write manifests to a file instead of stdout")
+
+	return cobraCmd
+}
+
+// Run executes the export k8s command
+func (c *K8sCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	c.WorkspaceID, c.EnvironmentID = sc.IDs()
+	if c.WorkspaceID == "" || c.EnvironmentID == "" {
+		return fmt.Errorf("workspace-id and environment-id are required to annotate manifests. Provide the flags or switch to an environment with 'blimu env switch'")
+	}
+
+	var blimuConfig *config.BlimuConfig
+	if c.Remote {
+		runner, err := cli.NewFromContext(sc, cli.WithOutput(os.Stderr))
+		if err != nil {
+			return fmt.Errorf("authentication required for --remote. Run 'blimu auth login' first: %w", err)
+		}
+		blimuConfig, err = runner.FetchConfig(cmd.Context(), c.WorkspaceID, c.EnvironmentID)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		blimuConfig, err = config.LoadBlimuConfig(c.Directory)
+		if err != nil {
+			return fmt.Errorf("failed to load .blimu configuration: %w", err)
+		}
+	}
+
+	manifests := c.buildManifests(blimuConfig)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	for _, manifest := range manifests {
+		if err := encoder.Encode(manifest); err != nil {
+			return fmt.Errorf("failed to encode manifest %s/%s: %w", manifest.Kind, manifest.Metadata.Name, err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode manifests: %w", err)
+	}
+
+	if c.Output == "" {
+		fmt.Print(buf.String())
+		return nil
+	}
+
+	if dir := filepath.Dir(c.Output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(c.Output, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write manifests: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ Exported %d manifest(s) to %s\n", len(manifests), c.Output)
+	return nil
+}
+
+// buildManifests converts every definition into a manifest, ordered by kind and name
+func (c *K8sCommand) buildManifests(blimuConfig *config.BlimuConfig) []k8sManifest {
+	var manifests []k8sManifest
+
+	for _, name := range sortedKeys(blimuConfig.Resources) {
+		manifests = append(manifests, c.newManifest("BlimuResource", name, blimuConfig.Resources[name]))
+	}
+	for _, name := range sortedKeys(blimuConfig.Entitlements) {
+		manifests = append(manifests, c.newManifest("BlimuEntitlement", name, blimuConfig.Entitlements[name]))
+	}
+	for _, name := range sortedKeys(blimuConfig.Features) {
+		manifests = append(manifests, c.newManifest("BlimuFeature", name, blimuConfig.Features[name]))
+	}
+	for _, name := range sortedKeys(blimuConfig.Plans) {
+		manifests = append(manifests, c.newManifest("BlimuPlan", name, blimuConfig.Plans[name]))
+	}
+
+	return manifests
+}
+
+// newManifest creates an annotated manifest for a single definition
+func (c *K8sCommand) newManifest(kind, name string, spec interface{}) k8sManifest {
+	return k8sManifest{
+		APIVersion: k8sAPIVersion,
+		Kind:       kind,
+		Metadata: k8sMetadata{
+			Name:      k8sName(name),
+			Namespace: c.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "blimu-cli",
+			},
+			Annotations: map[string]string{
+				annotationWorkspaceID:   c.WorkspaceID,
+				annotationEnvironmentID: c.EnvironmentID,
+				annotationName:          name,
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// k8sName converts a definition name into a valid Kubernetes object name (RFC 1123 subdomain)
+func k8sName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+
+	result := strings.Trim(b.String(), "-.")
+	if len(result) > 253 {
+		result = strings.TrimRight(result[:253], "-.")
+	}
+	if result == "" {
+		result = "unnamed"
+	}
+	return result
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](data map[string]V) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,82 @@
+package fmtcmd
+
+import (
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// FmtCommand represents the fmt command
+type FmtCommand struct {
+	Directory string
+	Check     bool
+}
+
+// NewFmtCmd creates the fmt command
+func NewFmtCmd() *cobra.Command {
+	cmd := &FmtCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "fmt [directory]",
+		Short: "Format .blimu YAML files",
+		Long: `Rewrite resources.yml, entitlements.yml, features.yml, plans.yml and sdk.yml in canonical
+form so diffs stay minimal across a team:
+
+  - two-space indentation and a blank line between multi-line top-level entries
+  - the fields of each entry in a fixed order (e.g. roles, roles_inheritance, parents)
+  - role, plan, entitlement and tag lists sorted alphabetically
+
+Entries keep the order they were written in, as do the roles a resource declares and
+pre/post commands. Comments are preserved.
+
+Examples:
+  # Format the current project
+  blimu fmt
+
+  # Fail in CI when a file is not formatted
+  blimu fmt --check`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run()
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Check, "check", false, "List files that are not formatted and exit with an error instead of rewriting them")
+
+	return cobraCmd
+}
+
+// Run executes the fmt command
+func (c *FmtCommand) Run() error {
+	runner := cli.New(nil)
+	result, err := runner.Format(cli.FormatOptions{
+		Directory: c.Directory,
+		Check:     c.Check,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Changed) == 0 {
+		fmt.Printf("✅ All .blimu files are formatted\n")
+		return nil
+	}
+
+	if c.Check {
+		fmt.Printf("❌ %d file(s) need formatting:\n", len(result.Changed))
+		for _, path := range result.Changed {
+			fmt.Printf("  %s\n", path)
+		}
+		return shared.Errorf(shared.ErrValidation, "run 'blimu fmt' to format them")
+	}
+
+	fmt.Printf("✅ Formatted %d file(s)\n", len(result.Changed))
+	return nil
+}
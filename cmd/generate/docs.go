@@ -0,0 +1,110 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/docs"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+// DocsCommand represents the generate docs command
+type DocsCommand struct {
+	WorkspaceID   string
+	EnvironmentID string
+	SpecFile      string
+	Output        string
+	Format        string
+}
+
+// NewDocsCmd creates the generate docs command
+func NewDocsCmd() *cobra.Command {
+	cmd := &DocsCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate API reference docs from the environment's OpenAPI spec",
+		Long: `Render the environment's OpenAPI spec into API reference pages so customer-facing
+docs stay in lockstep with your definitions.
+
+The output contains an index page with the base URLs and authentication schemes, and one page
+per resource listing its endpoints, parameters, request bodies and example responses.
+
+Examples:
+  # Generate Markdown docs for the current environment
+  blimu generate docs -o ./docs/api
+
+  # Generate HTML docs
+  blimu generate docs -o ./docs/api --format html
+
+  # Generate docs from a saved spec file
+  blimu generate docs --spec openapi.json -o ./docs/api`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.SpecFile, "spec", "", "Render a local .json/.yaml spec file instead of fetching the environment's spec")
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "./docs/api", "Output directory")
+	cobraCmd.Flags().StringVar(&cmd.Format, "format", docs.FormatMarkdown, "Output format (markdown, html)")
+
+	return cobraCmd
+}
+
+// Run executes the generate docs command
+func (c *DocsCommand) Run(cmd *cobra.Command) error {
+	if c.Format != docs.FormatMarkdown && c.Format != docs.FormatHTML {
+		return fmt.Errorf("unsupported format '%s' (supported: %s, %s)", c.Format, docs.FormatMarkdown, docs.FormatHTML)
+	}
+
+	doc, err := c.loadSpec(cmd)
+	if err != nil {
+		return err
+	}
+
+	ref := docs.Build(spec.Canonicalize(doc))
+	files, err := docs.Write(ref, c.Output, c.Format)
+	if err != nil {
+		return err
+	}
+
+	endpoints := 0
+	for _, resource := range ref.Resources {
+		endpoints += len(resource.Endpoints)
+	}
+
+	fmt.Printf("✅ Generated %d page(s) documenting %d resource(s) and %d endpoint(s) in %s\n",
+		len(files), len(ref.Resources), endpoints, c.Output)
+	return nil
+}
+
+// loadSpec reads the spec file or fetches the environment's spec
+func (c *DocsCommand) loadSpec(cmd *cobra.Command) (map[string]interface{}, error) {
+	sc := shared.FromContext(cmd.Context())
+
+	if c.SpecFile != "" {
+		return spec.LoadFile(c.SpecFile)
+	}
+
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("docs generation"); err != nil {
+		return nil, err
+	}
+
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return nil, fmt.Errorf("authentication required for docs generation. Run 'blimu auth login' first: %w", err)
+	}
+
+	fmt.Printf("🔄 Fetching OpenAPI spec...\n")
+	result, err := runner.API().GetOpenAPISpec(cmd.Context(), c.WorkspaceID, c.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI spec: %w", err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("environment has definition errors; run 'blimu validate' and fix them before generating docs")
+	}
+	return result.Spec, nil
+}
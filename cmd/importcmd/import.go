@@ -0,0 +1,105 @@
+package importcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/importer"
+	"github.com/spf13/cobra"
+)
+
+// ImportCommand represents the import command
+type ImportCommand struct {
+	From         string
+	ExportFile   string
+	Directory    string
+	RootResource string
+	Force        bool
+	DryRun       bool
+}
+
+// NewImportCmd creates the import command
+func NewImportCmd() *cobra.Command {
+	cmd := &ImportCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "import <export-file>",
+		Short: "Import roles and permissions from another authorization system",
+		Long: fmt.Sprintf(`Translate a roles/permissions export from a third-party authorization system into
+.blimu resources and entitlements scaffolding.
+
+Supported sources: %s
+
+  auth0   Roles export (Deploy CLI or Management API). Roles become roles on the root
+          resource; "action:target" permissions become "target:action" entitlements.
+  okta    Groups export (/api/v1/groups). Groups become roles on the root resource.
+  cerbos  Resource policies (YAML or JSON). Each policy resource becomes a resource and
+          allowed actions become entitlements.
+  openapi OpenAPI or Swagger document (YAML or JSON). Collections in the paths become
+          resources nested like the paths; operations become CRUD entitlements.
+          'blimu init --from-openapi' also accepts a URL.
+
+Examples:
+  # Preview the scaffolding generated from an Auth0 export
+  blimu import --from auth0 tenant.json --dry-run
+
+  # Write Cerbos policies into ./.blimu, replacing existing definitions
+  blimu import --from cerbos policies.yaml --force`, strings.Join(importer.Sources(), ", ")),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.ExportFile = args[0]
+			return cmd.Run()
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.From, "from", "", fmt.Sprintf("Source system (%s)", strings.Join(importer.Sources(), ", ")))
+	cobraCmd.Flags().StringVarP(&cmd.Directory, "dir", "d", ".", "Project directory to write .blimu files into")
+	cobraCmd.Flags().StringVar(&cmd.RootResource, "resource", "organization", "Resource that receives tenant-wide roles")
+	cobraCmd.Flags().BoolVarP(&cmd.Force, "force", "f", false, "Overwrite existing .blimu definition files")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Print the generated definitions without writing files")
+	cobraCmd.MarkFlagRequired("from")
+
+	return cobraCmd
+}
+
+// Run executes the import command
+func (c *ImportCommand) Run() error {
+	data, err := os.ReadFile(c.ExportFile)
+	if err != nil {
+		return fmt.Errorf("failed to read export file: %w", err)
+	}
+
+	fmt.Printf("📥 Importing %s export from %s\n", c.From, c.ExportFile)
+
+	result, err := importer.Convert(c.From, data, importer.Options{RootResource: c.RootResource})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	if c.DryRun {
+		return result.Print(os.Stdout)
+	}
+
+	resourcesPath := filepath.Join(c.Directory, ".blimu", "resources.yml")
+	if _, err := os.Stat(resourcesPath); err == nil && !c.Force {
+		return fmt.Errorf("%s already exists; use --force to overwrite or --dry-run to preview", resourcesPath)
+	}
+
+	if err := config.SaveBlimuConfig(c.Directory, result.Config); err != nil {
+		return fmt.Errorf("failed to save imported definitions: %w", err)
+	}
+
+	fmt.Printf("✅ Imported definitions into %s\n", filepath.Join(c.Directory, ".blimu"))
+	fmt.Printf("  📦 Resources: %d\n", len(result.Config.Resources))
+	fmt.Printf("  🔑 Entitlements: %d\n", len(result.Config.Entitlements))
+	fmt.Printf("\nReview the generated files, then run 'blimu validate' and 'blimu push'.\n")
+
+	return nil
+}
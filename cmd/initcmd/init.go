@@ -1,19 +1,143 @@
 package initcmd
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/importer"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
 	"github.com/spf13/cobra"
 )
 
+// InitCommand represents the init command
+type InitCommand struct {
+	Directory   string
+	FromOpenAPI string
+	Force       bool
+	DryRun      bool
+}
+
 // NewInitCmd creates the init command
 func NewInitCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &InitCommand{}
+
+	cobraCmd := &cobra.Command{
 		Use:   "init [directory]",
 		Short: "Initialize a new .blimu configuration",
-		Long:  `Initialize a new .blimu configuration directory with template files`,
-		// TODO: Implement initialization logic
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return nil
+		Long: `Initialize a new .blimu configuration directory with template files.
+
+With --from-openapi, the configuration is bootstrapped from an existing API: the paths of an
+OpenAPI or Swagger document (a file, or a URL such as https://api.example.com/docs/json) are
+turned into resource types and CRUD entitlements. Collections followed by a path parameter
+become resources, nested like the paths ("/organizations/{orgId}/projects/{projectId}" makes
+project a child of organization), and each resource gets viewer, editor and admin roles
+inherited from its parent. GET maps to read, POST to create, PUT/PATCH to update and DELETE to
+delete; other trailing segments such as "/projects/{id}/archive" become custom actions.
+
+Examples:
+  # Propose definitions from a spec file and review them first
+  blimu init --from-openapi api.yml --dry-run
+
+  # Bootstrap from a running API's spec
+  blimu init --from-openapi http://localhost:3000/docs/json`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.Directory = "."
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			}
+			return cmd.Run(cobraCmd.Context())
 		},
 		Args: cobra.MaximumNArgs(1),
 	}
+
+	cobraCmd.Flags().StringVar(&cmd.FromOpenAPI, "from-openapi", "", "OpenAPI document (file or URL) to derive resources and entitlements from")
+	cobraCmd.Flags().BoolVarP(&cmd.Force, "force", "f", false, "Overwrite existing .blimu definition files")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Print the generated definitions without writing files")
+
+	return cobraCmd
+}
+
+// Run executes the init command
+func (c *InitCommand) Run(ctx context.Context) error {
+	if c.FromOpenAPI == "" {
+		// TODO: Implement initialization from templates
+		return nil
+	}
+
+	data, err := readOpenAPI(ctx, c.FromOpenAPI)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📥 Deriving definitions from %s\n", c.FromOpenAPI)
+
+	result, err := importer.Convert("openapi", data, importer.Options{})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	if c.DryRun {
+		return result.Print(os.Stdout)
+	}
+
+	if config.HasResources(filepath.Join(c.Directory, ".blimu")) && !c.Force {
+		return fmt.Errorf("%s already has definitions; use --force to overwrite or --dry-run to preview", filepath.Join(c.Directory, ".blimu"))
+	}
+
+	if err := config.SaveBlimuConfig(c.Directory, result.Config); err != nil {
+		return fmt.Errorf("failed to save definitions: %w", err)
+	}
+
+	fmt.Printf("✅ Initialized %s\n", filepath.Join(c.Directory, ".blimu"))
+	fmt.Printf("  📦 Resources: %d\n", len(result.Config.Resources))
+	fmt.Printf("  🔑 Entitlements: %d\n", len(result.Config.Entitlements))
+	fmt.Printf("\nReview the proposed roles and entitlements, then run 'blimu validate' and 'blimu push'.\n")
+
+	return nil
+}
+
+// readOpenAPI reads an OpenAPI document from a file or an http(s) URL
+func readOpenAPI(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+		}
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI URL: %w", err)
+	}
+	req.Header.Set("Accept", "application/json, application/yaml")
+
+	resp, err := telemetry.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document from %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document from %s: HTTP %d", source, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	return data, nil
 }
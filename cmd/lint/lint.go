@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/lint"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// LintCommand represents the lint command
+type LintCommand struct {
+	Directory string
+	ListRules bool
+}
+
+// NewLintCmd creates the lint command
+func NewLintCmd() *cobra.Command {
+	cmd := &LintCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "lint [directory]",
+		Short: "Lint .blimu definitions",
+		Long: `Check your .blimu definitions for configurations that are valid but likely mistakes:
+plans no entitlement or feature grants, entitlements no feature references, roles never
+used in roles_inheritance, and names that break the project's naming conventions.
+
+Rules are configured in .blimu/lint.yml. Each rule can be set to error, warn or off, and
+names default to snake_case:
+
+  rules:
+    unused-plan: error
+    unused-role: off
+  naming:
+    roles: kebab-case
+    features: snake_case
+
+The command fails when a rule set to error reports a finding.
+
+Examples:
+  # Lint the current project
+  blimu lint
+
+  # Show the available rules and their severities
+  blimu lint --list-rules`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run()
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.ListRules, "list-rules", false, "List the lint rules with their configured severity")
+
+	return cobraCmd
+}
+
+// Run executes the lint command
+func (c *LintCommand) Run() error {
+	lintConfig, err := lint.LoadConfig(c.Directory)
+	if err != nil {
+		return err
+	}
+
+	if c.ListRules {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RULE\tSEVERITY\tDESCRIPTION")
+		for _, rule := range lint.Rules {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", rule.Name, lintConfig.Severity(rule), rule.Description)
+		}
+		return w.Flush()
+	}
+
+	blimuConfig, err := config.LoadBlimuConfig(c.Directory)
+	if err != nil {
+		return fmt.Errorf("failed to load .blimu configuration: %w", err)
+	}
+
+	result := lint.Run(blimuConfig, lintConfig)
+	if len(result.Findings) == 0 {
+		fmt.Printf("✅ No lint findings\n")
+		return nil
+	}
+
+	for _, finding := range result.Findings {
+		fmt.Println(finding)
+	}
+	fmt.Printf("\n📊 %d error(s), %d warning(s)\n", result.Errors(), result.Warnings())
+
+	if result.Errors() > 0 {
+		return shared.Errorf(shared.ErrValidation, "lint failed with %d error(s)", result.Errors())
+	}
+	return nil
+}
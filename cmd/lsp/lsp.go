@@ -0,0 +1,31 @@
+package lsp
+
+import (
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/lsp"
+	"github.com/spf13/cobra"
+)
+
+// NewLSPCmd creates the lsp command
+func NewLSPCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a language server for .blimu files over stdio",
+		Long: `Run a Language Server Protocol server over stdin and stdout for the YAML files of .blimu
+directories. Editors start it themselves; it is not meant to be run by hand.
+
+The server provides:
+  - diagnostics from the checks of 'blimu validate --offline' and 'blimu lint' as you type
+  - completion of roles, plans, entitlements and parent resources where they are referenced
+  - go to definition from a reference to the role, resource, plan or entitlement it names
+
+Configure your editor to run 'blimu lsp' for YAML files in .blimu directories. For example, in
+Neovim:
+  vim.lsp.start({ name = "blimu", cmd = { "blimu", "lsp" }, root_dir = vim.fs.root(0, ".blimu") })`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lsp.Serve(cmd.Context(), os.Stdin, os.Stdout)
+		},
+	}
+}
@@ -0,0 +1,281 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/metrics"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+// MockCommand represents the mock command
+type MockCommand struct {
+	WorkspaceID   string
+	EnvironmentID string
+	SpecFile      string
+	Host          string
+	Port          int
+	Metrics       metrics.Options
+}
+
+// route is a spec operation matched against incoming requests
+type route struct {
+	method  string
+	path    string
+	pattern *regexp.Regexp
+	params  int
+	status  int
+	body    interface{}
+}
+
+// NewMockCmd creates the mock command
+func NewMockCmd() *cobra.Command {
+	cmd := &MockCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Serve example responses from the environment's OpenAPI spec",
+		Long: `Start a local HTTP server that answers every operation of the environment's OpenAPI
+spec with an example response, so frontends can develop against the generated SDK before
+the backend resources exist.
+
+Responses use the examples declared in the spec when present and are otherwise synthesized
+from the response schemas. CORS is enabled for all origins.
+
+Examples:
+  # Mock the current environment on port 4010
+  blimu mock
+
+  # Mock a saved spec file on a custom port
+  blimu mock --spec openapi.json --port 8080
+
+  # Expose request counters for Prometheus
+  blimu mock --metrics-addr :9464`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.SpecFile, "spec", "", "Serve a local .json/.yaml spec file instead of fetching the environment's spec")
+	cobraCmd.Flags().StringVar(&cmd.Host, "host", "127.0.0.1", "Host to listen on")
+	cobraCmd.Flags().IntVar(&cmd.Port, "port", 4010, "Port to listen on")
+	cobraCmd.Flags().StringVar(&cmd.Metrics.Addr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. :9464)")
+	cobraCmd.Flags().StringVar(&cmd.Metrics.File, "metrics-file", "", "Periodically write Prometheus metrics to this textfile-collector file")
+	cobraCmd.Flags().DurationVar(&cmd.Metrics.Interval, "metrics-interval", metrics.DefaultTextfileInterval, "How often to rewrite --metrics-file")
+
+	return cobraCmd
+}
+
+// Run executes the mock command
+func (c *MockCommand) Run(cmd *cobra.Command) error {
+	doc, err := c.loadSpec(cmd)
+	if err != nil {
+		return err
+	}
+
+	routes, err := buildRoutes(doc)
+	if err != nil {
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("the spec has no operations to mock")
+	}
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	fmt.Printf("🧪 Mock server listening on http://%s\n", listener.Addr())
+	fmt.Printf("📋 Serving %d operation(s):\n", len(routes))
+	for _, r := range routes {
+		fmt.Printf("   %-7s %s → %d\n", r.method, r.path, r.status)
+	}
+
+	if c.Metrics.Enabled() {
+		stopMetrics, err := metrics.Start(c.Metrics)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer stopMetrics()
+	}
+
+	fmt.Printf("\nPress Ctrl+C to stop\n\n")
+
+	ctx := cmd.Context()
+
+	server := &http.Server{Handler: newHandler(routes, basePath(doc))}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("mock server failed: %w", err)
+	}
+
+	fmt.Printf("\n👋 Mock server stopped\n")
+	return nil
+}
+
+// loadSpec reads the spec file or fetches the environment's spec
+func (c *MockCommand) loadSpec(cmd *cobra.Command) (map[string]interface{}, error) {
+	sc := shared.FromContext(cmd.Context())
+
+	if c.SpecFile != "" {
+		return spec.LoadFile(c.SpecFile)
+	}
+
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("mock"); err != nil {
+		return nil, err
+	}
+
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := runner.API().GetOpenAPISpec(cmd.Context(), c.WorkspaceID, c.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI spec: %w", err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("environment has definition errors; run 'blimu validate' and fix them before mocking")
+	}
+	return result.Spec, nil
+}
+
+// paramPattern matches {param} segments of an OpenAPI path template
+var paramPattern = regexp.MustCompile(`\{[^}/]+\}`)
+
+// buildRoutes compiles the spec's operations, ordering literal paths before templated ones
+func buildRoutes(doc map[string]interface{}) ([]route, error) {
+	var routes []route
+	for _, op := range spec.Operations(doc) {
+		re, err := compilePath(op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile path %s: %w", op.Path, err)
+		}
+
+		status, body := spec.ResponseExample(doc, op.Operation)
+		routes = append(routes, route{
+			method:  op.Method,
+			path:    op.Path,
+			pattern: re,
+			params:  len(paramPattern.FindAllString(op.Path, -1)),
+			status:  status,
+			body:    body,
+		})
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].params < routes[j].params
+	})
+	return routes, nil
+}
+
+// compilePath turns an OpenAPI path template into a regular expression matching request paths
+func compilePath(path string) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range paramPattern.FindAllStringIndex(path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		pattern.WriteString(`[^/]+`)
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(path[last:]))
+	pattern.WriteString("/?$")
+	return regexp.Compile(pattern.String())
+}
+
+// basePath returns the path of the spec's first server URL, which is stripped from requests
+func basePath(doc map[string]interface{}) string {
+	servers, _ := doc["servers"].([]interface{})
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]interface{})
+	rawURL, _ := server["url"].(string)
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(parsed.Path, "/")
+}
+
+// newHandler serves the example response of the first matching route
+func newHandler(routes []route, base string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "*")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		path := r.URL.Path
+		if base != "" && strings.HasPrefix(path, base) {
+			path = strings.TrimPrefix(path, base)
+		}
+
+		pathMatched := false
+		for _, rt := range routes {
+			if !rt.pattern.MatchString(path) {
+				continue
+			}
+			pathMatched = true
+			if rt.method != r.Method {
+				continue
+			}
+
+			fmt.Printf("%s %s → %d (%s)\n", r.Method, r.URL.Path, rt.status, rt.path)
+			metrics.MockRequests.Inc(r.Method, strconv.Itoa(rt.status))
+			writeResponse(w, rt.status, rt.body)
+			return
+		}
+
+		status := http.StatusNotFound
+		if pathMatched {
+			status = http.StatusMethodNotAllowed
+		}
+		fmt.Printf("%s %s → %d\n", r.Method, r.URL.Path, status)
+		metrics.MockRequests.Inc(r.Method, strconv.Itoa(status))
+		writeResponse(w, status, map[string]interface{}{
+			"error": fmt.Sprintf("no mocked operation for %s %s", r.Method, r.URL.Path),
+		})
+	})
+}
+
+// writeResponse writes body as JSON, or no body for empty responses
+func writeResponse(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil || status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		fmt.Printf("⚠️  Failed to write response: %v\n", err)
+	}
+}
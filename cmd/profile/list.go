@@ -0,0 +1,38 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// NewListCmd creates the list command
+func NewListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List profiles",
+		Long: `List the profiles and show which one is active.
+
+A profile is created by logging in with it, e.g. 'blimu auth login --profile acme'.`,
+		RunE: runList,
+	}
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		return err
+	}
+
+	active := config.ActiveProfile()
+	for _, name := range profiles {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\n", marker, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package profile
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewProfileCmd creates the profile command group
+func NewProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Profile management commands",
+		Long: `Commands for managing profiles. Each profile has its own environments and tokens, e.g.
+one per customer account; select one with --profile or BLIMU_PROFILE.`,
+	}
+
+	cmd.AddCommand(NewListCmd())
+
+	return cmd
+}
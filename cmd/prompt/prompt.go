@@ -0,0 +1,176 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// defaultFormat prints the environment, a token warning and a drift marker, e.g. "prod* ⏳12m"
+const defaultFormat = `{{.Env}}{{if .Drift}}*{{end}}{{if .Expired}} ⚠ login{{else if .Expiring}} ⏳{{.ExpiresIn}}{{end}}`
+
+// Status is the data available to --format templates
+type Status struct {
+	// Env is the local name of the active environment
+	Env string
+	// Expired is set when the session has expired or needs a new login
+	Expired bool
+	// Expiring is set when the access token expires within --warn-within
+	Expiring bool
+	// ExpiresIn is the rounded time until the access token expires, e.g. "12m"
+	ExpiresIn string
+	// Drift is set when the .blimu files differ from the environment's last pull or push
+	Drift bool
+	// DriftSections lists the sections that differ
+	DriftSections []string
+}
+
+// PromptCommand represents the prompt command
+type PromptCommand struct {
+	Format     string
+	WarnWithin time.Duration
+	NoDrift    bool
+}
+
+// NewPromptCmd creates the prompt command
+func NewPromptCmd() *cobra.Command {
+	cmd := &PromptCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print a short status string for shell prompts",
+		Long: `Print the active environment with a token expiry warning and a drift marker, for embedding
+in PS1 or a starship custom module. Only local config and cached data are read, so the
+command never calls the API, and it prints nothing instead of failing.
+
+The default output looks like "prod* ⏳12m":
+  *          the .blimu files in this project differ from the last pull or push
+  ⏳12m      the access token expires within --warn-within
+  ⚠ login    the session expired; run 'blimu auth login'
+
+--format takes a Go template with the fields .Env, .Expired, .Expiring, .ExpiresIn,
+.Drift and .DriftSections.
+
+Examples:
+  # bash
+  PS1='$(blimu prompt) \$ '
+
+  # starship.toml
+  [custom.blimu]
+  command = "blimu prompt"
+  when = "test -d .blimu"
+
+  # Only the environment name and a drift marker
+  blimu prompt --format '{{.Env}}{{if .Drift}} (modified){{end}}'`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run()
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Format, "format", defaultFormat, "Go template for the output")
+	cobraCmd.Flags().DurationVar(&cmd.WarnWithin, "warn-within", time.Hour, "Warn when the access token expires within this duration")
+	cobraCmd.Flags().BoolVar(&cmd.NoDrift, "no-drift", false, "Skip comparing the .blimu files with the last pull or push")
+
+	return cobraCmd
+}
+
+// Run executes the prompt command
+func (c *PromptCommand) Run() error {
+	tmpl, err := template.New("prompt").Parse(c.Format)
+	if err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+
+	status, ok := c.status()
+	if !ok {
+		return nil
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, status); err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+	fmt.Println(out.String())
+	return nil
+}
+
+// status collects the prompt data; ok is false when no environment is configured
+func (c *PromptCommand) status() (*Status, bool) {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return nil, false
+	}
+	name := shared.ActiveEnvironmentName(cliConfig)
+	env, exists := cliConfig.Environments[name]
+	if name == "" || !exists {
+		return nil, false
+	}
+
+	status := &Status{Env: name}
+
+	switch {
+	case env.ReauthRequired || !env.IsOAuthAuthenticated():
+		status.Expired = true
+	case env.ExpiresAt != nil:
+		remaining := time.Until(*env.ExpiresAt)
+		// An expired access token is refreshed on the next command, so only a missing refresh token is fatal
+		if remaining <= 0 && env.RefreshToken == "" {
+			status.Expired = true
+		} else if remaining > 0 && remaining < c.WarnWithin {
+			status.Expiring = true
+			status.ExpiresIn = formatRemaining(remaining)
+		}
+	}
+
+	if !c.NoDrift && env.ID != "" && env.WorkspaceID != "" {
+		if dir, found := projectDirectory(); found {
+			changed, known, err := cli.DefinitionsDrift(dir, env.WorkspaceID, env.ID, cliConfig.EnvironmentKeys(env.ID)...)
+			if err == nil && known && len(changed) > 0 {
+				status.Drift = true
+				status.DriftSections = changed
+			}
+		}
+	}
+
+	return status, true
+}
+
+// projectDirectory finds the nearest directory at or above the working directory whose .blimu
+// directory defines resources
+func projectDirectory() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	for {
+		if config.HasResources(filepath.Join(dir, ".blimu")) {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// formatRemaining rounds a duration to its largest unit, e.g. 2h or 12m
+func formatRemaining(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
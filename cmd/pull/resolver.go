@@ -0,0 +1,129 @@
+package pull
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+)
+
+// interactiveResolver prompts on the terminal for every conflicting definition key
+type interactiveResolver struct {
+	in *bufio.Reader
+}
+
+func newInteractiveResolver() *interactiveResolver {
+	return &interactiveResolver{in: bufio.NewReader(os.Stdin)}
+}
+
+// Resolve implements cli.ConflictResolver
+func (r *interactiveResolver) Resolve(c cli.Conflict) (cli.Resolution, error) {
+	fmt.Printf("\n🔀 Conflict in %s: %s\n", c.Section, c.Key)
+	printSide("local", c.LocalYAML)
+	printSide("cloud", c.RemoteYAML)
+
+	for {
+		fmt.Printf("Keep [l]ocal, [r]emote or [e]dit? ")
+		line, err := r.in.ReadString('\n')
+		if err != nil && line == "" {
+			return cli.Resolution{}, fmt.Errorf("no answer given: %w", err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "l", "local":
+			return cli.AcceptLocal(c), nil
+		case "r", "remote":
+			return cli.AcceptRemote(c), nil
+		case "e", "edit":
+			resolution, err := editConflict(c)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			return resolution, nil
+		default:
+			fmt.Println("Please answer l, r or e.")
+		}
+	}
+}
+
+// printSide prints one side of a conflict with indented YAML
+func printSide(label, value string) {
+	if value == "" {
+		fmt.Printf("  %s: (not present)\n", label)
+		return
+	}
+	fmt.Printf("  %s:\n", label)
+	for _, line := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// editConflict opens the user's editor with both sides and returns the edited value
+func editConflict(c cli.Conflict) (cli.Resolution, error) {
+	file, err := os.CreateTemp("", "blimu-conflict-*.yml")
+	if err != nil {
+		return cli.Resolution{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	initial := c.LocalYAML
+	if initial == "" {
+		initial = c.RemoteYAML
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Resolve %s: %s\n", c.Section, c.Key)
+	fmt.Fprintf(&b, "# Lines starting with '#' are ignored. Leave the value empty to remove the key.\n")
+	b.WriteString("#\n# cloud value:\n")
+	if c.RemoteYAML == "" {
+		b.WriteString("#   (not present)\n")
+	}
+	for _, line := range strings.Split(strings.TrimRight(c.RemoteYAML, "\n"), "\n") {
+		if line != "" {
+			fmt.Fprintf(&b, "#   %s\n", line)
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(initial)
+
+	if _, err := file.WriteString(b.String()); err != nil {
+		file.Close()
+		return cli.Resolution{}, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	file.Close()
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	args := append(strings.Fields(editor), file.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return cli.Resolution{}, fmt.Errorf("editor '%s' failed: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return cli.Resolution{}, fmt.Errorf("failed to read edited value: %w", err)
+	}
+
+	var kept []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			kept = append(kept, line)
+		}
+	}
+
+	return cli.Resolution{YAML: strings.Join(kept, "\n")}, nil
+}
@@ -2,21 +2,23 @@ package push
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
-	platform "github.com/blimu-dev/blimu-cli/internal/sdk"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // PushCommand represents the push command
 type PushCommand struct {
-	WorkspaceID   string
-	EnvironmentID string
-	Directory     string
+	WorkspaceID     string
+	EnvironmentID   string
+	Directory       string
+	Projects        []string
+	FailOnBreaking  bool
+	SkipBranchCheck bool
+	ChangedOnly     bool
+	Yes             bool
 }
 
 // NewPushCmd creates the push command
@@ -30,178 +32,112 @@ func NewPushCmd() *cobra.Command {
 to the cloud. Only files that exist and are non-empty will be pushed. Missing files will be ignored,
 and existing definitions in the database will be preserved for those fields.
 
+Each definition file may be split into a directory named after it, e.g. .blimu/resources.d/*.yml
+or .blimu/entitlements.d/*.yml, whose files are merged into it. An entry defined in two files is
+an error.
+
+Files in .blimu/overlays/<env>/ (named after the environment's local name, lookup key or ID)
+are merged over the base files before pushing to that environment. Mappings merge key by key,
+lists and other values replace the base value, and a null value removes the key:
+
+  # .blimu/overlays/staging/plans.yml - an extra plan only staging has
+  beta:
+    name: Beta
+
+Definition files may reference variables as ${NAME} or ${NAME:-default}. Values come from
+environment variables, then from .blimu/overlays/<env>/values.yml and .blimu/values.yml
+(flat NAME: value files); write $${ for a literal ${:
+
+  # .blimu/plans.yml
+  pro:
+    name: ${PRO_PLAN_NAME:-Pro}
+
 Examples:
   # Push definitions using current directory .blimu config
   blimu push --workspace-id ws_123 --environment-id env_456
 
+  # Refuse to push changes that would break existing API consumers
+  blimu push --fail-on-breaking
+
+  # Send only the sections that changed since the last pull or push
+  blimu push --changed-only
+
+  # Push even if the current git branch is not mapped to the environment in .blimu/project.yml
+  blimu push --skip-branch-check
+
   # Push definitions from specific directory
-  blimu push /path/to/project --workspace-id ws_123 --environment-id env_456`,
+  blimu push /path/to/project --workspace-id ws_123 --environment-id env_456
+
+  # In a monorepo, push one project of .blimu/workspace.yml to the environment it maps to
+  # (run at the workspace root without --project to push all of them)
+  blimu push --project billing`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
-			if len(args) > 0 {
-				cmd.Directory = args[0]
-			} else {
-				cmd.Directory = "."
+			projects, err := shared.ResolveProjects(args, cmd.Projects)
+			if err != nil {
+				return err
 			}
-			return cmd.Run(cobraCmd)
+			pinnedIDs := shared.FromContext(cobraCmd.Context()).PinnedIDs()
+			return shared.RunProjects(projects, pinnedIDs, func(project shared.Project) error {
+				run := *cmd
+				run.Directory = project.Directory
+				return run.Run(cobraCmd)
+			})
 		},
 		Args: cobra.MaximumNArgs(1),
 	}
 
-	cobraCmd.Flags().StringVar(&cmd.WorkspaceID, "workspace-id", "", "Workspace ID (uses current environment's workspace if available)")
-	cobraCmd.Flags().StringVar(&cmd.EnvironmentID, "environment-id", "", "Environment ID (uses current environment ID if available)")
+	cobraCmd.Flags().StringSliceVar(&cmd.Projects, "project", nil, "Projects of .blimu/workspace.yml to run for (default: all of them at the workspace root)")
+	cobraCmd.Flags().BoolVar(&cmd.FailOnBreaking, "fail-on-breaking", false, "Fail if the local definitions introduce breaking changes to the deployed API")
+	cobraCmd.Flags().BoolVar(&cmd.SkipBranchCheck, "skip-branch-check", false, "Push even if the git branch is not mapped to the environment in .blimu/project.yml")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Push to a protected environment without asking for confirmation")
+	cobraCmd.Flags().BoolVar(&cmd.ChangedOnly, "changed-only", false, "Only push sections that changed since the last pull or push of this environment")
 
 	return cobraCmd
 }
 
 func (c *PushCommand) Run(cmd *cobra.Command) error {
-	fmt.Printf("🔧 Starting push command in directory: %s\n", c.Directory)
-
-	// Get current environment info to auto-populate missing IDs
-	_, currentEnv, err := shared.GetCurrentEnvironmentInfo()
-	if err != nil {
-		return fmt.Errorf("failed to get current environment info: %w", err)
-	}
-
-	// Auto-populate environment ID from current environment if not provided
-	if c.EnvironmentID == "" && currentEnv.ID != "" {
-		c.EnvironmentID = currentEnv.ID
-		fmt.Printf("📋 Using environment ID from current environment: %s\n", c.EnvironmentID)
-	}
-
-	// Auto-populate workspace ID from current environment if not provided
-	if c.WorkspaceID == "" && currentEnv.WorkspaceID != "" {
-		c.WorkspaceID = currentEnv.WorkspaceID
-		fmt.Printf("📋 Using workspace ID from current environment: %s\n", c.WorkspaceID)
-	}
-
-	// Check required parameters
-	if c.EnvironmentID == "" {
-		return fmt.Errorf("environment-id is required for push. Either:\n" +
-			"  1. Provide --environment-id flag\n" +
-			"  2. Configure your current environment with an ID using 'blimu env create --workspace-id <workspace-id> <env-name>'")
-	}
-
-	if c.WorkspaceID == "" {
-		return fmt.Errorf("workspace-id is required for push. Provide --workspace-id flag.\n" +
-			"Use 'blimu workspaces list' to find your workspace ID (when available)")
-	}
-
-	// Load definitions files (only those that exist and are non-empty)
-	blimuDir := filepath.Join(c.Directory, ".blimu")
-	request := platform.DefinitionUpdateDto{
-		Resources:    make(map[string]interface{}),
-		Entitlements: make(map[string]interface{}),
-		Features:     make(map[string]interface{}),
-		Plans:        make(map[string]interface{}),
-	}
+	sc := shared.FromContext(cmd.Context())
 
-	// Load resources.yml (required)
-	resourcesPath := filepath.Join(blimuDir, "resources.yml")
-	loaded, err := loadDefinitionFile(resourcesPath, "resources")
-	if err != nil {
-		return fmt.Errorf("failed to load resources.yml: %w", err)
-	}
-	if len(loaded) == 0 {
-		return fmt.Errorf("resources.yml is required and cannot be empty")
-	}
-	request.Resources = loaded
-	fmt.Printf("✅ Loaded resources.yml\n")
+	fmt.Printf("🔧 Starting push command in directory: %s\n", c.Directory)
 
-	// Load entitlements.yml (optional)
-	entitlementsPath := filepath.Join(blimuDir, "entitlements.yml")
-	loaded, err = loadDefinitionFile(entitlementsPath, "entitlements")
+	cliConfig, err := sc.Config()
 	if err != nil {
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to load entitlements.yml: %w", err)
-		}
-		fmt.Printf("⏭️  Skipping entitlements.yml (file not found)\n")
-	} else if len(loaded) > 0 {
-		request.Entitlements = loaded
-		fmt.Printf("✅ Loaded entitlements.yml\n")
+		return err
 	}
-
-	// Load features.yml (optional)
-	featuresPath := filepath.Join(blimuDir, "features.yml")
-	loaded, err = loadDefinitionFile(featuresPath, "features")
-	if err != nil {
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to load features.yml: %w", err)
-		}
-		fmt.Printf("⏭️  Skipping features.yml (file not found)\n")
-	} else if len(loaded) > 0 {
-		request.Features = loaded
-		fmt.Printf("✅ Loaded features.yml\n")
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("push"); err != nil {
+		return err
 	}
 
-	// Load plans.yml (optional)
-	plansPath := filepath.Join(blimuDir, "plans.yml")
-	loaded, err = loadDefinitionFile(plansPath, "plans")
-	if err != nil {
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to load plans.yml: %w", err)
-		}
-		fmt.Printf("⏭️  Skipping plans.yml (file not found)\n")
-	} else if len(loaded) > 0 {
-		request.Plans = loaded
-		fmt.Printf("✅ Loaded plans.yml\n")
+	if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "push definitions to", c.Yes); err != nil {
+		return err
 	}
 
-	fmt.Printf("📤 Pushing definitions to cloud...\n")
-
-	// Check if dev mode is enabled
-	devMode, _ := cmd.Flags().GetBool("dev")
-
-	// Get auth client
-	authClient, err := shared.GetAuthClientWithDevMode(devMode)
+	// Get runner authenticated with the current environment
+	runner, err := cli.NewFromContext(sc)
 	if err != nil {
 		return fmt.Errorf("authentication required for push. Run 'blimu auth login' first: %w", err)
 	}
 
-	// Get platform SDK client
-	sdk := authClient.GetAppSDK()
-	if sdk == nil {
-		return fmt.Errorf("platform SDK not available")
-	}
-
-	// Update definitions in the cloud (partial update - only provided fields will be updated)
-	_, err = sdk.Definitions.Update(c.WorkspaceID, c.EnvironmentID, request)
+	result, err := runner.Push(cmd.Context(), cli.PushOptions{
+		Directory:       c.Directory,
+		WorkspaceID:     c.WorkspaceID,
+		EnvironmentID:   c.EnvironmentID,
+		FailOnBreaking:  c.FailOnBreaking,
+		EnvironmentKeys: cliConfig.EnvironmentKeys(c.EnvironmentID),
+		SkipBranchGuard: c.SkipBranchCheck,
+		ChangedOnly:     c.ChangedOnly,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to push definitions: %w", err)
+		return err
+	}
+	if len(result.Sections) == 0 {
+		return nil
 	}
 
 	fmt.Printf("✅ Definitions pushed successfully!\n")
 	fmt.Printf("  📋 Workspace: %s\n", c.WorkspaceID)
 	fmt.Printf("  🌍 Environment: %s\n", c.EnvironmentID)
+	fmt.Printf("  📦 Sections: %s\n", strings.Join(result.Sections, ", "))
 
 	return nil
 }
-
-// loadDefinitionFile loads a YAML definition file and parses it into a map
-func loadDefinitionFile(filePath, fileType string) (map[string]interface{}, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
-	// Check if file is empty or only whitespace
-	content := strings.TrimSpace(string(data))
-	if content == "" {
-		return nil, fmt.Errorf("file is empty")
-	}
-
-	// Parse YAML
-	var yamlData map[string]interface{}
-	if err := yaml.Unmarshal(data, &yamlData); err != nil {
-		return nil, fmt.Errorf("failed to parse %s: %w", fileType, err)
-	}
-
-	// Extract the root key (e.g., "resources", "entitlements", etc.)
-	if rootValue, ok := yamlData[fileType]; ok {
-		if rootMap, ok := rootValue.(map[string]interface{}); ok {
-			return rootMap, nil
-		}
-	}
-
-	// If no root key, use the entire config
-	return yamlData, nil
-}
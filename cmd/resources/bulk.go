@@ -0,0 +1,332 @@
+package resources
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/bulk"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// BulkCommand represents the bulk create resources command
+type BulkCommand struct {
+	CSVFile         string
+	BatchSize       int
+	ContinueOnError bool
+	SkipExisting    bool
+	WorkspaceID     string
+	EnvironmentID   string
+	IdempotencyKey  string
+	Sort            bool
+	bulkReport
+}
+
+// resourceCSVHeader are the columns of a resources CSV file
+var resourceCSVHeader = []string{"type", "id", "parent_type", "parent_id"}
+
+// NewBulkCmd creates the bulk command
+func NewBulkCmd() *cobra.Command {
+	cmd := &BulkCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "bulk <csv-file|->",
+		Short: "Bulk create resources from CSV file",
+		Long: `Bulk create resources from a CSV file, or from stdin with "-" so other tools can pipe rows
+in, e.g. psql ... --csv | blimu resources bulk -.
+
+The CSV file should have the following columns:
+- type: Resource type
+- id: Resource ID
+- parent_type: Parent resource type (optional)
+- parent_id: Parent resource ID (optional)
+
+Example CSV:
+type,id,parent_type,parent_id
+organization,org123,,
+workspace,ws456,organization,org123
+project,proj789,workspace,ws456
+
+Before creating anything, the file is checked: rows repeating a resource are dropped, or
+rejected when they give it another parent, and parents must come before their children.
+--sort reorders the rows to create parents first instead.
+
+The command processes resources in batches to avoid payload size limits.
+Use --batch-size to control the number of resources processed per batch (maximum 1000).
+
+For better error handling:
+- Use --continue-on-error to process all batches even if some fail
+- Use --skip-existing to skip resources that already exist, e.g. when running a file again;
+  they are counted apart from the created and failed rows
+
+On a terminal, the first 10 failed rows of each batch and of the summary are listed; use
+--display-limit to change that or --show-all to list every row. Output written to a file or
+pipe is never truncated.
+
+--failed-output writes the rows that were not created, with an error column, to
+<csv-file>.failed.csv, stdin.failed.csv for stdin (or --failed-output=<file>), so they can be fixed and passed to the
+command again.
+
+Every create carries an Idempotency-Key derived from the resource, so running the command
+again after a network failure does not create resources twice when the API supports it. Pass
+--idempotency-key to derive the keys from your own key too, e.g. to create resources again
+that were deleted since.
+
+With --json, progress is printed to stderr and a summary with per-batch results, timings
+and failed rows (with their CSV line) is printed to stdout.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.CSVFile = args[0]
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().IntVar(&cmd.BatchSize, "batch-size", bulk.DefaultBatchSize, "Number of resources to process in each batch (max 1000)")
+	cobraCmd.Flags().BoolVar(&cmd.ContinueOnError, "continue-on-error", false, "Continue processing remaining batches even if some batches fail")
+	cobraCmd.Flags().BoolVar(&cmd.SkipExisting, "skip-existing", false, "Skip resources that already exist instead of reporting them as failed")
+	cobraCmd.Flags().StringVar(&cmd.IdempotencyKey, "idempotency-key", "", "Key the Idempotency-Key of every create is derived from, along with the resource")
+	cobraCmd.Flags().BoolVar(&cmd.Sort, "sort", false, "Reorder the rows so parents in the file are created before their children")
+	cmd.addReportFlags(cobraCmd, "created")
+
+	return cobraCmd
+}
+
+// Run executes the bulk create command
+func (c *BulkCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	out := c.startReport()
+
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("bulk creation"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "📥 Loading resources from %s...\n", inputName(c.CSVFile))
+
+	rows, err := c.parseResourcesCSV()
+	if err != nil {
+		return fmt.Errorf("failed to parse CSV file: %w", err)
+	}
+	if rows, err = preflightRows(out, rows, c.Sort); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "📊 Found %d resources to create in workspace '%s', environment '%s'\n", len(rows), c.WorkspaceID, c.EnvironmentID)
+
+	if c.BatchSize > bulk.MaxBatchSize {
+		fmt.Fprintf(out, "⚠️  Batch size %d exceeds maximum of %d. Using %d instead.\n", c.BatchSize, bulk.MaxBatchSize, bulk.MaxBatchSize)
+	}
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+
+	// Stop between batches on Ctrl-C and still report what was created
+	ctx := cmd.Context()
+
+	summary, runErr := bulk.Run(ctx, rows, bulk.Options{
+		BatchSize:       c.BatchSize,
+		ContinueOnError: c.ContinueOnError,
+		OnBatch:         c.printBatch,
+	}, c.createBatch(client))
+
+	if err := c.printSummary(summary, "created", "already existed"); err != nil {
+		return err
+	}
+	if err := writeFailedRows(&c.bulkReport, c.CSVFile, resourceCSVHeader, rows, summary, Resource.records); err != nil {
+		return err
+	}
+
+	if runErr != nil {
+		return runErr
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d resources failed", summary.Failed, summary.Total)
+	}
+	return nil
+}
+
+// createBatch returns a processor creating each resource of a batch. The platform API has no
+// bulk endpoint, so failures are reported per row and never fail the batch as a whole. With
+// --skip-existing, resources the API reports as conflicting already exist and count as unchanged.
+func (c *BulkCommand) createBatch(client *blimu.Client) bulk.Processor[Resource] {
+	return func(ctx context.Context, batch []bulk.Row[Resource]) (bulk.BatchOutcome, error) {
+		var outcome bulk.BatchOutcome
+		for _, row := range batch {
+			if err := ctx.Err(); err != nil {
+				outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+				continue
+			}
+			body := row.Item.createBody()
+			rowCtx := blimu.WithIdempotencyKey(ctx, resourceIdempotencyKey(c.IdempotencyKey, c.WorkspaceID, c.EnvironmentID, body))
+			_, err := client.Resources.CreateWithContext(rowCtx, c.WorkspaceID, c.EnvironmentID, body)
+			switch {
+			case err == nil:
+			case c.SkipExisting && isStatus(err, http.StatusConflict):
+				outcome.Unchanged++
+			default:
+				outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+			}
+		}
+		return outcome, nil
+	}
+}
+
+// parseResourcesCSV parses the CSV file containing resources, keeping each row's line number
+func (c *BulkCommand) parseResourcesCSV() ([]bulk.Row[Resource], error) {
+	file, err := openInput(c.CSVFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	// Validate header
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expectedHeaders := resourceCSVHeader
+	if len(header) < 2 {
+		return nil, fmt.Errorf("CSV must have at least 'type' and 'id' columns")
+	}
+
+	headerSet := make(map[string]bool)
+	for _, h := range header {
+		headerSet[h] = true
+	}
+
+	for _, expectedHeader := range expectedHeaders {
+		if !headerSet[expectedHeader] {
+			return nil, fmt.Errorf("CSV must have '%s' column", expectedHeader)
+		}
+	}
+
+	// Parse records
+	var rows []bulk.Row[Resource]
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: must have at least type and id", line)
+		}
+
+		resource := Resource{
+			Type: record[0],
+			ID:   record[1],
+		}
+
+		// Optional parent fields
+		if len(record) > 2 {
+			resource.ParentType = record[2]
+		}
+		if len(record) > 3 {
+			resource.ParentID = record[3]
+		}
+
+		// Validate that if parent_type is provided, parent_id is also provided
+		if resource.ParentType != "" && resource.ParentID == "" {
+			return nil, fmt.Errorf("line %d: parent_type provided but parent_id is missing", line)
+		}
+		if resource.ParentID != "" && resource.ParentType == "" {
+			return nil, fmt.Errorf("line %d: parent_id provided but parent_type is missing", line)
+		}
+
+		rows = append(rows, bulk.Row[Resource]{Line: line, Key: resource.Type + ":" + resource.ID, Item: resource})
+	}
+
+	return rows, nil
+}
+
+// Resource represents a resource from CSV
+type Resource struct {
+	Type       string
+	ID         string
+	ParentType string
+	ParentID   string
+}
+
+// createBody converts the resource to the API create payload
+func (r Resource) createBody() blimu.ResourceCreateDto {
+	body := blimu.ResourceCreateDto{
+		Id:      r.ID,
+		Type:    r.Type,
+		Name:    r.ID, // Use ID as name by default
+		Parents: []map[string]interface{}{},
+	}
+	if r.ParentType != "" && r.ParentID != "" {
+		body.Parents = append(body.Parents, map[string]interface{}{
+			"id":   r.ParentID,
+			"type": r.ParentType,
+		})
+	}
+	return body
+}
+
+// parentKey returns the key of the resource's parent, or "" when it has none
+func (r Resource) parentKey() string {
+	if r.ParentType == "" {
+		return ""
+	}
+	return r.ParentType + ":" + r.ParentID
+}
+
+// records returns the CSV record of the resource, in the order of resourceCSVHeader
+func (r Resource) records() [][]string {
+	return [][]string{{r.Type, r.ID, r.ParentType, r.ParentID}}
+}
+
+// stdinInput is the file argument that makes a bulk command read its CSV from stdin
+const stdinInput = "-"
+
+// openInput opens the CSV file of a bulk command, or stdin for "-"
+func openInput(path string) (io.ReadCloser, error) {
+	if path == stdinInput {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// inputName describes the CSV file of a bulk command in messages
+func inputName(path string) string {
+	if path == stdinInput {
+		return "stdin"
+	}
+	return path
+}
+
+// resourceIdempotencyKey derives the Idempotency-Key of a resource create from the resource and
+// an optional seed, so that repeating the create after a network failure has no additional effect
+func resourceIdempotencyKey(seed, workspaceID, environmentID string, body blimu.ResourceCreateDto) string {
+	return blimu.IdempotencyKey(seed, workspaceID, environmentID, body)
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
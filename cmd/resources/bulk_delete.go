@@ -0,0 +1,407 @@
+package resources
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blimu-dev/blimu-cli/pkg/bulk"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// defaultDeleteConcurrency is the default number of resources deleted at the same time
+const defaultDeleteConcurrency = 4
+
+// maxDeleteConcurrency caps --concurrency so a bulk delete does not trip the API's rate limits
+const maxDeleteConcurrency = 16
+
+// bulkDeletePreviewLimit is the number of resources listed before asking for confirmation
+const bulkDeletePreviewLimit = 20
+
+// BulkDeleteCommand represents the bulk delete resources command
+type BulkDeleteCommand struct {
+	CSVFile         string
+	Type            string
+	Parent          string
+	Search          string
+	Concurrency     int
+	WorkspaceID     string
+	EnvironmentID   string
+	DryRun          bool
+	Yes             bool
+	ContinueOnError bool
+	bulkReport
+}
+
+// NewBulkDeleteCmd creates the bulk-delete command
+func NewBulkDeleteCmd() *cobra.Command {
+	cmd := &BulkDeleteCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "bulk-delete [csv-file|-]",
+		Short: "Bulk delete resources listed in a CSV file or matching a filter",
+		Long: `Delete many resources at once, e.g. to clean up test data.
+
+The resources to delete come either from a CSV file with the columns type and id (other
+columns are ignored, so the output of 'blimu resources list --format csv' or 'blimu resources
+export' works as is) or from stdin with "-", or from the resources of --type matching --search
+and --parent. Confirmation prompts cannot be answered while stdin carries the CSV; pass --yes.
+
+The resources are listed and, unless --yes is given, confirmed before anything is deleted.
+Deeper resource types are deleted first, so a file holding both parents and their children
+deletes the children before the parents; resources of the same depth are deleted --concurrency
+at a time. Resources that are already gone are reported as such. A resource that still has
+children outside the list fails; use 'blimu resources delete --cascade' to delete a whole tree.
+
+Failed rows are listed as with 'blimu resources bulk': the first 10 on a terminal (see
+--display-limit and --show-all) and all of them otherwise. --failed-output writes them, with an
+error column, to <csv-file>.failed.csv, or <type>.failed.csv with --type (or
+--failed-output=<file>), for a retry, and with --json the summary is printed to stdout as JSON
+and the rest to stderr.`,
+		Example: `  blimu resources bulk-delete stale.csv --dry-run
+  blimu resources bulk-delete --type project --search "e2e-" --yes
+  blimu resources list --type project --all --format csv | awk 'NR == 1 || /e2e-/' | blimu resources bulk-delete - --yes
+  blimu resources bulk-delete --type workspace --parent org_test --concurrency 8`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cmd.CSVFile = args[0]
+			}
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Type, "type", "", "Delete resources of this type instead of those of a CSV file")
+	cobraCmd.Flags().StringVar(&cmd.Parent, "parent", "", "With --type, only delete resources with this parent resource ID")
+	cobraCmd.Flags().StringVar(&cmd.Search, "search", "", "With --type, only delete resources matching this search term")
+	cobraCmd.Flags().IntVar(&cmd.Concurrency, "concurrency", defaultDeleteConcurrency, fmt.Sprintf("Number of resources to delete at the same time (at most %d)", maxDeleteConcurrency))
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "List the resources that would be deleted without deleting them")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Delete without asking for confirmation")
+	cobraCmd.Flags().BoolVar(&cmd.ContinueOnError, "continue-on-error", false, "Keep deleting after a batch fails")
+	cmd.addReportFlags(cobraCmd, "deleted")
+
+	return cobraCmd
+}
+
+// Run executes the bulk delete command
+func (c *BulkDeleteCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	switch {
+	case c.CSVFile == "" && c.Type == "":
+		return fmt.Errorf("give a CSV file or --type")
+	case c.CSVFile != "" && c.Type != "":
+		return fmt.Errorf("give either a CSV file or --type, not both")
+	case c.Type == "" && (c.Search != "" || c.Parent != ""):
+		return fmt.Errorf("--search and --parent require --type")
+	case c.Concurrency < 1 || c.Concurrency > maxDeleteConcurrency:
+		return fmt.Errorf("--concurrency must be between 1 and %d", maxDeleteConcurrency)
+	}
+
+	out := c.startReport()
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("bulk deletion"); err != nil {
+		return err
+	}
+
+	if !c.DryRun {
+		if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "delete resources in", c.Yes); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return fmt.Errorf("authentication required. Run 'blimu auth login' first: %w", err)
+	}
+
+	var rows []bulk.Row[exportedResource]
+	if c.CSVFile != "" {
+		if rows, err = c.parseDeleteCSV(); err != nil {
+			return fmt.Errorf("failed to parse CSV file: %w", err)
+		}
+	} else {
+		fmt.Fprintf(out, "🔍 Finding %s resources...\n", c.Type)
+		if rows, err = c.findResources(ctx, client); err != nil {
+			return err
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "✅ No resources to delete")
+		return nil
+	}
+
+	definitions, err := runner.API().GetDefinitions(ctx, c.WorkspaceID, c.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get definitions: %w", err)
+	}
+	depths := typeDepths(definitions.Resources)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return depths[rows[i].Item.Type] > depths[rows[j].Item.Type]
+	})
+
+	fmt.Fprintf(out, "🗑️  %d resource(s) to delete:\n", len(rows))
+	for i, row := range rows {
+		if i == bulkDeletePreviewLimit {
+			fmt.Fprintf(out, "   ... and %d more\n", len(rows)-i)
+			break
+		}
+		fmt.Fprintf(out, "   - %s\n", row.Key)
+	}
+	if c.DryRun {
+		fmt.Fprintf(out, "🔍 Dry run: %d resource(s) would be deleted\n", len(rows))
+		return nil
+	}
+
+	approved, err := shared.AutoApproved(cliConfig, c.EnvironmentID, c.Yes)
+	if err != nil {
+		return err
+	}
+	confirmed, err := shared.Confirm(fmt.Sprintf("Delete %d resource(s)?", len(rows)), fmt.Sprintf("delete %d resource(s)", len(rows)), approved)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(out, "Aborted; nothing was deleted.")
+		return nil
+	}
+
+	summary, runErr := bulk.Run(ctx, rows, bulk.Options{
+		BatchSize:       bulk.DefaultBatchSize,
+		ContinueOnError: c.ContinueOnError,
+		OnBatch:         c.printBatch,
+	}, c.deleteBatch(client, depths))
+	if err := c.printSummary(summary, "deleted", "already deleted"); err != nil {
+		return err
+	}
+	input := c.CSVFile
+	if input == "" {
+		input = c.Type
+	}
+	if err := writeFailedRows(&c.bulkReport, input, []string{"type", "id"}, rows, summary, func(r exportedResource) [][]string {
+		return [][]string{{r.Type, r.ID}}
+	}); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d deletions failed", summary.Failed, summary.Total)
+	}
+	return nil
+}
+
+// deleteBatch returns a processor deleting the resources of a batch, one depth at a time and
+// Concurrency resources at a time within a depth. Resources that are already gone count as
+// unchanged.
+func (c *BulkDeleteCommand) deleteBatch(client *blimu.Client, depths map[string]int) bulk.Processor[exportedResource] {
+	return func(ctx context.Context, batch []bulk.Row[exportedResource]) (bulk.BatchOutcome, error) {
+		var (
+			outcome bulk.BatchOutcome
+			mu      sync.Mutex
+		)
+		deleteRow := func(row bulk.Row[exportedResource]) {
+			var message string
+			_, err := client.Resources.DeleteWithContext(ctx, c.WorkspaceID, c.EnvironmentID, row.Item.Type, row.Item.ID)
+			switch {
+			case err == nil:
+				return
+			case isStatus(err, http.StatusNotFound):
+				mu.Lock()
+				outcome.Unchanged++
+				mu.Unlock()
+				return
+			case isStatus(err, http.StatusConflict):
+				message = "still has child resources; delete them first or use 'blimu resources delete --cascade'"
+			default:
+				message = err.Error()
+			}
+			mu.Lock()
+			outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: message})
+			mu.Unlock()
+		}
+
+		// Rows are sorted deepest first; each depth finishes before the next one starts
+		for start := 0; start < len(batch); {
+			end := start + 1
+			for end < len(batch) && depths[batch[end].Item.Type] == depths[batch[start].Item.Type] {
+				end++
+			}
+
+			work := make(chan bulk.Row[exportedResource])
+			var wg sync.WaitGroup
+			for i := 0; i < c.Concurrency; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for row := range work {
+						deleteRow(row)
+					}
+				}()
+			}
+			for _, row := range batch[start:end] {
+				if err := ctx.Err(); err != nil {
+					mu.Lock()
+					outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+					mu.Unlock()
+					continue
+				}
+				work <- row
+			}
+			close(work)
+			wg.Wait()
+			start = end
+		}
+
+		// Workers finish in any order; report errors in the order of the rows
+		sort.Slice(outcome.Errors, func(i, j int) bool { return outcome.Errors[i].Line < outcome.Errors[j].Line })
+		return outcome, nil
+	}
+}
+
+// findResources lists the resources of Type matching Search and Parent
+func (c *BulkDeleteCommand) findResources(ctx context.Context, client *blimu.Client) ([]bulk.Row[exportedResource], error) {
+	query := &blimu.ResourcesListQuery{Type: c.Type}
+	if c.Search != "" {
+		query.Search = &c.Search
+	}
+	if c.Parent != "" {
+		query.Parent = &c.Parent
+	}
+
+	var rows []bulk.Row[exportedResource]
+	_, _, err := forEachResourcePage(ctx, client, c.WorkspaceID, c.EnvironmentID, query, 1, exportPageSize, true,
+		func(items []exportedResource) error {
+			for _, item := range items {
+				rows = append(rows, bulk.Row[exportedResource]{Line: len(rows) + 1, Key: item.Type + ":" + item.ID, Item: item})
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// parseDeleteCSV reads the resources to delete from a CSV file with type and id columns, dropping
+// repeated rows
+func (c *BulkDeleteCommand) parseDeleteCSV() ([]bulk.Row[exportedResource], error) {
+	file, err := openInput(c.CSVFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"type", "id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV must have '%s' column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i := columns[column]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []bulk.Row[exportedResource]
+	seen := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		resourceType, resourceID := field(record, "type"), field(record, "id")
+		if resourceType == "" || resourceID == "" {
+			return nil, fmt.Errorf("line %d: type and id are required", line)
+		}
+		key := resourceType + ":" + resourceID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rows = append(rows, bulk.Row[exportedResource]{Line: line, Key: key, Item: exportedResource{Type: resourceType, ID: resourceID}})
+	}
+	return rows, nil
+}
+
+// typeDepths maps every resource type to the length of the longest chain of parent types above
+// it, so that deleting deeper types first never deletes a parent before its children. A type
+// that is its own parent does not count as one.
+func typeDepths(resources map[string]interface{}) map[string]int {
+	parentTypes := map[string][]string{}
+	for name, definition := range resources {
+		resource, _ := definition.(map[string]interface{})
+		parents, _ := resource["parents"].(map[string]interface{})
+		for parent := range parents {
+			if parent != name {
+				parentTypes[name] = append(parentTypes[name], parent)
+			}
+		}
+	}
+
+	depths := map[string]int{}
+	visiting := map[string]bool{}
+	var depth func(name string) int
+	depth = func(name string) int {
+		if d, ok := depths[name]; ok {
+			return d
+		}
+		if visiting[name] {
+			// Definitions with a loop of parent types; stop counting there
+			return 0
+		}
+		visiting[name] = true
+		d := 0
+		for _, parent := range parentTypes[name] {
+			if p := depth(parent) + 1; p > d {
+				d = p
+			}
+		}
+		visiting[name] = false
+		depths[name] = d
+		return d
+	}
+	for name := range resources {
+		depth(name)
+	}
+	return depths
+}
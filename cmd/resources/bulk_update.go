@@ -0,0 +1,390 @@
+package resources
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/bulk"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// BulkUpdateCommand represents the bulk update resources command
+type BulkUpdateCommand struct {
+	CSVFile         string
+	WorkspaceID     string
+	EnvironmentID   string
+	DryRun          bool
+	Yes             bool
+	ContinueOnError bool
+	bulkReport
+}
+
+// update is the requested state of one existing resource, along with its current one
+type update struct {
+	Type string
+	ID   string
+	// Name is the new name; nil keeps the current one
+	Name *string
+	// Parents are the new parents when SetParents is set; otherwise the current ones are kept
+	Parents    []exportedParent
+	SetParents bool
+
+	CurrentName    string
+	CurrentParents []exportedParent
+}
+
+// NewBulkUpdateCmd creates the bulk-update command
+func NewBulkUpdateCmd() *cobra.Command {
+	cmd := &BulkUpdateCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "bulk-update <csv-file|->",
+		Short: "Bulk update the names and parents of resources from a CSV file",
+		Long: `Update the names and parents of existing resources listed in a CSV file, or read from stdin
+with "-". Confirmation prompts cannot be answered while stdin carries the CSV; pass --yes.
+
+The CSV file has the columns type and id, which select the resource, and any of:
+- name: New name of the resource; an empty cell keeps the current name
+- parent_type, parent_id: New parents, replacing the current ones. A resource listed on several
+  rows gets all of their parents; leave both empty to remove every parent. Without these
+  columns the parents are kept.
+
+Example CSV:
+type,id,name,parent_type,parent_id
+workspace,ws456,Marketing,organization,org123
+project,proj789,Website,,
+
+Every resource is read first and the changes are shown as a diff; resources that already match
+are left alone. --dry-run stops after the diff. Use 'blimu resources move' to check parent
+changes against the definitions and see which inherited roles change hands.
+
+Failed rows are listed as with 'blimu resources bulk': the first 10 on a terminal (see
+--display-limit and --show-all) and all of them otherwise. --failed-output writes them, with an
+error column, to <csv-file>.failed.csv (or --failed-output=<file>) for a retry, and with --json
+the summary is printed to stdout as JSON and the rest to stderr.`,
+		Example: `  blimu resources bulk-update renames.csv --dry-run
+  blimu resources bulk-update renames.csv --yes
+  ./renames.sh | blimu resources bulk-update - --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.CSVFile = args[0]
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Show the changes without applying them")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Apply without asking for confirmation")
+	cobraCmd.Flags().BoolVar(&cmd.ContinueOnError, "continue-on-error", false, "Keep applying the updates after a batch fails")
+	cmd.addReportFlags(cobraCmd, "updated")
+
+	return cobraCmd
+}
+
+// Run executes the bulk update command
+func (c *BulkUpdateCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+	out := c.startReport()
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("bulk updates"); err != nil {
+		return err
+	}
+
+	rows, err := c.parseUpdatesCSV()
+	if err != nil {
+		return fmt.Errorf("failed to parse CSV file: %w", err)
+	}
+
+	if !c.DryRun {
+		if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "update resources in", c.Yes); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+
+	// Read every resource before changing anything
+	fmt.Fprintf(out, "🔍 Reading %d resource(s)...\n", len(rows))
+	var problems []bulk.RowError
+	for i := range rows {
+		if err := c.readCurrent(ctx, client, &rows[i].Item); err != nil {
+			problems = append(problems, bulk.RowError{Line: rows[i].Line, Key: rows[i].Key, Message: err.Error()})
+		}
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(out, "❌ %d resource(s) cannot be updated:\n", len(problems))
+		for _, problem := range problems {
+			fmt.Fprintf(out, "   - line %d (%s): %s\n", problem.Line, problem.Key, problem.Message)
+		}
+		return fmt.Errorf("no resources were updated")
+	}
+
+	changed := 0
+	for _, row := range rows {
+		if printDiff(out, row) {
+			changed++
+		}
+	}
+	if changed == 0 {
+		fmt.Fprintf(out, "✅ Nothing to update\n")
+		return nil
+	}
+	if c.DryRun {
+		fmt.Fprintf(out, "🔍 Dry run: %d of %d resource(s) would be updated\n", changed, len(rows))
+		return nil
+	}
+
+	approved, err := shared.AutoApproved(cliConfig, c.EnvironmentID, c.Yes)
+	if err != nil {
+		return err
+	}
+	confirmed, err := shared.Confirm(fmt.Sprintf("Update %d resource(s)?", changed), fmt.Sprintf("update %d resource(s)", changed), approved)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(out, "Aborted; nothing was updated.")
+		return nil
+	}
+
+	summary, runErr := bulk.Run(ctx, rows, bulk.Options{
+		BatchSize:       bulk.DefaultBatchSize,
+		ContinueOnError: c.ContinueOnError,
+		OnBatch:         c.printBatch,
+	}, c.updateBatch(client))
+	if err := c.printSummary(summary, "updated", "already up to date"); err != nil {
+		return err
+	}
+	if err := writeFailedRows(&c.bulkReport, c.CSVFile, rows[0].Item.header(), rows, summary, update.records); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d updates failed", summary.Failed, summary.Total)
+	}
+	return nil
+}
+
+// readCurrent fills in the current name and parents of the resource to update
+func (c *BulkUpdateCommand) readCurrent(ctx context.Context, client *blimu.Client, u *update) error {
+	current, err := client.Resources.GetWithContext(ctx, c.WorkspaceID, c.EnvironmentID, u.Type, u.ID)
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return shared.Errorf(shared.ErrNotFound, "resource not found")
+		}
+		return fmt.Errorf("failed to get resource: %w", err)
+	}
+	if current.Name != nil {
+		u.CurrentName = *current.Name
+	}
+	u.CurrentParents = toParents(current.Parents)
+	return nil
+}
+
+// updateBatch returns a processor updating each resource of a batch; resources that already match
+// count as unchanged
+func (c *BulkUpdateCommand) updateBatch(client *blimu.Client) bulk.Processor[update] {
+	return func(ctx context.Context, batch []bulk.Row[update]) (bulk.BatchOutcome, error) {
+		var outcome bulk.BatchOutcome
+		for _, row := range batch {
+			if err := ctx.Err(); err != nil {
+				outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+				continue
+			}
+			if !row.Item.changed() {
+				outcome.Unchanged++
+				continue
+			}
+			if _, err := client.Resources.UpdateWithContext(ctx, c.WorkspaceID, c.EnvironmentID, row.Item.Type, row.Item.ID, row.Item.body()); err != nil {
+				outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+			}
+		}
+		return outcome, nil
+	}
+}
+
+// parseUpdatesCSV reads updates from a CSV file. Rows of the same resource are merged into one
+// update, at the line of its first row.
+func (c *BulkUpdateCommand) parseUpdatesCSV() ([]bulk.Row[update], error) {
+	file, err := openInput(c.CSVFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"type", "id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV must have '%s' column", required)
+		}
+	}
+	_, hasName := columns["name"]
+	_, hasParentType := columns["parent_type"]
+	_, hasParentID := columns["parent_id"]
+	if hasParentType != hasParentID {
+		return nil, fmt.Errorf("CSV must have both 'parent_type' and 'parent_id' columns, or neither")
+	}
+	if !hasName && !hasParentType {
+		return nil, fmt.Errorf("CSV must have a 'name' column or 'parent_type' and 'parent_id' columns")
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []bulk.Row[update]
+	index := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		resourceType, resourceID := field(record, "type"), field(record, "id")
+		if resourceType == "" || resourceID == "" {
+			return nil, fmt.Errorf("line %d: type and id are required", line)
+		}
+		parentType, parentID := field(record, "parent_type"), field(record, "parent_id")
+		if (parentType == "") != (parentID == "") {
+			return nil, fmt.Errorf("line %d: parent_type and parent_id must be given together", line)
+		}
+
+		key := resourceType + ":" + resourceID
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			rows = append(rows, bulk.Row[update]{Line: line, Key: key, Item: update{Type: resourceType, ID: resourceID, SetParents: hasParentType}})
+		}
+		u := &rows[i].Item
+		if name := field(record, "name"); name != "" {
+			if u.Name != nil && *u.Name != name {
+				return nil, fmt.Errorf("line %d: %s is given two names, '%s' and '%s'", line, key, *u.Name, name)
+			}
+			u.Name = &name
+		}
+		if parentType != "" {
+			parent := exportedParent{Type: parentType, ID: parentID}
+			if !hasParent(u.Parents, parent) {
+				u.Parents = append(u.Parents, parent)
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV file has no updates")
+	}
+	return rows, nil
+}
+
+// newName returns the name the resource ends up with
+func (u update) newName() string {
+	if u.Name != nil {
+		return *u.Name
+	}
+	return u.CurrentName
+}
+
+// newParents returns the parents the resource ends up with
+func (u update) newParents() []exportedParent {
+	if u.SetParents {
+		return u.Parents
+	}
+	return u.CurrentParents
+}
+
+// changed reports whether the update differs from the resource's current state
+func (u update) changed() bool {
+	return u.newName() != u.CurrentName || !sameParents(u.CurrentParents, u.newParents())
+}
+
+// body returns the API payload of the update, which always carries the name and the parents
+func (u update) body() blimu.ResourceUpdateDto {
+	body := blimu.ResourceUpdateDto{Name: u.newName(), Parents: []map[string]interface{}{}}
+	for _, parent := range u.newParents() {
+		body.Parents = append(body.Parents, map[string]interface{}{"type": parent.Type, "id": parent.ID})
+	}
+	return body
+}
+
+// header returns the columns of the input file of an update: name, and the parents when the file
+// sets them
+func (u update) header() []string {
+	if u.SetParents {
+		return []string{"type", "id", "name", "parent_type", "parent_id"}
+	}
+	return []string{"type", "id", "name"}
+}
+
+// records returns the CSV records of an update in the columns of header, one per new parent
+func (u update) records() [][]string {
+	var name string
+	if u.Name != nil {
+		name = *u.Name
+	}
+	if !u.SetParents {
+		return [][]string{{u.Type, u.ID, name}}
+	}
+	if len(u.Parents) == 0 {
+		// Empty parent cells remove every parent, as in the input
+		return [][]string{{u.Type, u.ID, name, "", ""}}
+	}
+	var records [][]string
+	for _, parent := range u.Parents {
+		records = append(records, []string{u.Type, u.ID, name, parent.Type, parent.ID})
+	}
+	return records
+}
+
+// printDiff prints the changes of one update and reports whether there are any
+func printDiff(out io.Writer, row bulk.Row[update]) bool {
+	u := row.Item
+	if !u.changed() {
+		return false
+	}
+	fmt.Fprintf(out, "✏️  %s\n", row.Key)
+	if u.newName() != u.CurrentName {
+		fmt.Fprintf(out, "   - name: %s\n", u.CurrentName)
+		fmt.Fprintf(out, "   + name: %s\n", u.newName())
+	}
+	if !sameParents(u.CurrentParents, u.newParents()) {
+		fmt.Fprintf(out, "   - parents: %s\n", describeParents(u.CurrentParents))
+		fmt.Fprintf(out, "   + parents: %s\n", describeParents(u.newParents()))
+	}
+	return true
+}
@@ -4,18 +4,19 @@ import (
 	"fmt"
 	"strings"
 
-	blimu "github.com/blimu-dev/blimu-cli/internal/sdk"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
 	"github.com/spf13/cobra"
 )
 
 // CreateCommand represents the create resource command
 type CreateCommand struct {
-	ResourceType  string
-	ResourceID    string
-	Parent        string
-	WorkspaceID   string
-	EnvironmentID string
+	ResourceType   string
+	ResourceID     string
+	Parent         string
+	WorkspaceID    string
+	EnvironmentID  string
+	IdempotencyKey string
 }
 
 // NewCreateCmd creates the create command
@@ -34,54 +35,30 @@ Example:
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			cmd.ResourceType = args[0]
 			cmd.ResourceID = args[1]
-			return cmd.Run()
+			return cmd.Run(cobraCmd)
 		},
 	}
 
 	cobraCmd.Flags().StringVar(&cmd.Parent, "parent", "", "Parent resource in format 'type:id'")
-	cobraCmd.Flags().StringVar(&cmd.WorkspaceID, "workspace-id", "", "Workspace ID (uses current environment's workspace if available)")
-	cobraCmd.Flags().StringVar(&cmd.EnvironmentID, "environment-id", "", "Environment ID (uses current environment ID if available)")
+	cobraCmd.Flags().StringVar(&cmd.IdempotencyKey, "idempotency-key", "", "Idempotency-Key of the request (default: derived from the resource)")
 
 	return cobraCmd
 }
 
 // Run executes the create resource command
-func (c *CreateCommand) Run() error {
-	// Get current environment info to auto-populate missing IDs
-	_, currentEnv, err := shared.GetCurrentEnvironmentInfo()
-	if err != nil {
-		return fmt.Errorf("failed to get current environment info: %w", err)
-	}
-
-	// Auto-populate environment ID from current environment if not provided
-	if c.EnvironmentID == "" && currentEnv.ID != "" {
-		c.EnvironmentID = currentEnv.ID
-		fmt.Printf("📋 Using environment ID from current environment: %s\n", c.EnvironmentID)
-	}
-
-	// Auto-populate workspace ID from current environment if not provided
-	if c.WorkspaceID == "" && currentEnv.WorkspaceID != "" {
-		c.WorkspaceID = currentEnv.WorkspaceID
-		fmt.Printf("📋 Using workspace ID from current environment: %s\n", c.WorkspaceID)
-	}
+func (c *CreateCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
 
-	// Check required parameters
-	if c.EnvironmentID == "" {
-		return fmt.Errorf("environment-id is required for resource creation. Either:\n" +
-			"  1. Provide --environment-id flag\n" +
-			"  2. Configure your current environment with an ID using 'blimu env create --workspace-id <workspace-id> <env-name>'")
-	}
-
-	if c.WorkspaceID == "" {
-		return fmt.Errorf("workspace-id is required for resource creation. Provide --workspace-id flag.\n" +
-			"Use 'blimu workspaces list' to find your workspace ID (when available)")
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("resource creation"); err != nil {
+		return err
 	}
 
 	fmt.Printf("🔧 Creating resource '%s:%s' in workspace '%s', environment '%s'...\n",
 		c.ResourceType, c.ResourceID, c.WorkspaceID, c.EnvironmentID)
 
 	// Get SDK client
-	client, err := shared.GetSDKClient()
+	client, err := sc.Client()
 	if err != nil {
 		return err
 	}
@@ -110,8 +87,13 @@ func (c *CreateCommand) Run() error {
 		}
 	}
 
-	// Create the resource
-	result, err := client.Resources.Create(c.WorkspaceID, c.EnvironmentID, body)
+	// Create the resource; a retry after a network failure does not create it twice
+	key := c.IdempotencyKey
+	if key == "" {
+		key = resourceIdempotencyKey("", c.WorkspaceID, c.EnvironmentID, body)
+	}
+	ctx := blimu.WithIdempotencyKey(cmd.Context(), key)
+	result, err := client.Resources.CreateWithContext(ctx, c.WorkspaceID, c.EnvironmentID, body)
 	if err != nil {
 		return fmt.Errorf("failed to create resource: %w", err)
 	}
@@ -119,7 +101,9 @@ func (c *CreateCommand) Run() error {
 	fmt.Println("✅ Resource created successfully!")
 	fmt.Printf("   Type: %s\n", result.Type)
 	fmt.Printf("   ID: %s\n", result.Id)
-	fmt.Printf("   Name: %s\n", result.Name)
+	if result.Name != nil {
+		fmt.Printf("   Name: %s\n", *result.Name)
+	}
 	if len(body.Parents) > 0 {
 		fmt.Printf("   Parent: %s:%s\n", body.Parents[0]["type"], body.Parents[0]["id"])
 	}
@@ -0,0 +1,355 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// DeleteCommand represents the delete resource command
+type DeleteCommand struct {
+	ResourceType  string
+	ResourceID    string
+	WorkspaceID   string
+	EnvironmentID string
+	Cascade       bool
+	IncludeShared bool
+	DryRun        bool
+	Yes           bool
+}
+
+// dependent is a resource deleted along with the requested one
+type dependent struct {
+	exportedResource
+	// Depth is the distance from the requested resource, which has depth 0
+	Depth int
+	// Users is the number of users holding a role on the resource
+	Users int
+	// OtherParents are parents outside the deleted tree
+	OtherParents []exportedParent
+	// Unlink is set for a resource that is kept because it has OtherParents; it is only removed
+	// from its parents inside the deleted tree
+	Unlink bool
+}
+
+// NewDeleteCmd creates the delete command
+func NewDeleteCmd() *cobra.Command {
+	cmd := &DeleteCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "delete <resource-type> <resource-id>",
+		Short: "Delete a resource",
+		Long: `Delete a resource from your Blimu environment.
+
+Before deleting, the resource's child resources (recursively) and the users holding roles on
+them are looked up. A resource with dependents is only deleted with --cascade, which deletes the
+children first, deepest first, and then the resource; users keep their accounts but lose their
+roles on the deleted resources. Without --cascade the command lists the dependents and stops.
+
+A child that also has parents outside the deleted tree is kept, along with the resources below
+it, and only removed from its parents inside the tree. --include-shared deletes such children
+too, which removes them from their other parents as well.
+
+Examples:
+  blimu resources delete project acme-website
+  blimu resources delete organization acme --cascade --dry-run
+  blimu resources delete organization acme --cascade --yes`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			cmd.ResourceType = args[0]
+			cmd.ResourceID = args[1]
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Cascade, "cascade", false, "Also delete child resources, deepest first, and the roles users hold on them")
+	cobraCmd.Flags().BoolVar(&cmd.IncludeShared, "include-shared", false, "With --cascade, also delete child resources that have parents outside the deleted tree")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Show what would be deleted without deleting anything")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Delete without asking for confirmation")
+
+	return cobraCmd
+}
+
+// Run executes the delete resource command
+func (c *DeleteCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("resource deletion"); err != nil {
+		return err
+	}
+
+	if !c.DryRun {
+		if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "delete resources in", c.Yes); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return fmt.Errorf("authentication required. Run 'blimu auth login' first: %w", err)
+	}
+
+	subject := c.ResourceType + ":" + c.ResourceID
+	if _, err := client.Resources.GetWithContext(ctx, c.WorkspaceID, c.EnvironmentID, c.ResourceType, c.ResourceID); err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return shared.Errorf(shared.ErrNotFound, "resource %s not found", subject)
+		}
+		return fmt.Errorf("failed to get resource %s: %w", subject, err)
+	}
+
+	definitions, err := runner.API().GetDefinitions(ctx, c.WorkspaceID, c.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get definitions: %w", err)
+	}
+
+	fmt.Printf("🔍 Checking dependents of %s...\n", subject)
+	plan, err := c.dependents(ctx, client, childTypes(definitions.Resources))
+	if err != nil {
+		return err
+	}
+
+	children, users, unlinked := len(plan)-1, 0, 0
+	for _, d := range plan {
+		if d.Unlink {
+			unlinked++
+			continue
+		}
+		users += d.Users
+	}
+	deleting := len(plan) - unlinked
+	if children == 0 && users == 0 {
+		fmt.Printf("ℹ️  %s has no child resources and no users with roles on it\n", subject)
+	} else {
+		fmt.Printf("⚠️  %s has %d child resource(s) and %d user role assignment(s):\n", subject, children, users)
+		printDependents(plan)
+	}
+
+	if (children > 0 || users > 0) && !c.Cascade {
+		return fmt.Errorf("%s has dependents; re-run with --cascade to delete them too, or remove them first", subject)
+	}
+	if unlinked > 0 {
+		fmt.Printf("ℹ️  %d child resource(s) with parents outside the tree are kept and only unlinked; use --include-shared to delete them\n", unlinked)
+	}
+	if c.DryRun {
+		fmt.Printf("🔍 Dry run: %d resource(s) would be deleted and %d unlinked\n", deleting, unlinked)
+		return nil
+	}
+
+	approved, err := shared.AutoApproved(cliConfig, c.EnvironmentID, c.Yes)
+	if err != nil {
+		return err
+	}
+	confirmed, err := shared.Confirm(fmt.Sprintf("Delete %d resource(s)?", deleting), fmt.Sprintf("delete %d resource(s)", deleting), approved)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Aborted; nothing was deleted.")
+		return nil
+	}
+
+	// Kept children leave the tree before anything is deleted, so their parents in it can go
+	for _, resource := range plan {
+		if !resource.Unlink {
+			continue
+		}
+		name := resource.Type + ":" + resource.ID
+		body := blimu.ResourceUpdateDto{Name: resource.Name, Parents: []map[string]interface{}{}}
+		for _, parent := range resource.OtherParents {
+			body.Parents = append(body.Parents, map[string]interface{}{"type": parent.Type, "id": parent.ID})
+		}
+		if _, err := client.Resources.UpdateWithContext(ctx, c.WorkspaceID, c.EnvironmentID, resource.Type, resource.ID, body); err != nil {
+			return fmt.Errorf("failed to unlink %s (nothing was deleted): %w", name, err)
+		}
+		fmt.Printf("🔗 Unlinked %s, kept under %s\n", name, formatParents(resource.OtherParents))
+	}
+
+	// Children go first so no resource is deleted while it still has children
+	deleted := 0
+	for i := len(plan) - 1; i >= 0; i-- {
+		resource := plan[i]
+		if resource.Unlink {
+			continue
+		}
+		name := resource.Type + ":" + resource.ID
+		_, err := client.Resources.DeleteWithContext(ctx, c.WorkspaceID, c.EnvironmentID, resource.Type, resource.ID)
+		switch {
+		case isStatus(err, http.StatusNotFound):
+			fmt.Printf("⏭️  %s was already deleted\n", name)
+			continue
+		case isStatus(err, http.StatusConflict):
+			return fmt.Errorf("failed to delete %s: it gained dependents since they were checked; %d resource(s) were deleted, run the command again", name, deleted)
+		case err != nil:
+			return fmt.Errorf("failed to delete %s (%d resource(s) were deleted): %w", name, deleted, err)
+		}
+		deleted++
+		fmt.Printf("🗑️  Deleted %s\n", name)
+	}
+
+	fmt.Printf("✅ Deleted %d resource(s)\n", deleted)
+	return nil
+}
+
+// dependents returns the requested resource followed by its descendants, breadth first, with the
+// number of users holding roles on each. Resources reachable through several parents appear once.
+// Unless IncludeShared, descendants with a parent outside the deleted tree are kept: those with a
+// parent inside it are marked Unlink, and those only below kept resources are left out.
+func (c *DeleteCommand) dependents(ctx context.Context, client *blimu.Client, childTypes map[string][]string) ([]dependent, error) {
+	root := dependent{exportedResource: exportedResource{Type: c.ResourceType, ID: c.ResourceID}}
+	plan := []dependent{root}
+	inPlan := map[string]bool{root.Type + ":" + root.ID: true}
+
+	for i := 0; i < len(plan); i++ {
+		users, err := countUsers(ctx, client, c.WorkspaceID, c.EnvironmentID, plan[i].Type, plan[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		plan[i].Users = users
+
+		for _, childType := range childTypes[plan[i].Type] {
+			children, err := listChildren(ctx, client, c.WorkspaceID, c.EnvironmentID, plan[i].exportedResource, childType)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				key := child.Type + ":" + child.ID
+				if inPlan[key] {
+					continue
+				}
+				inPlan[key] = true
+				plan = append(plan, dependent{exportedResource: child, Depth: plan[i].Depth + 1})
+			}
+		}
+	}
+
+	// Parents outside the tree are only known once the whole tree is. Keeping a resource puts its
+	// children outside the tree too, so keep going until no more resources are kept.
+	deleted := make(map[string]bool, len(plan))
+	for key := range inPlan {
+		deleted[key] = true
+	}
+	for changed := !c.IncludeShared; changed; {
+		changed = false
+		for _, d := range plan[1:] {
+			key := d.Type + ":" + d.ID
+			if deleted[key] && len(outsideParents(d.Parents, deleted)) > 0 {
+				deleted[key] = false
+				changed = true
+			}
+		}
+	}
+
+	final := make([]dependent, 0, len(plan))
+	for _, d := range plan {
+		d.OtherParents = outsideParents(d.Parents, deleted)
+		if !deleted[d.Type+":"+d.ID] {
+			if len(d.OtherParents) == len(d.Parents) {
+				// Only below kept resources; nothing changes for it
+				continue
+			}
+			d.Unlink = true
+		}
+		final = append(final, d)
+	}
+	return final, nil
+}
+
+// outsideParents returns the parents that are not in the deleted set
+func outsideParents(parents []exportedParent, deleted map[string]bool) []exportedParent {
+	var outside []exportedParent
+	for _, parent := range parents {
+		if !deleted[parent.Type+":"+parent.ID] {
+			outside = append(outside, parent)
+		}
+	}
+	return outside
+}
+
+// listChildren pages through the children of one type of a resource
+func listChildren(ctx context.Context, client *blimu.Client, workspaceID, environmentID string, parent exportedResource, childType string) ([]exportedResource, error) {
+	limit := float64(exportPageSize)
+	var children []exportedResource
+	for page := float64(1); ; page++ {
+		current := page
+		response, err := client.Resources.ListChildrenWithContext(ctx, workspaceID, environmentID, parent.Type, parent.ID,
+			&blimu.ResourcesListChildrenQuery{Type: childType, Limit: &limit, Page: &current})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s children of %s:%s: %w", childType, parent.Type, parent.ID, err)
+		}
+		for _, item := range response.Items {
+			children = append(children, toExportedResource(childType, item))
+		}
+		if len(response.Items) == 0 || len(children) >= int(response.Total) {
+			return children, nil
+		}
+	}
+}
+
+// countUsers returns the number of users holding a role on a resource
+func countUsers(ctx context.Context, client *blimu.Client, workspaceID, environmentID, resourceType, resourceID string) (int, error) {
+	limit := float64(1)
+	response, err := client.Resources.GetResourceUsersWithContext(ctx, workspaceID, environmentID, resourceType, resourceID,
+		&blimu.ResourcesGetResourceUsersQuery{Limit: &limit})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users of %s:%s: %w", resourceType, resourceID, err)
+	}
+	return int(response.Total), nil
+}
+
+// childTypes maps every resource type to the types that declare it as a parent
+func childTypes(resources map[string]interface{}) map[string][]string {
+	children := map[string][]string{}
+	for name, definition := range resources {
+		resource, _ := definition.(map[string]interface{})
+		parents, _ := resource["parents"].(map[string]interface{})
+		for parent := range parents {
+			children[parent] = append(children[parent], name)
+		}
+	}
+	for parent := range children {
+		sort.Strings(children[parent])
+	}
+	return children
+}
+
+// printDependents prints the resources to delete as an indented tree
+func printDependents(plan []dependent) {
+	for _, d := range plan {
+		line := fmt.Sprintf("  %s%s:%s", strings.Repeat("  ", d.Depth), d.Type, d.ID)
+		if d.Unlink {
+			fmt.Println(line + " (kept under " + formatParents(d.OtherParents) + ", only unlinked)")
+			continue
+		}
+		if d.Users > 0 {
+			line += fmt.Sprintf(" (%d user(s) with roles)", d.Users)
+		}
+		if len(d.OtherParents) > 0 {
+			line += " (also under " + formatParents(d.OtherParents) + ")"
+		}
+		fmt.Println(line)
+	}
+}
+
+// isStatus reports whether err is an API error with the given status code
+func isStatus(err error, status int) bool {
+	var apiErr *blimu.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
@@ -0,0 +1,286 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// exportPageSize is the number of resources requested per page while exporting
+const exportPageSize = 100
+
+// ExportCommand represents the export resources command
+type ExportCommand struct {
+	Format        string
+	Types         []string
+	Output        string
+	WorkspaceID   string
+	EnvironmentID string
+}
+
+// exportedResource is a resource as written by the exporters
+type exportedResource struct {
+	Type    string           `json:"type"`
+	ID      string           `json:"id"`
+	Name    string           `json:"name,omitempty"`
+	Parents []exportedParent `json:"parents,omitempty"`
+}
+
+// exportedParent is a parent reference of an exported resource
+type exportedParent struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// NewExportCmd creates the export command
+func NewExportCmd() *cobra.Command {
+	cmd := &ExportCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export existing resources",
+		Long: `Export the resources of an environment so they can be adopted by other tooling.
+
+Formats:
+  terraform         blimu_resource blocks plus import blocks (Terraform 1.5+), so existing
+                    resources are adopted into state on the next apply
+  terraform-import  import blocks only, for use with 'terraform plan -generate-config-out'
+  json              a JSON array of resources
+  jsonl             one JSON object per line
+
+JSON formats are written page by page as resources are listed, so memory use stays constant
+however many resources the environment holds. Terraform formats are written once every
+resource has been listed, since parents are referenced by their resource's label.
+
+All resource types from the environment's definitions are exported unless --type is given.
+
+Examples:
+  blimu resources export --format terraform --output blimu_resources.tf
+  blimu resources export --format terraform-import --type organization,workspace`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Format, "format", "terraform", "Output format (terraform, terraform-import, json, jsonl)")
+	cobraCmd.Flags().StringSliceVar(&cmd.Types, "type", nil, "Resource types to export (defaults to all types in the environment's definitions)")
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "Write the export to a file instead of stdout")
+
+	return cobraCmd
+}
+
+// Run executes the export command
+func (c *ExportCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	switch c.Format {
+	case "terraform", "terraform-import", "json", "jsonl":
+	default:
+		return fmt.Errorf("unsupported format '%s' (supported: terraform, terraform-import, json, jsonl)", c.Format)
+	}
+
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("export"); err != nil {
+		return err
+	}
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+
+	types := c.Types
+	if len(types) == 0 {
+		definitions, err := client.Definitions.Get(c.WorkspaceID, c.EnvironmentID)
+		if err != nil {
+			return fmt.Errorf("failed to get definitions: %w", err)
+		}
+		for resourceType := range definitions.Resources {
+			types = append(types, resourceType)
+		}
+		sort.Strings(types)
+	}
+
+	var out io.Writer = os.Stdout
+	if c.Output != "" {
+		file, err := os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	ctx := cmd.Context()
+
+	var exported int
+	switch c.Format {
+	case "terraform", "terraform-import":
+		var resources []exportedResource
+		for _, resourceType := range types {
+			fetched, err := listAllResources(ctx, client, c.WorkspaceID, c.EnvironmentID, resourceType)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "📦 %s: %d resource(s)\n", resourceType, len(fetched))
+			resources = append(resources, fetched...)
+		}
+		writeTerraform(out, resources, c.WorkspaceID, c.EnvironmentID, c.Format == "terraform")
+		exported = len(resources)
+	default:
+		w := resourceWriters[c.Format](out)
+		for _, resourceType := range types {
+			count, _, err := forEachResourcePage(ctx, client, c.WorkspaceID, c.EnvironmentID, &blimu.ResourcesListQuery{Type: resourceType}, 1, exportPageSize, true,
+				func(items []exportedResource) error {
+					for _, item := range items {
+						if err := w.Write(item); err != nil {
+							return fmt.Errorf("failed to encode resources: %w", err)
+						}
+					}
+					return w.Flush()
+				})
+			if err != nil {
+				w.Close()
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "📦 %s: %d resource(s)\n", resourceType, count)
+			exported += count
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("failed to encode resources: %w", err)
+		}
+	}
+
+	if c.Output != "" {
+		fmt.Fprintf(os.Stderr, "✅ Exported %d resource(s) to %s\n", exported, c.Output)
+	}
+
+	return nil
+}
+
+// listAllResources pages through every resource of a type
+func listAllResources(ctx context.Context, client *blimu.Client, workspaceID, environmentID, resourceType string) ([]exportedResource, error) {
+	var resources []exportedResource
+	_, _, err := forEachResourcePage(ctx, client, workspaceID, environmentID, &blimu.ResourcesListQuery{Type: resourceType}, 1, exportPageSize, true,
+		func(items []exportedResource) error {
+			resources = append(resources, items...)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// toExportedResource converts a resource list item into an exportedResource
+func toExportedResource(resourceType string, item map[string]interface{}) exportedResource {
+	resource := exportedResource{Type: resourceType}
+	if id, ok := item["id"].(string); ok {
+		resource.ID = id
+	}
+	if name, ok := item["name"].(string); ok {
+		resource.Name = name
+	}
+	if parents, ok := item["parents"].([]interface{}); ok {
+		for _, parent := range parents {
+			if parentMap, ok := parent.(map[string]interface{}); ok {
+				parentType, _ := parentMap["type"].(string)
+				parentID, _ := parentMap["id"].(string)
+				resource.Parents = append(resource.Parents, exportedParent{Type: parentType, ID: parentID})
+			}
+		}
+	}
+	return resource
+}
+
+// writeTerraform writes import blocks, and optionally blimu_resource blocks, for the resources.
+// Parents that are part of the export are referenced by address so Terraform orders creation correctly.
+func writeTerraform(out io.Writer, resources []exportedResource, workspaceID, environmentID string, includeResources bool) {
+	labels := terraformLabels(resources)
+
+	fmt.Fprintf(out, "# Generated by 'blimu resources export' for workspace %s, environment %s\n", workspaceID, environmentID)
+
+	for _, resource := range resources {
+		label := labels[resource.Type+":"+resource.ID]
+
+		fmt.Fprintf(out, "\nimport {\n")
+		fmt.Fprintf(out, "  to = blimu_resource.%s\n", label)
+		fmt.Fprintf(out, "  id = %s\n", hclString(strings.Join([]string{workspaceID, environmentID, resource.Type, resource.ID}, "/")))
+		fmt.Fprintf(out, "}\n")
+
+		if !includeResources {
+			continue
+		}
+
+		fmt.Fprintf(out, "\nresource \"blimu_resource\" %s {\n", hclString(label))
+		fmt.Fprintf(out, "  workspace_id   = %s\n", hclString(workspaceID))
+		fmt.Fprintf(out, "  environment_id = %s\n", hclString(environmentID))
+		fmt.Fprintf(out, "  type           = %s\n", hclString(resource.Type))
+		fmt.Fprintf(out, "  resource_id    = %s\n", hclString(resource.ID))
+		if resource.Name != "" {
+			fmt.Fprintf(out, "  name           = %s\n", hclString(resource.Name))
+		}
+		for _, parent := range resource.Parents {
+			parentID := hclString(parent.ID)
+			if parentLabel, ok := labels[parent.Type+":"+parent.ID]; ok {
+				parentID = "blimu_resource." + parentLabel + ".resource_id"
+			}
+			fmt.Fprintf(out, "\n  parent {\n")
+			fmt.Fprintf(out, "    type = %s\n", hclString(parent.Type))
+			fmt.Fprintf(out, "    id   = %s\n", parentID)
+			fmt.Fprintf(out, "  }\n")
+		}
+		fmt.Fprintf(out, "}\n")
+	}
+}
+
+// terraformLabels assigns each resource a unique, valid Terraform resource label keyed by "type:id"
+func terraformLabels(resources []exportedResource) map[string]string {
+	labels := make(map[string]string, len(resources))
+	used := make(map[string]bool, len(resources))
+
+	for _, resource := range resources {
+		base := terraformIdentifier(resource.Type + "_" + resource.ID)
+		label := base
+		for i := 2; used[label]; i++ {
+			label = fmt.Sprintf("%s_%d", base, i)
+		}
+		used[label] = true
+		labels[resource.Type+":"+resource.ID] = label
+	}
+
+	return labels
+}
+
+// terraformIdentifier converts a string into a valid Terraform identifier
+func terraformIdentifier(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	identifier := b.String()
+	if identifier == "" || !(identifier[0] >= 'a' && identifier[0] <= 'z' || identifier[0] == '_') {
+		identifier = "r_" + identifier
+	}
+	return identifier
+}
+
+// hclString quotes a value as an HCL string literal, escaping template sequences
+func hclString(value string) string {
+	quoted, _ := json.Marshal(value)
+	escaped := strings.ReplaceAll(string(quoted), "${", "$${")
+	return strings.ReplaceAll(escaped, "%{", "%%{")
+}
@@ -0,0 +1,296 @@
+package resources
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// listPageSize is the default number of resources requested per page
+const listPageSize = 100
+
+// ListCommand represents the list resources command
+type ListCommand struct {
+	Type          string
+	Parent        string
+	Search        string
+	All           bool
+	Page          int
+	Limit         int
+	Format        string
+	Output        string
+	WorkspaceID   string
+	EnvironmentID string
+}
+
+// NewListCmd creates the list command
+func NewListCmd() *cobra.Command {
+	cmd := &ListCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List resources of a type",
+		Long: `List the resources of a type in an environment, one page at a time or all of them with --all.
+
+With --all every page is fetched in turn and its rows are written as soon as it arrives, so
+memory use stays constant however many resources the environment holds. Use --format jsonl
+or csv to pipe large listings into other tools.
+
+Examples:
+  # First page of organizations
+  blimu resources list --type organization
+
+  # Every workspace of an organization as JSON lines
+  blimu resources list --type workspace --parent org_123 --all --format jsonl
+
+  # Export all brands to a CSV file
+  blimu resources list --type brand --all --format csv -o brands.csv`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Type, "type", "", "Resource type to list (required)")
+	cobraCmd.Flags().StringVar(&cmd.Parent, "parent", "", "Only list resources with this parent resource ID")
+	cobraCmd.Flags().StringVar(&cmd.Search, "search", "", "Only list resources matching this search term")
+	cobraCmd.Flags().BoolVar(&cmd.All, "all", false, "Fetch every page, streaming rows as they arrive")
+	cobraCmd.Flags().IntVar(&cmd.Page, "page", 1, "Page to list (ignored with --all)")
+	cobraCmd.Flags().IntVar(&cmd.Limit, "limit", listPageSize, "Resources per page")
+	cobraCmd.Flags().StringVar(&cmd.Format, "format", "table", "Output format (table, json, jsonl, csv)")
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "Write the listing to a file instead of stdout")
+	cobraCmd.MarkFlagRequired("type")
+
+	return cobraCmd
+}
+
+// Run executes the list command
+func (c *ListCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	writer, ok := resourceWriters[c.Format]
+	if !ok {
+		return fmt.Errorf("unsupported format '%s' (supported: table, json, jsonl, csv)", c.Format)
+	}
+	if c.Limit <= 0 || c.Page <= 0 {
+		return fmt.Errorf("--page and --limit must be positive")
+	}
+
+	var err error
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("list"); err != nil {
+		return err
+	}
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if c.Output != "" {
+		file, err := os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	ctx := cmd.Context()
+
+	query := &blimu.ResourcesListQuery{Type: c.Type}
+	if c.Parent != "" {
+		query.Parent = &c.Parent
+	}
+	if c.Search != "" {
+		query.Search = &c.Search
+	}
+
+	w := writer(out)
+	count, total, err := forEachResourcePage(ctx, client, c.WorkspaceID, c.EnvironmentID, query, c.Page, c.Limit, c.All,
+		func(items []exportedResource) error {
+			for _, item := range items {
+				if err := w.Write(item); err != nil {
+					return fmt.Errorf("failed to write resources: %w", err)
+				}
+			}
+			return w.Flush()
+		})
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.All || count == total {
+		fmt.Fprintf(os.Stderr, "📦 %d %s resource(s)\n", count, c.Type)
+	} else {
+		fmt.Fprintf(os.Stderr, "📦 %d of %d %s resource(s) (page %d; use --all to list every page)\n", count, total, c.Type, c.Page)
+	}
+	return nil
+}
+
+// forEachResourcePage lists resources page by page starting at page, calling fn with each page's
+// resources before the next page is requested. Only the first page is listed unless all is set.
+// It returns the number of resources passed to fn and the total the API reported.
+func forEachResourcePage(ctx context.Context, client *blimu.Client, workspaceID, environmentID string, query *blimu.ResourcesListQuery, page, limit int, all bool, fn func([]exportedResource) error) (int, int, error) {
+	pageLimit := float64(limit)
+	count, total := 0, 0
+
+	for pageNum := float64(page); ; pageNum++ {
+		if err := ctx.Err(); err != nil {
+			return count, total, err
+		}
+
+		pageQuery := *query
+		current := pageNum
+		pageQuery.Limit = &pageLimit
+		pageQuery.Page = &current
+
+		response, err := client.Resources.ListWithContext(ctx, workspaceID, environmentID, &pageQuery)
+		if err != nil {
+			return count, total, fmt.Errorf("failed to list %s resources: %w", query.Type, err)
+		}
+		total = int(response.Total)
+
+		items := make([]exportedResource, 0, len(response.Items))
+		for _, item := range response.Items {
+			items = append(items, toExportedResource(query.Type, item))
+		}
+		if err := fn(items); err != nil {
+			return count, total, err
+		}
+		count += len(items)
+
+		if !all || len(response.Items) == 0 || (page-1)*limit+count >= total {
+			return count, total, nil
+		}
+	}
+}
+
+// resourceWriter writes resources one at a time; Flush is called after each page
+type resourceWriter interface {
+	Write(exportedResource) error
+	Flush() error
+	Close() error
+}
+
+var resourceWriters = map[string]func(io.Writer) resourceWriter{
+	"table": func(out io.Writer) resourceWriter {
+		return &tableResourceWriter{w: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)}
+	},
+	"json": func(out io.Writer) resourceWriter {
+		return &jsonResourceWriter{out: out}
+	},
+	"jsonl": func(out io.Writer) resourceWriter {
+		return &jsonlResourceWriter{encoder: json.NewEncoder(out)}
+	},
+	"csv": func(out io.Writer) resourceWriter {
+		return &csvResourceWriter{w: csv.NewWriter(out)}
+	},
+}
+
+// tableResourceWriter aligns columns per page, so column widths may change between pages
+type tableResourceWriter struct {
+	w      *tabwriter.Writer
+	header bool
+}
+
+func (t *tableResourceWriter) Write(resource exportedResource) error {
+	if !t.header {
+		t.header = true
+		fmt.Fprintln(t.w, "ID\tNAME\tPARENTS")
+	}
+	_, err := fmt.Fprintf(t.w, "%s\t%s\t%s\n", resource.ID, resource.Name, formatParents(resource.Parents))
+	return err
+}
+
+func (t *tableResourceWriter) Flush() error { return t.w.Flush() }
+func (t *tableResourceWriter) Close() error { return t.w.Flush() }
+
+// jsonResourceWriter writes a JSON array element by element
+type jsonResourceWriter struct {
+	out     io.Writer
+	started bool
+}
+
+func (j *jsonResourceWriter) Write(resource exportedResource) error {
+	encoded, err := json.MarshalIndent(resource, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	separator := ",\n  "
+	if !j.started {
+		j.started = true
+		separator = "[\n  "
+	}
+	_, err = fmt.Fprintf(j.out, "%s%s", separator, encoded)
+	return err
+}
+
+func (j *jsonResourceWriter) Flush() error { return nil }
+
+func (j *jsonResourceWriter) Close() error {
+	if !j.started {
+		_, err := fmt.Fprintln(j.out, "[]")
+		return err
+	}
+	_, err := fmt.Fprintln(j.out, "\n]")
+	return err
+}
+
+type jsonlResourceWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlResourceWriter) Write(resource exportedResource) error {
+	return j.encoder.Encode(resource)
+}
+func (j *jsonlResourceWriter) Flush() error { return nil }
+func (j *jsonlResourceWriter) Close() error { return nil }
+
+// csvResourceWriter writes the columns read by 'blimu resources bulk', followed by the name.
+// Bulk rows hold a single parent, so only a resource's first parent is written.
+type csvResourceWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvResourceWriter) Write(resource exportedResource) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write([]string{"type", "id", "parent_type", "parent_id", "name"}); err != nil {
+			return err
+		}
+	}
+	var parent exportedParent
+	if len(resource.Parents) > 0 {
+		parent = resource.Parents[0]
+	}
+	return c.w.Write([]string{resource.Type, resource.ID, parent.Type, parent.ID, resource.Name})
+}
+
+func (c *csvResourceWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvResourceWriter) Close() error { return c.Flush() }
+
+// formatParents joins parents as type:id pairs
+func formatParents(parents []exportedParent) string {
+	pairs := make([]string, 0, len(parents))
+	for _, parent := range parents {
+		pairs = append(pairs, parent.Type+":"+parent.ID)
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,556 @@
+package resources
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/bulk"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	blimu "github.com/blimu-dev/blimu-cli/platform"
+	"github.com/spf13/cobra"
+)
+
+// MoveCommand represents the move resource command
+type MoveCommand struct {
+	ResourceType    string
+	ResourceID      string
+	To              []string
+	File            string
+	WorkspaceID     string
+	EnvironmentID   string
+	DryRun          bool
+	Yes             bool
+	ContinueOnError bool
+	bulkReport
+}
+
+// move replaces the parents of one resource
+type move struct {
+	Type string
+	ID   string
+	// Name is the resource's current name, sent back unchanged
+	Name string
+	From []exportedParent
+	To   []exportedParent
+}
+
+// NewMoveCmd creates the move command
+func NewMoveCmd() *cobra.Command {
+	cmd := &MoveCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "move [<resource-type> <resource-id>]",
+		Short: "Change the parents of resources",
+		Long: `Replace the parents of a resource, or of many resources listed in a CSV file.
+
+Every move is checked against the environment's definitions before anything changes: the new
+parent types must be listed under the resource type's parents, required parents must be kept
+and the new parent resources must exist. The command then shows which inherited roles change
+hands (from roles_inheritance) and asks for confirmation.
+
+The CSV file has the columns of 'blimu resources bulk': type, id, parent_type and parent_id. A
+resource listed on several rows gets all of their parents; leave parent_type and parent_id empty
+to remove every parent. Pass --file - to read the CSV from stdin; confirmation prompts cannot be
+answered then, so pass --yes.
+
+With --file, failed moves are listed as with 'blimu resources bulk': the first 10 on a terminal
+(see --display-limit and --show-all) and all of them otherwise. --failed-output writes them, with
+an error column, to <file>.failed.csv (or --failed-output=<file>) for a retry, and with --json the
+summary is printed to stdout as JSON and the rest to stderr.
+
+Examples:
+  # Move a project to another organization
+  blimu resources move project acme-website --to organization:globex
+
+  # Show the impact of a bulk move without applying it
+  blimu resources move --file moves.csv --dry-run`,
+		Args: cobra.RangeArgs(0, 2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			switch {
+			case cmd.File != "" && len(args) > 0:
+				return fmt.Errorf("pass either a resource or --file, not both")
+			case cmd.File != "" && len(cmd.To) > 0:
+				return fmt.Errorf("--to cannot be combined with --file; list the new parents in the CSV file")
+			case cmd.File == "" && len(args) != 2:
+				return fmt.Errorf("expected <resource-type> <resource-id>, or --file with a CSV of moves")
+			case cmd.File == "" && len(cmd.To) == 0:
+				return fmt.Errorf("--to is required: the new parents as type:id")
+			case cmd.File == "" && (cmd.JSON || cmd.FailedOutput != ""):
+				return fmt.Errorf("--json and --failed-output require --file")
+			}
+			if len(args) == 2 {
+				cmd.ResourceType = args[0]
+				cmd.ResourceID = args[1]
+			}
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringSliceVar(&cmd.To, "to", nil, "New parent as type:id; repeat for several parents")
+	cobraCmd.Flags().StringVar(&cmd.File, "file", "", "CSV file of moves (columns: type, id, parent_type, parent_id), or - for stdin")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Check the moves and show their impact without applying them")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Apply without asking for confirmation")
+	cobraCmd.Flags().BoolVar(&cmd.ContinueOnError, "continue-on-error", false, "Keep applying the moves of a CSV file after one fails")
+	cmd.addReportFlags(cobraCmd, "moved")
+
+	return cobraCmd
+}
+
+// Run executes the move resource command
+func (c *MoveCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	out := c.startReport()
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("resource moves"); err != nil {
+		return err
+	}
+
+	rows, err := c.moves()
+	if err != nil {
+		return err
+	}
+
+	if !c.DryRun {
+		if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "move resources in", c.Yes); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+
+	client, err := sc.Client()
+	if err != nil {
+		return err
+	}
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return fmt.Errorf("authentication required. Run 'blimu auth login' first: %w", err)
+	}
+	definitions, err := runner.API().GetDefinitions(ctx, c.WorkspaceID, c.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get definitions: %w", err)
+	}
+
+	// Check every move before changing anything
+	fmt.Fprintf(out, "🔍 Checking %d move(s)...\n", len(rows))
+	var problems []bulk.RowError
+	existing := map[string]bool{}
+	for i := range rows {
+		if err := c.check(ctx, client, definitions.Resources, &rows[i].Item, existing); err != nil {
+			problems = append(problems, bulk.RowError{Line: rows[i].Line, Key: rows[i].Key, Message: err.Error()})
+		}
+	}
+	if len(problems) > 0 {
+		if c.File == "" {
+			return fmt.Errorf("cannot move %s: %s", problems[0].Key, problems[0].Message)
+		}
+		fmt.Fprintf(out, "❌ %d move(s) are not allowed:\n", len(problems))
+		for _, problem := range problems {
+			fmt.Fprintf(out, "   - line %d (%s): %s\n", problem.Line, problem.Key, problem.Message)
+		}
+		return fmt.Errorf("no resources were moved")
+	}
+
+	var pending []bulk.Row[move]
+	for _, row := range rows {
+		if sameParents(row.Item.From, row.Item.To) {
+			fmt.Fprintf(out, "⏭️  %s is already under %s\n", row.Key, describeParents(row.Item.To))
+			continue
+		}
+		pending = append(pending, row)
+	}
+	if len(pending) == 0 {
+		fmt.Fprintf(out, "✅ Nothing to move\n")
+		return nil
+	}
+
+	if err := c.printImpact(ctx, out, client, definitions.Resources, pending); err != nil {
+		return err
+	}
+	if c.DryRun {
+		fmt.Fprintf(out, "🔍 Dry run: %d resource(s) would be moved\n", len(pending))
+		return nil
+	}
+
+	approved, err := shared.AutoApproved(cliConfig, c.EnvironmentID, c.Yes)
+	if err != nil {
+		return err
+	}
+	confirmed, err := shared.Confirm(fmt.Sprintf("Move %d resource(s)?", len(pending)), fmt.Sprintf("move %d resource(s)", len(pending)), approved)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(out, "Aborted; nothing was moved.")
+		return nil
+	}
+
+	summary, runErr := bulk.Run(ctx, pending, bulk.Options{
+		BatchSize:       bulk.DefaultBatchSize,
+		ContinueOnError: c.ContinueOnError,
+		OnBatch: func(result bulk.BatchResult) {
+			if c.File != "" {
+				c.printBatch(result)
+			}
+		},
+	}, c.moveBatch(client))
+	if runErr != nil {
+		return runErr
+	}
+
+	if c.File == "" {
+		if len(summary.Errors) > 0 {
+			return fmt.Errorf("failed to move %s: %s", summary.Errors[0].Key, summary.Errors[0].Message)
+		}
+		fmt.Fprintf(out, "✅ Moved %s under %s\n", pending[0].Key, describeParents(pending[0].Item.To))
+		return nil
+	}
+	if err := c.printSummary(summary, "moved", "unchanged"); err != nil {
+		return err
+	}
+	if err := writeFailedRows(&c.bulkReport, c.File, resourceCSVHeader, pending, summary, move.records); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d moves failed", summary.Failed, summary.Total)
+	}
+	return nil
+}
+
+// moves returns the requested moves, from the arguments or the CSV file
+func (c *MoveCommand) moves() ([]bulk.Row[move], error) {
+	if c.File != "" {
+		rows, err := c.parseMovesCSV()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV file: %w", err)
+		}
+		return rows, nil
+	}
+
+	m := move{Type: c.ResourceType, ID: c.ResourceID}
+	for _, to := range c.To {
+		parentType, parentID, ok := strings.Cut(to, ":")
+		if !ok || parentType == "" || parentID == "" {
+			return nil, fmt.Errorf("invalid parent '%s': expected type:id", to)
+		}
+		m.To = append(m.To, exportedParent{Type: parentType, ID: parentID})
+	}
+	return []bulk.Row[move]{{Key: m.Type + ":" + m.ID, Item: m}}, nil
+}
+
+// parseMovesCSV reads moves from a CSV file with the columns of the bulk create command. Rows of
+// the same resource are merged into one move, at the line of its first row.
+func (c *MoveCommand) parseMovesCSV() ([]bulk.Row[move], error) {
+	file, err := openInput(c.File)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"type", "id", "parent_type", "parent_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV must have '%s' column", required)
+		}
+	}
+
+	var rows []bulk.Row[move]
+	index := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		resourceType, resourceID := record[columns["type"]], record[columns["id"]]
+		parentType, parentID := record[columns["parent_type"]], record[columns["parent_id"]]
+		if resourceType == "" || resourceID == "" {
+			return nil, fmt.Errorf("line %d: type and id are required", line)
+		}
+		if (parentType == "") != (parentID == "") {
+			return nil, fmt.Errorf("line %d: parent_type and parent_id must be given together", line)
+		}
+
+		key := resourceType + ":" + resourceID
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			rows = append(rows, bulk.Row[move]{Line: line, Key: key, Item: move{Type: resourceType, ID: resourceID}})
+		}
+		if parentType != "" {
+			rows[i].Item.To = append(rows[i].Item.To, exportedParent{Type: parentType, ID: parentID})
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV file has no moves")
+	}
+	return rows, nil
+}
+
+// check validates a move against the resource type's definition and fills in the resource's
+// current name and parents. existing caches which parent resources were found.
+func (c *MoveCommand) check(ctx context.Context, client *blimu.Client, resources map[string]interface{}, m *move, existing map[string]bool) error {
+	definition, ok := resources[m.Type].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("resource type '%s' is not defined in this environment", m.Type)
+	}
+	allowed, _ := definition["parents"].(map[string]interface{})
+
+	seen := map[string]bool{}
+	for _, parent := range m.To {
+		key := parent.Type + ":" + parent.ID
+		switch {
+		case key == m.Type+":"+m.ID:
+			return fmt.Errorf("a resource cannot be its own parent")
+		case seen[key]:
+			return fmt.Errorf("parent %s is listed twice", key)
+		}
+		seen[key] = true
+		if _, ok := allowed[parent.Type]; !ok {
+			return fmt.Errorf("'%s' cannot have a '%s' parent (allowed: %s)", m.Type, parent.Type, allowedParents(allowed))
+		}
+	}
+	for _, parentType := range sortedKeys(allowed) {
+		settings, _ := allowed[parentType].(map[string]interface{})
+		if required, _ := settings["required"].(bool); required && !hasParentType(m.To, parentType) {
+			return fmt.Errorf("'%s' requires a '%s' parent", m.Type, parentType)
+		}
+	}
+
+	current, err := client.Resources.GetWithContext(ctx, c.WorkspaceID, c.EnvironmentID, m.Type, m.ID)
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return shared.Errorf(shared.ErrNotFound, "resource not found")
+		}
+		return fmt.Errorf("failed to get resource: %w", err)
+	}
+	if current.Name != nil {
+		m.Name = *current.Name
+	}
+	m.From = toParents(current.Parents)
+
+	for _, parent := range m.To {
+		key := parent.Type + ":" + parent.ID
+		if found, checked := existing[key]; checked {
+			if !found {
+				return shared.Errorf(shared.ErrNotFound, "parent %s not found", key)
+			}
+			continue
+		}
+		_, err := client.Resources.GetWithContext(ctx, c.WorkspaceID, c.EnvironmentID, parent.Type, parent.ID)
+		if err != nil && !isStatus(err, http.StatusNotFound) {
+			return fmt.Errorf("failed to get parent %s: %w", key, err)
+		}
+		existing[key] = err == nil
+		if err != nil {
+			return shared.Errorf(shared.ErrNotFound, "parent %s not found", key)
+		}
+	}
+	return nil
+}
+
+// printImpact shows the parents each resource leaves and joins and the inherited roles that change
+// hands with them. A single move also counts the users holding roles on the parents involved.
+func (c *MoveCommand) printImpact(ctx context.Context, out io.Writer, client *blimu.Client, resources map[string]interface{}, moves []bulk.Row[move]) error {
+	children := childTypes(resources)
+	for _, row := range moves {
+		m := row.Item
+		fmt.Fprintf(out, "🔀 %s: %s → %s\n", row.Key, describeParents(m.From), describeParents(m.To))
+
+		removed, added := parentChanges(m.From, m.To)
+		if len(moves) == 1 {
+			for _, parent := range removed {
+				users, err := countUsers(ctx, client, c.WorkspaceID, c.EnvironmentID, parent.Type, parent.ID)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "   − %s:%s (%d user(s) with roles there lose what they inherit)\n", parent.Type, parent.ID, users)
+			}
+			for _, parent := range added {
+				users, err := countUsers(ctx, client, c.WorkspaceID, c.EnvironmentID, parent.Type, parent.ID)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "   + %s:%s (%d user(s) with roles there start inheriting)\n", parent.Type, parent.ID, users)
+			}
+		}
+
+		changedTypes := map[string]bool{}
+		for _, parent := range append(removed, added...) {
+			changedTypes[parent.Type] = true
+		}
+		definition, _ := resources[m.Type].(map[string]interface{})
+		inherited := inheritedRoles(definition, changedTypes)
+		if len(inherited) == 0 {
+			fmt.Fprintf(out, "   No roles of '%s' are inherited from the parents that change\n", m.Type)
+		}
+		for _, line := range inherited {
+			fmt.Fprintf(out, "   %s\n", line)
+		}
+		if len(children[m.Type]) > 0 {
+			fmt.Fprintf(out, "   Resources under %s inherit through it and change with it\n", row.Key)
+		}
+	}
+	return nil
+}
+
+// moveBatch returns a processor updating the parents of each resource of a batch
+func (c *MoveCommand) moveBatch(client *blimu.Client) bulk.Processor[move] {
+	return func(ctx context.Context, batch []bulk.Row[move]) (bulk.BatchOutcome, error) {
+		var outcome bulk.BatchOutcome
+		for _, row := range batch {
+			if err := ctx.Err(); err != nil {
+				outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+				continue
+			}
+			body := blimu.ResourceUpdateDto{Name: row.Item.Name, Parents: []map[string]interface{}{}}
+			for _, parent := range row.Item.To {
+				body.Parents = append(body.Parents, map[string]interface{}{"type": parent.Type, "id": parent.ID})
+			}
+			if _, err := client.Resources.UpdateWithContext(ctx, c.WorkspaceID, c.EnvironmentID, row.Item.Type, row.Item.ID, body); err != nil {
+				outcome.Errors = append(outcome.Errors, bulk.RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+				continue
+			}
+			if c.File != "" {
+				fmt.Fprintf(c.out, "✅ Moved %s under %s\n", row.Key, describeParents(row.Item.To))
+			}
+		}
+		return outcome, nil
+	}
+}
+
+// records returns the CSV records of a move in the columns of resourceCSVHeader, one per new
+// parent
+func (m move) records() [][]string {
+	if len(m.To) == 0 {
+		// Empty parent cells remove every parent, as in the input
+		return [][]string{{m.Type, m.ID, "", ""}}
+	}
+	var records [][]string
+	for _, parent := range m.To {
+		records = append(records, []string{m.Type, m.ID, parent.Type, parent.ID})
+	}
+	return records
+}
+
+// inheritedRoles lists the roles_inheritance rules of a resource definition that grant roles from
+// the given parent types, e.g. "admin ← organization->owner"
+func inheritedRoles(definition map[string]interface{}, parentTypes map[string]bool) []string {
+	inheritance, _ := definition["roles_inheritance"].(map[string]interface{})
+	var lines []string
+	for _, role := range sortedKeys(inheritance) {
+		sources, _ := inheritance[role].([]interface{})
+		var matching []string
+		for _, source := range sources {
+			rule, _ := source.(string)
+			if parentType, _, ok := strings.Cut(rule, "->"); ok && parentTypes[parentType] {
+				matching = append(matching, rule)
+			}
+		}
+		if len(matching) > 0 {
+			lines = append(lines, fmt.Sprintf("%s ← %s", role, strings.Join(matching, ", ")))
+		}
+	}
+	return lines
+}
+
+// parentChanges returns the parents only in from and those only in to
+func parentChanges(from, to []exportedParent) (removed, added []exportedParent) {
+	for _, parent := range from {
+		if !hasParent(to, parent) {
+			removed = append(removed, parent)
+		}
+	}
+	for _, parent := range to {
+		if !hasParent(from, parent) {
+			added = append(added, parent)
+		}
+	}
+	return removed, added
+}
+
+func sameParents(a, b []exportedParent) bool {
+	removed, added := parentChanges(a, b)
+	return len(removed) == 0 && len(added) == 0
+}
+
+func hasParent(parents []exportedParent, parent exportedParent) bool {
+	for _, p := range parents {
+		if p == parent {
+			return true
+		}
+	}
+	return false
+}
+
+func hasParentType(parents []exportedParent, parentType string) bool {
+	for _, p := range parents {
+		if p.Type == parentType {
+			return true
+		}
+	}
+	return false
+}
+
+// toParents converts the parents of an API resource
+func toParents(parents []map[string]interface{}) []exportedParent {
+	var result []exportedParent
+	for _, parent := range parents {
+		parentType, _ := parent["type"].(string)
+		parentID, _ := parent["id"].(string)
+		result = append(result, exportedParent{Type: parentType, ID: parentID})
+	}
+	return result
+}
+
+// describeParents formats parents for messages
+func describeParents(parents []exportedParent) string {
+	if len(parents) == 0 {
+		return "(no parents)"
+	}
+	return formatParents(parents)
+}
+
+func allowedParents(allowed map[string]interface{}) string {
+	if len(allowed) == 0 {
+		return "none"
+	}
+	return strings.Join(sortedKeys(allowed), ", ")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
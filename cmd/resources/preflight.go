@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blimu-dev/blimu-cli/pkg/bulk"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+)
+
+// preflightRows checks the rows of a resources CSV file before anything is created, so an import
+// does not fail halfway through. Rows repeating a resource are dropped when identical and
+// rejected when their parents differ. A parent listed after one of its children is rejected, or
+// with sortRows moved ahead of it, keeping the file's order otherwise. Parents missing from the
+// file are left to the API, as they may already exist. It returns the rows to create.
+func preflightRows(out io.Writer, rows []bulk.Row[Resource], sortRows bool) ([]bulk.Row[Resource], error) {
+	var problems []bulk.RowError
+
+	// Duplicates
+	unique := make([]bulk.Row[Resource], 0, len(rows))
+	first := make(map[string]bulk.Row[Resource], len(rows))
+	duplicates := 0
+	for _, row := range rows {
+		earlier, seen := first[row.Key]
+		switch {
+		case !seen:
+			first[row.Key] = row
+			unique = append(unique, row)
+		case earlier.Item == row.Item:
+			duplicates++
+		default:
+			problems = append(problems, bulk.RowError{Line: row.Line, Key: row.Key,
+				Message: fmt.Sprintf("already listed on line %d with another parent", earlier.Line)})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, preflightError(out, problems)
+	}
+	if duplicates > 0 {
+		fmt.Fprintf(out, "🧹 Dropped %d duplicate row(s)\n", duplicates)
+	}
+
+	if sortRows {
+		sorted, err := parentsFirst(unique)
+		if err != nil {
+			return nil, preflightError(out, []bulk.RowError{*err})
+		}
+		moved := 0
+		for i := range sorted {
+			if sorted[i].Key != unique[i].Key {
+				moved++
+			}
+		}
+		if moved > 0 {
+			fmt.Fprintf(out, "🔀 Reordered %d row(s) to create parents before their children\n", moved)
+		}
+		return sorted, nil
+	}
+
+	// Parents listed after their children
+	position := make(map[string]int, len(unique))
+	for i, row := range unique {
+		position[row.Key] = i
+	}
+	for i, row := range unique {
+		parent := row.Item.parentKey()
+		if j, ok := position[parent]; ok && j > i {
+			problems = append(problems, bulk.RowError{Line: row.Line, Key: row.Key,
+				Message: fmt.Sprintf("parent %s is only created later, on line %d (use --sort to reorder)", parent, unique[j].Line)})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, preflightError(out, problems)
+	}
+	return unique, nil
+}
+
+// parentsFirst orders rows so that every parent in the file comes before its children, keeping
+// the order of the file where it already does. A parent chain that loops back is an error.
+func parentsFirst(rows []bulk.Row[Resource]) ([]bulk.Row[Resource], *bulk.RowError) {
+	byKey := make(map[string]bulk.Row[Resource], len(rows))
+	for _, row := range rows {
+		byKey[row.Key] = row
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(rows))
+	sorted := make([]bulk.Row[Resource], 0, len(rows))
+
+	var visit func(row bulk.Row[Resource]) *bulk.RowError
+	visit = func(row bulk.Row[Resource]) *bulk.RowError {
+		switch state[row.Key] {
+		case done:
+			return nil
+		case visiting:
+			return &bulk.RowError{Line: row.Line, Key: row.Key, Message: "is its own ancestor through its parents in the file"}
+		}
+		state[row.Key] = visiting
+		if parent, ok := byKey[row.Item.parentKey()]; ok {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[row.Key] = done
+		sorted = append(sorted, row)
+		return nil
+	}
+
+	for _, row := range rows {
+		if err := visit(row); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// preflightError lists the problems found before creating anything
+func preflightError(out io.Writer, problems []bulk.RowError) error {
+	fmt.Fprintf(out, "❌ %d row(s) cannot be imported:\n", len(problems))
+	for _, problem := range problems {
+		fmt.Fprintf(out, "   - line %d (%s): %s\n", problem.Line, problem.Key, problem.Message)
+	}
+	return shared.Errorf(shared.ErrValidation, "no resources were created")
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/bulk"
+	"github.com/spf13/cobra"
+)
+
+// defaultFailedOutput is the value of a bare --failed-output: the input file with .failed.csv
+const defaultFailedOutput = "<input>.failed.csv"
+
+// bulkReport holds the output flags shared by the bulk commands and where their output goes
+type bulkReport struct {
+	JSON         bool
+	ShowAll      bool
+	DisplayLimit int
+	FailedOutput string
+
+	// out receives the human-readable output: stdout, or stderr when stdout carries the JSON summary
+	out io.Writer
+	// limit is the number of failed rows listed per batch and in the summary; 0 lists all
+	limit int
+}
+
+// addReportFlags registers --json, --show-all, --display-limit and --failed-output; applied
+// tells what the command does to rows, e.g. "created"
+func (r *bulkReport) addReportFlags(cmd *cobra.Command, applied string) {
+	cmd.Flags().BoolVar(&r.JSON, "json", false, "Print the summary as JSON")
+	cmd.Flags().BoolVar(&r.ShowAll, "show-all", false, "List every failed row")
+	cmd.Flags().IntVar(&r.DisplayLimit, "display-limit", bulk.DefaultDisplayLimit, "Number of failed rows to list per batch and in the summary on a terminal (0 lists all)")
+	cmd.Flags().StringVar(&r.FailedOutput, "failed-output", "", fmt.Sprintf("Write the rows that were not %s, with their errors, to this CSV file for a retry", applied))
+	cmd.Flags().Lookup("failed-output").NoOptDefVal = defaultFailedOutput
+}
+
+// startReport resolves where output goes and returns the writer for human-readable output
+func (r *bulkReport) startReport() io.Writer {
+	// Human-readable progress goes to stderr when stdout carries the JSON summary
+	outFile := os.Stdout
+	if r.JSON {
+		outFile = os.Stderr
+	}
+	r.out = outFile
+
+	// Complete results are always obtainable: redirected output is never truncated
+	r.limit = r.DisplayLimit
+	if r.ShowAll || !isTerminal(outFile) {
+		r.limit = 0
+	}
+	return r.out
+}
+
+// printBatch prints the progress of one batch; it is the OnBatch of bulk runs
+func (r *bulkReport) printBatch(result bulk.BatchResult) {
+	bulk.PrintBatch(r.out, result, r.limit)
+}
+
+// printSummary prints the summary of a run, as JSON on stdout with --json; verb and unchanged
+// are those of bulk.PrintSummary
+func (r *bulkReport) printSummary(summary *bulk.Summary, verb, unchanged string) error {
+	if r.JSON {
+		return bulk.WriteJSON(os.Stdout, summary)
+	}
+	bulk.PrintSummary(r.out, summary, verb, unchanged, r.limit)
+	return nil
+}
+
+// failedOutputPath resolves the --failed-output file of an input file; input read from stdin
+// gets stdin.failed.csv in the current directory
+func failedOutputPath(flag, input string) string {
+	if flag != defaultFailedOutput {
+		return flag
+	}
+	if input == stdinInput {
+		return "stdin.failed.csv"
+	}
+	return strings.TrimSuffix(input, filepath.Ext(input)) + ".failed.csv"
+}
+
+// writeFailedRows writes the rows of a run that were not applied to the --failed-output file of
+// input, if any, for a retry; header and records are those of bulk.WriteFailedCSV
+func writeFailedRows[T any](r *bulkReport, input string, header []string, rows []bulk.Row[T], summary *bulk.Summary, records func(T) [][]string) error {
+	if r.FailedOutput == "" {
+		return nil
+	}
+	path := failedOutputPath(r.FailedOutput, input)
+	written, err := bulk.WriteFailedCSV(path, header, rows, summary, records)
+	if err != nil {
+		return err
+	}
+	if written > 0 {
+		fmt.Fprintf(r.out, "📝 Wrote %d rows to retry to %s\n", written, path)
+	}
+	return nil
+}
@@ -12,8 +12,14 @@ func NewResourcesCmd() *cobra.Command {
 		Long:  `Commands for managing resources in your Blimu environment`,
 	}
 
+	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewCreateCmd())
-	// cmd.AddCommand(NewBulkCmd()) // Temporarily disabled - needs SDK update
+	cmd.AddCommand(NewDeleteCmd())
+	cmd.AddCommand(NewMoveCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewBulkCmd())
+	cmd.AddCommand(NewBulkUpdateCmd())
+	cmd.AddCommand(NewBulkDeleteCmd())
 
 	return cmd
 }
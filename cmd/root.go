@@ -1,26 +1,74 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/blimu-dev/blimu-cli/cmd/apikeys"
 	"github.com/blimu-dev/blimu-cli/cmd/auth"
+	"github.com/blimu-dev/blimu-cli/cmd/batch"
 	"github.com/blimu-dev/blimu-cli/cmd/check"
 	"github.com/blimu-dev/blimu-cli/cmd/definitions"
+	"github.com/blimu-dev/blimu-cli/cmd/demo"
+	"github.com/blimu-dev/blimu-cli/cmd/doctor"
 	"github.com/blimu-dev/blimu-cli/cmd/env"
+	"github.com/blimu-dev/blimu-cli/cmd/export"
+	fmtcmd "github.com/blimu-dev/blimu-cli/cmd/fmtcmd"
 	"github.com/blimu-dev/blimu-cli/cmd/generate"
+	importcmd "github.com/blimu-dev/blimu-cli/cmd/importcmd"
 	initcmd "github.com/blimu-dev/blimu-cli/cmd/initcmd"
+	"github.com/blimu-dev/blimu-cli/cmd/lint"
+	"github.com/blimu-dev/blimu-cli/cmd/lsp"
+	"github.com/blimu-dev/blimu-cli/cmd/mock"
+	"github.com/blimu-dev/blimu-cli/cmd/profile"
+	"github.com/blimu-dev/blimu-cli/cmd/prompt"
 	"github.com/blimu-dev/blimu-cli/cmd/pull"
 	"github.com/blimu-dev/blimu-cli/cmd/push"
 
 	"github.com/blimu-dev/blimu-cli/cmd/resources"
 	"github.com/blimu-dev/blimu-cli/cmd/roles"
+	"github.com/blimu-dev/blimu-cli/cmd/schema"
+	"github.com/blimu-dev/blimu-cli/cmd/sdk"
+	"github.com/blimu-dev/blimu-cli/cmd/spec"
+	"github.com/blimu-dev/blimu-cli/cmd/upgrade"
 	"github.com/blimu-dev/blimu-cli/cmd/validate"
+	"github.com/blimu-dev/blimu-cli/cmd/versioncmd"
+	"github.com/blimu-dev/blimu-cli/cmd/watch"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	"github.com/blimu-dev/blimu-cli/pkg/update"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 var cfgFile string
 var devMode bool
+var apiEnvName string
+var envName string
+var autoApprove bool
+var nonInteractive bool
+var timeout time.Duration
+var verbose bool
+var quiet bool
+var traceHTTP string
+var caCert string
+var workspaceID string
+var environmentID string
+var profileName string
+
+// updateNotice returns the new-version notice to print after the command, see update.StartCheck
+var updateNotice = func() string { return "" }
+
+// stopCommand releases the signal handler and timer of the running command's context
+var stopCommand = func() {}
 
 var rootCmd = &cobra.Command{
 	Use:   "blimu",
@@ -31,21 +79,93 @@ It allows you to:
 - Validate your resource configurations  
 - Generate custom SDKs based on your resources
 - Authenticate with Blimu API`,
-}
-
-// GetDevMode returns whether dev mode is enabled
-func GetDevMode() bool {
-	return devMode
-}
-
-// GetDevAPIURL returns the development API URL
-func GetDevAPIURL() string {
-	return "http://localhost:3010"
+	// Execute reports errors itself
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments parsed fine, so later failures are not usage mistakes
+		cmd.SilenceUsage = true
+		// Diagnostics go to stderr, leveled by --verbose and --quiet
+		if err := logging.Setup(os.Stderr, verbose, quiet); err != nil {
+			return err
+		}
+		// Log every API request with --trace-http or BLIMU_TRACE
+		traceMode, err := telemetry.HTTPTraceFromEnv()
+		if cmd.Flags().Changed("trace-http") {
+			traceMode, err = telemetry.ParseHTTPTraceMode(traceHTTP)
+		}
+		if err != nil {
+			return err
+		}
+		telemetry.SetHTTPTrace(traceMode)
+		// Trust a private CA for API requests
+		if err := shared.ConfigureTransport(caCert); err != nil {
+			return err
+		}
+		// Use the environments and tokens of another profile, e.g. another customer's account
+		if err := config.SetProfile(profileName); err != nil {
+			return err
+		}
+		// Look for a newer release while the command runs, when enabled; upgrade reports it itself
+		if !quiet && cmd.Name() != "upgrade" {
+			updateNotice = update.StartCheck()
+		}
+		// Point every endpoint at another deployment of the platform
+		if devMode {
+			apiEnvName = config.APIEnvLocal
+		}
+		if err := shared.SetAPIEnvironment(apiEnvName); err != nil {
+			return err
+		}
+		devMode = shared.APIEnvironment() == config.APIEnvLocal
+		// Target another configured environment for this command only
+		shared.SetEnvironmentOverride(envName)
+		// Answer every confirmation prompt with yes
+		shared.SetAutoApprove(autoApprove)
+		// Never wait for an answer, e.g. in CI
+		shared.SetNonInteractive(nonInteractive)
+		// Cancel the command, and every API request it makes, on Ctrl-C, SIGTERM or --timeout
+		// (default: the command's timeout in the CLI config)
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		cancel := context.CancelFunc(func() {})
+		if !cmd.Flags().Changed("timeout") {
+			timeout = commandTimeout(cmd)
+		}
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		stopCommand = func() {
+			cancel()
+			stop()
+		}
+		// Share config, clients and global flags with the command
+		sc := shared.NewContext(ctx, devMode, os.Stdout)
+		sc.SetIDs(workspaceID, environmentID)
+		cmd.SetContext(shared.WithContext(ctx, sc))
+		return nil
+	},
 }
 
 func init() {
 	// Add global flags
-	rootCmd.PersistentFlags().BoolVar(&devMode, "dev", false, "Use development mode (localhost:3010)")
+	rootCmd.PersistentFlags().StringVar(&apiEnvName, "api-env", "", "Deployment of the platform whose endpoints to use: "+strings.Join(config.APIEnvs, ", ")+" (default: "+shared.APIEnvEnv+", or "+config.APIEnvProd+")")
+	rootCmd.PersistentFlags().BoolVar(&devMode, "dev", false, "Alias of --api-env "+config.APIEnvLocal+" (a platform running on localhost)")
+	rootCmd.MarkFlagsMutuallyExclusive("dev", "api-env")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Use the environments and tokens of this profile (default: "+config.ProfileEnv+", or the default profile)")
+	rootCmd.PersistentFlags().StringVar(&envName, "env", "", "Run against this configured environment instead of the current one")
+	rootCmd.PersistentFlags().StringVar(&workspaceID, "workspace-id", "", "Workspace ID to run against (default: the current environment's workspace, or "+shared.WorkspaceIDEnv+")")
+	rootCmd.PersistentFlags().StringVar(&environmentID, "environment-id", "", "Environment ID, lookup key or name to run against (default: the current environment's ID, or "+shared.EnvironmentIDEnv+")")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort the command after this long, e.g. 30s or 5m; 0 for no limit (default: the command's entry in command_timeouts of the CLI config, or no limit)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log diagnostic detail to stderr (BLIMU_LOG=json for structured logs)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "debug", false, "Alias of --verbose")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only log warnings and errors, e.g. in CI")
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
+	rootCmd.MarkFlagsMutuallyExclusive("debug", "quiet")
+	rootCmd.PersistentFlags().StringVar(&traceHTTP, "trace-http", "", "Log every API request to stderr: basic (method, URL, status, duration), headers or body (also "+telemetry.TraceEnv+"=1)")
+	rootCmd.PersistentFlags().Lookup("trace-http").NoOptDefVal = "basic"
+	rootCmd.MarkFlagsMutuallyExclusive("trace-http", "quiet")
+	rootCmd.PersistentFlags().StringVar(&caCert, "ca-cert", "", "PEM file of CA certificates to trust for API requests, e.g. a corporate proxy's CA")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt; fail with an error instead (the default when stdin or stdout is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&autoApprove, "auto-approve", false, "Answer every confirmation prompt with yes (also "+shared.AutoApproveEnv+"=1)")
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -53,18 +173,143 @@ func Execute() {
 	// Register commands using factory pattern
 	rootCmd.AddCommand(auth.NewAuthCmd())
 	rootCmd.AddCommand(env.NewEnvCmd())
+	rootCmd.AddCommand(profile.NewProfileCmd())
+	rootCmd.AddCommand(apikeys.NewAPIKeysCmd())
 	rootCmd.AddCommand(resources.NewResourcesCmd())
 	rootCmd.AddCommand(roles.NewRolesCmd())
 	rootCmd.AddCommand(validate.NewValidateCmd())
+	rootCmd.AddCommand(watch.NewWatchCmd())
 	rootCmd.AddCommand(generate.NewGenerateCmd())
 	rootCmd.AddCommand(initcmd.NewInitCmd())
+	rootCmd.AddCommand(fmtcmd.NewFmtCmd())
+	rootCmd.AddCommand(lint.NewLintCmd())
+	rootCmd.AddCommand(lsp.NewLSPCmd())
 	rootCmd.AddCommand(check.NewCheckCmd())
 	rootCmd.AddCommand(definitions.NewDefinitionsCmd())
 	rootCmd.AddCommand(push.NewPushCmd())
 	rootCmd.AddCommand(pull.NewPullCmd())
+	rootCmd.AddCommand(spec.NewSpecCmd())
+	rootCmd.AddCommand(schema.NewSchemaCmd())
+	rootCmd.AddCommand(batch.NewBatchCmd())
+	rootCmd.AddCommand(importcmd.NewImportCmd())
+	rootCmd.AddCommand(export.NewExportCmd())
+	rootCmd.AddCommand(mock.NewMockCmd())
+	rootCmd.AddCommand(sdk.NewSDKCmd())
+	rootCmd.AddCommand(prompt.NewPromptCmd())
+	rootCmd.AddCommand(demo.NewDemoCmd())
+	rootCmd.AddCommand(doctor.NewDoctorCmd())
+	rootCmd.AddCommand(doctor.NewSupportBundleCmd())
+	rootCmd.AddCommand(upgrade.NewUpgradeCmd())
+	rootCmd.AddCommand(versioncmd.NewVersionCmd())
+
+	// Trace the whole command when BLIMU_OTEL_ENDPOINT is set
+	telemetry.Init()
+	ctx, span := telemetry.StartRootSpan(context.Background(), "blimu")
+	started := time.Now()
+	executed, err := rootCmd.ExecuteContextC(ctx)
+	stopCommand()
+	if errors.Is(err, context.DeadlineExceeded) && timeout > 0 {
+		err = fmt.Errorf("%w (--timeout %s)", err, timeout)
+	}
+	if executed != nil {
+		span.SetName(executed.CommandPath())
+	}
+	span.End(err)
+	recordHistory(executed, started, err)
+	if notice := updateNotice(); notice != "" {
+		fmt.Fprintf(os.Stderr, "%s\n", notice)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if exportErr := telemetry.Shutdown(shutdownCtx); exportErr != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not export traces: %v\n", exportErr)
+	}
+	cancel()
+
+	// The exit code tells scripts the class of failure, see shared.ExitCode
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "🛑 Interrupted\n")
+		os.Exit(shared.ExitInterrupted)
+	}
+
+	var reauthErr *shared.ReauthRequiredError
+	if errors.As(err, &reauthErr) {
+		// One instruction instead of the wrapped error chain
+		fmt.Fprintf(os.Stderr, "🔒 %v\n", reauthErr)
+		offerLogin()
+		os.Exit(shared.ExitAuth)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if requestID := shared.RequestID(err); requestID != "" {
+			fmt.Fprintf(os.Stderr, "   Request ID: %s (include it when contacting support)\n", requestID)
+		}
+		os.Exit(shared.ExitCode(err))
+	}
+}
+
+// recordHistory adds the command to the history attached to support bundles. Only the names of
+// the flags given are kept, as their values and the arguments may be secrets.
+func recordHistory(executed *cobra.Command, started time.Time, err error) {
+	if executed == nil || executed == rootCmd || executed.Hidden {
+		return
+	}
+
+	entry := config.HistoryEntry{
+		Time:      started,
+		Command:   executed.CommandPath(),
+		Profile:   config.ActiveProfile(),
+		Version:   version.Current(),
+		Duration:  time.Since(started).Round(time.Millisecond).String(),
+		ExitCode:  shared.ExitCode(err),
+		RequestID: shared.RequestID(err),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	for _, arg := range os.Args[1:] {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+			name, _, _ := strings.Cut(arg, "=")
+			entry.Flags = append(entry.Flags, name)
+		}
+	}
+
+	if err := config.AppendHistory(entry); err != nil {
+		logging.Debug("failed to record command history", "error", err.Error())
+	}
+}
+
+// commandTimeout returns the timeout the CLI config sets for a command. A config that cannot be
+// loaded sets none; the command reports the error itself when it needs the config.
+func commandTimeout(cmd *cobra.Command) time.Duration {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return 0
+	}
+	return cliConfig.CommandTimeout(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+}
+
+// offerLogin starts 'blimu auth login' after an expired session when the user agrees
+func offerLogin() {
+	if !shared.IsInteractive() {
+		return
+	}
+
+	fmt.Printf("Log in now? [y/N]: ")
+	var response string
+	fmt.Scanln(&response)
+	if response = strings.ToLower(strings.TrimSpace(response)); response != "y" && response != "yes" {
+		return
+	}
 
+	// Global flags such as --api-env keep the values they were parsed with
+	rootCmd.SetArgs([]string{"auth", "login"})
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return
 	}
+	fmt.Printf("💡 Run your command again to continue\n")
 }
@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blimu-dev/blimu-cli/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+// ExportCommand represents the schema export command
+type ExportCommand struct {
+	File   string
+	Output string
+}
+
+// NewExportCmd creates the schema export command
+func NewExportCmd() *cobra.Command {
+	cmd := &ExportCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export JSON Schemas for the .blimu files",
+		Long: `Export the JSON Schemas of resources.yml, entitlements.yml, features.yml, plans.yml and
+sdk.yml. These are the schemas 'blimu validate' checks files against.
+
+With a file name, its schema is printed to stdout. Otherwise every schema is written to
+--output as <name>.schema.json.
+
+To get autocomplete and validation in editors using yaml-language-server (e.g. the VS Code
+YAML extension), add a modeline to the top of a file:
+  # yaml-language-server: $schema=./schemas/resources.schema.json
+
+Examples:
+  # Write all schemas to .blimu/schemas
+  blimu schema export
+
+  # Print the sdk.yml schema
+  blimu schema export sdk.yml`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.File = args[0]
+			}
+			return cmd.Run()
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", filepath.Join(".blimu", "schemas"), "Directory to write the schemas to")
+
+	return cobraCmd
+}
+
+// Run executes the schema export command
+func (c *ExportCommand) Run() error {
+	if c.File != "" {
+		data, err := schema.Get(c.File)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.MkdirAll(c.Output, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, name := range schema.Files {
+		data, err := schema.Get(name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(c.Output, schema.FileName(name))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("📄 %s → %s\n", name, path)
+	}
+
+	fmt.Printf("✅ Exported %d schema(s)\n", len(schema.Files))
+	return nil
+}
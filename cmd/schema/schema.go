@@ -0,0 +1,18 @@
+package schema
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewSchemaCmd creates the schema command group
+func NewSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "JSON Schema commands",
+		Long:  `Commands for the JSON Schemas of the .blimu configuration files`,
+	}
+
+	cmd.AddCommand(NewExportCmd())
+
+	return cmd
+}
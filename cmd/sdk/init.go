@@ -0,0 +1,227 @@
+package sdk
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/naming"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// InitCommand represents the sdk init command
+type InitCommand struct {
+	Directory string
+	Languages []string
+	Force     bool
+	Yes       bool
+
+	in *bufio.Reader
+}
+
+// NewInitCmd creates the sdk init command
+func NewInitCmd() *cobra.Command {
+	cmd := &InitCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "init [directory]",
+		Short: "Scaffold .blimu/sdk.yml",
+		Long: `Create .blimu/sdk.yml by choosing the SDK languages to generate and, for each one,
+its output directory, package name and client name. Defaults are derived from the
+project directory name.
+
+Examples:
+  # Answer the prompts
+  blimu sdk init
+
+  # Generate TypeScript and Go SDKs with the default settings
+  blimu sdk init --languages typescript,go --yes`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run()
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().StringSliceVar(&cmd.Languages, "languages", nil, "SDK languages to generate (e.g. typescript,go)")
+	cobraCmd.Flags().BoolVar(&cmd.Force, "force", false, "Overwrite an existing sdk.yml")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Accept the default for every prompt")
+
+	return cobraCmd
+}
+
+// Run executes the sdk init command
+func (c *InitCommand) Run() error {
+	if !c.Yes && !shared.IsInteractive() {
+		return fmt.Errorf("sdk init asks for the settings of every client; pass --yes to accept the defaults non-interactively")
+	}
+	c.in = bufio.NewReader(os.Stdin)
+
+	path := filepath.Join(c.Directory, ".blimu", "sdk.yml")
+	if _, err := os.Stat(path); err == nil && !c.Force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+
+	supported, err := cli.SDKClientTypes()
+	if err != nil {
+		return err
+	}
+
+	languages := c.Languages
+	if len(languages) == 0 {
+		fmt.Printf("Available languages: %s\n", strings.Join(supported, ", "))
+		answer, err := c.ask("Languages (comma separated)", "typescript")
+		if err != nil {
+			return err
+		}
+		languages = strings.Split(answer, ",")
+	}
+
+	slug, err := projectSlug(c.Directory)
+	if err != nil {
+		return err
+	}
+
+	sdkConfig := &config.SDKConfig{}
+	for _, language := range languages {
+		language = strings.TrimSpace(language)
+		if language == "" {
+			continue
+		}
+		if !containsString(supported, language) {
+			return fmt.Errorf("unsupported language '%s' (supported: %s)", language, strings.Join(supported, ", "))
+		}
+
+		client, err := c.promptClient(language, slug)
+		if err != nil {
+			return err
+		}
+		sdkConfig.Clients = append(sdkConfig.Clients, client)
+	}
+	if len(sdkConfig.Clients) == 0 {
+		return fmt.Errorf("no languages selected")
+	}
+
+	data, err := yaml.Marshal(sdkConfig)
+	if err != nil {
+		return fmt.Errorf("failed to render sdk.yml: %w", err)
+	}
+	header := "# SDK generation config used by 'blimu generate'.\n" +
+		"# outDir is relative to this directory. Run 'blimu sdk validate' after editing.\n"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create .blimu directory: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Printf("✅ Wrote %s with %d client(s)\n", path, len(sdkConfig.Clients))
+
+	report, err := cli.ValidateSDKConfigFile(path)
+	if err != nil {
+		return err
+	}
+	printReport(report)
+	if errors := report.Errors(); errors > 0 {
+		return fmt.Errorf("sdk.yml has %d error(s)", errors)
+	}
+
+	fmt.Printf("💡 Run 'blimu generate' to generate the SDKs\n")
+	return nil
+}
+
+// promptClient asks for the settings of one client, offering defaults derived from the project slug
+func (c *InitCommand) promptClient(language, slug string) (config.SDKClient, error) {
+	fmt.Printf("\n📦 %s\n", language)
+
+	client := config.SDKClient{Type: language}
+	var err error
+
+	if client.OutDir, err = c.ask("Output directory (relative to .blimu)", filepath.Join("..", "sdk", language)); err != nil {
+		return client, err
+	}
+	if client.PackageName, err = c.ask("Package name", defaultPackageName(language, slug)); err != nil {
+		return client, err
+	}
+	if language == "go" || language == "java" {
+		moduleDefault := client.PackageName
+		if language == "java" {
+			moduleDefault = fmt.Sprintf("com.%s:%s-sdk", strings.ReplaceAll(slug, "-", ""), slug)
+		}
+		if client.ModuleName, err = c.ask("Module name", moduleDefault); err != nil {
+			return client, err
+		}
+	}
+	if client.Name, err = c.ask("Client name", naming.Pascal(slug)); err != nil {
+		return client, err
+	}
+	return client, nil
+}
+
+// ask prints a prompt and returns the answer, or the default when the answer is blank or --yes is set
+func (c *InitCommand) ask(label, def string) (string, error) {
+	if c.Yes {
+		return def, nil
+	}
+
+	fmt.Printf("%s [%s]: ", label, def)
+	line, err := c.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no answer given: %w", err)
+	}
+
+	answer := strings.TrimSpace(line)
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// projectSlug derives a lowercase, dash-separated name from the project directory
+func projectSlug(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	slug := naming.Kebab(filepath.Base(abs))
+	if slug == "" {
+		slug = "app"
+	}
+	return slug, nil
+}
+
+// defaultPackageName follows each ecosystem's naming convention
+func defaultPackageName(language, slug string) string {
+	switch language {
+	case "typescript", "typescript-types":
+		return fmt.Sprintf("@%s/sdk", slug)
+	case "go":
+		return fmt.Sprintf("github.com/%s/%s-sdk", slug, slug)
+	case "python":
+		return naming.Snake(slug) + "_sdk"
+	case "java":
+		return fmt.Sprintf("com.%s.sdk", strings.ReplaceAll(slug, "-", ""))
+	case "csharp":
+		return naming.Pascal(slug) + ".Sdk"
+	}
+	return slug + "-sdk"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// PublishCommand represents the sdk publish command
+type PublishCommand struct {
+	Directory string
+	Clients   []string
+	Bump      string
+	Version   string
+	DryRun    bool
+	Yes       bool
+}
+
+// NewPublishCmd creates the sdk publish command
+func NewPublishCmd() *cobra.Command {
+	cmd := &PublishCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "publish [directory]",
+		Short: "Publish generated SDKs to their package registries",
+		Long: `Publish the SDKs generated by 'blimu generate' using the publish settings of each
+client in .blimu/sdk.yml:
+
+  typescript  npm publish (registry, tag and access options)
+  python      python3 -m build, then twine upload (registry option)
+  go          git tag <outDir path>/vX.Y.Z on the current commit, then git push (tagPrefix, remote, push options)
+
+Other client types need a custom publish.command. The version comes from each client's
+'version' key. With --bump or --version it is updated in sdk.yml after a successful publish
+and stamped into the generated package manifest, so later generations keep it.
+
+Example sdk.yml client:
+  - type: typescript
+    outDir: ../sdk/typescript
+    packageName: "@acme/sdk"
+    version: 1.4.2
+    publish:
+      access: public
+      tag: latest
+
+Examples:
+  # Release a patch version of every SDK
+  blimu sdk publish --bump patch
+
+  # Preview publishing the TypeScript SDK as 2.0.0
+  blimu sdk publish --client typescript --version 2.0.0 --dry-run`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run()
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().StringSliceVar(&cmd.Clients, "client", nil, "Only publish clients of these types (e.g. typescript,go)")
+	cobraCmd.Flags().StringVar(&cmd.Bump, "bump", "", "Increment the version before publishing: patch, minor or major")
+	cobraCmd.Flags().StringVar(&cmd.Version, "version", "", "Publish at this exact version")
+	cobraCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Print the publish steps without changing any files or releasing anything")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Publish without asking for confirmation")
+
+	return cobraCmd
+}
+
+// Run executes the sdk publish command
+func (c *PublishCommand) Run() error {
+	if c.Bump != "" && c.Version != "" {
+		return fmt.Errorf("--bump and --version cannot be used together")
+	}
+
+	commands, err := cli.TrustedCommandPolicy()
+	if err != nil {
+		return err
+	}
+	if shared.IsInteractive() {
+		commands.Confirm = confirmPublishCommand
+	}
+
+	runner := cli.New(nil)
+	result, err := runner.Publish(cli.PublishOptions{
+		Directory: c.Directory,
+		Types:     c.Clients,
+		Bump:      c.Bump,
+		Version:   c.Version,
+		DryRun:    c.DryRun,
+		Commands:  commands,
+		Confirm:   c.confirm,
+	})
+	if result != nil && len(result.Released) > 0 {
+		verb := "Published"
+		if c.DryRun {
+			verb = "Dry run complete for"
+		}
+		fmt.Printf("\n✅ %s %d SDK(s)\n", verb, len(result.Released))
+		for _, release := range result.Released {
+			fmt.Printf("  📦 %s %s\n", release.Type, release.Version)
+		}
+	}
+	return err
+}
+
+// confirm lists the planned releases and asks before publishing
+func (c *PublishCommand) confirm(releases []cli.PublishRelease) (bool, error) {
+	fmt.Printf("📋 Releases:\n")
+	for _, release := range releases {
+		if release.Version != release.PreviousVersion {
+			fmt.Printf("  📦 %s: %s → %s (%s)\n", release.Type, release.PreviousVersion, release.Version, release.OutDir)
+		} else {
+			fmt.Printf("  📦 %s: %s (%s)\n", release.Type, release.Version, release.OutDir)
+		}
+	}
+	approved, err := shared.AutoApproved(nil, "", c.Yes || c.DryRun)
+	if err != nil {
+		return false, err
+	}
+	if !approved {
+		fmt.Println()
+	}
+	confirmed, err := shared.Confirm(fmt.Sprintf("Publish %d SDK(s)?", len(releases)), "publish", approved)
+	if err != nil {
+		return false, err
+	}
+	if !confirmed {
+		fmt.Printf("❌ Publish cancelled\n")
+	}
+	return confirmed, nil
+}
+
+// confirmPublishCommand asks whether a custom publish command from sdk.yml may run
+func confirmPublishCommand(client, hook string, argv []string) (bool, error) {
+	fmt.Printf("\n⚠️  sdk.yml wants to run a %s command for the %s client:\n", hook, client)
+	fmt.Printf("   %s\n", strings.Join(argv, " "))
+	fmt.Printf("Run it? [y/N]: ")
+
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes", nil
+}
@@ -0,0 +1,20 @@
+package sdk
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewSDKCmd creates the sdk command group
+func NewSDKCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sdk",
+		Short: "Manage SDK generation and publishing",
+		Long:  `Commands for creating and checking the .blimu/sdk.yml file used by 'blimu generate', and for publishing the generated SDKs`,
+	}
+
+	cmd.AddCommand(NewInitCmd())
+	cmd.AddCommand(NewValidateCmd())
+	cmd.AddCommand(NewPublishCmd())
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/spf13/cobra"
+)
+
+// ValidateCommand represents the sdk validate command
+type ValidateCommand struct {
+	Directory string
+}
+
+// NewValidateCmd creates the sdk validate command
+func NewValidateCmd() *cobra.Command {
+	cmd := &ValidateCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "validate [directory]",
+		Short: "Check .blimu/sdk.yml before generating",
+		Long: `Check .blimu/sdk.yml against the sdk-gen config schema and the embedded base config,
+resolving each client exactly like 'blimu generate' does.
+
+Errors (missing or invalid fields, unsupported client types, conflicting output directories)
+make the command fail. Warnings cover unknown keys, which are silently ignored during
+generation, and values inherited from the base config that belong to Blimu's own SDKs.
+
+Examples:
+  # Validate .blimu/sdk.yml in the current directory
+  blimu sdk validate
+
+  # Validate another project
+  blimu sdk validate /path/to/project`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run()
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	return cobraCmd
+}
+
+// Run executes the sdk validate command
+func (c *ValidateCommand) Run() error {
+	path := filepath.Join(c.Directory, ".blimu", "sdk.yml")
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("no .blimu/sdk.yml found in %s (run 'blimu sdk init' to create one)", c.Directory)
+	}
+
+	fmt.Printf("🔍 Validating %s...\n", path)
+
+	report, err := cli.ValidateSDKConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	printReport(report)
+
+	if errors := report.Errors(); errors > 0 {
+		return shared.Errorf(shared.ErrValidation, "sdk.yml has %d error(s)", errors)
+	}
+	fmt.Printf("✅ sdk.yml is valid\n")
+	return nil
+}
+
+// printReport prints each issue with its location
+func printReport(report *cli.SDKConfigReport) {
+	for _, issue := range report.Issues {
+		icon := "⚠️ "
+		if issue.Severity == cli.SeverityError {
+			icon = "❌"
+		}
+
+		location := report.Path
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", report.Path, issue.Line)
+		}
+		if issue.Field != "" {
+			fmt.Printf("%s %s: %s: %s\n", icon, location, issue.Field, issue.Message)
+		} else {
+			fmt.Printf("%s %s: %s\n", icon, location, issue.Message)
+		}
+	}
+	if len(report.Issues) > 0 {
+		fmt.Println()
+	}
+}
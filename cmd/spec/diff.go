@@ -0,0 +1,153 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	specdiff "github.com/blimu-dev/blimu-cli/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+// DiffCommand represents the spec diff command
+type DiffCommand struct {
+	WorkspaceID string
+	From        string
+	To          string
+}
+
+// NewDiffCmd creates the spec diff command
+func NewDiffCmd() *cobra.Command {
+	cmd := &DiffCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show differences between two OpenAPI specs",
+		Long: `Compare the OpenAPI specs of two environments, or of an environment and a local
+spec file, and summarize added, removed and changed paths and schemas.
+
+--from and --to accept either an environment ID or a path to a .json/.yaml spec file.
+
+Examples:
+  # Compare production against the current environment
+  blimu spec diff --from env_prod
+
+  # Compare two environments
+  blimu spec diff --from env_staging --to env_prod
+
+  # Compare a saved spec file against an environment
+  blimu spec diff --from openapi.json --to env_prod`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.From, "from", "", "Environment ID or spec file to compare from (required)")
+	cobraCmd.Flags().StringVar(&cmd.To, "to", "", "Environment ID or spec file to compare to (uses current environment ID if not specified)")
+	cobraCmd.MarkFlagRequired("from")
+
+	return cobraCmd
+}
+
+// Run executes the spec diff command
+func (c *DiffCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	// The target defaults to the current environment
+	var environmentID string
+	c.WorkspaceID, environmentID = sc.IDs()
+	if c.To == "" {
+		c.To = environmentID
+	}
+	if c.To == "" {
+		return fmt.Errorf("target is required (use --to or set a current environment with 'blimu env switch')")
+	}
+
+	fromSpec, err := loadSpec(cmd, c.From, c.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	toSpec, err := loadSpec(cmd, c.To, c.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Comparing %s → %s\n\n", c.From, c.To)
+
+	diff := specdiff.Compare(fromSpec, toSpec)
+	if diff.IsEmpty() {
+		fmt.Printf("✅ No differences found\n")
+		return nil
+	}
+
+	printSection("Paths", diff.Filter(specdiff.PathAdded, specdiff.PathRemoved))
+	printSection("Operations", diff.Filter(specdiff.OperationAdded, specdiff.OperationRemoved, specdiff.OperationChanged))
+	printSection("Schemas", diff.Filter(specdiff.SchemaAdded, specdiff.SchemaRemoved, specdiff.SchemaChanged,
+		specdiff.PropertyAdded, specdiff.PropertyRemoved, specdiff.TypeChanged,
+		specdiff.EnumValueAdded, specdiff.EnumValueRemoved, specdiff.RequiredAdded, specdiff.RequiredRemoved))
+
+	breaking := diff.Breaking()
+	if len(breaking) > 0 {
+		fmt.Printf("⚠️  %d breaking change(s) out of %d total\n", len(breaking), len(diff.Changes))
+	} else {
+		fmt.Printf("✅ %d change(s), none breaking\n", len(diff.Changes))
+	}
+
+	return nil
+}
+
+// printSection prints a titled list of changes, marking breaking ones
+func printSection(title string, changes []specdiff.Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Printf("%s:\n", title)
+	for _, change := range changes {
+		fmt.Printf("  %s %s", changeSymbol(change), change.Location)
+		if change.Detail != "" {
+			fmt.Printf(" (%s)", change.Detail)
+		}
+		if change.Breaking {
+			fmt.Printf(" [breaking]")
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
+// changeSymbol returns the +/-/~ marker for a change
+func changeSymbol(change specdiff.Change) string {
+	switch change.Kind {
+	case specdiff.PathAdded, specdiff.OperationAdded, specdiff.SchemaAdded, specdiff.PropertyAdded, specdiff.EnumValueAdded:
+		return "+"
+	case specdiff.PathRemoved, specdiff.OperationRemoved, specdiff.SchemaRemoved, specdiff.PropertyRemoved, specdiff.EnumValueRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// loadSpec loads a spec from a file, or fetches the generated spec of an environment
+func loadSpec(cmd *cobra.Command, ref, workspaceID string) (map[string]interface{}, error) {
+	if specdiff.IsFileReference(ref) {
+		return specdiff.LoadFile(ref)
+	}
+
+	if workspaceID == "" {
+		return nil, fmt.Errorf("workspace ID is required to fetch the spec for '%s' (use --workspace-id or set current environment)", ref)
+	}
+	runner, err := cli.NewFromContext(shared.FromContext(cmd.Context()))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := runner.API().GetOpenAPISpec(cmd.Context(), workspaceID, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI spec for '%s': %w", ref, err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("environment '%s' has definition errors; run 'blimu validate' to see them", ref)
+	}
+	return result.Spec, nil
+}
@@ -0,0 +1,19 @@
+package spec
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewSpecCmd creates the spec command group
+func NewSpecCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "spec",
+		Short: "OpenAPI spec commands",
+		Long:  `Commands for inspecting and comparing the OpenAPI specs generated from your definitions`,
+	}
+
+	cmd.AddCommand(NewDiffCmd())
+	cmd.AddCommand(NewValidateCmd())
+
+	return cmd
+}
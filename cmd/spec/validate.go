@@ -0,0 +1,111 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	specdiff "github.com/blimu-dev/blimu-cli/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+// ValidateCommand represents the spec validate command
+type ValidateCommand struct {
+	WorkspaceID string
+	Target      string
+	Strict      bool
+	JSON        bool
+}
+
+// NewValidateCmd creates the spec validate command
+func NewValidateCmd() *cobra.Command {
+	cmd := &ValidateCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "validate [environment-id|spec-file]",
+		Short: "Check an OpenAPI spec for problems before generating SDKs",
+		Long: `Validate an environment's generated OpenAPI spec, or a local spec file, against the
+OpenAPI 3.0/3.1 structure and common lint rules.
+
+Errors are problems SDK generation cannot handle, such as unresolved $refs, operations
+without responses, undeclared path parameters or duplicate operationIds. 'blimu generate'
+runs the same checks and stops on errors. Warnings are lint findings such as operations
+without operationIds or tags, and unused schemas.
+
+Examples:
+  # Validate the current environment's spec
+  blimu spec validate
+
+  # Validate a spec file and fail on warnings too
+  blimu spec validate openapi.json --strict`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Target = args[0]
+			}
+			return cmd.Run(cobraCmd)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Strict, "strict", false, "Fail on warnings as well as errors")
+	cobraCmd.Flags().BoolVar(&cmd.JSON, "json", false, "Print the issues as JSON")
+
+	return cobraCmd
+}
+
+// Run executes the spec validate command
+func (c *ValidateCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	if c.Target == "" || !specdiff.IsFileReference(c.Target) {
+		var environmentID string
+		c.WorkspaceID, environmentID = sc.IDs()
+		if c.Target == "" {
+			c.Target = environmentID
+		}
+		if c.Target == "" {
+			return fmt.Errorf("an environment ID or spec file is required (or set a current environment with 'blimu env switch')")
+		}
+	}
+
+	doc, err := loadSpec(cmd, c.Target, c.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	report := specdiff.Validate(doc)
+	errors, warnings := report.Errors(), report.Warnings()
+
+	if c.JSON {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Println(string(encoded))
+	} else {
+		fmt.Printf("🔍 Validating OpenAPI spec of %s\n\n", c.Target)
+		for _, issue := range report.Issues {
+			icon := "❌"
+			if issue.Severity == specdiff.SeverityWarning {
+				icon = "⚠️ "
+			}
+			fmt.Printf("%s %s: %s [%s]\n", icon, issue.Location, issue.Message, issue.Rule)
+		}
+		if len(report.Issues) > 0 {
+			fmt.Printf("\n")
+		}
+		if len(errors) == 0 && len(warnings) == 0 {
+			fmt.Printf("✅ Spec is valid\n")
+		} else {
+			fmt.Printf("📊 %d error(s), %d warning(s)\n", len(errors), len(warnings))
+		}
+	}
+
+	if len(errors) > 0 {
+		return shared.Errorf(shared.ErrValidation, "spec has %d error(s)", len(errors))
+	}
+	if c.Strict && len(warnings) > 0 {
+		return shared.Errorf(shared.ErrValidation, "spec has %d warning(s)", len(warnings))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package upgrade
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	"github.com/blimu-dev/blimu-cli/pkg/update"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeCommand represents the upgrade command
+type UpgradeCommand struct {
+	Version string
+	Check   bool
+	Force   bool
+}
+
+// NewUpgradeCmd creates the upgrade command
+func NewUpgradeCmd() *cobra.Command {
+	cmd := &UpgradeCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the CLI to the latest release",
+		Long: `Download the latest release of the CLI from GitHub and replace the running binary with it.
+
+The download is verified against the SHA-256 checksums published with the release before it is
+installed. Use --version to install a specific release, e.g. to roll back.
+
+Set GITHUB_TOKEN if GitHub rate limits the requests. To be told about new releases after
+commands, set update_check: true in the CLI config or BLIMU_UPDATE_CHECK=1.`,
+		Example: `  blimu upgrade
+  blimu upgrade --check
+  blimu upgrade --version v1.4.0`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Version, "version", "", "Release to install, e.g. v1.4.0 (default: the latest)")
+	cobraCmd.Flags().BoolVar(&cmd.Check, "check", false, "Only report whether a newer release exists")
+	cobraCmd.Flags().BoolVar(&cmd.Force, "force", false, "Install the release even if it is not newer than this one")
+
+	return cobraCmd
+}
+
+// Run executes the upgrade command
+func (c *UpgradeCommand) Run(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	client := telemetry.HTTPClient()
+	current := version.Current()
+
+	var release *update.Release
+	var err error
+	if c.Version != "" {
+		release, err = update.GetRelease(ctx, client, c.Version)
+	} else {
+		release, err = update.LatestRelease(ctx, client)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find the release: %w", err)
+	}
+
+	newer, comparable := version.Compare(release.TagName, current)
+	upToDate := comparable && newer <= 0
+	if c.Check {
+		if upToDate {
+			fmt.Printf("✅ Blimu CLI %s is up to date (latest: %s)\n", current, release.TagName)
+		} else {
+			fmt.Printf("💡 Blimu CLI %s is available (you have %s), run 'blimu upgrade' to install it\n", release.TagName, current)
+		}
+		return nil
+	}
+	if upToDate && !c.Force && c.Version == "" {
+		fmt.Printf("✅ Blimu CLI %s is already the latest release\n", current)
+		return nil
+	}
+	if comparable && newer < 0 && !c.Force {
+		return shared.Errorf(shared.ErrValidation, "%s is older than the installed %s; use --force to downgrade", release.TagName, current)
+	}
+
+	fmt.Printf("⬇️  Downloading Blimu CLI %s (%s)...\n", release.TagName, update.AssetName())
+	binary, err := update.Download(ctx, client, release)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🔒 Checksum verified\n")
+
+	path, err := update.Install(binary)
+	if err != nil {
+		if errors.Is(err, update.ErrNotWritable) {
+			return fmt.Errorf("%w; run the upgrade as a user who can write it, e.g. with sudo", err)
+		}
+		return err
+	}
+
+	fmt.Printf("✅ Upgraded %s from %s to %s\n", path, current, release.TagName)
+	return nil
+}
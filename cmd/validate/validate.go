@@ -2,9 +2,15 @@ package validate
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
-	"github.com/blimu-dev/blimu-cli/pkg/api"
+	"github.com/blimu-dev/blimu-cli/pkg/blimu"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
 	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/diagnostics"
+	"github.com/blimu-dev/blimu-cli/pkg/schema"
 	"github.com/blimu-dev/blimu-cli/pkg/shared"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +20,12 @@ type ValidateCommand struct {
 	WorkspaceID   string
 	EnvironmentID string
 	Directory     string
+	Projects      []string
+	Offline       bool
+	Format        string
+
+	// out receives progress messages; stderr when the report is machine-readable
+	out io.Writer
 }
 
 // NewValidateCmd creates the validate command
@@ -26,56 +38,118 @@ func NewValidateCmd() *cobra.Command {
 		Long: `Validate your local .blimu configuration files for syntax and semantic errors.
 
 This command validates your Blimu configuration against the platform API and reports any issues.
-For full validation, provide workspace and environment IDs.`,
+For full validation, provide workspace and environment IDs.
+
+With --offline, or when no authentication or IDs are available, the configuration is checked
+locally: role inheritance syntax, circular parent dependencies, references between resources,
+entitlements, features and plans, and the SDK client settings in config.yml. The platform may
+still reject a configuration that passes local validation, e.g. for limits of your plan.
+
+--format json and --format sarif print the diagnostics (rule, severity, file, line, message)
+to stdout and progress to stderr. SARIF can be uploaded to GitHub code scanning to annotate
+pull requests.
+
+Examples:
+  # Validate locally and write SARIF for code scanning
+  blimu validate --offline --format sarif > blimu.sarif
+
+  # Validate every project of .blimu/workspace.yml (run at the workspace root)
+  blimu validate --offline`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
-			if len(args) > 0 {
-				cmd.Directory = args[0]
-			} else {
-				cmd.Directory = "."
+			projects, err := shared.ResolveProjects(args, cmd.Projects)
+			if err != nil {
+				return err
+			}
+			if len(projects) > 1 && cmd.Format != diagnostics.FormatText {
+				return fmt.Errorf("--format %s reports on a single project; select one with --project", cmd.Format)
 			}
-			return cmd.Run()
+			pinnedIDs := shared.FromContext(cobraCmd.Context()).PinnedIDs()
+			return shared.RunProjects(projects, pinnedIDs, func(project shared.Project) error {
+				run := *cmd
+				run.Directory = project.Directory
+				return run.Run(cobraCmd)
+			})
 		},
 		Args: cobra.MaximumNArgs(1),
 	}
 
-	cobraCmd.Flags().StringVar(&cmd.WorkspaceID, "workspace-id", "", "Workspace ID for platform validation")
-	cobraCmd.Flags().StringVar(&cmd.EnvironmentID, "environment-id", "", "Environment ID for platform validation")
+	cobraCmd.Flags().StringSliceVar(&cmd.Projects, "project", nil, "Projects of .blimu/workspace.yml to run for (default: all of them at the workspace root)")
+	cobraCmd.Flags().BoolVar(&cmd.Offline, "offline", false, "Validate locally without calling the platform API")
+	cobraCmd.Flags().StringVar(&cmd.Format, "format", diagnostics.FormatText, "Output format: text, json or sarif")
 
 	return cobraCmd
 }
 
-func (c *ValidateCommand) Run() error {
+func (c *ValidateCommand) Run(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	if err := diagnostics.CheckFormat(c.Format); err != nil {
+		return err
+	}
+	c.out = os.Stdout
+	if c.Format != diagnostics.FormatText {
+		c.out = os.Stderr
+	}
+
+	// Check the files against the embedded schemas first so structural mistakes point at a line
+	problems, err := schema.ValidateDirectory(filepath.Join(c.Directory, ".blimu"))
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		if c.Format != diagnostics.FormatText {
+			return c.writeReport(schemaDiagnostics(c.Directory, problems), "configuration does not match the .blimu schemas")
+		}
+		fmt.Printf("❌ Found %d schema error(s):\n\n", len(problems))
+		for i, problem := range problems {
+			fmt.Printf("%d. %s\n", i+1, problem)
+		}
+		fmt.Printf("\n💡 Run 'blimu schema export' to get these checks in your editor\n")
+		return shared.Errorf(shared.ErrValidation, "configuration does not match the .blimu schemas")
+	}
+
 	// Load Blimu configuration
 	blimuConfig, err := config.LoadBlimuConfig(c.Directory)
 	if err != nil {
 		return fmt.Errorf("failed to load .blimu configuration: %w", err)
 	}
 
-	fmt.Printf("📋 Validating Blimu configuration in %s...\n", c.Directory)
+	fmt.Fprintf(c.out, "📋 Validating Blimu configuration in %s...\n", c.Directory)
 
-	// Convert config to JSON for validation
-	configJSON, err := blimuConfig.MergeToJSON()
-	if err != nil {
-		return fmt.Errorf("failed to serialize configuration: %w", err)
+	if c.Offline {
+		return c.performLocalValidation(blimuConfig)
 	}
 
-	// Get auth client for API validation
-	authClient, err := shared.GetAuthClient()
+	// Get runner for API validation
+	runner, err := cli.NewFromContext(sc)
 	if err != nil {
-		fmt.Printf("⚠️  No authentication configured. Performing local validation only.\n")
-		fmt.Printf("Use 'blimu auth login' to enable platform validation.\n\n")
+		fmt.Fprintf(c.out, "⚠️  No authentication configured. Performing local validation only.\n")
+		fmt.Fprintf(c.out, "Use 'blimu auth login' to enable platform validation.\n\n")
 		return c.performLocalValidation(blimuConfig)
 	}
 
-	// Create API client
-	apiClient := api.NewClient(authClient)
+	// Fall back to the IDs of the active environment
+	c.WorkspaceID, c.EnvironmentID = sc.IDs()
+	if c.WorkspaceID == "" || c.EnvironmentID == "" {
+		fmt.Fprintf(c.out, "⚠️  Config validation requires workspace ID and environment ID.\n")
+		fmt.Fprintf(c.out, "Use --workspace-id and --environment-id flags or configure them in your environment.\n\n")
+		return c.performLocalValidation(blimuConfig)
+	}
 
 	// Validate via platform API
-	result, err := apiClient.ValidateConfig(configJSON, c.WorkspaceID, c.EnvironmentID)
+	result, err := runner.Validate(cmd.Context(), cli.ValidateOptions{
+		Directory:     c.Directory,
+		WorkspaceID:   c.WorkspaceID,
+		EnvironmentID: c.EnvironmentID,
+	})
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	if c.Format != diagnostics.FormatText {
+		return c.writeReport(platformDiagnostics(c.Directory, blimuConfig, result.Errors), "configuration validation failed")
+	}
+
 	// Display results
 	if result.Valid {
 		fmt.Printf("✅ Configuration is valid!\n")
@@ -97,58 +171,125 @@ func (c *ValidateCommand) Run() error {
 			fmt.Printf("\n")
 		}
 
-		return fmt.Errorf("configuration validation failed")
+		return shared.Errorf(shared.ErrValidation, "configuration validation failed")
 	}
 
 	return nil
 }
 
 func (c *ValidateCommand) performLocalValidation(blimuConfig *config.BlimuConfig) error {
-	fmt.Printf("🔍 Performing local validation...\n\n")
+	fmt.Fprintf(c.out, "🔍 Performing local validation...\n\n")
 
-	// Basic structure validation
-	errors := []string{}
-
-	if len(blimuConfig.Resources) == 0 {
-		errors = append(errors, "No resources defined")
+	result := blimu.ValidateConfig(blimuConfig)
+	if c.Format != diagnostics.FormatText {
+		return c.writeReport(localDiagnostics(c.Directory, result.Errors), "local validation failed")
 	}
 
-	// Validate resources have required fields
-	for resourceName, resource := range blimuConfig.Resources {
-		if len(resource.Roles) == 0 {
-			errors = append(errors, fmt.Sprintf("Resource '%s' has no roles defined", resourceName))
+	if !result.Valid {
+		fmt.Printf("❌ Found %d local validation error(s):\n\n", len(result.Errors))
+		for i, validationErr := range result.Errors {
+			fmt.Printf("%d. %s\n", i+1, validationErr.Error())
 		}
+		return shared.Errorf(shared.ErrValidation, "local validation failed")
 	}
 
-	// Validate entitlements reference valid resources
-	for entitlementName := range blimuConfig.Entitlements {
-		// Basic format check: should be "resource:action"
-		if !contains(entitlementName, ":") {
-			errors = append(errors, fmt.Sprintf("Entitlement '%s' should follow 'resource:action' format", entitlementName))
-		}
+	fmt.Printf("✅ Local validation passed!\n")
+	if !c.Offline {
+		fmt.Printf("💡 For platform validation, use --workspace-id and --environment-id\n")
 	}
 
-	if len(errors) > 0 {
-		fmt.Printf("❌ Found %d local validation error(s):\n\n", len(errors))
-		for i, err := range errors {
-			fmt.Printf("%d. %s\n", i+1, err)
-		}
-		fmt.Printf("\n💡 For complete validation, use platform API with --workspace-id and --environment-id\n")
-		return fmt.Errorf("local validation failed")
+	return nil
+}
+
+// writeReport prints the diagnostics in the machine-readable format and fails when there are any
+func (c *ValidateCommand) writeReport(diags []diagnostics.Diagnostic, failure string) error {
+	report := &diagnostics.Report{Valid: len(diags) == 0, Diagnostics: diags}
+	if err := diagnostics.Write(os.Stdout, c.Format, report); err != nil {
+		return err
+	}
+	if !report.Valid {
+		return shared.Errorf(shared.ErrValidation, "%s with %d error(s)", failure, len(diags))
 	}
+	return nil
+}
 
-	fmt.Printf("✅ Local validation passed!\n")
-	fmt.Printf("💡 For complete validation, use platform API with --workspace-id and --environment-id\n")
+func schemaDiagnostics(directory string, problems []schema.Problem) []diagnostics.Diagnostic {
+	diags := make([]diagnostics.Diagnostic, 0, len(problems))
+	for _, problem := range problems {
+		message := problem.Message
+		if problem.Path != "" {
+			message = problem.Path + ": " + message
+		}
+		diags = append(diags, diagnostics.Diagnostic{
+			Rule:     "schema",
+			Severity: diagnostics.SeverityError,
+			Source:   diagnostics.SourceSchema,
+			File:     filepath.Join(directory, ".blimu", problem.File),
+			Line:     problem.Line,
+			Column:   problem.Column,
+			Message:  message,
+		})
+	}
+	return diags
+}
 
-	return nil
+// localDiagnostics converts local validation errors; errors without a position point at the .blimu directory
+func localDiagnostics(directory string, errors []blimu.ValidationError) []diagnostics.Diagnostic {
+	diags := make([]diagnostics.Diagnostic, 0, len(errors))
+	for _, validationErr := range errors {
+		file := validationErr.File
+		if file == "" {
+			file = filepath.Join(directory, ".blimu")
+		}
+		message := validationErr.Resource
+		if validationErr.Field != "" {
+			message += "." + validationErr.Field
+		}
+		diags = append(diags, diagnostics.Diagnostic{
+			Rule:     validationErr.Rule,
+			Severity: diagnostics.SeverityError,
+			Source:   diagnostics.SourceLocal,
+			File:     file,
+			Line:     validationErr.Line,
+			Column:   validationErr.Column,
+			Message:  message + ": " + validationErr.Message,
+		})
+	}
+	return diags
 }
 
-// contains checks if a string contains a substring
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+// platformDiagnostics locates the platform's issues in the .blimu files: Resource is a resource
+// name or one of the entitlements, features and plans sections, and Field a key within it
+func platformDiagnostics(directory string, blimuConfig *config.BlimuConfig, issues []cli.ValidationIssue) []diagnostics.Diagnostic {
+	diags := make([]diagnostics.Diagnostic, 0, len(issues))
+	for _, issue := range issues {
+		var fileName string
+		var position config.Position
+		switch issue.Resource {
+		case "entitlements", "features", "plans":
+			fileName = issue.Resource + ".yml"
+			position = blimuConfig.Positions.Of(fileName, issue.Field)
+		default:
+			fileName = "resources.yml"
+			position = blimuConfig.Positions.Of(fileName, issue.Resource, issue.Field)
+		}
+		if position.File == "" {
+			position.File = filepath.Join(directory, ".blimu", fileName)
+		}
+
+		message := issue.Message
+		if issue.Resource != "" {
+			message = issue.Resource + "." + issue.Field + ": " + message
 		}
+		diags = append(diags, diagnostics.Diagnostic{
+			Rule:     "platform",
+			Severity: diagnostics.SeverityError,
+			Source:   diagnostics.SourcePlatform,
+			File:     position.File,
+			Line:     position.Line,
+			Column:   position.Column,
+			Message:  message,
+		})
 	}
-	return false
+	return diags
 }
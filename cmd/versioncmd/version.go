@@ -0,0 +1,91 @@
+package versioncmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of the version command
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// buildInfo is the build of the CLI and of the SDK toolchain compiled into it
+type buildInfo struct {
+	version.Info
+	SDKGen     string `json:"sdk_gen,omitempty"`
+	BaseConfig string `json:"base_config"`
+}
+
+// VersionCommand represents the version command
+type VersionCommand struct {
+	Output string
+}
+
+// NewVersionCmd creates the version command
+func NewVersionCmd() *cobra.Command {
+	cmd := &VersionCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version of the CLI and of its SDK generator",
+		Long: `Print the version of the CLI, the git commit and date it was built from, the Go version, and
+the versions of the sdk-gen generator and the SDK base config embedded in it.
+
+Include the output in bug reports. In CI, --output json lets caches of generated SDKs key on the
+exact toolchain, e.g. blimu version -o json | jq -r '.sdk_gen + "-" + .base_config'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run()
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", outputText, "Output format: text or json")
+
+	return cobraCmd
+}
+
+// Run executes the version command
+func (c *VersionCommand) Run() error {
+	info := buildInfo{
+		Info:       version.Build(),
+		SDKGen:     version.Dependency(cli.SDKGenModule),
+		BaseConfig: cli.BaseConfigVersion(),
+	}
+
+	switch c.Output {
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	case outputText:
+		commit := info.Commit
+		if commit == "" {
+			commit = "unknown"
+		} else if info.Modified {
+			commit += " (modified)"
+		}
+		fmt.Printf("Blimu CLI %s\n", info.Version)
+		fmt.Printf("  Commit:      %s\n", commit)
+		fmt.Printf("  Built:       %s\n", orUnknown(info.BuildDate))
+		fmt.Printf("  Go:          %s (%s)\n", info.GoVersion, info.Platform)
+		fmt.Printf("  sdk-gen:     %s\n", orUnknown(info.SDKGen))
+		fmt.Printf("  Base config: %s\n", info.BaseConfig)
+		return nil
+	}
+	return fmt.Errorf("unsupported output format '%s' (supported: %s, %s)", c.Output, outputText, outputJSON)
+}
+
+// orUnknown returns value, or "unknown" when it is empty
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,193 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/blimu"
+	"github.com/blimu-dev/blimu-cli/pkg/cli"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/metrics"
+	"github.com/blimu-dev/blimu-cli/pkg/schema"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/watch"
+	"github.com/spf13/cobra"
+)
+
+// WatchCommand represents the watch command
+type WatchCommand struct {
+	Directory     string
+	Push          bool
+	WorkspaceID   string
+	EnvironmentID string
+	Yes           bool
+	Interval      time.Duration
+	Metrics       metrics.Options
+
+	runner          *cli.Runner
+	environmentKeys []string
+}
+
+// NewWatchCmd creates the watch command
+func NewWatchCmd() *cobra.Command {
+	cmd := &WatchCommand{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "watch [directory]",
+		Short: "Re-validate .blimu files on save and optionally push them",
+		Long: `Watch the .blimu directory and validate the configuration every time a file is saved,
+using the same checks as 'blimu validate --offline'.
+
+With --push, a configuration that passes validation is pushed to the current environment (or
+--environment-id). Only sections that changed since the last pull or push are sent, as with
+'blimu push --changed-only'. Auto-pushing to a protected environment needs --yes.
+
+Examples:
+  # Validate on every save
+  blimu watch
+
+  # Push every valid save to the dev environment
+  blimu watch --push --env dev`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cmd.Directory = args[0]
+			} else {
+				cmd.Directory = "."
+			}
+			return cmd.Run(cobraCmd)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.Push, "push", false, "Push definitions after every save that passes validation")
+	cobraCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Allow auto-pushing to a protected environment")
+	cobraCmd.Flags().DurationVar(&cmd.Interval, "interval", watch.DefaultInterval, "How often to check the .blimu directory for changes")
+	cobraCmd.Flags().StringVar(&cmd.Metrics.Addr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. :9464)")
+	cobraCmd.Flags().StringVar(&cmd.Metrics.File, "metrics-file", "", "Periodically write Prometheus metrics to this textfile-collector file")
+	cobraCmd.Flags().DurationVar(&cmd.Metrics.Interval, "metrics-interval", metrics.DefaultTextfileInterval, "How often to rewrite --metrics-file")
+
+	return cobraCmd
+}
+
+// Run executes the watch command
+func (c *WatchCommand) Run(cmd *cobra.Command) error {
+	blimuDir := filepath.Join(c.Directory, ".blimu")
+
+	if c.Push {
+		if err := c.preparePush(cmd); err != nil {
+			return err
+		}
+	}
+
+	if c.Metrics.Enabled() {
+		stopMetrics, err := metrics.Start(c.Metrics)
+		if err != nil {
+			return err
+		}
+		defer stopMetrics()
+	}
+
+	ctx := cmd.Context()
+
+	fmt.Printf("👀 Watching %s (Ctrl+C to stop)\n", blimuDir)
+	c.check(ctx)
+
+	err := watch.Watch(ctx, blimuDir, c.Interval, func(changed []string) {
+		for _, path := range changed {
+			if rel, err := filepath.Rel(c.Directory, path); err == nil {
+				path = rel
+			}
+			fmt.Printf("\n📝 %s changed\n", path)
+		}
+		c.check(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", blimuDir, err)
+	}
+
+	fmt.Printf("\n👋 Stopped watching\n")
+	return nil
+}
+
+// preparePush resolves the environment to push to and authenticates once, before watching starts
+func (c *WatchCommand) preparePush(cmd *cobra.Command) error {
+	sc := shared.FromContext(cmd.Context())
+
+	cliConfig, err := sc.Config()
+	if err != nil {
+		return err
+	}
+	if c.WorkspaceID, c.EnvironmentID, err = sc.RequireIDs("--push"); err != nil {
+		return err
+	}
+
+	if err := shared.ConfirmProtectedEnvironment(cliConfig, c.EnvironmentID, "auto-push definitions to", c.Yes); err != nil {
+		return err
+	}
+
+	runner, err := cli.NewFromContext(sc)
+	if err != nil {
+		return fmt.Errorf("authentication required for --push. Run 'blimu auth login' first: %w", err)
+	}
+	c.runner = runner
+	c.environmentKeys = cliConfig.EnvironmentKeys(c.EnvironmentID)
+
+	fmt.Printf("🚀 Valid saves will be pushed to environment %s\n", c.EnvironmentID)
+	return nil
+}
+
+// check validates the configuration and pushes it when --push is set. Problems are printed
+// rather than returned so that watching continues.
+func (c *WatchCommand) check(ctx context.Context) {
+	fmt.Printf("🕒 %s ", time.Now().Format("15:04:05"))
+
+	problems, err := schema.ValidateDirectory(filepath.Join(c.Directory, ".blimu"))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	if len(problems) > 0 {
+		fmt.Printf("❌ Found %d schema error(s):\n", len(problems))
+		for _, problem := range problems {
+			fmt.Printf("   %s\n", problem)
+		}
+		return
+	}
+
+	blimuConfig, err := config.LoadBlimuConfig(c.Directory)
+	if err != nil {
+		fmt.Printf("❌ Failed to load .blimu configuration: %v\n", err)
+		return
+	}
+
+	result := blimu.ValidateConfig(blimuConfig)
+	if !result.Valid {
+		fmt.Printf("❌ Found %d validation error(s):\n", len(result.Errors))
+		for _, validationErr := range result.Errors {
+			fmt.Printf("   %s\n", validationErr.Error())
+		}
+		return
+	}
+	fmt.Printf("✅ Configuration is valid\n")
+
+	if c.runner == nil {
+		return
+	}
+
+	pushed, err := c.runner.Push(ctx, cli.PushOptions{
+		Directory:       c.Directory,
+		WorkspaceID:     c.WorkspaceID,
+		EnvironmentID:   c.EnvironmentID,
+		EnvironmentKeys: c.environmentKeys,
+		ChangedOnly:     true,
+	})
+	if err != nil {
+		fmt.Printf("❌ Push failed: %v\n", err)
+		return
+	}
+	if len(pushed.Sections) > 0 {
+		fmt.Printf("✅ Pushed to %s\n", c.EnvironmentID)
+	}
+}
@@ -0,0 +1,77 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+)
+
+// Environment variables GitHub Actions sets in jobs with the id-token: write permission
+const (
+	githubIDTokenURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubIDTokenTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// GitHubActionsAvailable reports whether this process runs in a GitHub Actions job that may
+// request OIDC ID tokens
+func GitHubActionsAvailable() bool {
+	return os.Getenv(githubIDTokenURLEnv) != "" && os.Getenv(githubIDTokenTokenEnv) != ""
+}
+
+// GitHubActionsIDToken requests an OIDC ID token for audience from GitHub Actions. The job needs
+// the id-token: write permission.
+func GitHubActionsIDToken(ctx context.Context, audience string) (string, error) {
+	requestURL := os.Getenv(githubIDTokenURLEnv)
+	requestToken := os.Getenv(githubIDTokenTokenEnv)
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("%s and %s are not set; run in GitHub Actions with 'permissions: id-token: write'", githubIDTokenURLEnv, githubIDTokenTokenEnv)
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", githubIDTokenURLEnv, err)
+	}
+	if audience != "" {
+		query := u.Query()
+		query.Set("audience", audience)
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create ID token request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := telemetry.HTTPClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request GitHub Actions ID token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ID token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub Actions ID token request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse ID token response: %w", err)
+	}
+	if tokenResp.Value == "" {
+		return "", fmt.Errorf("GitHub Actions returned an empty ID token")
+	}
+	return tokenResp.Value, nil
+}
@@ -11,8 +11,13 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
 )
 
+// DefaultClientID is the OAuth client of the CLI registered with the platform API
+const DefaultClientID = "blimu_cli"
+
 type Config struct {
 	ClientID    string
 	AuthURL     string
@@ -29,6 +34,22 @@ type TokenResponse struct {
 	Scope        string `json:"scope,omitempty"`
 }
 
+// RefreshError is returned when the token endpoint rejects a refresh request
+type RefreshError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *RefreshError) Error() string {
+	return fmt.Sprintf("token refresh failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Revoked reports whether the refresh token itself was rejected (expired, revoked or unknown),
+// as opposed to a transient server failure
+func (e *RefreshError) Revoked() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnauthorized
+}
+
 type Client struct {
 	config Config
 	client *http.Client
@@ -38,7 +59,8 @@ func NewClient(config Config) *Client {
 	return &Client{
 		config: config,
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: telemetry.HTTPClient().Transport,
+			Timeout:   30 * time.Second,
 		},
 	}
 }
@@ -125,7 +147,7 @@ func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*TokenR
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token refresh failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &RefreshError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var tokenResp TokenResponse
@@ -136,6 +158,65 @@ func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*TokenR
 	return &tokenResp, nil
 }
 
+// ClientCredentialsToken requests an access token for a service account with its client secret.
+// A rejected secret is reported as a *RefreshError.
+func (c *Client) ClientCredentialsToken(ctx context.Context, clientSecret string) (*TokenResponse, error) {
+	data := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.config.ClientID},
+		"client_secret": {clientSecret},
+	}
+	if len(c.config.Scopes) > 0 {
+		data.Set("scope", strings.Join(c.config.Scopes, " "))
+	}
+	return c.requestToken(ctx, data)
+}
+
+// ExchangeIDToken exchanges an OIDC ID token of a trusted identity provider, e.g. a CI system,
+// for an access token (RFC 8693). A rejected ID token is reported as a *RefreshError.
+func (c *Client) ExchangeIDToken(ctx context.Context, idToken string) (*TokenResponse, error) {
+	data := url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"client_id":          {c.config.ClientID},
+		"subject_token":      {idToken},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:id_token"},
+	}
+	return c.requestToken(ctx, data)
+}
+
+// requestToken posts a grant to the token endpoint
+func (c *Client) requestToken(ctx context.Context, data url.Values) (*TokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.config.TokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request %s token: %w", data.Get("grant_type"), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, &RefreshError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var tokenResp TokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	return &tokenResp, nil
+}
+
 // GenerateRandomString generates a cryptographically secure random string
 func GenerateRandomString(length int) (string, error) {
 	bytes := make([]byte, length)
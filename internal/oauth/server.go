@@ -2,15 +2,21 @@ package oauth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
 )
 
 type CallbackServer struct {
 	server   *http.Server
 	listener net.Listener
 	codeChan chan CallbackResult
+	host     string
 	port     int
 }
 
@@ -20,18 +26,59 @@ type CallbackResult struct {
 	Error string
 }
 
+// DefaultCallbackHost is the host of the redirect URI unless another one is configured
+const DefaultCallbackHost = "127.0.0.1"
+
+// CallbackOptions choose the address of the callback server, which must match a redirect URI
+// registered for the OAuth app
+type CallbackOptions struct {
+	// Host is the host of the redirect URI, and the address the server binds; it must resolve
+	// to this machine (default 127.0.0.1)
+	Host string
+	// Port is the port to bind. Zero tries 8080 and, if it is busy, the next ten ports; any other
+	// port is used as is, failing if it is busy, as the redirect URI must match exactly.
+	Port int
+}
+
 func NewCallbackServer() (*CallbackServer, error) {
 	return NewCallbackServerWithPort(8080) // Default to port 8080
 }
 
 func NewCallbackServerWithPort(port int) (*CallbackServer, error) {
-	address := fmt.Sprintf("127.0.0.1:%d", port)
-	listener, err := net.Listen("tcp", address)
+	return listenWithAlternatives(DefaultCallbackHost, port)
+}
+
+// NewCallbackServerWithOptions creates a callback server on the host and port of opts
+func NewCallbackServerWithOptions(opts CallbackOptions) (*CallbackServer, error) {
+	host := opts.Host
+	if host == "" {
+		host = DefaultCallbackHost
+	}
+	if opts.Port == 0 {
+		return listenWithAlternatives(host, 8080)
+	}
+	if opts.Port < 1 || opts.Port > 65535 {
+		return nil, fmt.Errorf("invalid callback port %d: use a port between 1 and 65535", opts.Port)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(opts.Port)))
+	if err != nil {
+		return nil, bindError(host, opts.Port, err)
+	}
+	return newCallbackServer(listener, host, opts.Port), nil
+}
+
+// listenWithAlternatives creates a callback server on port, or if it is busy on one of the next
+// ten ports
+func listenWithAlternatives(host string, port int) (*CallbackServer, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
 	if err != nil {
+		if !errors.Is(err, syscall.EADDRINUSE) {
+			return nil, bindError(host, port, err)
+		}
 		// If the preferred port is busy, try a few alternatives
 		for altPort := port + 1; altPort <= port+10; altPort++ {
-			altAddress := fmt.Sprintf("127.0.0.1:%d", altPort)
-			listener, err = net.Listen("tcp", altAddress)
+			listener, err = net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(altPort)))
 			if err == nil {
 				port = altPort
 				break
@@ -41,27 +88,76 @@ func NewCallbackServerWithPort(port int) (*CallbackServer, error) {
 			return nil, fmt.Errorf("failed to create listener on port %d or alternatives: %w", port, err)
 		}
 	}
-	codeChan := make(chan CallbackResult, 1)
+	return newCallbackServer(listener, host, port), nil
+}
 
-	mux := http.NewServeMux()
-	server := &http.Server{
-		Handler: mux,
+// bindError explains why the callback server could not bind host:port
+func bindError(host string, port int, err error) error {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, syscall.EADDRINUSE):
+		return fmt.Errorf("callback port %d is already in use by another program: stop it, or register another port's redirect URI in the OAuth app and pass it with --callback-port", port)
+	case errors.Is(err, syscall.EACCES):
+		return fmt.Errorf("not allowed to bind callback port %d: ports below 1024 need elevated privileges, use a higher port", port)
+	case errors.Is(err, syscall.EADDRNOTAVAIL), errors.As(err, &dnsErr):
+		return fmt.Errorf("cannot bind callback host %s: it must resolve to an address of this machine, e.g. 127.0.0.1 or localhost: %w", host, err)
 	}
+	return fmt.Errorf("failed to bind callback server on %s: %w", net.JoinHostPort(host, strconv.Itoa(port)), err)
+}
 
+func newCallbackServer(listener net.Listener, host string, port int) *CallbackServer {
+	mux := http.NewServeMux()
 	cs := &CallbackServer{
-		server:   server,
+		server:   &http.Server{Handler: mux},
 		listener: listener,
-		codeChan: codeChan,
+		codeChan: make(chan CallbackResult, 1),
+		host:     host,
 		port:     port,
 	}
 
 	mux.HandleFunc("/callback", cs.handleCallback)
 
-	return cs, nil
+	return cs
 }
 
 func (cs *CallbackServer) GetRedirectURI() string {
-	return fmt.Sprintf("http://127.0.0.1:%d/callback", cs.port)
+	return RedirectURI(cs.host, cs.port)
+}
+
+// RedirectURI returns the redirect URI of a callback server on host and port
+func RedirectURI(host string, port int) string {
+	return fmt.Sprintf("http://%s/callback", net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// ParseCallback reads the result of an authorization from what the user pasted: the URL the
+// browser was redirected to, its query string, or the bare authorization code. A bare code has
+// no state to check.
+func ParseCallback(input string) (CallbackResult, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return CallbackResult{}, fmt.Errorf("nothing was pasted")
+	}
+
+	query := input
+	if u, err := url.Parse(input); err == nil && (u.Scheme != "" || strings.HasPrefix(input, "/")) {
+		query = u.RawQuery
+	} else if !strings.Contains(input, "=") {
+		return CallbackResult{Code: input}, nil
+	}
+
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return CallbackResult{}, fmt.Errorf("invalid callback URL: %w", err)
+	}
+	result := CallbackResult{
+		Code:  values.Get("code"),
+		State: values.Get("state"),
+		Error: values.Get("error"),
+	}
+	if result.Code == "" && result.Error == "" {
+		return CallbackResult{}, fmt.Errorf("the callback URL has no code parameter; paste the whole URL of the page the browser was redirected to")
+	}
+	return result, nil
 }
 
 func (cs *CallbackServer) GetPort() int {
@@ -2,20 +2,47 @@ package blimu
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/naming"
 )
 
-// ValidationError represents a validation error
+// pythonPackagePattern matches names that can be imported as a Python package
+var pythonPackagePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// javaPackagePattern matches lowercase dotted Java package names (e.g. dev.blimu.client)
+var javaPackagePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)*$`)
+
+// javaModulePattern matches Maven coordinates in groupId:artifactId form
+var javaModulePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+:[A-Za-z0-9_.-]+$`)
+
+// csharpNamespacePattern matches dotted C# namespaces (e.g. Blimu.Client); C# identifiers may use any Unicode letter
+var csharpNamespacePattern = regexp.MustCompile(`^[\p{L}_][\p{L}\p{Nd}_]*(\.[\p{L}_][\p{L}\p{Nd}_]*)*$`)
+
+// ValidationError represents a validation error. Position is set when the configuration was
+// loaded from files, so errors can be reported as .blimu/resources.yml:42:5.
 type ValidationError struct {
 	Resource string
 	Field    string
-	Message  string
+	// Rule identifies the check that failed, e.g. "inheritance-syntax"
+	Rule    string
+	Message string
+	config.Position
 }
 
 func (e ValidationError) Error() string {
-	return fmt.Sprintf("%s.%s: %s", e.Resource, e.Field, e.Message)
+	subject := e.Resource
+	if e.Field != "" {
+		subject += "." + e.Field
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s: %s", e.File, e.Line, e.Column, subject, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", subject, e.Message)
 }
 
 // ValidationResult represents the result of validation
@@ -32,8 +59,16 @@ func ValidateConfig(config *config.BlimuConfig) *ValidationResult {
 	}
 
 	// Validate resources
+	if len(config.Resources) == 0 {
+		result.Errors = append(result.Errors, ValidationError{
+			Resource: "resources",
+			Rule:     "no-resources",
+			Message:  "at least one resource must be defined",
+			Position: config.Positions.Of("resources.yml"),
+		})
+	}
 	for resourceName, resourceConfig := range config.Resources {
-		validateResource(resourceName, resourceConfig, config.Resources, result)
+		validateResource(resourceName, resourceConfig, config.Resources, config.Positions, result)
 	}
 
 	// Validate entitlements
@@ -48,25 +83,39 @@ func ValidateConfig(config *config.BlimuConfig) *ValidationResult {
 
 	// Validate plans (basic validation - ensure they have names)
 	for planName, planConfig := range config.Plans {
-		validatePlan(planName, planConfig, result)
+		validatePlan(planName, planConfig, config.Positions, result)
 	}
 
 	// Validate SDK configuration
 	if config.SDKConfig != nil {
-		validateSDKConfig(config.SDKConfig, result)
+		validateSDKConfig(config.SDKConfig, config.Positions, result)
 	}
 
+	// Report errors in file order; maps are iterated randomly
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		a, b := result.Errors[i].Position, result.Errors[j].Position
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
 	result.Valid = len(result.Errors) == 0
 	return result
 }
 
-func validateResource(name string, resource config.ResourceConfig, allResources map[string]config.ResourceConfig, result *ValidationResult) {
+func validateResource(name string, resource config.ResourceConfig, allResources map[string]config.ResourceConfig, positions config.Positions, result *ValidationResult) {
 	// Validate roles
 	if len(resource.Roles) == 0 {
 		result.Errors = append(result.Errors, ValidationError{
 			Resource: name,
 			Field:    "roles",
+			Rule:     "resource-roles",
 			Message:  "at least one role must be defined",
+			Position: positions.Of("resources.yml", name, "roles"),
 		})
 	}
 
@@ -76,7 +125,9 @@ func validateResource(name string, resource config.ResourceConfig, allResources
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: name,
 				Field:    "roles_inheritance",
+				Rule:     "inheritance-role",
 				Message:  fmt.Sprintf("role '%s' not found in roles list", role),
+				Position: positions.Of("resources.yml", name, "roles_inheritance", role),
 			})
 		}
 
@@ -85,7 +136,9 @@ func validateResource(name string, resource config.ResourceConfig, allResources
 				result.Errors = append(result.Errors, ValidationError{
 					Resource: name,
 					Field:    "roles_inheritance",
+					Rule:     "inheritance-syntax",
 					Message:  fmt.Sprintf("invalid inheritance '%s': %s", inheritance, err),
+					Position: positions.OfItem("resources.yml", inheritance, name, "roles_inheritance", role),
 				})
 			}
 		}
@@ -97,7 +150,9 @@ func validateResource(name string, resource config.ResourceConfig, allResources
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: name,
 				Field:    "parents",
+				Rule:     "parent-not-found",
 				Message:  fmt.Sprintf("parent resource '%s' not found", parentName),
+				Position: positions.Of("resources.yml", name, "parents", parentName),
 			})
 		}
 
@@ -106,7 +161,9 @@ func validateResource(name string, resource config.ResourceConfig, allResources
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: name,
 				Field:    "parents",
+				Rule:     "parent-cycle",
 				Message:  fmt.Sprintf("circular dependency detected with parent '%s'", parentName),
+				Position: positions.Of("resources.yml", name, "parents", parentName),
 			})
 		}
 
@@ -170,7 +227,9 @@ func validateEntitlement(name string, entitlement config.EntitlementConfig, conf
 		result.Errors = append(result.Errors, ValidationError{
 			Resource: "entitlements",
 			Field:    name,
+			Rule:     "entitlement-name",
 			Message:  "entitlement name must be in format 'resource:action'",
+			Position: config.Positions.Of("entitlements.yml", name),
 		})
 		return
 	}
@@ -182,7 +241,9 @@ func validateEntitlement(name string, entitlement config.EntitlementConfig, conf
 		result.Errors = append(result.Errors, ValidationError{
 			Resource: "entitlements",
 			Field:    name,
+			Rule:     "entitlement-resource",
 			Message:  fmt.Sprintf("resource '%s' not found in resources.yml", resourceName),
+			Position: config.Positions.Of("entitlements.yml", name),
 		})
 	}
 
@@ -193,7 +254,9 @@ func validateEntitlement(name string, entitlement config.EntitlementConfig, conf
 				result.Errors = append(result.Errors, ValidationError{
 					Resource: "entitlements",
 					Field:    name,
+					Rule:     "entitlement-role",
 					Message:  fmt.Sprintf("role '%s' not found in resource '%s'", role, resourceName),
+					Position: config.Positions.OfItem("entitlements.yml", role, name, "roles"),
 				})
 			}
 		}
@@ -205,7 +268,9 @@ func validateEntitlement(name string, entitlement config.EntitlementConfig, conf
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "entitlements",
 				Field:    name,
+				Rule:     "entitlement-plan",
 				Message:  fmt.Sprintf("plan '%s' not found in plans.yml", plan),
+				Position: config.Positions.OfItem("entitlements.yml", plan, name, "plans"),
 			})
 		}
 	}
@@ -218,7 +283,9 @@ func validateFeature(name string, feature config.FeatureConfig, config *config.B
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "features",
 				Field:    name,
+				Rule:     "feature-plan",
 				Message:  fmt.Sprintf("plan '%s' not found in plans.yml", plan),
+				Position: config.Positions.OfItem("features.yml", plan, name, "plans"),
 			})
 		}
 	}
@@ -229,19 +296,23 @@ func validateFeature(name string, feature config.FeatureConfig, config *config.B
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "features",
 				Field:    name,
+				Rule:     "feature-entitlement",
 				Message:  fmt.Sprintf("entitlement '%s' not found in entitlements.yml", entitlement),
+				Position: config.Positions.OfItem("features.yml", entitlement, name, "entitlements"),
 			})
 		}
 	}
 }
 
-func validatePlan(name string, plan config.PlanConfig, result *ValidationResult) {
+func validatePlan(name string, plan config.PlanConfig, positions config.Positions, result *ValidationResult) {
 	// Validate plan has a name
 	if strings.TrimSpace(plan.Name) == "" {
 		result.Errors = append(result.Errors, ValidationError{
 			Resource: "plans",
 			Field:    name,
+			Rule:     "plan-fields",
 			Message:  "plan must have a name",
+			Position: positions.Of("plans.yml", name, "name"),
 		})
 	}
 
@@ -250,17 +321,21 @@ func validatePlan(name string, plan config.PlanConfig, result *ValidationResult)
 		result.Errors = append(result.Errors, ValidationError{
 			Resource: "plans",
 			Field:    name,
+			Rule:     "plan-fields",
 			Message:  "plan must have a description",
+			Position: positions.Of("plans.yml", name, "description"),
 		})
 	}
 }
 
-func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
+func validateSDKConfig(sdkConfig *config.SDKConfig, positions config.Positions, result *ValidationResult) {
 	if len(sdkConfig.Clients) == 0 {
 		result.Errors = append(result.Errors, ValidationError{
 			Resource: "config",
 			Field:    "clients",
+			Rule:     "sdk-clients",
 			Message:  "at least one client must be defined",
+			Position: positions.Of("config.yml", "clients"),
 		})
 		return
 	}
@@ -274,16 +349,20 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "config",
 				Field:    clientName + ".type",
+				Rule:     "sdk-client-required",
 				Message:  "client type is required",
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "type"),
 			})
 		} else {
 			// Validate supported types
-			supportedTypes := []string{"typescript", "go"}
+			supportedTypes := []string{"typescript", "typescript-types", "go", "python", "java", "csharp"}
 			if !contains(supportedTypes, client.Type) {
 				result.Errors = append(result.Errors, ValidationError{
 					Resource: "config",
 					Field:    clientName + ".type",
+					Rule:     "sdk-client-type",
 					Message:  fmt.Sprintf("unsupported client type '%s'. Supported types: %s", client.Type, strings.Join(supportedTypes, ", ")),
+					Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "type"),
 				})
 			}
 		}
@@ -292,7 +371,9 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "config",
 				Field:    clientName + ".outDir",
+				Rule:     "sdk-client-required",
 				Message:  "output directory is required",
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "outDir"),
 			})
 		}
 
@@ -300,7 +381,9 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "config",
 				Field:    clientName + ".packageName",
+				Rule:     "sdk-client-required",
 				Message:  "package name is required",
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "packageName"),
 			})
 		}
 
@@ -308,7 +391,9 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "config",
 				Field:    clientName + ".name",
+				Rule:     "sdk-client-required",
 				Message:  "client name is required",
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "name"),
 			})
 		}
 
@@ -317,7 +402,64 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 			result.Errors = append(result.Errors, ValidationError{
 				Resource: "config",
 				Field:    clientName + ".moduleName",
+				Rule:     "sdk-client-required",
 				Message:  "module name is required for Go clients",
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "moduleName"),
+			})
+		}
+
+		// React Query hooks are generated on top of the TypeScript client
+		if client.ReactHooks && client.Type != "typescript" {
+			result.Errors = append(result.Errors, ValidationError{
+				Resource: "config",
+				Field:    clientName + ".reactHooks",
+				Rule:     "sdk-react-hooks",
+				Message:  fmt.Sprintf("reactHooks is only supported for typescript clients, not '%s'", client.Type),
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "reactHooks"),
+			})
+		}
+
+		// For Python clients, the package name becomes the import package directory
+		if client.Type == "python" && strings.TrimSpace(client.PackageName) != "" && !pythonPackagePattern.MatchString(client.PackageName) {
+			result.Errors = append(result.Errors, ValidationError{
+				Resource: "config",
+				Field:    clientName + ".packageName",
+				Rule:     "sdk-package-name",
+				Message:  fmt.Sprintf("package name '%s' must be a valid Python identifier (e.g. 'blimu_client') for Python clients%s", client.PackageName, suggestName(naming.Snake(client.PackageName), pythonPackagePattern)),
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "packageName"),
+			})
+		}
+
+		// For Java clients, the package name is a Java package and the module name is the Maven groupId:artifactId
+		if client.Type == "java" {
+			if strings.TrimSpace(client.PackageName) != "" && !javaPackagePattern.MatchString(client.PackageName) {
+				result.Errors = append(result.Errors, ValidationError{
+					Resource: "config",
+					Field:    clientName + ".packageName",
+					Rule:     "sdk-package-name",
+					Message:  fmt.Sprintf("package name '%s' must be a lowercase dotted Java package (e.g. 'dev.blimu.client') for Java clients%s", client.PackageName, suggestName(mapSegments(client.PackageName, naming.Snake), javaPackagePattern)),
+					Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "packageName"),
+				})
+			}
+			if strings.TrimSpace(client.ModuleName) != "" && !javaModulePattern.MatchString(client.ModuleName) {
+				result.Errors = append(result.Errors, ValidationError{
+					Resource: "config",
+					Field:    clientName + ".moduleName",
+					Rule:     "sdk-module-name",
+					Message:  fmt.Sprintf("module name '%s' must be Maven coordinates in 'groupId:artifactId' form for Java clients", client.ModuleName),
+					Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "moduleName"),
+				})
+			}
+		}
+
+		// For C# clients, the package name is the NuGet package ID and root namespace
+		if client.Type == "csharp" && strings.TrimSpace(client.PackageName) != "" && !csharpNamespacePattern.MatchString(client.PackageName) {
+			result.Errors = append(result.Errors, ValidationError{
+				Resource: "config",
+				Field:    clientName + ".packageName",
+				Rule:     "sdk-package-name",
+				Message:  fmt.Sprintf("package name '%s' must be a dotted C# namespace (e.g. 'Blimu.Client') for C# clients%s", client.PackageName, suggestName(mapSegments(client.PackageName, naming.Pascal), csharpNamespacePattern)),
+				Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "packageName"),
 			})
 		}
 	}
@@ -330,7 +472,9 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 				result.Errors = append(result.Errors, ValidationError{
 					Resource: "config",
 					Field:    fmt.Sprintf("clients[%d].outDir", i),
+					Rule:     "sdk-duplicate-out-dir",
 					Message:  fmt.Sprintf("output directory '%s' is already used by clients[%d]", client.OutDir, existingIndex),
+					Position: positions.Of("config.yml", "clients", strconv.Itoa(i), "outDir"),
 				})
 			} else {
 				outDirs[client.OutDir] = i
@@ -339,6 +483,23 @@ func validateSDKConfig(sdkConfig *config.SDKConfig, result *ValidationResult) {
 	}
 }
 
+// suggestName returns a " (did you mean ...?)" hint when the converted name is valid
+func suggestName(candidate string, pattern *regexp.Regexp) string {
+	if candidate == "" || !pattern.MatchString(candidate) {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean '%s'?)", candidate)
+}
+
+// mapSegments converts each dot-separated segment of a package name
+func mapSegments(name string, convert func(string) string) string {
+	segments := strings.Split(name, ".")
+	for i, segment := range segments {
+		segments[i] = convert(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -0,0 +1,156 @@
+// Package bulk runs an operation over many rows in batches and summarizes the outcome. It knows
+// nothing about where rows come from or how the summary is shown, so commands can render the
+// same Summary for people or as JSON.
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Batch size limits, enforced client-side: the platform has no bulk endpoint, so a batch only
+// bounds how many rows are processed between progress reports and failure checks
+const (
+	DefaultBatchSize = 1000
+	MaxBatchSize     = 1000
+)
+
+// Options configures a bulk run
+type Options struct {
+	// BatchSize is the number of rows per batch; values outside 1..MaxBatchSize use the nearest limit
+	BatchSize int
+	// ContinueOnError keeps going after a batch fails as a whole; otherwise the remaining rows are skipped
+	ContinueOnError bool
+	// OnBatch is called after every batch, for progress output
+	OnBatch func(BatchResult)
+}
+
+// Row is one input item along with where it came from
+type Row[T any] struct {
+	// Line is the 1-based line of the row in its source (0 when unknown)
+	Line int
+	// Key identifies the row in messages, e.g. "workspace:ws_1"
+	Key  string
+	Item T
+}
+
+// RowError is a row the operation did not apply
+type RowError struct {
+	Line    int    `json:"line,omitempty"`
+	Key     string `json:"key"`
+	Message string `json:"error"`
+}
+
+// BatchOutcome is a processor's report for one batch: rows neither listed in Errors nor counted
+// as Unchanged succeeded
+type BatchOutcome struct {
+	Errors []RowError
+	// Unchanged counts rows that needed no change, e.g. resources that already existed
+	Unchanged int
+}
+
+// Processor applies the operation to one batch. Returning an error fails every row of the batch.
+type Processor[T any] func(ctx context.Context, batch []Row[T]) (BatchOutcome, error)
+
+// BatchResult is the outcome of one batch
+type BatchResult struct {
+	Number    int           `json:"number"`
+	Of        int           `json:"of"`
+	Size      int           `json:"size"`
+	Succeeded int           `json:"succeeded"`
+	Unchanged int           `json:"unchanged"`
+	Failed    int           `json:"failed"`
+	Error     string        `json:"error,omitempty"`
+	Errors    []RowError    `json:"-"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Summary is the outcome of a bulk run
+type Summary struct {
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Succeeded int `json:"succeeded"`
+	// Unchanged counts rows that needed no change, e.g. resources that already existed
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+	// Skipped counts rows never attempted because an earlier batch failed or the run was cancelled
+	Skipped  int           `json:"skipped"`
+	Batches  []BatchResult `json:"batches"`
+	Errors   []RowError    `json:"errors"`
+	Duration time.Duration `json:"duration"`
+}
+
+// OK reports whether every row was applied
+func (s *Summary) OK() bool {
+	return s.Failed == 0 && s.Skipped == 0
+}
+
+// BatchSize clamps a requested batch size to the supported range
+func BatchSize(requested int) int {
+	switch {
+	case requested <= 0:
+		return DefaultBatchSize
+	case requested > MaxBatchSize:
+		return MaxBatchSize
+	}
+	return requested
+}
+
+// Run processes rows in batches. The summary is always returned; the error is set when the run
+// stopped early, either because a batch failed without ContinueOnError or because ctx was cancelled.
+func Run[T any](ctx context.Context, rows []Row[T], opts Options, process Processor[T]) (*Summary, error) {
+	start := time.Now()
+	size := BatchSize(opts.BatchSize)
+	batches := (len(rows) + size - 1) / size
+	summary := &Summary{Total: len(rows), Errors: []RowError{}}
+
+	var runErr error
+	for i := 0; i < len(rows); i += size {
+		if err := ctx.Err(); err != nil {
+			runErr = err
+			summary.Skipped = len(rows) - i
+			break
+		}
+
+		end := min(i+size, len(rows))
+		batch := rows[i:end]
+		result := BatchResult{Number: i/size + 1, Of: batches, Size: len(batch)}
+
+		batchStart := time.Now()
+		outcome, err := process(ctx, batch)
+		result.Duration = time.Since(batchStart)
+
+		if err != nil {
+			result.Error = err.Error()
+			result.Failed = len(batch)
+			for _, row := range batch {
+				result.Errors = append(result.Errors, RowError{Line: row.Line, Key: row.Key, Message: err.Error()})
+			}
+		} else {
+			result.Errors = outcome.Errors
+			result.Failed = len(outcome.Errors)
+			result.Unchanged = outcome.Unchanged
+			result.Succeeded = len(batch) - result.Failed - result.Unchanged
+		}
+
+		summary.Processed += len(batch)
+		summary.Succeeded += result.Succeeded
+		summary.Unchanged += result.Unchanged
+		summary.Failed += result.Failed
+		summary.Errors = append(summary.Errors, result.Errors...)
+		summary.Batches = append(summary.Batches, result)
+		if opts.OnBatch != nil {
+			opts.OnBatch(result)
+		}
+
+		if err != nil && !opts.ContinueOnError {
+			runErr = fmt.Errorf("batch %d failed: %w", result.Number, err)
+			summary.Skipped = len(rows) - end
+			break
+		}
+	}
+
+	summary.Duration = time.Since(start)
+	return summary, runErr
+}
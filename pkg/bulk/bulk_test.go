@@ -0,0 +1,195 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// testRows returns n rows keyed "row:1".."row:n" on lines 1..n
+func testRows(n int) []Row[int] {
+	rows := make([]Row[int], n)
+	for i := range rows {
+		rows[i] = Row[int]{Line: i + 1, Key: fmt.Sprintf("row:%d", i+1), Item: i + 1}
+	}
+	return rows
+}
+
+// succeed is a processor applying every row
+func succeed(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+	return BatchOutcome{}, nil
+}
+
+func TestBatchSize(t *testing.T) {
+	tests := []struct {
+		requested, want int
+	}{
+		{0, DefaultBatchSize},
+		{-5, DefaultBatchSize},
+		{1, 1},
+		{250, 250},
+		{MaxBatchSize, MaxBatchSize},
+		{MaxBatchSize + 1, MaxBatchSize},
+	}
+	for _, tt := range tests {
+		if got := BatchSize(tt.requested); got != tt.want {
+			t.Errorf("BatchSize(%d) = %d, want %d", tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestRunSplitsRowsIntoBatches(t *testing.T) {
+	var sizes []int
+	summary, err := Run(context.Background(), testRows(7), Options{BatchSize: 3}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		sizes = append(sizes, len(batch))
+		return BatchOutcome{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if fmt.Sprint(sizes) != "[3 3 1]" {
+		t.Errorf("batch sizes = %v, want [3 3 1]", sizes)
+	}
+	if summary.Total != 7 || summary.Processed != 7 || summary.Succeeded != 7 || !summary.OK() {
+		t.Errorf("summary = %+v, want all 7 rows succeeded", summary)
+	}
+	if len(summary.Batches) != 3 || summary.Batches[2].Number != 3 || summary.Batches[2].Of != 3 {
+		t.Errorf("batches = %+v, want 3 numbered batches", summary.Batches)
+	}
+}
+
+func TestRunClampsBatchSize(t *testing.T) {
+	var sizes []int
+	_, err := Run(context.Background(), testRows(MaxBatchSize+1), Options{BatchSize: MaxBatchSize * 2}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		sizes = append(sizes, len(batch))
+		return BatchOutcome{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if fmt.Sprint(sizes) != fmt.Sprintf("[%d 1]", MaxBatchSize) {
+		t.Errorf("batch sizes = %v, want [%d 1]", sizes, MaxBatchSize)
+	}
+}
+
+func TestRunCountsRowOutcomes(t *testing.T) {
+	summary, err := Run(context.Background(), testRows(5), Options{BatchSize: 5}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		return BatchOutcome{
+			Errors:    []RowError{{Line: 2, Key: "row:2", Message: "conflict"}},
+			Unchanged: 1,
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Succeeded != 3 || summary.Unchanged != 1 || summary.Failed != 1 || summary.Skipped != 0 {
+		t.Errorf("summary = %+v, want 3 succeeded, 1 unchanged, 1 failed", summary)
+	}
+	if len(summary.Errors) != 1 || summary.Errors[0].Key != "row:2" {
+		t.Errorf("errors = %+v, want row:2", summary.Errors)
+	}
+}
+
+func TestRunProcessorErrorFailsWholeBatch(t *testing.T) {
+	boom := errors.New("boom")
+	summary, err := Run(context.Background(), testRows(4), Options{BatchSize: 2, ContinueOnError: true}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		if batch[0].Item == 1 {
+			return BatchOutcome{}, boom
+		}
+		return BatchOutcome{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v, want nil with ContinueOnError", err)
+	}
+	if summary.Failed != 2 || summary.Succeeded != 2 {
+		t.Errorf("summary = %+v, want 2 failed and 2 succeeded", summary)
+	}
+	first := summary.Batches[0]
+	if first.Error != "boom" || first.Failed != 2 || len(first.Errors) != 2 {
+		t.Errorf("first batch = %+v, want both rows failed with boom", first)
+	}
+	for _, rowErr := range summary.Errors {
+		if rowErr.Message != "boom" {
+			t.Errorf("row error = %+v, want message boom", rowErr)
+		}
+	}
+}
+
+func TestRunStopsOnFirstFailedBatch(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	summary, err := Run(context.Background(), testRows(7), Options{BatchSize: 2}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		calls++
+		if calls == 2 {
+			return BatchOutcome{}, boom
+		}
+		return BatchOutcome{}, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Run() error = %v, want boom", err)
+	}
+	if calls != 2 {
+		t.Errorf("processor called %d times, want 2", calls)
+	}
+	if summary.Processed != 4 || summary.Succeeded != 2 || summary.Failed != 2 || summary.Skipped != 3 {
+		t.Errorf("summary = %+v, want 4 processed, 2 succeeded, 2 failed, 3 skipped", summary)
+	}
+	if summary.OK() {
+		t.Error("OK() = true for a run that stopped early")
+	}
+}
+
+func TestRunContinueOnError(t *testing.T) {
+	calls := 0
+	summary, err := Run(context.Background(), testRows(7), Options{BatchSize: 2, ContinueOnError: true}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		calls++
+		if calls%2 == 1 {
+			return BatchOutcome{}, errors.New("boom")
+		}
+		return BatchOutcome{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v, want nil with ContinueOnError", err)
+	}
+	if calls != 4 {
+		t.Errorf("processor called %d times, want 4", calls)
+	}
+	// Batches 1 and 3 (2 rows each) fail; batches 2 (2 rows) and 4 (1 row) succeed
+	if summary.Processed != 7 || summary.Failed != 4 || summary.Succeeded != 3 || summary.Skipped != 0 {
+		t.Errorf("summary = %+v, want 7 processed, 4 failed, 3 succeeded, 0 skipped", summary)
+	}
+}
+
+func TestRunCancelledMidRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var reported []int
+	summary, err := Run(ctx, testRows(6), Options{
+		BatchSize: 2,
+		OnBatch:   func(result BatchResult) { reported = append(reported, result.Number) },
+	}, func(ctx context.Context, batch []Row[int]) (BatchOutcome, error) {
+		cancel()
+		return BatchOutcome{}, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if fmt.Sprint(reported) != "[1]" {
+		t.Errorf("reported batches = %v, want [1]", reported)
+	}
+	if summary.Processed != 2 || summary.Succeeded != 2 || summary.Skipped != 4 {
+		t.Errorf("summary = %+v, want 2 processed and succeeded, 4 skipped", summary)
+	}
+}
+
+func TestRunEmpty(t *testing.T) {
+	summary, err := Run(context.Background(), nil, Options{}, succeed)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Total != 0 || len(summary.Batches) != 0 || !summary.OK() {
+		t.Errorf("summary = %+v, want an empty successful run", summary)
+	}
+}
@@ -0,0 +1,79 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+// ErrorColumn is the column of the failure message in files written by WriteFailedCSV
+const ErrorColumn = "error"
+
+// FailedRows returns the rows a run did not apply along with why: the failed rows, then the rows
+// it skipped. rows must be the rows given to Run.
+func FailedRows[T any](rows []Row[T], summary *Summary) ([]Row[T], []string) {
+	messages := make(map[string]string, len(summary.Errors))
+	for _, rowErr := range summary.Errors {
+		messages[rowID(rowErr.Line, rowErr.Key)] = rowErr.Message
+	}
+
+	var failed []Row[T]
+	var reasons []string
+	for i, row := range rows {
+		if i >= summary.Processed {
+			failed = append(failed, row)
+			reasons = append(reasons, "skipped after an earlier error")
+			continue
+		}
+		if message, ok := messages[rowID(row.Line, row.Key)]; ok {
+			failed = append(failed, row)
+			reasons = append(reasons, message)
+		}
+	}
+	return failed, reasons
+}
+
+// WriteFailedCSV writes the rows a run did not apply to path as CSV, so they can be fixed and run
+// again: header and records give the columns of the input, followed by an error column. records
+// returns the CSV records of an item, several when the input spread it over several lines. It
+// returns the number of rows written; nothing is written when every row was applied.
+func WriteFailedCSV[T any](path string, header []string, rows []Row[T], summary *Summary, records func(T) [][]string) (int, error) {
+	failed, reasons := FailedRows(rows, summary)
+	if len(failed) == 0 {
+		return 0, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create failed-rows file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(append(append([]string{}, header...), ErrorColumn)); err != nil {
+		return 0, err
+	}
+	for i, row := range failed {
+		for _, record := range records(row.Item) {
+			if err := writer.Write(append(record, reasons[i])); err != nil {
+				return 0, err
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to write failed-rows file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write failed-rows file: %w", err)
+	}
+	return len(failed), nil
+}
+
+// rowID identifies a row by its line, or by its key when the line is unknown
+func rowID(line int, key string) string {
+	if line > 0 {
+		return fmt.Sprintf("%d", line)
+	}
+	return key
+}
@@ -0,0 +1,78 @@
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultDisplayLimit is the number of failed rows listed per batch and in the summary on a terminal
+const DefaultDisplayLimit = 10
+
+// PrintBatch prints the progress line of one batch and up to limit of its failed rows (all when limit <= 0)
+func PrintBatch(w io.Writer, result BatchResult, limit int) {
+	if result.Error != "" {
+		fmt.Fprintf(w, "❌ Batch %d/%d failed: %s\n", result.Number, result.Of, result.Error)
+		return
+	}
+	unchanged := ""
+	if result.Unchanged > 0 {
+		unchanged = fmt.Sprintf(", %d unchanged", result.Unchanged)
+	}
+	fmt.Fprintf(w, "✅ Batch %d/%d completed: %d succeeded%s, %d failed (%s)\n",
+		result.Number, result.Of, result.Succeeded, unchanged, result.Failed, result.Duration.Round(time.Millisecond))
+	printRowErrors(w, result.Errors, limit)
+}
+
+// PrintSummary prints the totals of a run and up to limit failed rows (all when limit <= 0); verb
+// describes the operation ("created") and unchanged the rows that needed no change ("already
+// existed")
+func PrintSummary(w io.Writer, summary *Summary, verb, unchanged string, limit int) {
+	fmt.Fprintf(w, "\n📊 Bulk operation completed in %s\n", summary.Duration.Round(time.Millisecond))
+	fmt.Fprintf(w, "   Total rows: %d\n", summary.Total)
+	fmt.Fprintf(w, "   Successfully %s: %d\n", verb, summary.Succeeded)
+	if summary.Unchanged > 0 {
+		fmt.Fprintf(w, "   Skipped, %s: %d\n", unchanged, summary.Unchanged)
+	}
+	fmt.Fprintf(w, "   Failed: %d\n", summary.Failed)
+	if summary.Skipped > 0 {
+		fmt.Fprintf(w, "   Skipped after an error: %d\n", summary.Skipped)
+	}
+
+	if len(summary.Errors) > 0 {
+		fmt.Fprintf(w, "\n❌ Failed rows:\n")
+		printRowErrors(w, summary.Errors, limit)
+	}
+}
+
+// printRowErrors lists failed rows, noting how many were left out
+func printRowErrors(w io.Writer, rowErrs []RowError, limit int) {
+	shown := rowErrs
+	if limit > 0 && len(rowErrs) > limit {
+		shown = rowErrs[:limit]
+	}
+	for _, rowErr := range shown {
+		fmt.Fprintf(w, "   - %s\n", formatRowError(rowErr))
+	}
+	if hidden := len(rowErrs) - len(shown); hidden > 0 {
+		fmt.Fprintf(w, "   ... and %d more (use --show-all or --display-limit to list them)\n", hidden)
+	}
+}
+
+// WriteJSON writes the summary as indented JSON
+func WriteJSON(w io.Writer, summary *Summary) error {
+	encoded, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bulk summary: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(encoded))
+	return err
+}
+
+func formatRowError(rowErr RowError) string {
+	if rowErr.Line > 0 {
+		return fmt.Sprintf("line %d %s: %s", rowErr.Line, rowErr.Key, rowErr.Message)
+	}
+	return fmt.Sprintf("%s: %s", rowErr.Key, rowErr.Message)
+}
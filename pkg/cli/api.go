@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	platform "github.com/blimu-dev/blimu-cli/platform"
+)
+
+// Definitions holds the definition sections exchanged with the platform API
+type Definitions struct {
+	Resources    map[string]interface{}
+	Entitlements map[string]interface{}
+	Features     map[string]interface{}
+	Plans        map[string]interface{}
+}
+
+// ValidationIssue describes a single problem reported by the platform
+type ValidationIssue struct {
+	Resource string
+	Field    string
+	Message  string
+}
+
+// ValidationResult is the outcome of validating definitions
+type ValidationResult struct {
+	Valid  bool
+	Errors []ValidationIssue
+	Spec   map[string]interface{}
+}
+
+// SpecResult is the OpenAPI spec generated from an environment's stored definitions
+type SpecResult struct {
+	Success bool
+	Spec    map[string]interface{}
+	Errors  []ValidationIssue
+}
+
+// DefinitionsLock is an advisory lock on an environment's definitions
+type DefinitionsLock struct {
+	ID       string
+	LockedBy string
+	LockedAt time.Time
+	Reason   string
+}
+
+// API is the subset of the platform API used by the runner.
+// Implementations can be injected to embed the runner or to test against fakes.
+type API interface {
+	GetDefinitions(ctx context.Context, workspaceID, environmentID string) (*Definitions, error)
+	UpdateDefinitions(ctx context.Context, workspaceID, environmentID string, definitions *Definitions) error
+	ValidateDefinitions(ctx context.Context, workspaceID, environmentID string, definitions *Definitions, version string) (*ValidationResult, error)
+	GetOpenAPISpec(ctx context.Context, workspaceID, environmentID string) (*SpecResult, error)
+	// GetDefinitionsLock returns the lock on an environment's definitions, or nil when unlocked.
+	// It returns ErrLocksUnsupported when the platform has no definitions locks.
+	GetDefinitionsLock(ctx context.Context, workspaceID, environmentID string) (*DefinitionsLock, error)
+	LockDefinitions(ctx context.Context, workspaceID, environmentID, reason string) (*DefinitionsLock, error)
+	// UnlockDefinitions releases the lock with the given ID, or any lock when lockID is empty
+	UnlockDefinitions(ctx context.Context, workspaceID, environmentID, lockID string) error
+}
+
+// platformAPI implements API on top of the platform SDK client
+type platformAPI struct {
+	client *platform.Client
+}
+
+// NewPlatformAPI wraps a platform SDK client as an API
+func NewPlatformAPI(client *platform.Client) API {
+	return &platformAPI{client: client}
+}
+
+func (p *platformAPI) GetDefinitions(ctx context.Context, workspaceID, environmentID string) (*Definitions, error) {
+	response, err := p.client.Definitions.GetWithContext(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Definitions{
+		Resources:    response.Resources,
+		Entitlements: response.Entitlements,
+		Features:     response.Features,
+		Plans:        response.Plans,
+	}, nil
+}
+
+func (p *platformAPI) UpdateDefinitions(ctx context.Context, workspaceID, environmentID string, definitions *Definitions) error {
+	request := platform.DefinitionUpdateDto{
+		Resources:    nonNilMap(definitions.Resources),
+		Entitlements: nonNilMap(definitions.Entitlements),
+		Features:     nonNilMap(definitions.Features),
+		Plans:        nonNilMap(definitions.Plans),
+	}
+
+	_, err := p.client.Definitions.UpdateWithContext(ctx, workspaceID, environmentID, request)
+	return err
+}
+
+func (p *platformAPI) ValidateDefinitions(ctx context.Context, workspaceID, environmentID string, definitions *Definitions, version string) (*ValidationResult, error) {
+	request := platform.DefinitionValidateRequestDto{
+		Resources:    nonNilMap(definitions.Resources),
+		Entitlements: nonNilMap(definitions.Entitlements),
+		Features:     nonNilMap(definitions.Features),
+		Plans:        nonNilMap(definitions.Plans),
+		Version:      version,
+	}
+
+	response, err := p.client.Definitions.ValidateWithContext(ctx, workspaceID, environmentID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidationResult{
+		Valid:  response.Valid,
+		Errors: convertIssues(response.Errors),
+		Spec:   response.Spec,
+	}, nil
+}
+
+func (p *platformAPI) GetOpenAPISpec(ctx context.Context, workspaceID, environmentID string) (*SpecResult, error) {
+	response, err := p.client.Definitions.GetOpenApiWithContext(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpecResult{
+		Success: response.Success,
+		Spec:    response.Spec,
+		Errors:  convertIssues(response.Errors),
+	}, nil
+}
+
+func (p *platformAPI) GetDefinitionsLock(ctx context.Context, workspaceID, environmentID string) (*DefinitionsLock, error) {
+	response, err := p.client.Definitions.GetLockWithContext(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, lockError(err)
+	}
+	if !response.Locked || response.Lock == nil {
+		return nil, nil
+	}
+	return convertLock(*response.Lock), nil
+}
+
+func (p *platformAPI) LockDefinitions(ctx context.Context, workspaceID, environmentID, reason string) (*DefinitionsLock, error) {
+	response, err := p.client.Definitions.LockWithContext(ctx, workspaceID, environmentID, platform.DefinitionLockDto{Reason: reason})
+	if err != nil {
+		return nil, lockError(err)
+	}
+	// A lock without an ID could never be told apart from someone else's
+	if response.Id == "" {
+		return nil, ErrLocksUnsupported
+	}
+	return convertLock(response), nil
+}
+
+func (p *platformAPI) UnlockDefinitions(ctx context.Context, workspaceID, environmentID, lockID string) error {
+	var query *platform.DefinitionsUnlockQuery
+	if lockID != "" {
+		query = &platform.DefinitionsUnlockQuery{LockId: &lockID}
+	}
+	_, err := p.client.Definitions.UnlockWithContext(ctx, workspaceID, environmentID, query)
+	return lockError(err)
+}
+
+// lockError maps the status codes of the lock endpoints to ErrLocksUnsupported and ErrLockConflict
+func lockError(err error) error {
+	var apiErr *platform.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound:
+			return ErrLocksUnsupported
+		case http.StatusConflict:
+			return ErrLockConflict
+		}
+	}
+	return err
+}
+
+// convertLock converts an API lock; an unparseable timestamp leaves LockedAt zero
+func convertLock(lock platform.DefinitionLockDtoOutput) *DefinitionsLock {
+	lockedAt, _ := time.Parse(time.RFC3339, lock.LockedAt)
+	converted := &DefinitionsLock{ID: lock.Id, LockedBy: lock.LockedBy, LockedAt: lockedAt}
+	if lock.Reason != nil {
+		converted.Reason = *lock.Reason
+	}
+	return converted
+}
+
+// convertIssues converts raw API error maps into validation issues
+func convertIssues(errors []map[string]interface{}) []ValidationIssue {
+	issues := make([]ValidationIssue, len(errors))
+	for i, errorData := range errors {
+		issues[i] = ValidationIssue{
+			Resource: getStringFromMap(errorData, "resource"),
+			Field:    getStringFromMap(errorData, "field"),
+			Message:  getStringFromMap(errorData, "message"),
+		}
+	}
+	return issues
+}
+
+// nonNilMap returns an empty map in place of nil so sections serialize as {}
+func nonNilMap(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return make(map[string]interface{})
+	}
+	return data
+}
+
+// getStringFromMap safely extracts a string value from a map[string]interface{}
+func getStringFromMap(data map[string]interface{}, key string) string {
+	if val, ok := data[key]; ok {
+		if str, ok := val.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
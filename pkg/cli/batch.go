@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"gopkg.in/yaml.v3"
+)
+
+// Batch step operations
+const (
+	BatchOpPush     = "push"
+	BatchOpPull     = "pull"
+	BatchOpValidate = "validate"
+	BatchOpGenerate = "generate"
+)
+
+// Batch step statuses
+const (
+	BatchStatusSucceeded = "succeeded"
+	BatchStatusFailed    = "failed"
+	BatchStatusSkipped   = "skipped"
+)
+
+// BatchScript is a sequence of operations run with a single runner and shared context.
+// Scripts can be written in YAML or JSON.
+type BatchScript struct {
+	WorkspaceID     string      `yaml:"workspace_id" json:"workspace_id"`
+	EnvironmentID   string      `yaml:"environment_id" json:"environment_id"`
+	Directory       string      `yaml:"directory" json:"directory"`
+	ContinueOnError bool        `yaml:"continue_on_error" json:"continue_on_error"`
+	Steps           []BatchStep `yaml:"steps" json:"steps"`
+}
+
+// BatchStep is a single operation in a batch script.
+// Empty IDs and directory fall back to the script-level values.
+type BatchStep struct {
+	Name           string `yaml:"name" json:"name"`
+	Op             string `yaml:"op" json:"op"`
+	WorkspaceID    string `yaml:"workspace_id" json:"workspace_id"`
+	EnvironmentID  string `yaml:"environment_id" json:"environment_id"`
+	Directory      string `yaml:"directory" json:"directory"`
+	FailOnBreaking bool   `yaml:"fail_on_breaking" json:"fail_on_breaking"`
+	ChangedOnly    bool   `yaml:"changed_only" json:"changed_only"`
+	IfChanged      bool   `yaml:"if_changed" json:"if_changed"`
+	NoPostCommands bool   `yaml:"no_post_commands" json:"no_post_commands"`
+	Clean          bool   `yaml:"clean" json:"clean"`
+	Bump           string `yaml:"bump" json:"bump"`
+}
+
+// BatchStepResult is the outcome of one batch step
+type BatchStepResult struct {
+	Name     string        `json:"name"`
+	Op       string        `json:"op"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// BatchReport summarizes a batch run
+type BatchReport struct {
+	Steps     []BatchStepResult `json:"steps"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+}
+
+// ParseBatchScript parses a YAML or JSON batch script and checks its steps
+func ParseBatchScript(data []byte) (*BatchScript, error) {
+	var script BatchScript
+	// JSON is valid YAML, so a single decoder handles both formats
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("failed to parse batch script: %w", err)
+	}
+
+	if len(script.Steps) == 0 {
+		return nil, fmt.Errorf("batch script has no steps")
+	}
+
+	for i, step := range script.Steps {
+		switch step.Op {
+		case BatchOpPush, BatchOpPull, BatchOpValidate, BatchOpGenerate:
+		case "":
+			return nil, fmt.Errorf("steps[%d] missing required field 'op'", i)
+		default:
+			return nil, fmt.Errorf("steps[%d] has unsupported op '%s' (supported: push, pull, validate, generate)", i, step.Op)
+		}
+	}
+
+	return &script, nil
+}
+
+// RunBatch executes the script's steps in order. Unless ContinueOnError is set, the first
+// failure stops the run and the remaining steps are reported as skipped.
+func (r *Runner) RunBatch(ctx context.Context, script *BatchScript) *BatchReport {
+	report := &BatchReport{}
+	stopped := false
+
+	for i, step := range script.Steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("%d:%s", i+1, step.Op)
+		}
+		result := BatchStepResult{Name: name, Op: step.Op}
+
+		if stopped {
+			result.Status = BatchStatusSkipped
+			report.Skipped++
+			report.Steps = append(report.Steps, result)
+			continue
+		}
+
+		r.printf("\n▶️  [%s] %s\n", name, step.Op)
+		start := time.Now()
+		err := r.runBatchStep(ctx, script, step)
+		result.Duration = time.Since(start)
+
+		if err != nil {
+			result.Status = BatchStatusFailed
+			result.Error = err.Error()
+			report.Failed++
+			r.printf("❌ [%s] failed: %v\n", name, err)
+			if !script.ContinueOnError {
+				stopped = true
+			}
+		} else {
+			result.Status = BatchStatusSucceeded
+			report.Succeeded++
+			r.printf("✅ [%s] done\n", name)
+		}
+
+		report.Steps = append(report.Steps, result)
+	}
+
+	return report
+}
+
+// runBatchStep dispatches a single step to the matching runner operation
+func (r *Runner) runBatchStep(ctx context.Context, script *BatchScript, step BatchStep) error {
+	workspaceID := firstNonEmpty(step.WorkspaceID, script.WorkspaceID)
+	environmentID := firstNonEmpty(step.EnvironmentID, script.EnvironmentID)
+	directory := firstNonEmpty(step.Directory, script.Directory, ".")
+
+	if workspaceID == "" || environmentID == "" {
+		return fmt.Errorf("workspace ID and environment ID are required")
+	}
+
+	switch step.Op {
+	case BatchOpPush:
+		_, err := r.Push(ctx, PushOptions{
+			Directory:      directory,
+			WorkspaceID:    workspaceID,
+			EnvironmentID:  environmentID,
+			FailOnBreaking: step.FailOnBreaking,
+			ChangedOnly:    step.ChangedOnly,
+		})
+		return err
+	case BatchOpPull:
+		_, err := r.Pull(ctx, PullOptions{
+			Directory:     directory,
+			WorkspaceID:   workspaceID,
+			EnvironmentID: environmentID,
+		})
+		return err
+	case BatchOpValidate:
+		result, err := r.Validate(ctx, ValidateOptions{
+			Directory:     directory,
+			WorkspaceID:   workspaceID,
+			EnvironmentID: environmentID,
+		})
+		if err != nil {
+			return err
+		}
+		if !result.Valid {
+			for _, issue := range result.Errors {
+				r.printf("  ❌ %s.%s: %s\n", issue.Resource, issue.Field, issue.Message)
+			}
+			return shared.Errorf(shared.ErrValidation, "validation failed with %d error(s)", len(result.Errors))
+		}
+		return nil
+	case BatchOpGenerate:
+		commands, err := TrustedCommandPolicy()
+		if err != nil {
+			return err
+		}
+		commands.Disabled = step.NoPostCommands
+		_, err = r.Generate(ctx, GenerateOptions{
+			Directory:     directory,
+			WorkspaceID:   workspaceID,
+			EnvironmentID: environmentID,
+			IfChanged:     step.IfChanged,
+			Commands:      commands,
+			Clean:         step.Clean,
+			Bump:          step.Bump,
+		})
+		return err
+	default:
+		return fmt.Errorf("unsupported op '%s'", step.Op)
+	}
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// ciBranchVariables are checked, in order, when the checkout is on a detached HEAD as is common in CI
+var ciBranchVariables = []string{"GITHUB_HEAD_REF", "GITHUB_REF_NAME", "CI_COMMIT_REF_NAME", "BITBUCKET_BRANCH", "BRANCH_NAME"}
+
+// BranchGuardViolation describes a push to an environment from a branch not mapped to it
+type BranchGuardViolation struct {
+	Environment string
+	Branch      string
+	Allowed     []string
+	Mode        string
+}
+
+// Error implements error
+func (v *BranchGuardViolation) Error() string {
+	branch := v.Branch
+	if branch == "" {
+		branch = "an unknown branch"
+	} else {
+		branch = fmt.Sprintf("branch '%s'", branch)
+	}
+	return fmt.Sprintf("environment '%s' may only be pushed from %s, not %s",
+		v.Environment, strings.Join(v.Allowed, ", "), branch)
+}
+
+// CheckBranchGuard compares the directory's git branch against the branches mapped to the
+// environment in .blimu/project.yml. environmentKeys are the names the target environment is known
+// by (local name, ID, lookup key). It returns nil when no mapping applies or the branch is allowed.
+func CheckBranchGuard(directory string, environmentKeys ...string) (*BranchGuardViolation, error) {
+	project, err := config.LoadProjectConfig(directory)
+	if err != nil {
+		return nil, err
+	}
+	if len(project.Branches.Environments) == 0 {
+		return nil, nil
+	}
+
+	environment, allowed := matchEnvironment(project.Branches.Environments, environmentKeys)
+	if environment == "" {
+		return nil, nil
+	}
+
+	branch := CurrentGitBranch(directory)
+	if branch != "" {
+		for _, pattern := range allowed {
+			if matched, _ := path.Match(pattern, branch); matched {
+				return nil, nil
+			}
+		}
+	}
+
+	return &BranchGuardViolation{
+		Environment: environment,
+		Branch:      branch,
+		Allowed:     allowed,
+		Mode:        project.Branches.GuardMode(),
+	}, nil
+}
+
+// CurrentGitBranch returns the checked-out branch of the repository containing directory.
+// On a detached HEAD it falls back to CI branch variables; it returns "" when unknown.
+func CurrentGitBranch(directory string) string {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	cmd.Dir = directory
+	if output, err := cmd.Output(); err == nil {
+		if branch := strings.TrimSpace(string(output)); branch != "" {
+			return branch
+		}
+	}
+
+	for _, name := range ciBranchVariables {
+		if branch := os.Getenv(name); branch != "" {
+			return branch
+		}
+	}
+	return ""
+}
+
+// matchEnvironment finds the first mapping whose key matches one of the environment's names
+func matchEnvironment(environments map[string]config.BranchList, keys []string) (string, []string) {
+	names := make([]string, 0, len(environments))
+	for name := range environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		for _, name := range names {
+			if name == key {
+				return name, environments[name]
+			}
+		}
+	}
+	return "", nil
+}
+
+// enforceBranchGuard prints a warning or returns an error for a branch guard violation
+func (r *Runner) enforceBranchGuard(directory string, environmentKeys []string) error {
+	violation, err := CheckBranchGuard(directory, environmentKeys...)
+	if err != nil {
+		return err
+	}
+	if violation == nil {
+		return nil
+	}
+	if violation.Mode == config.BranchGuardWarn {
+		r.printf("⚠️  %s\n", violation.Error())
+		return nil
+	}
+	return fmt.Errorf("push blocked by branch guard: %w (use --skip-branch-check to override)", violation)
+}
@@ -0,0 +1,81 @@
+// Package cli exposes Blimu CLI operations (push, pull, validate, generate) as a Go API
+// so other programs can run them without shelling out to the blimu binary.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+)
+
+// Option configures a Runner
+type Option func(*Runner)
+
+// WithOutput sets the writer used for progress output (defaults to os.Stdout)
+func WithOutput(w io.Writer) Option {
+	return func(r *Runner) {
+		r.out = w
+	}
+}
+
+// Runner executes CLI operations against an injected API
+type Runner struct {
+	api API
+	out io.Writer
+}
+
+// New creates a runner that uses the given API for all platform calls
+func New(api API, opts ...Option) *Runner {
+	r := &Runner{
+		api: api,
+		out: os.Stdout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// NewFromEnvironment creates a runner authenticated with the current CLI environment
+func NewFromEnvironment(devMode bool, opts ...Option) (*Runner, error) {
+	client, err := shared.GetSDKClientWithDevMode(devMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(NewPlatformAPI(client), opts...), nil
+}
+
+// NewFromContext creates a runner using the command context's platform client and output
+func NewFromContext(sc *shared.Context, opts ...Option) (*Runner, error) {
+	client, err := sc.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(NewPlatformAPI(client), append([]Option{WithOutput(sc.Out)}, opts...)...), nil
+}
+
+// NewForEnvironment creates a runner authenticated with a named local environment
+func NewForEnvironment(name string, devMode bool, opts ...Option) (*Runner, error) {
+	client, _, err := shared.GetSDKClientForEnvironment(name, devMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(NewPlatformAPI(client), opts...), nil
+}
+
+// API returns the API used by the runner
+func (r *Runner) API() API {
+	return r.api
+}
+
+// printf writes progress output
+func (r *Runner) printf(format string, args ...interface{}) {
+	fmt.Fprintf(r.out, format, args...)
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	sdkconfig "github.com/blimu-dev/sdk-gen/pkg/config"
+)
+
+// CommandPolicy controls which preCommand/postCommand entries of sdk.yml run during generation.
+// Commands inherited unchanged from the embedded base config are always trusted; any other
+// command must be on the Trusted list or approved by Confirm, and is skipped otherwise.
+type CommandPolicy struct {
+	// Disabled skips every pre- and post-generation command, including the base config's
+	Disabled bool
+	// Trusted reports whether a command's argv is allow-listed
+	Trusted func(argv []string) bool
+	// Confirm asks whether an untrusted command may run; nil skips untrusted commands
+	Confirm func(client, hook string, argv []string) (bool, error)
+}
+
+// TrustedCommandPolicy returns a non-interactive policy that allows the commands listed under
+// trusted_commands in the CLI config
+func TrustedCommandPolicy() (CommandPolicy, error) {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return CommandPolicy{}, fmt.Errorf("failed to load CLI config: %w", err)
+	}
+	return CommandPolicy{Trusted: cliConfig.IsCommandTrusted}, nil
+}
+
+// applyCommandPolicy removes pre/post commands the policy does not allow from the loaded config
+func (r *Runner) applyCommandPolicy(cfg *sdkconfig.Config, baseConfig map[string]interface{}, policy CommandPolicy) error {
+	for i := range cfg.Clients {
+		client := &cfg.Clients[i]
+		hooks := []struct {
+			name    string
+			command *[]string
+		}{
+			{"preCommand", &client.PreCommand},
+			{"postCommand", &client.PostCommand},
+		}
+
+		for _, hook := range hooks {
+			argv := *hook.command
+			if len(argv) == 0 {
+				continue
+			}
+			commandLine := strings.Join(argv, " ")
+
+			if policy.Disabled {
+				r.printf("⏭️  Skipping %s %s: %s\n", client.Type, hook.name, commandLine)
+				*hook.command = nil
+				continue
+			}
+
+			allowed := isBaseCommand(baseConfig, client.Type, hook.name, argv)
+			if !allowed {
+				var err error
+				if allowed, err = allowCommand(policy, client.Type, hook.name, argv); err != nil {
+					return err
+				}
+			}
+
+			if !allowed {
+				r.printf("⚠️  Skipping untrusted %s %s: %s\n", client.Type, hook.name, commandLine)
+				r.printf("   Add it to trusted_commands in the CLI config to allow it\n")
+				*hook.command = nil
+			}
+		}
+	}
+	return nil
+}
+
+// allowCommand reports whether the policy trusts argv, asking Confirm when it is not allow-listed
+func allowCommand(policy CommandPolicy, clientType, hook string, argv []string) (bool, error) {
+	if policy.Trusted != nil && policy.Trusted(argv) {
+		return true, nil
+	}
+	if policy.Confirm == nil {
+		return false, nil
+	}
+	return policy.Confirm(clientType, hook, argv)
+}
+
+// isBaseCommand reports whether argv is the command the embedded base config declares for a client type
+func isBaseCommand(baseConfig map[string]interface{}, clientType, hook string, argv []string) bool {
+	base, ok := baseConfig[clientType].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	baseArgv, ok := base[hook].([]interface{})
+	if !ok || len(baseArgv) != len(argv) {
+		return false
+	}
+	for i, arg := range baseArgv {
+		if fmt.Sprint(arg) != argv[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// clientCommands are the pre/post commands of one client, taken out of the sdk-gen config so the
+// CLI can run them around its own steps (React hooks, manifest bookkeeping)
+type clientCommands struct {
+	pre  []string
+	post []string
+}
+
+// takeClientCommands moves every client's commands out of cfg
+func takeClientCommands(cfg *sdkconfig.Config) []clientCommands {
+	commands := make([]clientCommands, len(cfg.Clients))
+	for i := range cfg.Clients {
+		commands[i] = clientCommands{pre: cfg.Clients[i].PreCommand, post: cfg.Clients[i].PostCommand}
+		cfg.Clients[i].PreCommand = nil
+		cfg.Clients[i].PostCommand = nil
+	}
+	return commands
+}
+
+// runClientCommand runs a pre/post command in the client's output directory, like sdk-gen does
+func runClientCommand(client sdkconfig.Client, label string, argv []string) error {
+	if len(argv) == 0 {
+		return nil
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = client.OutDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed for client %s (%s): %w", label, client.Name, strings.Join(argv, " "), err)
+	}
+	return nil
+}
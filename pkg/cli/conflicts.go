@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition sections that can conflict during a pull
+const (
+	SectionResources    = "resources"
+	SectionEntitlements = "entitlements"
+	SectionFeatures     = "features"
+	SectionPlans        = "plans"
+)
+
+// Conflict is a definition key whose local value differs from the cloud value.
+// Values are rendered as YAML; an empty string means the key is absent on that side.
+type Conflict struct {
+	Section    string
+	Key        string
+	LocalYAML  string
+	RemoteYAML string
+}
+
+// HasLocal reports whether the key exists in the local files
+func (c Conflict) HasLocal() bool { return c.LocalYAML != "" }
+
+// HasRemote reports whether the key exists in the cloud definitions
+func (c Conflict) HasRemote() bool { return c.RemoteYAML != "" }
+
+// Resolution is the outcome chosen for a conflict
+type Resolution struct {
+	// YAML is the value to keep for the key; blank removes the key
+	YAML string
+}
+
+// AcceptLocal resolves a conflict with the local value
+func AcceptLocal(c Conflict) Resolution { return Resolution{YAML: c.LocalYAML} }
+
+// AcceptRemote resolves a conflict with the cloud value
+func AcceptRemote(c Conflict) Resolution { return Resolution{YAML: c.RemoteYAML} }
+
+// ConflictResolver decides how each conflicting key is merged during a pull
+type ConflictResolver func(c Conflict) (Resolution, error)
+
+// FindConflicts lists keys whose local and cloud values differ, including keys that only exist
+// locally (they would otherwise be dropped). Keys that only exist in the cloud are new and are
+// not conflicts.
+func FindConflicts(local, remote *config.BlimuConfig) ([]Conflict, error) {
+	resources, err := sectionConflicts(SectionResources, local.Resources, remote.Resources)
+	if err != nil {
+		return nil, err
+	}
+	entitlements, err := sectionConflicts(SectionEntitlements, local.Entitlements, remote.Entitlements)
+	if err != nil {
+		return nil, err
+	}
+	features, err := sectionConflicts(SectionFeatures, local.Features, remote.Features)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := sectionConflicts(SectionPlans, local.Plans, remote.Plans)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := append(resources, entitlements...)
+	conflicts = append(conflicts, features...)
+	return append(conflicts, plans...), nil
+}
+
+// ApplyResolution writes a resolved value into the merged config
+func ApplyResolution(merged *config.BlimuConfig, c Conflict, r Resolution) error {
+	switch c.Section {
+	case SectionResources:
+		return applyEntry(&merged.Resources, c, r)
+	case SectionEntitlements:
+		return applyEntry(&merged.Entitlements, c, r)
+	case SectionFeatures:
+		return applyEntry(&merged.Features, c, r)
+	case SectionPlans:
+		return applyEntry(&merged.Plans, c, r)
+	}
+	return fmt.Errorf("unknown section '%s'", c.Section)
+}
+
+func sectionConflicts[T any](section string, local, remote map[string]T) ([]Conflict, error) {
+	keys := make([]string, 0, len(local))
+	for key := range local {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var conflicts []Conflict
+	for _, key := range keys {
+		localValue := local[key]
+		remoteValue, inRemote := remote[key]
+		if inRemote && reflect.DeepEqual(normalize(localValue), normalize(remoteValue)) {
+			continue
+		}
+
+		conflict := Conflict{Section: section, Key: key}
+		var err error
+		if conflict.LocalYAML, err = renderEntry(localValue); err != nil {
+			return nil, err
+		}
+		if inRemote {
+			if conflict.RemoteYAML, err = renderEntry(remoteValue); err != nil {
+				return nil, err
+			}
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
+
+func applyEntry[T any](entries *map[string]T, c Conflict, r Resolution) error {
+	if *entries == nil {
+		*entries = make(map[string]T)
+	}
+	if strings.TrimSpace(r.YAML) == "" {
+		delete(*entries, c.Key)
+		return nil
+	}
+
+	var value T
+	if err := yaml.Unmarshal([]byte(r.YAML), &value); err != nil {
+		return fmt.Errorf("invalid value for %s.%s: %w", c.Section, c.Key, err)
+	}
+	(*entries)[c.Key] = value
+	return nil
+}
+
+// renderEntry renders a value as YAML. Empty values render as "{}" so presence stays visible.
+func renderEntry(value interface{}) (string, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to render definition: %w", err)
+	}
+	return string(data), nil
+}
+
+// normalize round-trips a value through YAML so nil and empty collections compare equal
+func normalize(value interface{}) interface{} {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var out interface{}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return value
+	}
+	return out
+}
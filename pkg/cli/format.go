@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	sdkconfig "github.com/blimu-dev/sdk-gen/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// FormatOptions configures formatting of the .blimu YAML files
+type FormatOptions struct {
+	Directory string
+	// Check reports files that are not formatted without rewriting them
+	Check bool
+}
+
+// FormatResult lists the files that were (or, with Check, would be) rewritten
+type FormatResult struct {
+	Changed []string
+}
+
+// formatRule describes the canonical layout of a mapping: the key order and which lists are sets
+// that can be sorted. Keys not in Keys keep their relative order after the known ones.
+type formatRule struct {
+	Keys   []string
+	Sorted []string
+	// Children applies rules to mapping values by key
+	Children map[string]*formatRule
+	// Items applies a rule to every value of the mapping, or every item of a sequence
+	Items *formatRule
+	// SortedItems sorts the list values of every key, as in roles_inheritance
+	SortedItems bool
+}
+
+// formatFiles maps each formatted .blimu file to the rule for its top-level mapping. Entries keep
+// the order they were written in (plans are usually listed by tier, resources parents first); the
+// fields of each entry follow the order of the config structs.
+var formatFiles = []struct {
+	Name string
+	Rule *formatRule
+}{
+	{"resources.yml", &formatRule{Items: &formatRule{
+		Keys: yamlKeys(reflect.TypeOf(config.ResourceConfig{})),
+		Children: map[string]*formatRule{
+			"roles_inheritance": {SortedItems: true},
+			"parents":           {Items: &formatRule{Keys: yamlKeys(reflect.TypeOf(config.ParentConfig{}))}},
+		},
+	}}},
+	{"entitlements.yml", &formatRule{Items: &formatRule{
+		Keys:   yamlKeys(reflect.TypeOf(config.EntitlementConfig{})),
+		Sorted: []string{"roles", "plans"},
+	}}},
+	{"features.yml", &formatRule{Items: &formatRule{
+		Keys:   yamlKeys(reflect.TypeOf(config.FeatureConfig{})),
+		Sorted: []string{"plans", "entitlements"},
+	}}},
+	{"plans.yml", &formatRule{Items: &formatRule{
+		Keys: yamlKeys(reflect.TypeOf(config.PlanConfig{})),
+	}}},
+	{"sdk.yml", &formatRule{
+		Keys: yamlKeys(reflect.TypeOf(sdkconfig.Config{})),
+		Children: map[string]*formatRule{
+			"clients": {Items: &formatRule{
+				Keys:   append(yamlKeys(reflect.TypeOf(sdkconfig.Client{})), cliClientKeys...),
+				Sorted: []string{"includeTags", "excludeTags", "exclude"},
+				Children: map[string]*formatRule{
+					"typeAugmentation": {
+						Keys:   yamlKeys(reflect.TypeOf(sdkconfig.TypeAugmentationOptions{})),
+						Sorted: []string{"typeNames"},
+					},
+					"publish": {Keys: yamlKeys(reflect.TypeOf(PublishConfig{}))},
+				},
+			}},
+		},
+	}},
+}
+
+// Format rewrites the .blimu YAML files of a directory in canonical form. Comments are kept;
+// files that are missing or empty are skipped, and files that fail to parse abort the run.
+func (r *Runner) Format(opts FormatOptions) (*FormatResult, error) {
+	blimuDir := filepath.Join(opts.Directory, ".blimu")
+	if _, err := os.Stat(blimuDir); err != nil {
+		return nil, fmt.Errorf("no .blimu directory found in %s", opts.Directory)
+	}
+
+	result := &FormatResult{}
+	for _, file := range formatFiles {
+		// The files of a split directory hold entries of the file they are merged into
+		names := []string{file.Name}
+		if slices.Contains(config.DefinitionFiles, file.Name) {
+			split, err := config.SplitFiles(blimuDir, file.Name)
+			if err != nil {
+				return result, err
+			}
+			names = append(names, split...)
+		}
+
+		for _, name := range names {
+			path := filepath.Join(blimuDir, filepath.FromSlash(name))
+			data, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return result, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+
+			formatted, err := formatYAML(data, file.Rule)
+			if err != nil {
+				return result, fmt.Errorf("failed to format %s: %w", name, err)
+			}
+			if bytes.Equal(formatted, data) {
+				continue
+			}
+
+			result.Changed = append(result.Changed, path)
+			if opts.Check {
+				continue
+			}
+			if err := os.WriteFile(path, formatted, 0644); err != nil {
+				return result, fmt.Errorf("failed to write %s: %w", name, err)
+			}
+			r.printf("✏️  Formatted %s\n", path)
+		}
+	}
+
+	return result, nil
+}
+
+// formatYAML re-emits a YAML document with two-space indentation, keys and lists ordered by rule,
+// and a blank line before every top-level entry that spans several lines
+func formatYAML(data []byte, rule *formatRule) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return data, nil
+	}
+
+	doc := root.Content[0]
+	applyFormatRule(doc, rule)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return separateTopLevelEntries(buf.Bytes(), doc), nil
+}
+
+// applyFormatRule orders a node's keys and lists in place
+func applyFormatRule(node *yaml.Node, rule *formatRule) {
+	if node == nil || rule == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			applyFormatRule(item, rule.Items)
+		}
+	case yaml.MappingNode:
+		orderMappingKeys(node, rule.Keys)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if rule.SortedItems || containsString(rule.Sorted, key) {
+				sortScalarSequence(value)
+			}
+			if child, ok := rule.Children[key]; ok {
+				applyFormatRule(value, child)
+			} else {
+				applyFormatRule(value, rule.Items)
+			}
+		}
+	}
+}
+
+// orderMappingKeys moves known keys to the front in the given order, keeping the rest as written
+func orderMappingKeys(node *yaml.Node, order []string) {
+	if len(order) == 0 {
+		return
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, key := range order {
+		rank[key] = i
+	}
+	position := func(key string) int {
+		if i, ok := rank[key]; ok {
+			return i
+		}
+		return len(order)
+	}
+
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(a, b int) bool {
+		return position(pairs[a][0].Value) < position(pairs[b][0].Value)
+	})
+
+	node.Content = node.Content[:0]
+	for _, pair := range pairs {
+		node.Content = append(node.Content, pair[0], pair[1])
+	}
+}
+
+// sortScalarSequence sorts a list of scalars; lists holding anything else are left alone
+func sortScalarSequence(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return
+		}
+	}
+	sort.SliceStable(node.Content, func(a, b int) bool {
+		return node.Content[a].Value < node.Content[b].Value
+	})
+}
+
+// separateTopLevelEntries inserts a blank line before each top-level key whose value is a block
+// mapping or sequence, above any comment lines that belong to it
+func separateTopLevelEntries(out []byte, doc *yaml.Node) []byte {
+	if doc.Kind != yaml.MappingNode {
+		return out
+	}
+
+	lines := strings.Split(string(out), "\n")
+	var formatted []string
+	entry := 0
+	for _, line := range lines {
+		// Lines starting at column 0 that are not comments are the document's top-level keys, in order
+		isKey := line != "" && line[0] != ' ' && line[0] != '#' && !strings.HasPrefix(line, "- ") && line != "---"
+		if isKey {
+			if entry > 0 && 2*entry+1 < len(doc.Content) && isBlock(doc.Content[2*entry+1]) {
+				insertAt := len(formatted)
+				for insertAt > 0 && strings.HasPrefix(formatted[insertAt-1], "#") {
+					insertAt--
+				}
+				if insertAt > 0 && formatted[insertAt-1] != "" {
+					formatted = append(formatted[:insertAt], append([]string{""}, formatted[insertAt:]...)...)
+				}
+			}
+			entry++
+		}
+		formatted = append(formatted, line)
+	}
+
+	return []byte(strings.Join(formatted, "\n"))
+}
+
+// isBlock reports whether a value renders over several lines
+func isBlock(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) &&
+		node.Style&yaml.FlowStyle == 0 && len(node.Content) > 0
+}
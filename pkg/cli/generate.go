@@ -0,0 +1,582 @@
+package cli
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/spec"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	sdkconfig "github.com/blimu-dev/sdk-gen/pkg/config"
+	"github.com/blimu-dev/sdk-gen/pkg/generator"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed sdk-baseconfig.yml
+var embeddedBaseConfig []byte
+
+// GenerateOptions configures SDK generation
+type GenerateOptions struct {
+	Directory     string
+	WorkspaceID   string
+	EnvironmentID string
+	// IfChanged skips generation when the spec and sdk.yml match the last cached run
+	IfChanged bool
+	// Commands controls which sdk.yml pre/post commands may run
+	Commands CommandPolicy
+	// Clean removes files generated by a previous run that were not generated again
+	Clean bool
+	// Diff generates into a temporary directory and reports file changes instead of writing outDir
+	Diff bool
+	// Bump updates each client's version before stamping it: patch, minor, major, or auto to use
+	// the bump recommended from the spec changes since the client was last generated
+	Bump string
+}
+
+// GeneratedClient describes a single generated SDK client
+type GeneratedClient struct {
+	Type        string
+	OutDir      string
+	PackageName string
+	Name        string
+}
+
+// GenerateResult summarizes an SDK generation run
+type GenerateResult struct {
+	Skipped bool
+	Clients []GeneratedClient
+	// Diffs is set for a --diff run, one per client, and nothing was written
+	Diffs []*OutputDiff
+}
+
+// Generate fetches the environment's OpenAPI spec and generates the SDKs declared in .blimu/sdk.yml
+func (r *Runner) Generate(ctx context.Context, opts GenerateOptions) (result *GenerateResult, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "blimu.generate")
+	span.SetAttribute("blimu.workspace_id", opts.WorkspaceID)
+	span.SetAttribute("blimu.environment_id", opts.EnvironmentID)
+	defer func() { span.End(err) }()
+
+	switch opts.Bump {
+	case "", BumpAuto, BumpPatch, BumpMinor, BumpMajor:
+	default:
+		return nil, fmt.Errorf("unknown version bump '%s' (expected %s, %s, %s or %s)", opts.Bump, BumpAuto, BumpPatch, BumpMinor, BumpMajor)
+	}
+
+	r.printf("🔧 Generating SDK from database definitions...\n")
+
+	// Generate OpenAPI spec from database (using GET endpoint)
+	response, err := r.api.GetOpenAPISpec(ctx, opts.WorkspaceID, opts.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+	}
+
+	if !response.Success {
+		r.printf("❌ OpenAPI spec generation failed with %d error(s):\n\n", len(response.Errors))
+
+		for i, errorData := range response.Errors {
+			r.printf("%d. %s\n", i+1, errorData.Message)
+			if errorData.Resource != "" {
+				r.printf("   Resource: %s\n", errorData.Resource)
+			}
+			if errorData.Field != "" {
+				r.printf("   Field: %s\n", errorData.Field)
+			}
+			r.printf("\n")
+		}
+
+		return nil, shared.Errorf(shared.ErrValidation, "OpenAPI spec generation failed")
+	}
+
+	// A malformed spec otherwise surfaces as an obscure sdk-gen failure
+	specReport := spec.Validate(response.Spec)
+	if specErrors := specReport.Errors(); len(specErrors) > 0 {
+		r.printf("❌ The OpenAPI spec has %d error(s):\n\n", len(specErrors))
+		for _, issue := range specErrors {
+			r.printf("  - %s: %s [%s]\n", issue.Location, issue.Message, issue.Rule)
+		}
+		return nil, shared.Errorf(shared.ErrValidation, "the OpenAPI spec is invalid; nothing was generated")
+	}
+	if warnings := specReport.Warnings(); len(warnings) > 0 {
+		r.printf("⚠️  The OpenAPI spec has %d lint warning(s); run 'blimu spec validate' for details\n", len(warnings))
+	}
+
+	// Create temporary OpenAPI spec file for sdk-gen
+	tempDir, err := os.MkdirTemp("", "blimu-openapi-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir) // Clean up temp directory
+
+	specFile := filepath.Join(tempDir, "openapi.json")
+	// Canonical ordering keeps generated SDKs and the spec cache hash byte-identical across runs
+	specJSON, err := json.MarshalIndent(spec.Canonicalize(response.Spec), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI spec: %w", err)
+	}
+
+	if err := os.WriteFile(specFile, specJSON, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write OpenAPI spec: %w", err)
+	}
+
+	r.printf("📄 Generated OpenAPI specification\n")
+
+	// Look for sdk.yml in the directory
+	sdkConfigPath := filepath.Join(opts.Directory, ".blimu", "sdk.yml")
+	logging.Debug("reading SDK config", "path", sdkConfigPath)
+	sdkConfigData, statErr := os.ReadFile(sdkConfigPath)
+	if statErr != nil {
+		r.printf("❌ SDK config not found: %v\n", statErr)
+		return nil, fmt.Errorf("no .blimu/sdk.yml found in %s", opts.Directory)
+	}
+
+	// Compare against the last generated spec for this environment
+	cacheEntry := &config.SpecCacheEntry{
+		WorkspaceID:   opts.WorkspaceID,
+		EnvironmentID: opts.EnvironmentID,
+		SpecHash:      config.HashBytes(specJSON),
+		ConfigHash:    config.HashBytes(sdkConfigData),
+	}
+	if opts.IfChanged {
+		cached, err := config.LoadSpecCache(opts.WorkspaceID, opts.EnvironmentID)
+		if err != nil {
+			r.printf("⚠️  Could not read spec cache: %v\n", err)
+		} else if cached != nil && cached.SpecHash == cacheEntry.SpecHash && cached.ConfigHash == cacheEntry.ConfigHash {
+			r.printf("✅ OpenAPI spec unchanged since %s, skipping generation\n", cached.GeneratedAt.Format(time.RFC3339))
+			span.SetAttribute("blimu.skipped", true)
+			return &GenerateResult{Skipped: true}, nil
+		}
+	}
+
+	// sdk.yml exists, use it for multi-language generation
+	r.printf("✅ Found SDK config, using multi-language generation\n")
+	_, genSpan := telemetry.StartSpan(ctx, "blimu.generate.sdk")
+	clients, diffs, err := r.generateWithConfigFile(specFile, sdkConfigPath, opts)
+	genSpan.SetAttribute("blimu.clients", len(clients))
+	genSpan.End(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SDK: %w", err)
+	}
+	if opts.Diff {
+		return &GenerateResult{Clients: clients, Diffs: diffs}, nil
+	}
+
+	// A version bump rewrote sdk.yml; cache the config as it is now
+	if data, err := os.ReadFile(sdkConfigPath); err == nil {
+		cacheEntry.ConfigHash = config.HashBytes(data)
+	}
+	cacheEntry.GeneratedAt = time.Now()
+	if err := config.SaveSpecCache(cacheEntry, specJSON); err != nil {
+		r.printf("⚠️  Could not update spec cache: %v\n", err)
+	}
+
+	return &GenerateResult{Clients: clients}, nil
+}
+
+// generateWithConfigFile generates SDKs for multiple languages using an existing config file with custom OpenAPI spec
+// With opts.Diff, clients are generated into a temporary directory and compared with their outDir.
+func (r *Runner) generateWithConfigFile(specFile, configPath string, opts GenerateOptions) ([]GeneratedClient, []*OutputDiff, error) {
+	r.printf("🔧 Loading SDK config from: %s\n", configPath)
+
+	// Read the config file content
+	configData, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read SDK config file: %w", err)
+	}
+
+	// Parse the YAML content
+	var configMap map[string]interface{}
+	if err := yaml.Unmarshal(configData, &configMap); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse SDK config: %w", err)
+	}
+
+	// Get the directory containing the original config file
+	configDir := filepath.Dir(configPath)
+	r.printf("📁 Config file directory: %s\n", configDir)
+
+	// Load base config from embedded file
+	baseConfig, err := loadBaseConfig()
+	if err != nil {
+		r.printf("⚠️  Warning: Could not load base config: %v\n", err)
+		r.printf("   Continuing without base config merge...\n")
+		baseConfig = make(map[string]interface{})
+	} else {
+		r.printf("✅ Loaded base config\n")
+	}
+
+	// Merge base config with client-specific configs
+	reactHooks := make(map[int]bool)
+	versions := make(map[int]string)
+	if clients, ok := configMap["clients"].([]interface{}); ok {
+		r.printf("📋 Found %d clients in config\n", len(clients))
+		for i, clientInterface := range clients {
+			if client, ok := clientInterface.(map[string]interface{}); ok {
+				clientType := ""
+				if t, ok := client["type"].(string); ok {
+					clientType = t
+				}
+
+				if clientType == "" {
+					return nil, nil, fmt.Errorf("clients[%d] missing required field 'type'", i)
+				}
+
+				// Find and merge base config for this client type
+				mergedClient := mergeClientConfig(baseConfig, clientType, client, configDir)
+				clients[i] = mergedClient
+
+				// reactHooks is handled by the CLI after sdk-gen has generated the TypeScript client
+				if enabled, ok := mergedClient["reactHooks"].(bool); ok && enabled {
+					if clientType != "typescript" {
+						return nil, nil, fmt.Errorf("clients[%d]: reactHooks is only supported for typescript clients", i)
+					}
+					reactHooks[i] = true
+				}
+
+				// version is stamped into the generated package manifest, which sdk-gen always writes as 0.1.0
+				if version, exists := mergedClient["version"]; exists {
+					versionStr := fmt.Sprint(version)
+					if !ValidVersion(versionStr) {
+						return nil, nil, fmt.Errorf("clients[%d]: version '%s' is not a semantic version", i, versionStr)
+					}
+					versions[i] = strings.TrimPrefix(versionStr, "v")
+				}
+
+				if outDir, exists := mergedClient["outDir"]; exists {
+					if outDirStr, ok := outDir.(string); ok {
+						r.printf("📁 %s client: %s\n", clientType, outDirStr)
+					}
+				}
+			}
+		}
+	}
+
+	// Add spec field if missing
+	if _, exists := configMap["spec"]; !exists {
+		configMap["spec"] = "placeholder"
+	}
+
+	// Marshal back to YAML
+	resolvedConfigData, err := yaml.Marshal(configMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal resolved config: %w", err)
+	}
+
+	// Create a temporary config file with resolved paths
+	tempDir, err := os.MkdirTemp("", "blimu-sdk-config-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tempConfigPath := filepath.Join(tempDir, "sdk.yml")
+	if err := os.WriteFile(tempConfigPath, resolvedConfigData, 0644); err != nil {
+		return nil, nil, fmt.Errorf("failed to write temp config: %w", err)
+	}
+
+	// Load the config with resolved paths
+	cfg, err := sdkconfig.Load(tempConfigPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load SDK config: %w", err)
+	}
+
+	// Replace the spec with our custom generated one
+	cfg.Spec = specFile
+
+	if err := r.applyCommandPolicy(cfg, baseConfig, opts.Commands); err != nil {
+		return nil, nil, err
+	}
+
+	// A diff run writes every client into its own temporary directory
+	outDirs := make([]string, len(cfg.Clients))
+	if opts.Diff {
+		diffDir, err := os.MkdirTemp("", "blimu-sdk-diff-*")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer os.RemoveAll(diffDir)
+		for i := range cfg.Clients {
+			outDirs[i] = cfg.Clients[i].OutDir
+			cfg.Clients[i].OutDir = filepath.Join(diffDir, fmt.Sprintf("%d-%s", i, cfg.Clients[i].Type))
+		}
+	}
+
+	// Run pre/post commands here rather than in sdk-gen so files they create (lockfiles,
+	// installed packages) are not mistaken for generated files, and so the post commands
+	// also format the React hooks
+	commands := takeClientCommands(cfg)
+	snapshots := make([]dirSnapshot, len(cfg.Clients))
+	for i, client := range cfg.Clients {
+		if err := os.MkdirAll(client.OutDir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create output directory for client %s: %w", client.Name, err)
+		}
+		if err := runClientCommand(client, "pre-command", commands[i].pre); err != nil {
+			return nil, nil, err
+		}
+		if snapshots[i], err = snapshotDir(client.OutDir); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	r.printf("🔧 Generating SDKs for %d language(s)...\n", len(cfg.Clients))
+
+	// Use sdk-gen service to generate from the modified config
+	service := generator.NewService()
+	err = service.GenerateFromConfig(cfg, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	specData, err := os.ReadFile(specFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	var specDoc map[string]interface{}
+	if err := json.Unmarshal(specData, &specDoc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	if len(reactHooks) > 0 {
+
+		for i, client := range cfg.Clients {
+			if !reactHooks[i] {
+				continue
+			}
+			hooksPath, err := writeReactHooks(specDoc, client)
+			if err != nil {
+				return nil, nil, err
+			}
+			r.printf("🪝 React Query hooks: %s\n", hooksPath)
+			r.printf("   Requires react and @tanstack/react-query (v5) in the consuming app\n")
+		}
+	}
+
+	// Recommend (and with opts.Bump apply) a version bump from the spec changes of each client
+	bumped := make(map[int]string)
+	for i, client := range cfg.Clients {
+		outDir := client.OutDir
+		bump := opts.Bump
+		if opts.Diff {
+			outDir, bump = outDirs[i], ""
+		}
+		next, err := r.suggestVersion(client.Type, outDir, firstNonEmpty(versions[i], defaultSDKVersion), specDoc, bump)
+		if err != nil {
+			return nil, nil, err
+		}
+		if next != "" {
+			versions[i], bumped[i] = next, next
+		}
+	}
+
+	var diffs []*OutputDiff
+	for i, client := range cfg.Clients {
+		if _, err := stampVersion(client.Type, client.OutDir, versions[i]); err != nil {
+			return nil, nil, err
+		}
+		generated, err := generatedFiles(client.OutDir, snapshots[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := runClientCommand(client, "post-command", commands[i].post); err != nil {
+			return nil, nil, err
+		}
+		if opts.Diff {
+			diff, err := r.diffOutput(client.Type, outDirs[i], client.OutDir, generated)
+			if err != nil {
+				return nil, nil, err
+			}
+			diffs = append(diffs, diff)
+			continue
+		}
+		if err := r.updateManifest(client, generated, opts.Clean); err != nil {
+			return nil, nil, err
+		}
+		if err := r.updateSpecSnapshot(client.OutDir, firstNonEmpty(versions[i], defaultSDKVersion), specDoc); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(bumped) > 0 && !opts.Diff {
+		if err := recordVersions(configPath, bumped); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if opts.Diff {
+		r.printf("🔍 Changes generation would make:\n")
+		for _, diff := range diffs {
+			r.printOutputDiff(diff)
+		}
+		return nil, diffs, nil
+	}
+
+	r.printf("✅ Multi-language SDKs generated successfully!\n")
+	generated := make([]GeneratedClient, 0, len(cfg.Clients))
+	for _, client := range cfg.Clients {
+		r.printf("  📁 %s: %s\n", client.Type, client.OutDir)
+		r.printf("  📦 Package: %s\n", client.PackageName)
+		r.printf("  🏗️  Client: %s\n", client.Name)
+		r.printf("\n")
+
+		generated = append(generated, GeneratedClient{
+			Type:        client.Type,
+			OutDir:      client.OutDir,
+			PackageName: client.PackageName,
+			Name:        client.Name,
+		})
+	}
+
+	return generated, nil, nil
+}
+
+// loadBaseConfig loads the base SDK configuration from the embedded sdk-baseconfig.yml file
+func loadBaseConfig() (map[string]interface{}, error) {
+	var baseConfig map[string]interface{}
+	if err := yaml.Unmarshal(embeddedBaseConfig, &baseConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded base config: %w", err)
+	}
+
+	return baseConfig, nil
+}
+
+// mergeClientConfig merges base configuration with client-specific configuration
+// The client-specific config takes precedence over base config
+func mergeClientConfig(baseConfig map[string]interface{}, clientType string, clientConfig map[string]interface{}, configDir string) map[string]interface{} {
+	// Start with a copy of the client config (user's config takes precedence)
+	merged := make(map[string]interface{})
+	for k, v := range clientConfig {
+		merged[k] = v
+	}
+
+	// Java module coordinates follow the user's package rather than the base config's package
+	if clientType == "java" {
+		if packageName, ok := clientConfig["packageName"].(string); ok && packageName != "" {
+			if _, exists := merged["moduleName"]; !exists {
+				merged["moduleName"] = javaModuleFromPackage(packageName)
+			}
+		}
+	}
+
+	// Find base config for this client type
+	// Try exact match (e.g., "typescript", "go", "python", "java", "csharp", "typescript-types")
+	var baseClientConfig map[string]interface{}
+	if base, ok := baseConfig[clientType]; ok {
+		if baseMap, ok := base.(map[string]interface{}); ok {
+			baseClientConfig = baseMap
+		}
+	}
+
+	// Merge base config into merged config (only if not already set in client config)
+	if baseClientConfig != nil {
+		for key, baseValue := range baseClientConfig {
+			// Special handling for typeAugmentation - always merge (deep merge)
+			if key == "typeAugmentation" {
+				if baseAug, ok := baseValue.(map[string]interface{}); ok {
+					if userAug, exists := merged[key]; exists {
+						// Both exist - merge them (user takes precedence)
+						if userAugMap, ok := userAug.(map[string]interface{}); ok {
+							mergedAug := make(map[string]interface{})
+							// Start with base values
+							for k, v := range baseAug {
+								mergedAug[k] = v
+							}
+							// Override with user values
+							for k, v := range userAugMap {
+								mergedAug[k] = v
+							}
+							merged[key] = mergedAug
+						} else {
+							merged[key] = baseAug
+						}
+					} else {
+						// Only base exists
+						merged[key] = baseAug
+					}
+				}
+				continue
+			}
+
+			// Skip if client config already has this key (user override)
+			if _, exists := merged[key]; exists {
+				continue
+			}
+
+			// Special handling for postCommand - convert array to array format
+			if key == "postCommand" {
+				if baseArray, ok := baseValue.([]interface{}); ok {
+					// Convert to string array format expected by sdk-gen
+					postCmdArray := make([]string, len(baseArray))
+					for i, item := range baseArray {
+						if str, ok := item.(string); ok {
+							postCmdArray[i] = str
+						}
+					}
+					merged[key] = postCmdArray
+				}
+			} else {
+				merged[key] = baseValue
+			}
+		}
+	}
+
+	// Handle outDir - required field, provide default if missing
+	if outDir, exists := merged["outDir"]; !exists || outDir == "" {
+		// Try to derive from typeAugmentation.outputFileName if available
+		if typeAug, ok := merged["typeAugmentation"]; ok {
+			if typeAugMap, ok := typeAug.(map[string]interface{}); ok {
+				if outputFileName, ok := typeAugMap["outputFileName"].(string); ok && outputFileName != "" {
+					// Use directory of outputFileName as outDir
+					outDirPath := filepath.Dir(outputFileName)
+					if outDirPath == "." || outDirPath == "" {
+						// Default to config directory
+						merged["outDir"] = configDir
+					} else {
+						// Resolve relative to config directory
+						if filepath.IsAbs(outDirPath) {
+							merged["outDir"] = outDirPath
+						} else {
+							merged["outDir"] = filepath.Join(configDir, outDirPath)
+						}
+					}
+				} else {
+					// Default to config directory
+					merged["outDir"] = configDir
+				}
+			} else {
+				// Default to config directory
+				merged["outDir"] = configDir
+			}
+		} else {
+			// Default to config directory
+			merged["outDir"] = configDir
+		}
+	} else {
+		// Resolve outDir path relative to config file location if it's relative
+		if outDirStr, ok := outDir.(string); ok {
+			if outDirStr == "" {
+				merged["outDir"] = configDir
+			} else if !filepath.IsAbs(outDirStr) {
+				resolvedPath := filepath.Join(configDir, outDirStr)
+				merged["outDir"] = resolvedPath
+			}
+		}
+	}
+
+	return merged
+}
+
+// javaModuleFromPackage derives Maven groupId:artifactId coordinates from a Java package name
+// (e.g. "com.acme.blimu" becomes "com.acme:blimu")
+func javaModuleFromPackage(packageName string) string {
+	idx := strings.LastIndex(packageName, ".")
+	if idx < 0 {
+		return packageName + ":" + packageName
+	}
+	return packageName[:idx] + ":" + strings.ReplaceAll(packageName[idx+1:], "_", "-")
+}
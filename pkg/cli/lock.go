@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+)
+
+var (
+	// ErrLocksUnsupported is returned by the lock operations of platforms without definitions locks
+	ErrLocksUnsupported = errors.New("the platform does not support definitions locks")
+	// ErrLockConflict is returned by the API when the definitions are locked by someone else
+	ErrLockConflict = errors.New("definitions are locked")
+)
+
+// DefinitionsLockedError is returned when a lock held by someone else prevents an operation
+type DefinitionsLockedError struct {
+	EnvironmentID string
+	Lock          *DefinitionsLock
+}
+
+// Error implements error
+func (e *DefinitionsLockedError) Error() string {
+	message := fmt.Sprintf("definitions of environment '%s' are locked by %s", e.EnvironmentID, e.Lock.LockedBy)
+	if !e.Lock.LockedAt.IsZero() {
+		message += " since " + formatLockTime(e.Lock.LockedAt)
+	}
+	if e.Lock.Reason != "" {
+		message += fmt.Sprintf(" (%s)", e.Lock.Reason)
+	}
+	return message + "; ask them to run 'blimu definitions unlock', or break the lock with 'blimu definitions unlock --force'"
+}
+
+// Unwrap classifies the error as a conflict
+func (e *DefinitionsLockedError) Unwrap() error {
+	return shared.ErrConflict
+}
+
+// formatLockTime shows the time of day for locks taken today and the date otherwise
+func formatLockTime(t time.Time) string {
+	t = t.Local()
+	if t.Format("2006-01-02") == time.Now().Format("2006-01-02") {
+		return t.Format("15:04")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// DefinitionsLockStatus returns the lock on an environment's definitions, or nil when unlocked,
+// and whether it was taken from this machine. A stale record of a released lock is cleaned up.
+func (r *Runner) DefinitionsLockStatus(ctx context.Context, workspaceID, environmentID string) (lock *DefinitionsLock, held bool, err error) {
+	lock, err = r.api.GetDefinitionsLock(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	heldLock, err := config.LoadHeldLock(workspaceID, environmentID)
+	if err != nil {
+		return nil, false, err
+	}
+	if heldLock == nil {
+		return lock, false, nil
+	}
+	if lock != nil && lock.ID == heldLock.LockID {
+		return lock, true, nil
+	}
+
+	if err := config.DeleteHeldLock(workspaceID, environmentID); err != nil {
+		r.printf("⚠️  %v\n", err)
+	}
+	return lock, false, nil
+}
+
+// LockDefinitions takes the advisory lock on an environment's definitions. Taking a lock already
+// held from this machine returns it unchanged.
+func (r *Runner) LockDefinitions(ctx context.Context, workspaceID, environmentID, reason string) (*DefinitionsLock, error) {
+	current, held, err := r.DefinitionsLockStatus(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		if held {
+			return current, nil
+		}
+		return nil, &DefinitionsLockedError{EnvironmentID: environmentID, Lock: current}
+	}
+
+	lock, err := r.api.LockDefinitions(ctx, workspaceID, environmentID, reason)
+	if errors.Is(err, ErrLockConflict) {
+		// Someone else locked in between; report who
+		if current, _, statusErr := r.DefinitionsLockStatus(ctx, workspaceID, environmentID); statusErr == nil && current != nil {
+			return nil, &DefinitionsLockedError{EnvironmentID: environmentID, Lock: current}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.SaveHeldLock(&config.HeldLock{
+		WorkspaceID:   workspaceID,
+		EnvironmentID: environmentID,
+		LockID:        lock.ID,
+		LockedAt:      lock.LockedAt,
+	}); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// UnlockDefinitions releases the lock on an environment's definitions and returns it, or nil when
+// the definitions were not locked. Locks taken elsewhere are only released with force.
+func (r *Runner) UnlockDefinitions(ctx context.Context, workspaceID, environmentID string, force bool) (*DefinitionsLock, error) {
+	current, held, err := r.DefinitionsLockStatus(ctx, workspaceID, environmentID)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	if !held && !force {
+		return nil, &DefinitionsLockedError{EnvironmentID: environmentID, Lock: current}
+	}
+
+	lockID := current.ID
+	if force {
+		lockID = ""
+	}
+	if err := r.api.UnlockDefinitions(ctx, workspaceID, environmentID, lockID); err != nil {
+		return nil, err
+	}
+
+	if err := config.DeleteHeldLock(workspaceID, environmentID); err != nil {
+		r.printf("⚠️  %v\n", err)
+	}
+	return current, nil
+}
+
+// CheckDefinitionsLock fails with a DefinitionsLockedError when someone else holds the lock on an
+// environment's definitions. Platforms without definitions locks never block.
+func (r *Runner) CheckDefinitionsLock(ctx context.Context, workspaceID, environmentID string) error {
+	lock, held, err := r.DefinitionsLockStatus(ctx, workspaceID, environmentID)
+	if errors.Is(err, ErrLocksUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check the definitions lock: %w", err)
+	}
+	if lock != nil && !held {
+		return &DefinitionsLockedError{EnvironmentID: environmentID, Lock: lock}
+	}
+	return nil
+}
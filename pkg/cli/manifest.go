@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	sdkconfig "github.com/blimu-dev/sdk-gen/pkg/config"
+)
+
+// manifestFileName is written to every client outDir and lists the files the last generation produced
+const manifestFileName = ".blimu-generated.json"
+
+// specSnapshotFileName keeps the OpenAPI spec a client was last generated from, for version recommendations
+const specSnapshotFileName = ".blimu-spec.json"
+
+// manifestIgnoredDirs are never recorded or cleaned; post commands (npm install, pip) fill them
+var manifestIgnoredDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"__pycache__":  true,
+	".venv":        true,
+}
+
+// generatedManifest maps slash-separated paths relative to outDir to the SHA-256 of their contents
+type generatedManifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// dirSnapshot records the modification time of every file under an outDir before generation
+type dirSnapshot map[string]time.Time
+
+// snapshotDir records the files currently under dir; a missing dir gives an empty snapshot
+func snapshotDir(dir string) (dirSnapshot, error) {
+	snapshot := make(dirSnapshot)
+	err := walkOutDir(dir, func(rel string, info fs.FileInfo) {
+		snapshot[rel] = info.ModTime()
+	})
+	return snapshot, err
+}
+
+// generatedFiles returns the files under outDir written since the snapshot was taken
+func generatedFiles(outDir string, before dirSnapshot) ([]string, error) {
+	var files []string
+	err := walkOutDir(outDir, func(rel string, info fs.FileInfo) {
+		if modTime, existed := before[rel]; !existed || !modTime.Equal(info.ModTime()) {
+			files = append(files, rel)
+		}
+	})
+	return files, err
+}
+
+// updateManifest records the generated files of a client. With clean set, files listed by the
+// previous manifest that were not generated again are deleted, unless they were edited since
+// (their hash changed) or are excluded in sdk.yml, in which case they are left to the user.
+func (r *Runner) updateManifest(client sdkconfig.Client, generated []string, clean bool) error {
+	outDir := client.OutDir
+
+	previous, err := r.readManifest(outDir)
+	if err != nil {
+		return err
+	}
+
+	current := &generatedManifest{Files: make(map[string]string)}
+	for _, rel := range generated {
+		if hash, err := hashFile(filepath.Join(outDir, filepath.FromSlash(rel))); err == nil {
+			current.Files[rel] = hash
+		}
+	}
+
+	var stale []string
+	for rel := range previous.Files {
+		if _, ok := current.Files[rel]; !ok {
+			stale = append(stale, rel)
+		}
+	}
+	sort.Strings(stale)
+
+	removed, pending := 0, 0
+	for _, rel := range stale {
+		path := filepath.Join(outDir, filepath.FromSlash(rel))
+		hash, err := hashFile(path)
+		if err != nil {
+			// Already gone
+			continue
+		}
+		if hash != previous.Files[rel] || client.ShouldExcludeFile(path) {
+			// Edited or excluded files belong to the user from now on
+			r.printf("  ✋ Keeping %s (modified or excluded since it was generated)\n", path)
+			continue
+		}
+		if !clean {
+			// Keep tracking it so a later --clean run can remove it
+			current.Files[rel] = hash
+			pending++
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove stale file %s: %w", path, err)
+		}
+		removeEmptyParents(filepath.Dir(path), outDir)
+		r.printf("  🗑️  Removed stale %s\n", path)
+		removed++
+	}
+
+	if pending > 0 {
+		r.printf("  💡 %s: %d file(s) from a previous run were not regenerated; run with --clean to remove them\n", outDir, pending)
+	}
+	if removed > 0 {
+		r.printf("  🧹 %s: removed %d stale file(s)\n", outDir, removed)
+	}
+
+	return writeManifest(outDir, current)
+}
+
+func writeManifest(outDir string, manifest *generatedManifest) error {
+	manifestPath := filepath.Join(outDir, manifestFileName)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render manifest: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+	return nil
+}
+
+// refreshManifest re-records the hash of a generated file the CLI changed after generation
+// (such as a stamped package version), so it is not mistaken for a local edit
+func (r *Runner) refreshManifest(outDir, rel string) error {
+	manifest, err := r.readManifest(outDir)
+	if err != nil {
+		return err
+	}
+	if _, ok := manifest.Files[rel]; !ok {
+		return nil
+	}
+	hash, err := hashFile(filepath.Join(outDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	manifest.Files[rel] = hash
+	return writeManifest(outDir, manifest)
+}
+
+// readManifest loads the manifest of an outDir; a missing or unreadable manifest is empty
+func (r *Runner) readManifest(outDir string) (*generatedManifest, error) {
+	manifestPath := filepath.Join(outDir, manifestFileName)
+	manifest := &generatedManifest{Files: make(map[string]string)}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", manifestPath, err)
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		r.printf("⚠️  Ignoring unreadable %s: %v\n", manifestPath, err)
+		return &generatedManifest{Files: make(map[string]string)}, nil
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]string)
+	}
+	return manifest, nil
+}
+
+// walkOutDir calls fn for every regular file under dir except the manifest and ignored directories
+func walkOutDir(dir string, fn func(rel string, info fs.FileInfo)) error {
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && manifestIgnoredDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || (isBookkeepingFile(entry.Name()) && filepath.Dir(path) == dir) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		fn(filepath.ToSlash(rel), info)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return nil
+}
+
+// isBookkeepingFile reports whether name is a file the CLI keeps in the root of an outDir
+func isBookkeepingFile(name string) bool {
+	return name == manifestFileName || name == specSnapshotFileName
+}
+
+// removeEmptyParents removes now-empty directories from dir up to, but not including, root
+func removeEmptyParents(dir, root string) {
+	for dir != root && len(dir) > len(root) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return config.HashBytes(data), nil
+}
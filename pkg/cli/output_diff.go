@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// File change statuses reported by generate --diff
+const (
+	FileAdded     = "added"
+	FileModified  = "modified"
+	FileUnchanged = "unchanged"
+	FileStale     = "stale"
+)
+
+// FileChange is the effect generation would have on one file of an output directory
+type FileChange struct {
+	Path   string
+	Status string
+	// LocalEdits is set when the existing file differs from what was last generated
+	// (or was never generated), so overwriting or removing it would lose hand edits
+	LocalEdits bool
+	Added      int
+	Removed    int
+}
+
+// OutputDiff compares freshly generated output with a client's existing output directory
+type OutputDiff struct {
+	Type    string
+	OutDir  string
+	Changes []FileChange
+}
+
+// Changed returns the changes that would modify the output directory
+func (d *OutputDiff) Changed() []FileChange {
+	var changed []FileChange
+	for _, change := range d.Changes {
+		if change.Status != FileUnchanged {
+			changed = append(changed, change)
+		}
+	}
+	return changed
+}
+
+// diffOutput compares the generated files in tempDir with outDir, using outDir's manifest to find
+// stale files and files edited since they were generated
+func (r *Runner) diffOutput(clientType, outDir, tempDir string, generated []string) (*OutputDiff, error) {
+	manifest, err := r.readManifest(outDir)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &OutputDiff{Type: clientType, OutDir: outDir}
+	isGenerated := make(map[string]bool, len(generated))
+
+	for _, rel := range generated {
+		isGenerated[rel] = true
+		next, err := os.ReadFile(filepath.Join(tempDir, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+
+		change := FileChange{Path: rel}
+		existing, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(rel)))
+		switch {
+		case os.IsNotExist(err):
+			change.Status = FileAdded
+			change.Added = countLines(string(next))
+		case err != nil:
+			return nil, err
+		case string(existing) == string(next):
+			change.Status = FileUnchanged
+		default:
+			change.Status = FileModified
+			change.Added, change.Removed = lineChanges(string(existing), string(next))
+			// Without a manifest (output from before manifests existed) edits can't be told apart
+			hash, ok := manifest.Files[rel]
+			change.LocalEdits = len(manifest.Files) > 0 && (!ok || config.HashBytes(existing) != hash)
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	for rel, hash := range manifest.Files {
+		if isGenerated[rel] {
+			continue
+		}
+		existing, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+		diff.Changes = append(diff.Changes, FileChange{
+			Path:       rel,
+			Status:     FileStale,
+			LocalEdits: config.HashBytes(existing) != hash,
+			Removed:    countLines(string(existing)),
+		})
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Path < diff.Changes[j].Path
+	})
+	return diff, nil
+}
+
+// printOutputDiff prints a file-level summary of an output diff
+func (r *Runner) printOutputDiff(diff *OutputDiff) {
+	changed := diff.Changed()
+	r.printf("\n📂 %s: %s\n", diff.Type, diff.OutDir)
+	if len(changed) == 0 {
+		r.printf("  ✅ No changes (%d file(s) up to date)\n", len(diff.Changes))
+		return
+	}
+
+	collisions := 0
+	for _, change := range changed {
+		var line string
+		switch change.Status {
+		case FileAdded:
+			line = "  ➕ " + change.Path + " " + lineSummary(change)
+		case FileModified:
+			line = "  ✏️  " + change.Path + " " + lineSummary(change)
+		case FileStale:
+			line = "  🗑️  " + change.Path + " (no longer generated; removed by --clean)"
+			if change.LocalEdits {
+				line = "  📌 " + change.Path + " (no longer generated; kept because it was edited)"
+			}
+		}
+		if change.LocalEdits && change.Status == FileModified {
+			line += "  ⚠️  overwrites local edits"
+			collisions++
+		}
+		r.printf("%s\n", line)
+	}
+
+	r.printf("  %d changed, %d unchanged\n", len(changed), len(diff.Changes)-len(changed))
+	if collisions > 0 {
+		r.printf("  ⚠️  %d file(s) were edited by hand and would be overwritten; exclude them in sdk.yml to keep your changes\n", collisions)
+	}
+}
+
+func lineSummary(change FileChange) string {
+	return fmt.Sprintf("(+%d -%d)", change.Added, change.Removed)
+}
+
+// lineChanges counts added and removed lines, treating each file as a multiset of lines
+func lineChanges(before, after string) (added, removed int) {
+	counts := make(map[string]int)
+	for _, line := range splitLines(before) {
+		counts[line]++
+	}
+	for _, line := range splitLines(after) {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			added++
+		}
+	}
+	for _, remaining := range counts {
+		removed += remaining
+	}
+	return added, removed
+}
+
+func countLines(content string) int {
+	return len(splitLines(content))
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// FindOverlay returns the .blimu/overlays/<key> directory of the environment known by any of the
+// given keys (local name, lookup key or ID), or "" when it has none. Matching overlays under more
+// than one key are rejected, since the order they would apply in is not obvious.
+func FindOverlay(directory string, environmentKeys ...string) (string, error) {
+	var found, foundKey string
+	for _, key := range environmentKeys {
+		if key == "" || key == foundKey {
+			continue
+		}
+		overlayDir := filepath.Join(directory, ".blimu", "overlays", key)
+		info, err := os.Stat(overlayDir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		if found != "" {
+			return "", fmt.Errorf("environment has overlays under both '%s' and '%s'; keep only one", foundKey, key)
+		}
+		found, foundKey = overlayDir, key
+	}
+	return found, nil
+}
+
+// applyOverlay merges the definition files of an overlay directory over definitions, following
+// JSON Merge Patch (RFC 7386): mappings merge key by key, other values replace the base value and
+// null removes the key. It returns sections with any section only the overlay defines appended.
+func (r *Runner) applyOverlay(definitions *Definitions, sections []string, overlayDir string, values config.Values) ([]string, error) {
+	loaded := map[string]bool{}
+	for _, name := range sections {
+		loaded[name] = true
+	}
+
+	for _, section := range []struct {
+		name   string
+		target *map[string]interface{}
+	}{
+		{"resources", &definitions.Resources},
+		{"entitlements", &definitions.Entitlements},
+		{"features", &definitions.Features},
+		{"plans", &definitions.Plans},
+	} {
+		fileName := section.name + ".yml"
+		patch, err := loadDefinitionFile(filepath.Join(overlayDir, fileName), section.name, values)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load overlay %s: %w", fileName, err)
+		}
+
+		*section.target = mergePatch(*section.target, patch)
+		if !loaded[section.name] && len(*section.target) > 0 {
+			loaded[section.name] = true
+			sections = append(sections, section.name)
+		}
+		r.printf("🧩 Applied overlay %s\n", filepath.Join(filepath.Base(overlayDir), fileName))
+	}
+
+	return sections, nil
+}
+
+// mergePatch applies patch to target as a JSON Merge Patch and returns the result
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	if target == nil {
+		target = map[string]interface{}{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchMap, isMap := value.(map[string]interface{})
+		if !isMap {
+			target[key] = value
+			continue
+		}
+		targetMap, _ := target[key].(map[string]interface{})
+		target[key] = mergePatch(targetMap, patchMap)
+	}
+	return target
+}
+
+// loadDefinitionsFor loads the directory's definitions with the environment's overlay applied.
+// A values.yml in the overlay overrides the base values for ${VAR} references.
+func (r *Runner) loadDefinitionsFor(directory string, environmentKeys []string) (*Definitions, []string, error) {
+	overlayDir, err := FindOverlay(directory, environmentKeys...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := config.LoadValues(filepath.Join(directory, ".blimu"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if overlayDir != "" {
+		overlayValues, err := config.LoadValues(overlayDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load overlay values: %w", err)
+		}
+		maps.Copy(values, overlayValues)
+	}
+
+	definitions, sections, err := r.loadDefinitions(directory, values)
+	if err != nil || overlayDir == "" {
+		return definitions, sections, err
+	}
+
+	sections, err = r.applyOverlay(definitions, sections, overlayDir, values)
+	if err != nil {
+		return nil, nil, err
+	}
+	return definitions, sections, nil
+}
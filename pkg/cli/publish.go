@@ -0,0 +1,357 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PublishConfig is the per-client publish section of sdk.yml
+type PublishConfig struct {
+	// Registry overrides the package registry (npm --registry, twine --repository-url)
+	Registry string `yaml:"registry,omitempty"`
+	// Tag is the npm dist-tag to publish under (npm defaults to latest)
+	Tag string `yaml:"tag,omitempty"`
+	// Access is the npm access level of scoped packages: public or restricted
+	Access string `yaml:"access,omitempty"`
+	// TagPrefix prefixes Go module tags; by default it is the outDir's path inside its git repository,
+	// which is what the Go toolchain expects for modules in subdirectories
+	TagPrefix *string `yaml:"tagPrefix,omitempty"`
+	// Remote is the git remote Go module tags are pushed to (default origin)
+	Remote string `yaml:"remote,omitempty"`
+	// Push controls whether Go module tags are pushed (default true)
+	Push *bool `yaml:"push,omitempty"`
+	// Command replaces the built-in publish steps; {version} is replaced by the version being published
+	Command []string `yaml:"command,omitempty"`
+}
+
+// PublishOptions configures publishing of generated SDKs
+type PublishOptions struct {
+	// Directory contains the .blimu directory with sdk.yml
+	Directory string
+	// Types limits publishing to these client types; empty publishes every client
+	Types []string
+	// Bump increments each client's version (patch, minor or major) before publishing
+	Bump string
+	// Version publishes every selected client at this exact version
+	Version string
+	// DryRun prints the publish steps without changing sdk.yml, the generated files or running them
+	DryRun bool
+	// Commands decides whether custom publish commands from sdk.yml may run
+	Commands CommandPolicy
+	// Confirm is shown the planned releases before anything is changed; nil proceeds
+	Confirm func(releases []PublishRelease) (bool, error)
+}
+
+// PublishRelease is one client about to be published
+type PublishRelease struct {
+	Type            string
+	OutDir          string
+	PreviousVersion string
+	Version         string
+	Publish         PublishConfig
+
+	node *yaml.Node
+}
+
+// PublishResult lists the clients that were published
+type PublishResult struct {
+	Released []PublishRelease
+}
+
+// Publish releases the generated SDKs listed in sdk.yml. Each client's version is read from its
+// 'version' key (sdk-gen's 0.1.0 when unset), optionally bumped and written back to sdk.yml so
+// that later generations keep it, stamped into the generated package manifest and published.
+func (r *Runner) Publish(opts PublishOptions) (*PublishResult, error) {
+	configPath := filepath.Join(opts.Directory, ".blimu", "sdk.yml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no .blimu/sdk.yml found in %s (run 'blimu sdk init' to create one)", opts.Directory)
+		}
+		return nil, fmt.Errorf("failed to read SDK config file: %w", err)
+	}
+	if opts.Version != "" && !ValidVersion(opts.Version) {
+		return nil, fmt.Errorf("'%s' is not a semantic version (expected MAJOR.MINOR.PATCH)", opts.Version)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse SDK config: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty", configPath)
+	}
+	clientsNode := mappingValue(root.Content[0], "clients")
+	if clientsNode == nil || clientsNode.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s defines no clients", configPath)
+	}
+
+	releases, err := r.planReleases(clientsNode, filepath.Dir(configPath), opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no clients of type %s in %s", strings.Join(opts.Types, ", "), configPath)
+	}
+
+	if opts.Confirm != nil {
+		confirmed, err := opts.Confirm(releases)
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &PublishResult{}, nil
+		}
+	}
+
+	result := &PublishResult{}
+	var publishErr error
+	for _, release := range releases {
+		r.printf("\n🚀 Publishing %s %s from %s\n", release.Type, release.Version, release.OutDir)
+		if err := r.publishRelease(release, opts); err != nil {
+			publishErr = fmt.Errorf("failed to publish %s client: %w", release.Type, err)
+			break
+		}
+		if release.Version != release.PreviousVersion && !opts.DryRun {
+			setMappingValue(release.node, "version", release.Version)
+		}
+		result.Released = append(result.Released, release)
+	}
+
+	// Record the versions that made it out, even when a later client failed
+	if !opts.DryRun && len(result.Released) > 0 {
+		if err := writeYAMLDocument(configPath, &root); err != nil {
+			return result, err
+		}
+	}
+	return result, publishErr
+}
+
+// planReleases resolves the selected clients, their output directories and next versions
+func (r *Runner) planReleases(clientsNode *yaml.Node, configDir string, opts PublishOptions) ([]PublishRelease, error) {
+	baseConfig, err := loadBaseConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []PublishRelease
+	for i, clientNode := range clientsNode.Content {
+		var client map[string]interface{}
+		if err := clientNode.Decode(&client); err != nil {
+			return nil, fmt.Errorf("clients[%d]: invalid client: %w", i, err)
+		}
+		clientType, _ := client["type"].(string)
+		if clientType == "" {
+			return nil, fmt.Errorf("clients[%d] missing required field 'type'", i)
+		}
+		if len(opts.Types) > 0 && !containsString(opts.Types, clientType) {
+			continue
+		}
+
+		merged := mergeClientConfig(baseConfig, clientType, client, configDir)
+		outDir, _ := merged["outDir"].(string)
+		if outDir == "" {
+			return nil, fmt.Errorf("clients[%d] has no outDir", i)
+		}
+		if _, err := os.Stat(filepath.Join(outDir, manifestFileName)); err != nil {
+			return nil, fmt.Errorf("%s client has not been generated in %s (run 'blimu generate' first)", clientType, outDir)
+		}
+
+		release := PublishRelease{Type: clientType, OutDir: outDir, node: clientNode}
+		if publishNode := mappingValue(clientNode, "publish"); publishNode != nil {
+			if err := publishNode.Decode(&release.Publish); err != nil {
+				return nil, fmt.Errorf("clients[%d].publish: %w", i, err)
+			}
+		}
+
+		release.PreviousVersion = defaultSDKVersion
+		if versionNode := mappingValue(clientNode, "version"); versionNode != nil {
+			release.PreviousVersion = versionNode.Value
+		}
+		switch {
+		case opts.Version != "":
+			release.Version = strings.TrimPrefix(opts.Version, "v")
+		case opts.Bump != "":
+			if release.Version, err = BumpVersion(release.PreviousVersion, opts.Bump); err != nil {
+				return nil, fmt.Errorf("clients[%d].version: %w", i, err)
+			}
+		default:
+			release.Version = release.PreviousVersion
+		}
+
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+// publishRelease stamps the version into the generated files and runs the client's publish steps
+func (r *Runner) publishRelease(release PublishRelease, opts PublishOptions) error {
+	if !opts.DryRun {
+		stamped, err := stampVersion(release.Type, release.OutDir, release.Version)
+		if err != nil {
+			return err
+		}
+		if stamped {
+			if err := r.refreshManifest(release.OutDir, versionFiles[release.Type].file); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(release.Publish.Command) > 0 {
+		argv := make([]string, len(release.Publish.Command))
+		for i, arg := range release.Publish.Command {
+			argv[i] = strings.ReplaceAll(arg, "{version}", release.Version)
+		}
+		allowed, err := allowCommand(opts.Commands, release.Type, "publish", argv)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("untrusted publish command: %s (add it to trusted_commands in the CLI config to allow it)", strings.Join(argv, " "))
+		}
+		return r.runPublishStep(release.OutDir, opts.DryRun, argv...)
+	}
+
+	switch release.Type {
+	case "typescript", "typescript-types":
+		return r.publishNPM(release, opts.DryRun)
+	case "python":
+		return r.publishPyPI(release, opts.DryRun)
+	case "go":
+		return r.publishGoModule(release, opts.DryRun)
+	}
+	return fmt.Errorf("publishing %s clients is not supported; set publish.command in sdk.yml", release.Type)
+}
+
+func (r *Runner) publishNPM(release PublishRelease, dryRun bool) error {
+	argv := []string{"npm", "publish"}
+	if release.Publish.Registry != "" {
+		argv = append(argv, "--registry", release.Publish.Registry)
+	}
+	if release.Publish.Tag != "" {
+		argv = append(argv, "--tag", release.Publish.Tag)
+	}
+	if release.Publish.Access != "" {
+		argv = append(argv, "--access", release.Publish.Access)
+	}
+	return r.runPublishStep(release.OutDir, dryRun, argv...)
+}
+
+func (r *Runner) publishPyPI(release PublishRelease, dryRun bool) error {
+	upload := []string{"python3", "-m", "twine", "upload", "--non-interactive"}
+	if release.Publish.Registry != "" {
+		upload = append(upload, "--repository-url", release.Publish.Registry)
+	}
+	if dryRun {
+		r.printf("  $ python3 -m build --outdir <tmp>\n")
+		return r.runPublishStep(release.OutDir, true, append(upload, "<tmp>/*")...)
+	}
+
+	distDir, err := os.MkdirTemp("", "blimu-sdk-dist-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(distDir)
+
+	if err := r.runPublishStep(release.OutDir, false, "python3", "-m", "build", "--outdir", distDir); err != nil {
+		return err
+	}
+	dists, err := filepath.Glob(filepath.Join(distDir, "*"))
+	if err != nil || len(dists) == 0 {
+		return fmt.Errorf("python -m build produced no distributions")
+	}
+	return r.runPublishStep(release.OutDir, false, append(upload, dists...)...)
+}
+
+// publishGoModule tags the commit holding the generated module; the module proxy picks the
+// version up from the tag
+func (r *Runner) publishGoModule(release PublishRelease, dryRun bool) error {
+	if release.Publish.TagPrefix == nil {
+		prefix, err := gitOutput(release.OutDir, "rev-parse", "--show-prefix")
+		if err != nil {
+			return fmt.Errorf("%s is not inside a git repository: %w", release.OutDir, err)
+		}
+		release.Publish.TagPrefix = &prefix
+	}
+	tag := *release.Publish.TagPrefix + "v" + release.Version
+
+	if status, err := gitOutput(release.OutDir, "status", "--porcelain", "--", "."); err != nil {
+		return err
+	} else if status != "" && !dryRun {
+		return fmt.Errorf("%s has uncommitted changes; commit the generated module before tagging %s", release.OutDir, tag)
+	}
+	if major := strings.SplitN(release.Version, ".", 2)[0]; major != "0" && major != "1" {
+		r.printf("⚠️  Go modules v2+ need a /v%s suffix in their module path\n", major)
+	}
+
+	if err := r.runPublishStep(release.OutDir, dryRun, "git", "tag", "-a", tag, "-m", "Release "+tag); err != nil {
+		return err
+	}
+	if release.Publish.Push != nil && !*release.Publish.Push {
+		r.printf("💡 Push the tag with: git push %s %s\n", firstNonEmpty(release.Publish.Remote, "origin"), tag)
+		return nil
+	}
+	return r.runPublishStep(release.OutDir, dryRun, "git", "push", firstNonEmpty(release.Publish.Remote, "origin"), tag)
+}
+
+// runPublishStep runs a command in dir, or only prints it when dryRun is set
+func (r *Runner) runPublishStep(dir string, dryRun bool, argv ...string) error {
+	r.printf("  $ %s\n", strings.Join(argv, " "))
+	if dryRun {
+		return nil
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", strings.Join(argv, " "), err)
+	}
+	return nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// setMappingValue sets key to a string value in a mapping node, appending the key when missing
+func setMappingValue(node *yaml.Node, key, value string) {
+	if existing := mappingValue(node, key); existing != nil {
+		existing.Kind, existing.Tag, existing.Value = yaml.ScalarNode, "!!str", value
+		return
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
+
+// writeYAMLDocument writes a parsed document back, keeping its comments
+func writeYAMLDocument(path string, root *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// PullOptions configures a pull of cloud definitions into local files
+type PullOptions struct {
+	Directory     string
+	WorkspaceID   string
+	EnvironmentID string
+	// EnvironmentKeys are the names the environment is known by (local name, lookup key), used to
+	// warn when it has an overlay whose changes the pulled definitions already contain
+	EnvironmentKeys []string
+	// Resolver is consulted for every key whose local value differs from the cloud value.
+	// When nil the cloud definitions overwrite the local files.
+	Resolver ConflictResolver
+}
+
+// Pull fetches the environment's definitions and saves them to the directory's .blimu files
+func (r *Runner) Pull(ctx context.Context, opts PullOptions) (*config.BlimuConfig, error) {
+	r.printf("📥 Pulling definitions from cloud...\n")
+
+	blimuConfig, err := r.FetchConfig(ctx, opts.WorkspaceID, opts.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Resolver != nil {
+		if err := r.resolveConflicts(opts.Directory, blimuConfig, opts.Resolver); err != nil {
+			return nil, err
+		}
+	}
+
+	overlayDir, err := FindOverlay(opts.Directory, append([]string{opts.EnvironmentID}, opts.EnvironmentKeys...)...)
+	if err != nil {
+		return nil, err
+	}
+	if overlayDir != "" {
+		r.printf("⚠️  The pulled definitions include the changes of %s; review the base files before pushing to other environments\n", overlayDir)
+	}
+	if config.UsesComposition(filepath.Join(opts.Directory, ".blimu")) {
+		r.printf("⚠️  The pulled resources.yml lists every resource in full; move them back into their included files and templates before pushing\n")
+	}
+	if templated := config.TemplatedFiles(filepath.Join(opts.Directory, ".blimu")); len(templated) > 0 {
+		r.printf("⚠️  The pulled definitions replace the ${VAR} references in %s with their values; restore the references before pushing to other environments\n", strings.Join(templated, ", "))
+	}
+
+	// Save to local files
+	if err := config.SaveBlimuConfig(opts.Directory, blimuConfig); err != nil {
+		return nil, fmt.Errorf("failed to save definitions to local files: %w", err)
+	}
+
+	// Record what the environment holds for push --changed-only. Files merged with local changes
+	// no longer match the cloud, so the snapshot is dropped and the next push sends everything.
+	if opts.Resolver == nil {
+		err = r.recordPulledSnapshot(opts.Directory, opts.WorkspaceID, opts.EnvironmentID)
+	} else {
+		err = config.DeleteDefinitionsSnapshot(opts.WorkspaceID, opts.EnvironmentID)
+	}
+	if err != nil {
+		r.printf("⚠️  Failed to update the definitions snapshot: %v\n", err)
+	}
+
+	return blimuConfig, nil
+}
+
+// resolveConflicts merges local definitions that differ from the cloud into merged, asking the
+// resolver for each conflicting key
+func (r *Runner) resolveConflicts(dir string, merged *config.BlimuConfig, resolver ConflictResolver) error {
+	if !config.HasResources(filepath.Join(dir, ".blimu")) {
+		// Nothing local to merge with
+		return nil
+	}
+
+	local, err := config.LoadBlimuConfig(dir)
+	if err != nil {
+		return fmt.Errorf("failed to load local definitions: %w", err)
+	}
+
+	conflicts, err := FindConflicts(local, merged)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	r.printf("⚠️  %d definition(s) differ between local files and the cloud\n", len(conflicts))
+	for _, conflict := range conflicts {
+		resolution, err := resolver(conflict)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s.%s: %w", conflict.Section, conflict.Key, err)
+		}
+		if err := ApplyResolution(merged, conflict, resolution); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FetchConfig fetches the environment's definitions and converts them to a BlimuConfig
+func (r *Runner) FetchConfig(ctx context.Context, workspaceID, environmentID string) (*config.BlimuConfig, error) {
+	definitions, err := r.api.GetDefinitions(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull definitions: %w", err)
+	}
+
+	return DefinitionsToConfig(definitions), nil
+}
+
+// DefinitionsToConfig converts platform definitions into a BlimuConfig
+func DefinitionsToConfig(definitions *Definitions) *config.BlimuConfig {
+	return &config.BlimuConfig{
+		Resources:    convertToResourceConfig(definitions.Resources),
+		Entitlements: convertToEntitlementConfig(definitions.Entitlements),
+		Features:     convertToFeatureConfig(definitions.Features),
+		Plans:        convertToPlanConfig(definitions.Plans),
+	}
+}
+
+// convertToResourceConfig converts map[string]interface{} to ResourceConfig map
+func convertToResourceConfig(data map[string]interface{}) map[string]config.ResourceConfig {
+	result := make(map[string]config.ResourceConfig)
+	for k, v := range data {
+		if vMap, ok := v.(map[string]interface{}); ok {
+			resourceConfig := config.ResourceConfig{}
+			if roles, ok := vMap["roles"].([]interface{}); ok {
+				resourceConfig.Roles = toStringSlice(roles)
+			}
+			if rolesInheritance, ok := vMap["roles_inheritance"].(map[string]interface{}); ok {
+				resourceConfig.RolesInheritance = make(map[string][]string)
+				for role, inheritances := range rolesInheritance {
+					if inheritancesArr, ok := inheritances.([]interface{}); ok {
+						resourceConfig.RolesInheritance[role] = toStringSlice(inheritancesArr)
+					}
+				}
+			}
+			if parents, ok := vMap["parents"].(map[string]interface{}); ok {
+				resourceConfig.Parents = make(map[string]config.ParentConfig)
+				for parentName, parentData := range parents {
+					if parentMap, ok := parentData.(map[string]interface{}); ok {
+						resourceConfig.Parents[parentName] = config.ParentConfig{
+							Required: getBool(parentMap, "required"),
+						}
+					}
+				}
+			}
+			result[k] = resourceConfig
+		}
+	}
+	return result
+}
+
+// convertToEntitlementConfig converts map[string]interface{} to EntitlementConfig map
+func convertToEntitlementConfig(data map[string]interface{}) map[string]config.EntitlementConfig {
+	result := make(map[string]config.EntitlementConfig)
+	for k, v := range data {
+		if vMap, ok := v.(map[string]interface{}); ok {
+			entitlementConfig := config.EntitlementConfig{}
+			if roles, ok := vMap["roles"].([]interface{}); ok {
+				entitlementConfig.Roles = toStringSlice(roles)
+			}
+			if plans, ok := vMap["plans"].([]interface{}); ok {
+				entitlementConfig.Plans = toStringSlice(plans)
+			}
+			result[k] = entitlementConfig
+		}
+	}
+	return result
+}
+
+// convertToFeatureConfig converts map[string]interface{} to FeatureConfig map
+func convertToFeatureConfig(data map[string]interface{}) map[string]config.FeatureConfig {
+	result := make(map[string]config.FeatureConfig)
+	for k, v := range data {
+		if vMap, ok := v.(map[string]interface{}); ok {
+			featureConfig := config.FeatureConfig{}
+			if plans, ok := vMap["plans"].([]interface{}); ok {
+				featureConfig.Plans = toStringSlice(plans)
+			}
+			if defaultEnabled, ok := vMap["default_enabled"].(bool); ok {
+				featureConfig.DefaultEnabled = defaultEnabled
+			}
+			if entitlements, ok := vMap["entitlements"].([]interface{}); ok {
+				featureConfig.Entitlements = toStringSlice(entitlements)
+			}
+			result[k] = featureConfig
+		}
+	}
+	return result
+}
+
+// convertToPlanConfig converts map[string]interface{} to PlanConfig map
+func convertToPlanConfig(data map[string]interface{}) map[string]config.PlanConfig {
+	result := make(map[string]config.PlanConfig)
+	for k, v := range data {
+		if vMap, ok := v.(map[string]interface{}); ok {
+			planConfig := config.PlanConfig{}
+			if name, ok := vMap["name"].(string); ok {
+				planConfig.Name = name
+			}
+			if description, ok := vMap["description"].(string); ok {
+				planConfig.Description = description
+			} else if summary, ok := vMap["summary"].(string); ok {
+				planConfig.Description = summary
+			}
+			result[k] = planConfig
+		}
+	}
+	return result
+}
+
+// toStringSlice converts a []interface{} of strings to a []string
+func toStringSlice(items []interface{}) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		if str, ok := item.(string); ok {
+			result[i] = str
+		}
+	}
+	return result
+}
+
+// getBool safely extracts a boolean value from a map[string]interface{}
+func getBool(data map[string]interface{}, key string) bool {
+	if val, ok := data[key]; ok {
+		if boolVal, ok := val.(bool); ok {
+			return boolVal
+		}
+	}
+	return false
+}
@@ -0,0 +1,339 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/metrics"
+	"github.com/blimu-dev/blimu-cli/pkg/shared"
+	"github.com/blimu-dev/blimu-cli/pkg/spec"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	"gopkg.in/yaml.v3"
+)
+
+// PushOptions configures a push of local definition files
+type PushOptions struct {
+	Directory     string
+	WorkspaceID   string
+	EnvironmentID string
+	// FailOnBreaking aborts the push when the local definitions would introduce breaking API changes
+	FailOnBreaking bool
+	// EnvironmentKeys are the names the target environment is known by (local name, ID, lookup key),
+	// matched against the branch mapping in .blimu/project.yml and the .blimu/overlays directories
+	EnvironmentKeys []string
+	// SkipBranchGuard pushes even when the current git branch is not mapped to the environment
+	SkipBranchGuard bool
+	// ChangedOnly sends only the sections that changed since the environment's last pull or push.
+	// Without a snapshot of the last sync every section is sent.
+	ChangedOnly bool
+}
+
+// PushResult summarizes a completed push
+type PushResult struct {
+	WorkspaceID   string
+	EnvironmentID string
+	// Sections are the sections sent to the API
+	Sections []string
+	// Unchanged are the sections left out by ChangedOnly because they match the last sync
+	Unchanged []string
+}
+
+// Push loads the .blimu definition files from a directory and updates the environment's definitions.
+// Only files that exist and are non-empty are pushed; other sections are preserved by the API.
+// The environment's overlay in .blimu/overlays, if any, is merged over the files first.
+func (r *Runner) Push(ctx context.Context, opts PushOptions) (result *PushResult, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "blimu.push")
+	span.SetAttribute("blimu.workspace_id", opts.WorkspaceID)
+	span.SetAttribute("blimu.environment_id", opts.EnvironmentID)
+	defer func() {
+		span.End(err)
+		if err != nil {
+			metrics.Pushes.Inc("failure")
+			metrics.Errors.Inc("push")
+		} else {
+			metrics.Pushes.Inc("success")
+		}
+	}()
+
+	keys := append([]string{opts.EnvironmentID}, opts.EnvironmentKeys...)
+	if !opts.SkipBranchGuard {
+		if err := r.enforceBranchGuard(opts.Directory, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.CheckDefinitionsLock(ctx, opts.WorkspaceID, opts.EnvironmentID); err != nil {
+		return nil, err
+	}
+
+	definitions, sections, err := r.loadDefinitionsFor(opts.Directory, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.FailOnBreaking {
+		diff, err := r.CheckBreakingChanges(ctx, opts.WorkspaceID, opts.EnvironmentID, definitions)
+		if err != nil {
+			return nil, err
+		}
+		if breaking := diff.Breaking(); len(breaking) > 0 {
+			r.printBreakingReport(diff)
+			return nil, shared.Errorf(shared.ErrValidation, "push aborted: %d breaking change(s) detected", len(breaking))
+		}
+		r.printf("✅ No breaking changes detected\n")
+	}
+
+	hashes, err := hashSections(definitions)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := config.LoadDefinitionsSnapshot(opts.WorkspaceID, opts.EnvironmentID)
+	if err != nil {
+		r.printf("⚠️  Ignoring the definitions snapshot: %v\n", err)
+		snapshot = nil
+	}
+
+	result = &PushResult{
+		WorkspaceID:   opts.WorkspaceID,
+		EnvironmentID: opts.EnvironmentID,
+		Sections:      sections,
+	}
+
+	if opts.ChangedOnly {
+		if snapshot == nil {
+			r.printf("ℹ️  No snapshot of the last pull or push for this environment; pushing all sections\n")
+		} else {
+			result.Sections, result.Unchanged = dropUnchangedSections(definitions, hashes, snapshot)
+			for _, name := range result.Unchanged {
+				r.printf("⏭️  Skipping %s (unchanged since %s)\n", name, snapshot.SyncedAt.Local().Format("2006-01-02 15:04"))
+			}
+			if len(result.Sections) == 0 {
+				r.printf("✅ No sections changed since the last sync; nothing to push\n")
+				return result, nil
+			}
+		}
+	}
+
+	r.printf("📤 Pushing definitions to cloud...\n")
+
+	// Update definitions in the cloud (partial update - only provided fields will be updated)
+	if err := r.api.UpdateDefinitions(ctx, opts.WorkspaceID, opts.EnvironmentID, definitions); err != nil {
+		return nil, fmt.Errorf("failed to push definitions: %w", err)
+	}
+
+	if err := saveSnapshot(opts.WorkspaceID, opts.EnvironmentID, snapshot, hashes); err != nil {
+		r.printf("⚠️  Failed to update the definitions snapshot: %v\n", err)
+	}
+
+	return result, nil
+}
+
+// CheckBreakingChanges compares the spec generated from local definitions against the
+// environment's deployed spec. Sections missing locally are taken from the deployed definitions,
+// mirroring how a push preserves them.
+func (r *Runner) CheckBreakingChanges(ctx context.Context, workspaceID, environmentID string, definitions *Definitions) (*spec.Diff, error) {
+	r.printf("🔍 Checking for breaking changes...\n")
+
+	deployed, err := r.api.GetOpenAPISpec(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployed OpenAPI spec: %w", err)
+	}
+	if !deployed.Success {
+		return nil, fmt.Errorf("deployed definitions have errors; cannot compare specs")
+	}
+
+	current, err := r.api.GetDefinitions(ctx, workspaceID, environmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployed definitions: %w", err)
+	}
+
+	merged := &Definitions{
+		Resources:    definitions.Resources,
+		Entitlements: definitions.Entitlements,
+		Features:     definitions.Features,
+		Plans:        definitions.Plans,
+	}
+	if len(merged.Entitlements) == 0 {
+		merged.Entitlements = current.Entitlements
+	}
+	if len(merged.Features) == 0 {
+		merged.Features = current.Features
+	}
+	if len(merged.Plans) == 0 {
+		merged.Plans = current.Plans
+	}
+
+	local, err := r.api.ValidateDefinitions(ctx, workspaceID, environmentID, merged, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate spec from local definitions: %w", err)
+	}
+	if !local.Valid {
+		for _, issue := range local.Errors {
+			r.printf("  ❌ %s.%s: %s\n", issue.Resource, issue.Field, issue.Message)
+		}
+		return nil, shared.Errorf(shared.ErrValidation, "local definitions are invalid")
+	}
+
+	return spec.Compare(deployed.Spec, local.Spec), nil
+}
+
+// printBreakingReport prints the breaking changes of a diff grouped by category
+func (r *Runner) printBreakingReport(diff *spec.Diff) {
+	r.printf("❌ Breaking changes detected:\n")
+	for _, group := range diff.BreakingByCategory() {
+		r.printf("\n  %s (%d):\n", group.Category, len(group.Changes))
+		for _, change := range group.Changes {
+			if change.Detail != "" {
+				r.printf("    - %s (%s)\n", change.Location, change.Detail)
+			} else {
+				r.printf("    - %s\n", change.Location)
+			}
+		}
+	}
+	r.printf("\n")
+}
+
+// LoadDefinitions loads the definition files (only those that exist and are non-empty)
+// from a directory's .blimu folder and returns them along with the loaded section names
+func (r *Runner) LoadDefinitions(directory string) (*Definitions, []string, error) {
+	values, err := config.LoadValues(filepath.Join(directory, ".blimu"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.loadDefinitions(directory, values)
+}
+
+// loadDefinitions loads the definition files with their ${VAR} references substituted from values
+func (r *Runner) loadDefinitions(directory string, values config.Values) (*Definitions, []string, error) {
+	blimuDir := filepath.Join(directory, ".blimu")
+	definitions := &Definitions{
+		Resources:    make(map[string]interface{}),
+		Entitlements: make(map[string]interface{}),
+		Features:     make(map[string]interface{}),
+		Plans:        make(map[string]interface{}),
+	}
+
+	// Load resources.yml (required) with its includes and templates resolved
+	loaded, err := loadResources(blimuDir, values)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load resources.yml: %w", err)
+	}
+	if len(loaded) == 0 {
+		return nil, nil, fmt.Errorf("resources.yml is required and cannot be empty")
+	}
+	definitions.Resources = loaded
+	sections := []string{"resources"}
+	r.printf("✅ Loaded %s\n", sectionFiles(blimuDir, "resources.yml"))
+
+	// Load optional sections
+	optional := []struct {
+		name   string
+		target *map[string]interface{}
+	}{
+		{"entitlements", &definitions.Entitlements},
+		{"features", &definitions.Features},
+		{"plans", &definitions.Plans},
+	}
+
+	for _, section := range optional {
+		fileName := section.name + ".yml"
+		loaded, err := loadSection(blimuDir, section.name, values)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return nil, nil, fmt.Errorf("failed to load %s: %w", fileName, err)
+			}
+			r.printf("⏭️  Skipping %s (file not found)\n", fileName)
+			continue
+		}
+		if len(loaded) > 0 {
+			*section.target = loaded
+			sections = append(sections, section.name)
+			r.printf("✅ Loaded %s\n", sectionFiles(blimuDir, fileName))
+		}
+	}
+
+	return definitions, sections, nil
+}
+
+// loadResources composes resources.yml and converts it to a map, unwrapping a "resources" root key
+// as loadDefinitionFile does
+func loadResources(blimuDir string, values config.Values) (map[string]interface{}, error) {
+	composed, err := config.ComposeResources(blimuDir, values)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSection(composed, "resources")
+}
+
+// loadSection loads the definition file of a section merged with the files of its split
+// directory, e.g. entitlements.yml and entitlements.d/*.yml
+func loadSection(blimuDir, section string, values config.Values) (map[string]interface{}, error) {
+	composed, err := config.ComposeDefinition(blimuDir, section+".yml", values)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSection(composed, section)
+}
+
+// sectionFiles describes the files a definition file was loaded from, for progress output
+func sectionFiles(blimuDir, fileName string) string {
+	files, _ := config.SplitFiles(blimuDir, fileName)
+	if len(files) == 0 {
+		return fileName
+	}
+	if _, err := os.Stat(filepath.Join(blimuDir, fileName)); err != nil {
+		return fmt.Sprintf("%d file(s) in %s", len(files), config.SplitDir(fileName))
+	}
+	return fmt.Sprintf("%s and %d file(s) in %s", fileName, len(files), config.SplitDir(fileName))
+}
+
+// decodeSection converts a composed definition file to a map, unwrapping a root key named after
+// the section
+func decodeSection(composed *config.ComposedDefinition, section string) (map[string]interface{}, error) {
+	if composed.Node == nil {
+		return nil, nil
+	}
+
+	var items map[string]interface{}
+	if err := composed.Node.Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", section, err)
+	}
+	if root, ok := items[section].(map[string]interface{}); ok {
+		return root, nil
+	}
+	return items, nil
+}
+
+// loadDefinitionFile loads a YAML definition file, substitutes its ${VAR} references and parses it into a map
+func loadDefinitionFile(filePath, fileType string, values config.Values) (map[string]interface{}, error) {
+	data, err := config.ReadDefinitionFile(filePath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if file is empty or only whitespace
+	content := strings.TrimSpace(string(data))
+	if content == "" {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	// Parse YAML
+	var yamlData map[string]interface{}
+	if err := yaml.Unmarshal(data, &yamlData); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileType, err)
+	}
+
+	// Extract the root key (e.g., "resources", "entitlements", etc.)
+	if rootValue, ok := yamlData[fileType]; ok {
+		if rootMap, ok := rootValue.(map[string]interface{}); ok {
+			return rootMap, nil
+		}
+	}
+
+	// If no root key, use the entire config
+	return yamlData, nil
+}
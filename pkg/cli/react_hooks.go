@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	sdkconfig "github.com/blimu-dev/sdk-gen/pkg/config"
+	"github.com/blimu-dev/sdk-gen/pkg/utils"
+)
+
+// reactHooksFileName is the file written next to the generated TypeScript sources
+const reactHooksFileName = "hooks.ts"
+
+// reactHookOperation is an SDK method exposed as a React Query hook
+type reactHookOperation struct {
+	HookName string
+	// Accessor is the property path from the client to the method (e.g. ["resources", "list"])
+	Accessor []string
+	Method   string
+	Path     string
+	Summary  string
+}
+
+// writeReactHooks emits React Query hooks for every operation of a generated TypeScript client.
+// Service and method names are resolved the same way sdk-gen names them so the hooks call the
+// generated methods directly; argument and result types are inferred from the client class.
+func writeReactHooks(spec map[string]interface{}, client sdkconfig.Client) (string, error) {
+	operations, err := buildReactHookOperations(spec, client)
+	if err != nil {
+		return "", err
+	}
+
+	srcDir := filepath.Join(client.OutDir, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	hooksPath := filepath.Join(srcDir, reactHooksFileName)
+	if err := os.WriteFile(hooksPath, []byte(renderReactHooks(client.Name, operations)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write React hooks: %w", err)
+	}
+
+	return hooksPath, nil
+}
+
+// buildReactHookOperations collects the operations of a spec in sdk-gen's service order
+func buildReactHookOperations(spec map[string]interface{}, client sdkconfig.Client) ([]reactHookOperation, error) {
+	include, err := compilePatterns(client.IncludeTags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid includeTags: %w", err)
+	}
+	exclude, err := compilePatterns(client.ExcludeTags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excludeTags: %w", err)
+	}
+
+	paths, _ := spec["paths"].(map[string]interface{})
+	var operations []reactHookOperation
+	seen := make(map[string]bool)
+
+	// Walk paths in order so the first of any duplicate hook names is the same on every run
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	for _, path := range pathNames {
+		itemMap, ok := paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+			op, ok := itemMap[strings.ToLower(method)].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			tag := selectTag(op, include, exclude)
+			if tag == "" {
+				continue
+			}
+
+			operationID, _ := op["operationId"].(string)
+			methodName := resolveSDKMethodName(client, operationID, method, path)
+
+			accessor := []string{}
+			for _, part := range strings.Split(tag, ".") {
+				accessor = append(accessor, utils.ToCamelCase(part))
+			}
+			accessor = append(accessor, methodName)
+
+			hookName := "use"
+			for _, part := range accessor {
+				hookName += utils.ToPascalCase(part)
+			}
+			if method == "GET" {
+				hookName += "Query"
+			} else {
+				hookName += "Mutation"
+			}
+
+			// sdk-gen would emit duplicate methods as well; keep the first so hooks.ts compiles
+			if seen[hookName] {
+				continue
+			}
+			seen[hookName] = true
+
+			summary, _ := op["summary"].(string)
+			operations = append(operations, reactHookOperation{
+				HookName: hookName,
+				Accessor: accessor,
+				Method:   method,
+				Path:     path,
+				Summary:  summary,
+			})
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].HookName < operations[j].HookName
+	})
+
+	return operations, nil
+}
+
+// selectTag picks the operation's service tag like sdk-gen: the first allowed tag, or "misc" when untagged
+func selectTag(op map[string]interface{}, include, exclude []*regexp.Regexp) string {
+	tags, _ := op["tags"].([]interface{})
+	if len(tags) == 0 {
+		tags = []interface{}{"misc"}
+	}
+
+	for _, t := range tags {
+		tag, ok := t.(string)
+		if !ok {
+			continue
+		}
+		if len(include) > 0 && !matchesAny(tag, include) {
+			continue
+		}
+		if matchesAny(tag, exclude) {
+			continue
+		}
+		return tag
+	}
+	return ""
+}
+
+// resolveSDKMethodName mirrors sdk-gen's TypeScript method naming: the operationIdParser when
+// configured, then the operationId (without any "...Controller_" prefix), then REST heuristics
+func resolveSDKMethodName(client sdkconfig.Client, operationID, method, path string) string {
+	if client.OperationIDParser != "" {
+		out, err := exec.Command(client.OperationIDParser, operationID, method, path).CombinedOutput()
+		if err == nil {
+			if name := strings.TrimSpace(string(out)); name != "" {
+				return utils.ToCamelCase(name)
+			}
+		}
+	}
+
+	if operationID != "" {
+		if idx := strings.Index(operationID, "Controller_"); idx >= 0 {
+			operationID = operationID[idx+len("Controller_"):]
+		}
+		return utils.ToCamelCase(operationID)
+	}
+
+	hasID := strings.Contains(path, "{") && strings.Contains(path, "}")
+	switch method {
+	case "GET":
+		if hasID {
+			return "retrieve"
+		}
+		return "list"
+	case "POST":
+		return "create"
+	case "PUT", "PATCH":
+		return "update"
+	default:
+		return "delete"
+	}
+}
+
+// renderReactHooks renders hooks.ts for a client class
+func renderReactHooks(clientName string, operations []reactHookOperation) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// Code generated by blimu generate (reactHooks). DO NOT EDIT.\n")
+	fmt.Fprintf(&b, "import { createContext, createElement, useContext, type ReactNode } from \"react\";\n")
+	fmt.Fprintf(&b, "import {\n  useMutation,\n  useQuery,\n  type UseMutationOptions,\n  type UseQueryOptions,\n} from \"@tanstack/react-query\";\n")
+	fmt.Fprintf(&b, "import { %s } from \"./index\";\n\n", clientName)
+
+	fmt.Fprintf(&b, "type QueryOptions<T> = Omit<UseQueryOptions<T>, \"queryKey\" | \"queryFn\">;\n")
+	fmt.Fprintf(&b, "type MutationOptions<T, V> = Omit<UseMutationOptions<T, unknown, V>, \"mutationFn\">;\n\n")
+
+	fmt.Fprintf(&b, "const %sClientContext = createContext<%s | null>(null);\n\n", clientName, clientName)
+	fmt.Fprintf(&b, "export function %sClientProvider({ client, children }: { client: %s; children: ReactNode }) {\n", clientName, clientName)
+	fmt.Fprintf(&b, "  return createElement(%sClientContext.Provider, { value: client }, children);\n}\n\n", clientName)
+	fmt.Fprintf(&b, "export function use%sClient(): %s {\n", clientName, clientName)
+	fmt.Fprintf(&b, "  const client = useContext(%sClientContext);\n", clientName)
+	fmt.Fprintf(&b, "  if (!client) {\n    throw new Error(\"use%sClient must be used within a %sClientProvider\");\n  }\n", clientName, clientName)
+	fmt.Fprintf(&b, "  return client;\n}\n")
+
+	for _, op := range operations {
+		methodType := clientName
+		for _, part := range op.Accessor {
+			methodType += fmt.Sprintf("[%q]", part)
+		}
+		call := "client." + strings.Join(op.Accessor, ".")
+
+		fmt.Fprintf(&b, "\n/**\n * %s %s\n", op.Method, op.Path)
+		if op.Summary != "" {
+			fmt.Fprintf(&b, " * @summary %s\n", strings.ReplaceAll(op.Summary, "*/", "*\\/"))
+		}
+		fmt.Fprintf(&b, " */\n")
+
+		if op.Method == "GET" {
+			keyParts := make([]string, len(op.Accessor))
+			for i, part := range op.Accessor {
+				keyParts[i] = fmt.Sprintf("%q", part)
+			}
+			fmt.Fprintf(&b, "export function %s(\n", op.HookName)
+			fmt.Fprintf(&b, "  args: Parameters<%s>,\n", methodType)
+			fmt.Fprintf(&b, "  options?: QueryOptions<Awaited<ReturnType<%s>>>,\n) {\n", methodType)
+			fmt.Fprintf(&b, "  const client = use%sClient();\n", clientName)
+			fmt.Fprintf(&b, "  return useQuery({\n")
+			fmt.Fprintf(&b, "    queryKey: [%s, ...args],\n", strings.Join(keyParts, ", "))
+			fmt.Fprintf(&b, "    queryFn: () => %s(...args),\n", call)
+			fmt.Fprintf(&b, "    ...options,\n  });\n}\n")
+		} else {
+			fmt.Fprintf(&b, "export function %s(\n", op.HookName)
+			fmt.Fprintf(&b, "  options?: MutationOptions<Awaited<ReturnType<%s>>, Parameters<%s>>,\n) {\n", methodType, methodType)
+			fmt.Fprintf(&b, "  const client = use%sClient();\n", clientName)
+			fmt.Fprintf(&b, "  return useMutation({\n")
+			fmt.Fprintf(&b, "    mutationFn: (args: Parameters<%s>) => %s(...args),\n", methodType, call)
+			fmt.Fprintf(&b, "    ...options,\n  });\n}\n")
+		}
+	}
+
+	return b.String()
+}
+
+// compilePatterns compiles tag filter regular expressions
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// matchesAny reports whether value matches any of the patterns
+func matchesAny(value string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,404 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/blimu"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	sdkconfig "github.com/blimu-dev/sdk-gen/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity levels of sdk.yml issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// clientIndexPattern matches client references in validator messages
+var clientIndexPattern = regexp.MustCompile(`clients\[\d+\]`)
+
+// cliClientKeys are client keys handled by the CLI rather than sdk-gen
+var cliClientKeys = []string{"reactHooks", "version", "publish"}
+
+// SDKConfigIssue is a problem found in an sdk.yml file
+type SDKConfigIssue struct {
+	Severity string
+	Field    string
+	// Line is the 1-based line in the file, or 0 when unknown
+	Line    int
+	Message string
+}
+
+// SDKConfigReport lists the issues found in an sdk.yml file
+type SDKConfigReport struct {
+	Path   string
+	Issues []SDKConfigIssue
+}
+
+// Errors returns the number of error-level issues
+func (r *SDKConfigReport) Errors() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *SDKConfigReport) add(severity, field string, line int, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, SDKConfigIssue{
+		Severity: severity,
+		Field:    field,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// SDKClientTypes returns the client types with an entry in the embedded base config
+func SDKClientTypes() ([]string, error) {
+	baseConfig, err := loadBaseConfig()
+	if err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(baseConfig))
+	for clientType := range baseConfig {
+		types = append(types, clientType)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// SDKGenModule is the module of the SDK generator compiled into the CLI
+const SDKGenModule = "github.com/blimu-dev/sdk-gen"
+
+// BaseConfigVersion identifies the embedded base config by the first 12 hex digits of its
+// SHA-256, e.g. "sha256:3f2a9c1b7d04": it has no version of its own, but changes with every release
+// that changes it
+func BaseConfigVersion() string {
+	sum := sha256.Sum256(embeddedBaseConfig)
+	return "sha256:" + hex.EncodeToString(sum[:])[:12]
+}
+
+// ValidateSDKConfigFile checks an sdk.yml file against the sdk-gen config schema and the embedded
+// base config, the same way 'blimu generate' resolves it, without generating anything
+func ValidateSDKConfigFile(path string) (*SDKConfigReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SDK config file: %w", err)
+	}
+
+	report := &SDKConfigReport{Path: path}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		report.add(SeverityError, "", 0, "invalid YAML: %v", err)
+		return report, nil
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		report.add(SeverityError, "", 0, "sdk.yml must be a mapping with a 'clients' list")
+		return report, nil
+	}
+	doc := root.Content[0]
+
+	baseConfig, err := loadBaseConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	checkUnknownKeys(report, "", doc, yamlKeys(reflect.TypeOf(sdkconfig.Config{})))
+	if specNode := mappingValue(doc, "spec"); specNode != nil {
+		report.add(SeverityWarning, "spec", specNode.Line, "spec is ignored; 'blimu generate' always uses the environment's generated spec")
+	}
+
+	clientsNode := mappingValue(doc, "clients")
+	if clientsNode == nil || clientsNode.Kind != yaml.SequenceNode || len(clientsNode.Content) == 0 {
+		line := doc.Line
+		if clientsNode != nil {
+			line = clientsNode.Line
+		}
+		report.add(SeverityError, "clients", line, "at least one client must be defined")
+		return report, nil
+	}
+
+	clientKeys := append(yamlKeys(reflect.TypeOf(sdkconfig.Client{})), cliClientKeys...)
+	augmentationKeys := yamlKeys(reflect.TypeOf(sdkconfig.TypeAugmentationOptions{}))
+	configDir := filepath.Dir(path)
+
+	// resolved holds the merged clients that passed the structural checks; origins maps them back
+	// to their index in the file
+	var resolved []config.SDKClient
+	var origins []int
+	for i, clientNode := range clientsNode.Content {
+		field := fmt.Sprintf("clients[%d]", i)
+		if clientNode.Kind != yaml.MappingNode {
+			report.add(SeverityError, field, clientNode.Line, "client must be a mapping")
+			continue
+		}
+
+		checkUnknownKeys(report, field, clientNode, clientKeys)
+		if augNode := mappingValue(clientNode, "typeAugmentation"); augNode != nil && augNode.Kind == yaml.MappingNode {
+			checkUnknownKeys(report, field+".typeAugmentation", augNode, augmentationKeys)
+		}
+		checkPublishConfig(report, field, clientNode)
+
+		var client map[string]interface{}
+		if err := clientNode.Decode(&client); err != nil {
+			report.add(SeverityError, field, clientNode.Line, "invalid client: %v", err)
+			continue
+		}
+
+		clientType, _ := client["type"].(string)
+		if clientType == "" {
+			report.add(SeverityError, field+".type", clientNode.Line, "client type is required")
+			continue
+		}
+		if _, ok := baseConfig[clientType]; !ok {
+			types, _ := SDKClientTypes()
+			report.add(SeverityError, field+".type", lineOf(clientNode, "type"),
+				"unsupported client type '%s'. Supported types: %s", clientType, strings.Join(types, ", "))
+			continue
+		}
+
+		checkBaseConflicts(report, field, clientNode, clientType, client, baseConfig)
+
+		merged := mergeClientConfig(baseConfig, clientType, client, configDir)
+
+		// Decode the merged client the way sdk-gen will, catching wrongly typed values
+		mergedData, err := yaml.Marshal(merged)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render merged client: %w", err)
+		}
+		var sdkClient sdkconfig.Client
+		if err := yaml.Unmarshal(mergedData, &sdkClient); err != nil {
+			report.add(SeverityError, field, clientNode.Line, "invalid value: %v", err)
+			continue
+		}
+		reactHooks, _ := merged["reactHooks"].(bool)
+
+		tagFilters := []struct {
+			key      string
+			patterns []string
+		}{
+			{"includeTags", sdkClient.IncludeTags},
+			{"excludeTags", sdkClient.ExcludeTags},
+		}
+		for _, filter := range tagFilters {
+			for _, pattern := range filter.patterns {
+				if _, err := regexp.Compile(pattern); err != nil {
+					report.add(SeverityError, field+"."+filter.key, lineOf(clientNode, filter.key), "invalid pattern '%s': %v", pattern, err)
+				}
+			}
+		}
+
+		resolved = append(resolved, config.SDKClient{
+			Type:              sdkClient.Type,
+			OutDir:            filepath.Clean(sdkClient.OutDir),
+			PackageName:       sdkClient.PackageName,
+			ModuleName:        sdkClient.ModuleName,
+			Name:              sdkClient.Name,
+			IncludeTags:       sdkClient.IncludeTags,
+			ExcludeTags:       sdkClient.ExcludeTags,
+			OperationIDParser: sdkClient.OperationIDParser,
+			ReactHooks:        reactHooks,
+		})
+		origins = append(origins, i)
+	}
+
+	// Field-level checks shared with the .blimu config validator
+	result := blimu.ValidateConfig(&config.BlimuConfig{SDKConfig: &config.SDKConfig{Clients: resolved}})
+	for _, validationErr := range result.Errors {
+		field, line := validationErr.Field, 0
+		var index int
+		if _, err := fmt.Sscanf(field, "clients[%d]", &index); err == nil && index < len(origins) {
+			original := origins[index]
+			field = fmt.Sprintf("clients[%d]", original) + field[strings.Index(field, "]")+1:]
+			line = clientsNode.Content[original].Line
+		}
+		// The validator reports duplicates by resolved index too
+		message := clientIndexPattern.ReplaceAllStringFunc(validationErr.Message, func(match string) string {
+			var index int
+			fmt.Sscanf(match, "clients[%d]", &index)
+			if index < len(origins) {
+				return fmt.Sprintf("clients[%d]", origins[index])
+			}
+			return match
+		})
+		report.add(SeverityError, field, line, "%s", message)
+	}
+
+	checkNestedOutDirs(report, resolved, origins, clientsNode)
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Line < report.Issues[j].Line
+	})
+
+	return report, nil
+}
+
+// checkBaseConflicts warns when a client keeps values that only make sense for Blimu's own SDKs
+func checkBaseConflicts(report *SDKConfigReport, field string, node *yaml.Node, clientType string, client, baseConfig map[string]interface{}) {
+	base, _ := baseConfig[clientType].(map[string]interface{})
+	for _, key := range []string{"packageName", "moduleName"} {
+		baseValue, _ := base[key].(string)
+		if baseValue == "" {
+			continue
+		}
+		value, set := client[key].(string)
+		if !set {
+			if key == "packageName" && clientType != "typescript-types" {
+				report.add(SeverityWarning, field+"."+key, node.Line,
+					"%s is not set and defaults to '%s' from the base config; set your own to avoid publishing under Blimu's name", key, baseValue)
+			}
+			continue
+		}
+		if value == baseValue {
+			report.add(SeverityWarning, field+"."+key, lineOf(node, key),
+				"%s '%s' is the base config default for Blimu's own SDK", key, value)
+		}
+	}
+}
+
+// checkPublishConfig checks the CLI-handled version and publish keys of a client
+func checkPublishConfig(report *SDKConfigReport, field string, clientNode *yaml.Node) {
+	if versionNode := mappingValue(clientNode, "version"); versionNode != nil && !ValidVersion(versionNode.Value) {
+		report.add(SeverityError, field+".version", versionNode.Line, "'%s' is not a semantic version (expected MAJOR.MINOR.PATCH)", versionNode.Value)
+	}
+
+	publishNode := mappingValue(clientNode, "publish")
+	if publishNode == nil {
+		return
+	}
+	if publishNode.Kind != yaml.MappingNode {
+		report.add(SeverityError, field+".publish", publishNode.Line, "publish must be a mapping")
+		return
+	}
+	checkUnknownKeys(report, field+".publish", publishNode, yamlKeys(reflect.TypeOf(PublishConfig{})))
+
+	var publish PublishConfig
+	if err := publishNode.Decode(&publish); err != nil {
+		report.add(SeverityError, field+".publish", publishNode.Line, "invalid value: %v", err)
+		return
+	}
+	if publish.Access != "" && publish.Access != "public" && publish.Access != "restricted" {
+		report.add(SeverityError, field+".publish.access", lineOf(publishNode, "access"), "access must be public or restricted")
+	}
+}
+
+// checkNestedOutDirs reports clients whose output directories contain each other
+func checkNestedOutDirs(report *SDKConfigReport, clients []config.SDKClient, origins []int, clientsNode *yaml.Node) {
+	for i := range clients {
+		for j := range clients {
+			if i == j || clients[i].OutDir == clients[j].OutDir {
+				continue
+			}
+			rel, err := filepath.Rel(clients[i].OutDir, clients[j].OutDir)
+			if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+				report.add(SeverityError, fmt.Sprintf("clients[%d].outDir", origins[j]), clientsNode.Content[origins[j]].Line,
+					"output directory '%s' is inside clients[%d]'s output directory '%s'", clients[j].OutDir, origins[i], clients[i].OutDir)
+			}
+		}
+	}
+}
+
+// checkUnknownKeys reports mapping keys that are not in known, suggesting close matches
+func checkUnknownKeys(report *SDKConfigReport, prefix string, node *yaml.Node, known []string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if containsString(known, key.Value) {
+			continue
+		}
+
+		field := key.Value
+		if prefix != "" {
+			field = prefix + "." + key.Value
+		}
+		message := fmt.Sprintf("unknown key '%s' is ignored", key.Value)
+		if suggestion := closestKey(key.Value, known); suggestion != "" {
+			message += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+		}
+		report.add(SeverityWarning, field, key.Line, "%s", message)
+	}
+}
+
+// closestKey returns the known key within a small edit distance of key, if any
+func closestKey(key string, known []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range known {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// yamlKeys lists the yaml tag names of a struct type
+func yamlKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if tag != "" && tag != "-" {
+			keys = append(keys, tag)
+		}
+	}
+	return keys
+}
+
+// mappingValue returns the value node of key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// lineOf returns the line of key in a mapping node, falling back to the node's own line
+func lineOf(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i].Line
+		}
+	}
+	return node.Line
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
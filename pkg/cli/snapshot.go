@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// definitionSections returns the non-empty sections of definitions keyed by section name
+func definitionSections(definitions *Definitions) map[string]map[string]interface{} {
+	sections := map[string]map[string]interface{}{}
+	for name, section := range map[string]map[string]interface{}{
+		"resources":    definitions.Resources,
+		"entitlements": definitions.Entitlements,
+		"features":     definitions.Features,
+		"plans":        definitions.Plans,
+	} {
+		if len(section) > 0 {
+			sections[name] = section
+		}
+	}
+	return sections
+}
+
+// hashSections hashes the non-empty sections of definitions. Sections are hashed in their JSON
+// encoding, which orders map keys, so formatting and key order in the files do not matter.
+func hashSections(definitions *Definitions) (map[string]string, error) {
+	hashes := map[string]string{}
+	for name, section := range definitionSections(definitions) {
+		data, err := json.Marshal(section)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", name, err)
+		}
+		hashes[name] = config.HashBytes(data)
+	}
+	return hashes, nil
+}
+
+// dropUnchangedSections empties the sections of definitions whose hash matches the snapshot and
+// returns the names of the sections left to push and of those dropped
+func dropUnchangedSections(definitions *Definitions, hashes map[string]string, snapshot *config.DefinitionsSnapshot) (changed, unchanged []string) {
+	for _, section := range []struct {
+		name   string
+		target *map[string]interface{}
+	}{
+		{"resources", &definitions.Resources},
+		{"entitlements", &definitions.Entitlements},
+		{"features", &definitions.Features},
+		{"plans", &definitions.Plans},
+	} {
+		hash, loaded := hashes[section.name]
+		if !loaded {
+			continue
+		}
+		if snapshot.Sections[section.name] == hash {
+			*section.target = map[string]interface{}{}
+			unchanged = append(unchanged, section.name)
+			continue
+		}
+		changed = append(changed, section.name)
+	}
+	return changed, unchanged
+}
+
+// saveSnapshot records the section hashes now deployed to an environment. Sections missing from
+// hashes keep their previous hash, since a push leaves them untouched.
+func saveSnapshot(workspaceID, environmentID string, previous *config.DefinitionsSnapshot, hashes map[string]string) error {
+	snapshot := &config.DefinitionsSnapshot{
+		WorkspaceID:   workspaceID,
+		EnvironmentID: environmentID,
+		Sections:      map[string]string{},
+		SyncedAt:      time.Now(),
+	}
+	if previous != nil {
+		for name, hash := range previous.Sections {
+			snapshot.Sections[name] = hash
+		}
+	}
+	for name, hash := range hashes {
+		snapshot.Sections[name] = hash
+	}
+	return config.SaveDefinitionsSnapshot(snapshot)
+}
+
+// recordPulledSnapshot records the files just written by a pull as the environment's snapshot
+func (r *Runner) recordPulledSnapshot(directory, workspaceID, environmentID string) error {
+	quiet := &Runner{api: r.api, out: io.Discard}
+	definitions, _, err := quiet.LoadDefinitions(directory)
+	if err != nil {
+		return err
+	}
+	hashes, err := hashSections(definitions)
+	if err != nil {
+		return err
+	}
+	return saveSnapshot(workspaceID, environmentID, nil, hashes)
+}
+
+// DefinitionsDrift reports which sections of a directory's definition files, with the
+// environment's overlay applied, differ from the snapshot of the environment's last pull or push.
+// It reads only local files and the CLI cache; known is false when the environment has no
+// snapshot yet. environmentKeys are the environment's other names, used to find its overlay.
+func DefinitionsDrift(directory, workspaceID, environmentID string, environmentKeys ...string) (changed []string, known bool, err error) {
+	snapshot, err := config.LoadDefinitionsSnapshot(workspaceID, environmentID)
+	if err != nil || snapshot == nil {
+		return nil, false, err
+	}
+
+	quiet := &Runner{out: io.Discard}
+	definitions, _, err := quiet.loadDefinitionsFor(directory, append([]string{environmentID}, environmentKeys...))
+	if err != nil {
+		return nil, false, err
+	}
+	hashes, err := hashSections(definitions)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, name := range []string{"resources", "entitlements", "features", "plans"} {
+		if hash, loaded := hashes[name]; loaded && snapshot.Sections[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	return changed, true, nil
+}
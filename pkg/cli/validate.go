@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// ValidateOptions configures validation of local definition files
+type ValidateOptions struct {
+	Directory     string
+	WorkspaceID   string
+	EnvironmentID string
+}
+
+// Validate loads the directory's .blimu configuration and validates it against the platform API
+func (r *Runner) Validate(ctx context.Context, opts ValidateOptions) (*ValidationResult, error) {
+	if opts.WorkspaceID == "" || opts.EnvironmentID == "" {
+		return nil, fmt.Errorf("workspace ID and environment ID are required for platform validation")
+	}
+
+	blimuConfig, err := config.LoadBlimuConfig(opts.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .blimu configuration: %w", err)
+	}
+
+	definitions, version, err := ConfigToDefinitions(blimuConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.api.ValidateDefinitions(ctx, opts.WorkspaceID, opts.EnvironmentID, definitions, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	return result, nil
+}
+
+// ConfigToDefinitions converts a BlimuConfig into platform definitions and its schema version
+func ConfigToDefinitions(blimuConfig *config.BlimuConfig) (*Definitions, string, error) {
+	configJSON, err := blimuConfig.MergeToJSON()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to serialize configuration: %w", err)
+	}
+
+	var configMap map[string]interface{}
+	if err := json.Unmarshal(configJSON, &configMap); err != nil {
+		return nil, "", fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	definitions := &Definitions{
+		Resources:    make(map[string]interface{}),
+		Entitlements: make(map[string]interface{}),
+		Features:     make(map[string]interface{}),
+		Plans:        make(map[string]interface{}),
+	}
+
+	if resources, ok := configMap["resources"].(map[string]interface{}); ok {
+		definitions.Resources = resources
+	}
+	if entitlements, ok := configMap["entitlements"].(map[string]interface{}); ok {
+		definitions.Entitlements = entitlements
+	}
+	if features, ok := configMap["features"].(map[string]interface{}); ok {
+		definitions.Features = features
+	}
+	if plans, ok := configMap["plans"].(map[string]interface{}); ok {
+		definitions.Plans = plans
+	}
+
+	return definitions, getStringFromMap(configMap, "version"), nil
+}
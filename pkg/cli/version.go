@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// Version bump parts
+const (
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+	// BumpAuto picks the part from the spec changes since the last generation (see RecommendBump)
+	BumpAuto = "auto"
+)
+
+// defaultSDKVersion is the version sdk-gen writes into generated package manifests
+const defaultSDKVersion = "0.1.0"
+
+var semverPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ValidVersion reports whether v is a semantic version (an optional leading "v" is allowed)
+func ValidVersion(v string) bool {
+	return semverPattern.MatchString(v)
+}
+
+// BumpVersion increments the given part of a semantic version and drops any pre-release suffix.
+// Bumping a pre-release patch (1.2.3-rc.1) releases it as 1.2.3.
+func BumpVersion(current, part string) (string, error) {
+	match := semverPattern.FindStringSubmatch(current)
+	if match == nil {
+		return "", fmt.Errorf("'%s' is not a semantic version (expected MAJOR.MINOR.PATCH)", current)
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+	preRelease := match[4] != ""
+
+	switch part {
+	case BumpMajor:
+		major, minor, patch = major+1, 0, 0
+	case BumpMinor:
+		minor, patch = minor+1, 0
+	case BumpPatch:
+		if !preRelease {
+			patch++
+		}
+	default:
+		return "", fmt.Errorf("unknown version bump '%s' (expected %s, %s or %s)", part, BumpPatch, BumpMinor, BumpMajor)
+	}
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch), nil
+}
+
+// RecommendBump picks the version bump for the spec changes between two generations: major for
+// breaking changes, minor for additions and patch for changes the diff does not classify (such as
+// descriptions). Before 1.0.0 each level moves down one, as in Cargo: breaking changes bump the
+// minor version and additions the patch version. It returns "" when the spec is unchanged.
+func RecommendBump(diff *spec.Diff, specChanged bool, current string) string {
+	var bump string
+	switch {
+	case len(diff.Breaking()) > 0:
+		bump = BumpMajor
+	case !diff.IsEmpty():
+		bump = BumpMinor
+	case specChanged:
+		bump = BumpPatch
+	default:
+		return ""
+	}
+
+	if match := semverPattern.FindStringSubmatch(current); match != nil && match[1] == "0" {
+		switch bump {
+		case BumpMajor:
+			bump = BumpMinor
+		case BumpMinor:
+			bump = BumpPatch
+		}
+	}
+	return bump
+}
+
+// versionFiles lists, per client type, the generated manifest holding the package version and
+// a pattern whose first group is the version value
+var versionFiles = map[string]struct {
+	file    string
+	pattern *regexp.Regexp
+}{
+	"typescript": {"package.json", regexp.MustCompile(`(?m)^(\s*"version"\s*:\s*")[^"]*(")`)},
+	"python":     {"pyproject.toml", regexp.MustCompile(`(?m)^(version\s*=\s*")[^"]*(")`)},
+}
+
+// stampVersion writes version into the client's generated package manifest. Client types whose
+// version lives outside the generated files (Go modules use git tags) are left untouched.
+func stampVersion(clientType, outDir, version string) (bool, error) {
+	target, ok := versionFiles[clientType]
+	if !ok || version == "" {
+		return false, nil
+	}
+
+	path := filepath.Join(outDir, target.file)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	replaced := false
+	updated := target.pattern.ReplaceAllStringFunc(string(data), func(match string) string {
+		if replaced {
+			return match
+		}
+		replaced = true
+		return target.pattern.ReplaceAllString(match, "${1}"+version+"${2}")
+	})
+	if !replaced || updated == string(data) {
+		return false, nil
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// specSnapshot is the spec a client's current version was generated from; it only moves forward
+// when the version changes, so recommendations cover every change since the last version bump
+type specSnapshot struct {
+	Version string                 `json:"version"`
+	Spec    map[string]interface{} `json:"spec"`
+}
+
+// readSpecSnapshot loads the spec snapshot of an outDir; it returns nil when there is none
+func (r *Runner) readSpecSnapshot(outDir string) (*specSnapshot, error) {
+	path := filepath.Join(outDir, specSnapshotFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	snapshot := &specSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil || snapshot.Spec == nil {
+		r.printf("⚠️  Ignoring unreadable %s\n", path)
+		return nil, nil
+	}
+	return snapshot, nil
+}
+
+// updateSpecSnapshot records the spec as the baseline of version, unless it already is the baseline
+func (r *Runner) updateSpecSnapshot(outDir, version string, specDoc map[string]interface{}) error {
+	existing, err := r.readSpecSnapshot(outDir)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Version == version {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(specSnapshot{Version: version, Spec: specDoc}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render spec snapshot: %w", err)
+	}
+	path := filepath.Join(outDir, specSnapshotFileName)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// suggestVersion compares the spec the client's current version was generated from (kept in its
+// outDir) with the new one and prints the recommended version bump. It returns the version to
+// apply: with bump set to auto the recommended one, with an explicit part that part, and "" to
+// keep the current version.
+func (r *Runner) suggestVersion(clientType, outDir, current string, specDoc map[string]interface{}, bump string) (string, error) {
+	snapshot, err := r.readSpecSnapshot(outDir)
+	if err != nil {
+		return "", err
+	}
+
+	recommended := ""
+	switch {
+	case snapshot == nil:
+		// First generation with snapshots: nothing to compare against
+		if bump == BumpAuto {
+			r.printf("ℹ️  %s: no previous spec recorded, keeping version %s\n", clientType, current)
+		}
+	case snapshot.Version != current:
+		// The version was changed by hand; the snapshot no longer describes it
+		if bump == BumpAuto {
+			r.printf("ℹ️  %s: version changed from %s to %s outside generate, keeping it\n", clientType, snapshot.Version, current)
+		}
+	default:
+		diff := spec.Compare(snapshot.Spec, specDoc)
+		recommended = RecommendBump(diff, !reflect.DeepEqual(snapshot.Spec, specDoc), current)
+		if recommended != "" {
+			breaking := len(diff.Breaking())
+			r.printf("📈 %s: %d breaking and %d other spec change(s) since version %s; recommended: %s bump\n",
+				clientType, breaking, len(diff.Changes)-breaking, current, recommended)
+		}
+	}
+
+	part := bump
+	if bump == BumpAuto {
+		part = recommended
+	}
+	if part == "" {
+		if recommended != "" {
+			r.printf("   Run with --bump auto to apply it\n")
+		}
+		return "", nil
+	}
+
+	next, err := BumpVersion(current, part)
+	if err != nil {
+		return "", err
+	}
+	if bumpRank(part) < bumpRank(recommended) {
+		r.printf("⚠️  %s: a %s bump understates the spec changes (recommended: %s)\n", clientType, part, recommended)
+	}
+	r.printf("🔖 %s: version %s → %s\n", clientType, current, next)
+	return next, nil
+}
+
+func bumpRank(part string) int {
+	switch part {
+	case BumpMajor:
+		return 3
+	case BumpMinor:
+		return 2
+	case BumpPatch:
+		return 1
+	}
+	return 0
+}
+
+// recordVersions writes bumped client versions back to sdk.yml so later generations and
+// 'blimu sdk publish' use them
+func recordVersions(configPath string, versions map[int]string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read SDK config file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse SDK config: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return fmt.Errorf("%s is empty", configPath)
+	}
+	clientsNode := mappingValue(root.Content[0], "clients")
+	if clientsNode == nil || clientsNode.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%s defines no clients", configPath)
+	}
+
+	for i, version := range versions {
+		if i < len(clientsNode.Content) {
+			setMappingValue(clientsNode.Content[i], "version", version)
+		}
+	}
+	return writeYAMLDocument(configPath, &root)
+}
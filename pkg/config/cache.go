@@ -0,0 +1,122 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SpecCacheEntry records the last OpenAPI spec used for SDK generation in an environment
+type SpecCacheEntry struct {
+	WorkspaceID   string    `json:"workspace_id"`
+	EnvironmentID string    `json:"environment_id"`
+	SpecHash      string    `json:"spec_hash"`
+	ConfigHash    string    `json:"config_hash,omitempty"`
+	GeneratedAt   time.Time `json:"generated_at"`
+}
+
+// GetCacheDir returns the directory used for CLI caches, creating it if needed
+func GetCacheDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir := filepath.Join(configDir, "cache")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return cacheDir, nil
+}
+
+// getSpecCacheDir returns the spec cache directory for a workspace
+func getSpecCacheDir(workspaceID string) (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	specDir := filepath.Join(cacheDir, "specs", workspaceID)
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create spec cache directory: %w", err)
+	}
+
+	return specDir, nil
+}
+
+// LoadSpecCache loads the cached spec entry for an environment.
+// Returns nil without error if nothing has been cached yet.
+func LoadSpecCache(workspaceID, environmentID string) (*SpecCacheEntry, error) {
+	specDir, err := getSpecCacheDir(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(specDir, environmentID+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read spec cache: %w", err)
+	}
+
+	var entry SpecCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse spec cache: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// LoadCachedSpec returns the raw OpenAPI spec last cached for an environment.
+// Returns nil without error if nothing has been cached yet.
+func LoadCachedSpec(workspaceID, environmentID string) ([]byte, error) {
+	specDir, err := getSpecCacheDir(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(specDir, environmentID+".openapi.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cached spec: %w", err)
+	}
+
+	return data, nil
+}
+
+// SaveSpecCache stores the spec entry and the spec itself for an environment
+func SaveSpecCache(entry *SpecCacheEntry, specJSON []byte) error {
+	specDir, err := getSpecCacheDir(entry.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec cache: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(specDir, entry.EnvironmentID+".openapi.json"), specJSON, 0644); err != nil {
+		return fmt.Errorf("failed to write cached spec: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(specDir, entry.EnvironmentID+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write spec cache: %w", err)
+	}
+
+	return nil
+}
+
+// HashBytes returns the hex-encoded SHA-256 hash of data
+func HashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -13,43 +16,251 @@ import (
 type CLIConfig struct {
 	CurrentEnvironment string                 `yaml:"current_environment,omitempty"`
 	Environments       map[string]Environment `yaml:"environments,omitempty"`
-	DefaultAPIURL      string                 `yaml:"default_api_url,omitempty"`
+	// Region selects the endpoints of environments that do not name a region (default: DefaultRegion)
+	Region string `yaml:"region,omitempty"`
+	// Endpoints override the endpoints of every region, e.g. for a self-hosted platform; an
+	// environment's own endpoints override them
+	Endpoints *Endpoints `yaml:"endpoints,omitempty"`
+	// Regions add regions or override the endpoints of built-in ones, by name
+	Regions map[string]Endpoints `yaml:"regions,omitempty"`
+	// APIEnvs override the endpoints of the staging and local API environments (--api-env)
+	APIEnvs map[string]Endpoints `yaml:"api_envs,omitempty"`
+	// TrustedCommands are sdk.yml pre/post commands (argv lists) allowed to run during generate
+	TrustedCommands []TrustedCommand `yaml:"trusted_commands,omitempty"`
+	// ProtectedEnvironments are label selectors; commands that change a matching environment ask
+	// for confirmation first
+	ProtectedEnvironments []string `yaml:"protected_environments,omitempty"`
+	// CACert is a PEM file of CA certificates trusted for API requests in addition to the system
+	// roots, e.g. the CA of a corporate proxy (--ca-cert overrides it)
+	CACert string `yaml:"ca_cert,omitempty"`
+	// InsecureSkipVerify disables TLS certificate verification of API requests
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+	// HTTP holds the HTTP settings of every environment; an environment's own settings override them
+	HTTP *HTTPSettings `yaml:"http,omitempty"`
+	// OAuth overrides the OAuth client and endpoints of logins, e.g. for a self-hosted platform;
+	// an environment's own settings override them
+	OAuth *OAuthSettings `yaml:"oauth,omitempty"`
+	// CommandTimeouts abort commands that run longer, when --timeout is not given, by command path
+	// without "blimu", e.g. "resources bulk"; a command without an entry uses its closest parent's
+	CommandTimeouts map[string]time.Duration `yaml:"command_timeouts,omitempty"`
+	// UpdateCheck prints a notice after commands when a newer CLI release exists, checking GitHub
+	// at most once a day (BLIMU_UPDATE_CHECK overrides it)
+	UpdateCheck bool `yaml:"update_check,omitempty"`
+}
+
+// HTTPSettings tune the HTTP client of API requests. Durations are written like "10s" or "2m";
+// zero values keep the defaults.
+type HTTPSettings struct {
+	// ConnectTimeout limits establishing a connection
+	ConnectTimeout time.Duration `yaml:"connect_timeout,omitempty"`
+	// RequestTimeout limits each request, from connecting to reading the whole response
+	RequestTimeout time.Duration `yaml:"request_timeout,omitempty"`
+	// MaxIdleConns is the number of idle connections kept open for reuse
+	MaxIdleConns int `yaml:"max_idle_conns,omitempty"`
+	// KeepAlive is the interval of TCP keep-alive probes; negative disables them
+	KeepAlive time.Duration `yaml:"keep_alive,omitempty"`
+}
+
+// Merge returns the settings with the non-zero fields of override applied. Either may be nil.
+func (s *HTTPSettings) Merge(override *HTTPSettings) HTTPSettings {
+	var merged HTTPSettings
+	if s != nil {
+		merged = *s
+	}
+	if override == nil {
+		return merged
+	}
+	if override.ConnectTimeout != 0 {
+		merged.ConnectTimeout = override.ConnectTimeout
+	}
+	if override.RequestTimeout != 0 {
+		merged.RequestTimeout = override.RequestTimeout
+	}
+	if override.MaxIdleConns != 0 {
+		merged.MaxIdleConns = override.MaxIdleConns
+	}
+	if override.KeepAlive != 0 {
+		merged.KeepAlive = override.KeepAlive
+	}
+	return merged
+}
+
+// OAuthSettings choose the OAuth client and endpoints of logins and token refreshes. Empty fields
+// keep the defaults: the CLI's client ID and the /oauth endpoints of the platform API.
+type OAuthSettings struct {
+	// ClientID is the OAuth client registered for the CLI
+	ClientID string `yaml:"client_id,omitempty"`
+	// AuthURL is the authorization endpoint the browser is sent to
+	AuthURL string `yaml:"auth_url,omitempty"`
+	// TokenURL is the endpoint exchanging codes and refresh tokens for access tokens
+	TokenURL string `yaml:"token_url,omitempty"`
+}
+
+// Merge returns the settings with the non-empty fields of override applied. Either may be nil.
+func (s *OAuthSettings) Merge(override *OAuthSettings) OAuthSettings {
+	var merged OAuthSettings
+	if s != nil {
+		merged = *s
+	}
+	if override == nil {
+		return merged
+	}
+	if override.ClientID != "" {
+		merged.ClientID = override.ClientID
+	}
+	if override.AuthURL != "" {
+		merged.AuthURL = override.AuthURL
+	}
+	if override.TokenURL != "" {
+		merged.TokenURL = override.TokenURL
+	}
+	return merged
 }
 
 // Environment represents a single environment configuration
 type Environment struct {
-	APIURL      string `yaml:"api_url,omitempty"`
-	ID          string `yaml:"id,omitempty"`           // Environment ID from the API
-	WorkspaceID string `yaml:"workspace_id,omitempty"` // Workspace ID from the API
-	LookupKey   string `yaml:"lookup_key,omitempty"`   // Optional lookup key for the environment
+	// APIURL overrides the platform API of the environment's endpoints
+	APIURL string `yaml:"api_url,omitempty"`
+	// Region selects the endpoints of the environment (default: the CLI config's region)
+	Region string `yaml:"region,omitempty"`
+	// Endpoints override the endpoints of the environment's region and of the CLI config
+	Endpoints   *Endpoints `yaml:"endpoints,omitempty"`
+	ID          string     `yaml:"id,omitempty"`           // Environment ID from the API
+	WorkspaceID string     `yaml:"workspace_id,omitempty"` // Workspace ID from the API
+	LookupKey   string     `yaml:"lookup_key,omitempty"`   // Optional lookup key for the environment
+	// Labels are local key/value tags (e.g. team=payments, tier=prod) matched by selectors
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// AutoApprove answers confirmation prompts for changes to this environment: true skips them,
+	// false keeps asking even when BLIMU_AUTO_APPROVE is set. Unset follows BLIMU_AUTO_APPROVE.
+	AutoApprove *bool `yaml:"auto_approve,omitempty"`
+	// HTTP overrides the HTTP settings of the CLI config for this environment
+	HTTP *HTTPSettings `yaml:"http,omitempty"`
+	// OAuth overrides the OAuth settings of the CLI config for this environment; logins with
+	// --oauth-* flags store them here so token refreshes use the same client
+	OAuth *OAuthSettings `yaml:"oauth,omitempty"`
+
+	// OAuth fields, stored in the credentials file rather than config.yml
+	AccessToken  string     `yaml:"-"`
+	RefreshToken string     `yaml:"-"`
+	ExpiresAt    *time.Time `yaml:"-"`
+	TokenType    string     `yaml:"-"`
+	// ReauthRequired is set when the refresh token was rejected; commands stop trying to refresh
+	// and ask for 'blimu auth login' until new tokens are stored
+	ReauthRequired bool `yaml:"reauth_required,omitempty"`
+
+	// AuthType is how the tokens were obtained: AuthTypeOAuth (when empty) or
+	// AuthTypeClientCredentials for a service account
+	AuthType string `yaml:"auth_type,omitempty"`
+	// ClientID is the service account whose secret requests a new access token when it expires
+	ClientID string `yaml:"client_id,omitempty"`
+	// ClientSecret is the service account secret, stored in the credentials file
+	ClientSecret string `yaml:"-"`
+	// OIDCAudience is the audience of the CI ID tokens exchanged for access tokens with
+	// AuthTypeGitHubOIDC
+	OIDCAudience string `yaml:"oidc_audience,omitempty"`
+}
+
+// Authentication types of an environment
+const (
+	// AuthTypeOAuth is the interactive login of a user, renewed with a refresh token
+	AuthTypeOAuth = "oauth"
+	// AuthTypeClientCredentials is a service account, renewed with its client ID and secret
+	AuthTypeClientCredentials = "client_credentials"
+	// AuthTypeGitHubOIDC is a GitHub Actions job, renewed by exchanging a new ID token of the job
+	AuthTypeGitHubOIDC = "github_oidc"
+)
+
+// ConfigDirEnv overrides the directory of the CLI config, credentials and caches
+const ConfigDirEnv = "BLIMU_CONFIG_DIR"
+
+// GetConfigDir returns the directory holding CLI configuration and caches: BLIMU_CONFIG_DIR, or
+// else $XDG_CONFIG_HOME/blimu on Linux and %APPDATA%\blimu on Windows. ~/.blimu, where older
+// versions kept it, is still used when it exists and the new directory does not.
+func GetConfigDir() (string, error) {
+	configDir := os.Getenv(ConfigDirEnv)
+	if configDir == "" {
+		var err error
+		if configDir, err = defaultConfigDir(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
 
-	// OAuth fields
-	AccessToken  string     `yaml:"access_token,omitempty"`
-	RefreshToken string     `yaml:"refresh_token,omitempty"`
-	ExpiresAt    *time.Time `yaml:"expires_at,omitempty"`
-	TokenType    string     `yaml:"token_type,omitempty"`
+	return configDir, nil
 }
 
-// GetCLIConfigPath returns the path to the CLI configuration file
-func GetCLIConfigPath() (string, error) {
+// defaultConfigDir returns the config directory of the platform, or the legacy ~/.blimu of
+// existing installations
+func defaultConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get user home directory: %w", err)
 	}
+	legacyDir := filepath.Join(homeDir, ".blimu")
 
-	configDir := filepath.Join(homeDir, ".blimu")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create config directory: %w", err)
+	var platformDir string
+	switch runtime.GOOS {
+	case "windows":
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			platformDir = filepath.Join(appData, "blimu")
+		}
+	case "darwin":
+		// ~/.blimu stays the home of the config on macOS
+	default:
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(homeDir, ".config")
+		}
+		platformDir = filepath.Join(configHome, "blimu")
+	}
+
+	if platformDir == "" {
+		return legacyDir, nil
+	}
+	// Existing installations keep ~/.blimu until the new directory exists
+	if !isDir(platformDir) && isDir(legacyDir) {
+		return legacyDir, nil
+	}
+	return platformDir, nil
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// GetCLIConfigPath returns the path to the CLI configuration file of the active profile
+func GetCLIConfigPath() (string, error) {
+	profileDir, err := GetProfileDir()
+	if err != nil {
+		return "", err
 	}
 
-	return filepath.Join(configDir, "config.yml"), nil
+	return filepath.Join(profileDir, "config.yml"), nil
 }
 
-// LoadCLIConfig loads CLI configuration from file and environment variables
+// CommandTimeout returns the timeout of a command path (see CommandTimeouts), or 0 for no limit
+func (c *CLIConfig) CommandTimeout(path string) time.Duration {
+	for {
+		if timeout, ok := c.CommandTimeouts[path]; ok {
+			return timeout
+		}
+		i := strings.LastIndex(path, " ")
+		if i < 0 {
+			return 0
+		}
+		path = path[:i]
+	}
+}
+
+// LoadCLIConfig loads CLI configuration from config.yml and the credentials file
 func LoadCLIConfig() (*CLIConfig, error) {
 	config := &CLIConfig{
-		Environments:  make(map[string]Environment),
-		DefaultAPIURL: "https://api.blimu.dev", // Runtime API for OAuth, platform API determined at runtime
+		Environments: make(map[string]Environment),
 	}
 
 	// Try to load from config file
@@ -67,6 +278,20 @@ func LoadCLIConfig() (*CLIConfig, error) {
 		if err := yaml.Unmarshal(data, config); err != nil {
 			return nil, fmt.Errorf("failed to parse CLI config file: %w", err)
 		}
+
+		credentials, err := loadCredentials()
+		if err != nil {
+			return nil, err
+		}
+		config.applyCredentials(credentials)
+
+		if err := config.migrateCredentials(data, credentials); err != nil {
+			return nil, err
+		}
+
+		if err := config.validateRegions(); err != nil {
+			return nil, err
+		}
 	}
 
 	// Note: API key environment variable support has been removed
@@ -83,7 +308,7 @@ func LoadCLIConfig() (*CLIConfig, error) {
 	return config, nil
 }
 
-// SaveCLIConfig saves the CLI configuration to file
+// Save writes the CLI configuration to config.yml and its tokens to the credentials file
 func (c *CLIConfig) Save() error {
 	configPath, err := GetCLIConfigPath()
 	if err != nil {
@@ -95,6 +320,11 @@ func (c *CLIConfig) Save() error {
 		return fmt.Errorf("failed to marshal CLI config: %w", err)
 	}
 
+	// Tokens first, so they are never lost when writing config.yml drops them
+	if err := saveCredentials(c.credentials()); err != nil {
+		return err
+	}
+
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write CLI config file: %w", err)
 	}
@@ -128,17 +358,32 @@ func (c *CLIConfig) SetCurrentEnvironment(name string) error {
 
 // AddEnvironment adds or updates an environment
 func (c *CLIConfig) AddEnvironment(env Environment) error {
+	return c.SaveEnvironment(env.ID, env)
+}
+
+// SaveEnvironment adds or replaces an environment under its local name
+func (c *CLIConfig) SaveEnvironment(name string, env Environment) error {
 	if c.Environments == nil {
 		c.Environments = make(map[string]Environment)
 	}
 
-	c.Environments[env.ID] = env
+	c.Environments[name] = env
 
 	// Set as current if it's the first environment
 	if c.CurrentEnvironment == "" {
-		c.CurrentEnvironment = env.ID
+		c.CurrentEnvironment = name
+	}
+
+	return c.Save()
+}
+
+// UpdateEnvironment stores an existing environment under its local name
+func (c *CLIConfig) UpdateEnvironment(name string, env Environment) error {
+	if _, exists := c.Environments[name]; !exists {
+		return fmt.Errorf("environment '%s' not found", name)
 	}
 
+	c.Environments[name] = env
 	return c.Save()
 }
 
@@ -167,6 +412,92 @@ func (c *CLIConfig) ListEnvironments() map[string]Environment {
 	return c.Environments
 }
 
+// EnvironmentKeys returns the local names and lookup keys of the configured environments with the given ID
+func (c *CLIConfig) EnvironmentKeys(environmentID string) []string {
+	var keys []string
+	for name, env := range c.Environments {
+		if env.ID != environmentID {
+			continue
+		}
+		keys = append(keys, name)
+		if env.LookupKey != "" {
+			keys = append(keys, env.LookupKey)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FindEnvironment returns the local name of the configured environment known by key, which may
+// be its local name, lookup key or ID
+func (c *CLIConfig) FindEnvironment(key string) (string, bool) {
+	if _, ok := c.Environments[key]; ok {
+		return key, true
+	}
+	names := make([]string, 0, len(c.Environments))
+	for name := range c.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env := c.Environments[name]
+		if env.ID == key || (env.LookupKey != "" && env.LookupKey == key) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// TrustedCommand is the argv of a trusted sdk.yml command. In YAML it is a list, or a single
+// string split on whitespace as written by older versions
+type TrustedCommand []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (t *TrustedCommand) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*t = strings.Fields(value.Value)
+		return nil
+	}
+	var argv []string
+	if err := value.Decode(&argv); err != nil {
+		return err
+	}
+	*t = argv
+	return nil
+}
+
+// Matches reports whether argv is exactly the trusted command, argument by argument
+func (t TrustedCommand) Matches(argv []string) bool {
+	if len(t) != len(argv) {
+		return false
+	}
+	for i := range t {
+		if t[i] != argv[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsCommandTrusted reports whether an sdk.yml command's argv is on the trusted list
+func (c *CLIConfig) IsCommandTrusted(argv []string) bool {
+	for _, trusted := range c.TrustedCommands {
+		if trusted.Matches(argv) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustCommand adds an sdk.yml command's argv to the trusted list
+func (c *CLIConfig) TrustCommand(argv []string) error {
+	if c.IsCommandTrusted(argv) {
+		return nil
+	}
+	c.TrustedCommands = append(c.TrustedCommands, append(TrustedCommand(nil), argv...))
+	return c.Save()
+}
+
 // NeedsTokenRefresh checks if the OAuth token needs refresh
 func (e *Environment) NeedsTokenRefresh() bool {
 	if e.AccessToken == "" || e.ExpiresAt == nil {
@@ -180,3 +511,13 @@ func (e *Environment) NeedsTokenRefresh() bool {
 func (e *Environment) IsOAuthAuthenticated() bool {
 	return e.AccessToken != "" && e.TokenType == "Bearer"
 }
+
+// IsGitHubOIDC reports whether the environment authenticates with GitHub Actions ID tokens
+func (e *Environment) IsGitHubOIDC() bool {
+	return e.AuthType == AuthTypeGitHubOIDC
+}
+
+// IsServiceAccount reports whether the environment authenticates with client credentials
+func (e *Environment) IsServiceAccount() bool {
+	return e.AuthType == AuthTypeClientCredentials
+}
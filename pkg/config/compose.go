@@ -0,0 +1,303 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Reserved keys of resources.yml for composing resources from several files and shared templates
+const (
+	includeKey   = "include"
+	templatesKey = "templates"
+	extendsKey   = "extends"
+)
+
+// ComposedDefinition is a definition file with the files of its split directory merged in and,
+// for resources.yml, the files it includes and the templates its resources extend
+type ComposedDefinition struct {
+	// Node maps item names to items in the order they were written; nil when every file is empty
+	Node *yaml.Node
+	// Sources maps every item to the file it was written in, relative to the .blimu directory
+	Sources map[string]string
+}
+
+// template is a named set of resource fields that resources extend
+type template struct {
+	node *yaml.Node
+	file string
+	line int
+}
+
+type composer struct {
+	blimuDir  string
+	values    Values
+	resources *yaml.Node
+	sources   map[string]string
+	templates map[string]*template
+	resolved  map[string]*yaml.Node
+	including map[string]bool
+}
+
+// ComposeResources loads .blimu/resources.yml and the files of .blimu/resources.d with their
+// ${VAR} references substituted, merges the resources of the files listed under include: and
+// applies templates to the resources that extend them. Template fields are merged under the resource's own: mappings merge key by key,
+// lists are concatenated without duplicates and other values are replaced.
+func ComposeResources(blimuDir string, values Values) (*ComposedDefinition, error) {
+	c := &composer{
+		blimuDir:  blimuDir,
+		values:    values,
+		resources: &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		sources:   map[string]string{},
+		templates: map[string]*template{},
+		resolved:  map[string]*yaml.Node{},
+		including: map[string]bool{},
+	}
+
+	files, err := SplitFiles(blimuDir, "resources.yml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(blimuDir, "resources.yml")); err == nil || len(files) == 0 {
+		files = append([]string{"resources.yml"}, files...)
+	}
+	for _, file := range files {
+		if err := c.load(file); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.resources.Content) == 0 {
+		return &ComposedDefinition{Sources: c.sources}, nil
+	}
+
+	for i := 0; i+1 < len(c.resources.Content); i += 2 {
+		name, resource := c.resources.Content[i].Value, c.resources.Content[i+1]
+		extended, err := c.extend(resource, c.sources[name], fmt.Sprintf("resource '%s'", name), nil)
+		if err != nil {
+			return nil, err
+		}
+		c.resources.Content[i+1] = extended
+	}
+
+	return &ComposedDefinition{Node: c.resources, Sources: c.sources}, nil
+}
+
+// UsesComposition reports whether resources.yml or the files of resources.d include other files
+// or define templates
+func UsesComposition(blimuDir string) bool {
+	files, _ := SplitFiles(blimuDir, "resources.yml")
+	for _, file := range append([]string{"resources.yml"}, files...) {
+		data, err := os.ReadFile(filepath.Join(blimuDir, filepath.FromSlash(file)))
+		if err != nil {
+			continue
+		}
+		var root map[string]interface{}
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			continue
+		}
+		_, includes := root[includeKey]
+		_, templates := root[templatesKey]
+		if includes || templates {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads a resources file relative to the .blimu directory and collects its resources,
+// templates and includes
+func (c *composer) load(name string) error {
+	if c.including[name] {
+		return fmt.Errorf("%s is included by itself", name)
+	}
+	c.including[name] = true
+	defer delete(c.including, name)
+
+	data, err := ReadDefinitionFile(filepath.Join(c.blimuDir, name), c.values)
+	if err != nil {
+		return err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s:%d: expected a mapping of resource names to resources", name, doc.Line)
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		switch key.Value {
+		case includeKey:
+			files, err := names(value)
+			if err != nil {
+				return fmt.Errorf("%s:%d: %s: %w", name, value.Line, includeKey, err)
+			}
+			for _, file := range files {
+				included := filepath.ToSlash(filepath.Join(filepath.Dir(name), file))
+				if err := c.load(included); err != nil {
+					if os.IsNotExist(err) {
+						return fmt.Errorf("%s:%d: included file %s does not exist", name, value.Line, included)
+					}
+					return err
+				}
+			}
+		case templatesKey:
+			if value.Kind != yaml.MappingNode {
+				return fmt.Errorf("%s:%d: %s must map template names to resource fields", name, value.Line, templatesKey)
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				templateName := value.Content[j].Value
+				if existing, ok := c.templates[templateName]; ok {
+					return fmt.Errorf("%s:%d: template '%s' is already defined in %s:%d", name, value.Content[j].Line, templateName, existing.file, existing.line)
+				}
+				c.templates[templateName] = &template{node: value.Content[j+1], file: name, line: value.Content[j].Line}
+			}
+		default:
+			if source, ok := c.sources[key.Value]; ok {
+				return fmt.Errorf("%s:%d: resource '%s' is already defined in %s", name, key.Line, key.Value, source)
+			}
+			c.sources[key.Value] = name
+			c.resources.Content = append(c.resources.Content, key, value)
+		}
+	}
+	return nil
+}
+
+// extend returns node with the templates listed under its extends: key merged under its own
+// fields. chain holds the templates being resolved, to report cycles.
+func (c *composer) extend(node *yaml.Node, file, owner string, chain []string) (*yaml.Node, error) {
+	if node.Kind != yaml.MappingNode {
+		return node, nil
+	}
+
+	var extends, extendsValue *yaml.Node
+	own := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag, Line: node.Line, Column: node.Column}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == extendsKey {
+			extends, extendsValue = node.Content[i], node.Content[i+1]
+			continue
+		}
+		own.Content = append(own.Content, node.Content[i], node.Content[i+1])
+	}
+	if extends == nil {
+		return node, nil
+	}
+
+	templateNames, err := names(extendsValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%d: %s %s: %w", file, extends.Line, owner, extendsKey, err)
+	}
+
+	var merged *yaml.Node
+	for _, templateName := range templateNames {
+		resolved, err := c.resolve(templateName, file, extends.Line, owner, chain)
+		if err != nil {
+			return nil, err
+		}
+		// Fields taken from a template are located at the extends: line that brought them in
+		inherited := copyNode(resolved, extends.Line, extends.Column)
+		if merged == nil {
+			merged = inherited
+		} else {
+			merged = mergeNodes(merged, inherited)
+		}
+	}
+	return mergeNodes(merged, own), nil
+}
+
+// resolve returns a template with the templates it extends applied
+func (c *composer) resolve(name, file string, line int, owner string, chain []string) (*yaml.Node, error) {
+	if resolved, ok := c.resolved[name]; ok {
+		return resolved, nil
+	}
+	t, ok := c.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("%s:%d: %s extends unknown template '%s'", file, line, owner, name)
+	}
+	for _, previous := range chain {
+		if previous == name {
+			return nil, fmt.Errorf("%s:%d: template '%s' extends itself through %v", t.file, t.line, name, append(chain, name))
+		}
+	}
+
+	resolved, err := c.extend(t.node, t.file, fmt.Sprintf("template '%s'", name), append(chain, name))
+	if err != nil {
+		return nil, err
+	}
+	c.resolved[name] = resolved
+	return resolved, nil
+}
+
+// names reads a scalar or a list of scalars
+func names(node *yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		var values []string
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("expected a name or a list of names")
+			}
+			values = append(values, item.Value)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("expected a name or a list of names")
+}
+
+// mergeNodes merges over into base: mappings merge key by key, lists are concatenated without
+// duplicate scalars and other values are replaced. base is modified.
+func mergeNodes(base, over *yaml.Node) *yaml.Node {
+	switch {
+	case base.Kind == yaml.MappingNode && over.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(over.Content); i += 2 {
+			key, value := over.Content[i], over.Content[i+1]
+			found := false
+			for j := 0; j+1 < len(base.Content); j += 2 {
+				if base.Content[j].Value == key.Value {
+					base.Content[j+1] = mergeNodes(base.Content[j+1], value)
+					found = true
+					break
+				}
+			}
+			if !found {
+				base.Content = append(base.Content, key, value)
+			}
+		}
+		return base
+	case base.Kind == yaml.SequenceNode && over.Kind == yaml.SequenceNode:
+		seen := map[string]bool{}
+		for _, item := range base.Content {
+			if item.Kind == yaml.ScalarNode {
+				seen[item.Value] = true
+			}
+		}
+		for _, item := range over.Content {
+			if item.Kind == yaml.ScalarNode && seen[item.Value] {
+				continue
+			}
+			base.Content = append(base.Content, item)
+		}
+		return base
+	}
+	return over
+}
+
+// copyNode deep-copies a node, placing every copied node at line and column
+func copyNode(node *yaml.Node, line, column int) *yaml.Node {
+	copied := *node
+	copied.Line, copied.Column = line, column
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child, line, column)
+	}
+	return &copied
+}
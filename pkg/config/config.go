@@ -15,6 +15,8 @@ type BlimuConfig struct {
 	Features     map[string]FeatureConfig     `yaml:"-"`
 	Plans        map[string]PlanConfig        `yaml:"-"`
 	SDKConfig    *SDKConfig                   `yaml:"-"`
+	// Positions locates keys and list items in the loaded files; empty for configs built in code
+	Positions Positions `yaml:"-"`
 }
 
 // ResourceConfig represents a single resource configuration
@@ -67,6 +69,7 @@ type SDKClient struct {
 	IncludeQueryKeys  bool     `yaml:"includeQueryKeys,omitempty"`
 	OperationIDParser string   `yaml:"operationIdParser,omitempty"`
 	PostGenCommand    string   `yaml:"postGenCommand,omitempty"`
+	ReactHooks        bool     `yaml:"reactHooks,omitempty"`
 }
 
 // Legacy CLIConfig - now replaced by enhanced version in cli_config.go
@@ -75,25 +78,31 @@ type SDKClient struct {
 // LoadBlimuConfig loads all .blimu configuration files
 func LoadBlimuConfig(dir string) (*BlimuConfig, error) {
 	blimuDir := filepath.Join(dir, ".blimu")
-	config := &BlimuConfig{}
+	config := &BlimuConfig{Positions: Positions{}}
+
+	// Load values.yml for ${VAR} references (optional)
+	values, err := LoadValues(blimuDir)
+	if err != nil {
+		return nil, err
+	}
 
 	// Load resources.yml
-	if err := loadResourcesConfig(blimuDir, config); err != nil {
+	if err := loadResourcesConfig(blimuDir, config, values); err != nil {
 		return nil, err
 	}
 
 	// Load entitlements.yml (optional)
-	if err := loadEntitlementsConfig(blimuDir, config); err != nil {
+	if err := loadEntitlementsConfig(blimuDir, config, values); err != nil {
 		return nil, err
 	}
 
 	// Load features.yml (optional)
-	if err := loadFeaturesConfig(blimuDir, config); err != nil {
+	if err := loadFeaturesConfig(blimuDir, config, values); err != nil {
 		return nil, err
 	}
 
 	// Load plans.yml (optional)
-	if err := loadPlansConfig(blimuDir, config); err != nil {
+	if err := loadPlansConfig(blimuDir, config, values); err != nil {
 		return nil, err
 	}
 
@@ -105,23 +114,25 @@ func LoadBlimuConfig(dir string) (*BlimuConfig, error) {
 	return config, nil
 }
 
-func loadResourcesConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "resources.yml")
-	data, err := os.ReadFile(configPath)
+func loadResourcesConfig(blimuDir string, config *BlimuConfig, values Values) error {
+	composed, err := ComposeResources(blimuDir, values)
 	if err != nil {
 		return fmt.Errorf("failed to read resources.yml: %w", err)
 	}
+	if composed.Node == nil {
+		return nil
+	}
 
-	if err := yaml.Unmarshal(data, &config.Resources); err != nil {
+	if err := composed.Node.Decode(&config.Resources); err != nil {
 		return fmt.Errorf("failed to parse resources.yml: %w", err)
 	}
+	config.Positions.recordComposed("resources.yml", blimuDir, composed)
 
 	return nil
 }
 
-func loadEntitlementsConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "entitlements.yml")
-	data, err := os.ReadFile(configPath)
+func loadEntitlementsConfig(blimuDir string, config *BlimuConfig, values Values) error {
+	composed, err := ComposeDefinition(blimuDir, "entitlements.yml", values)
 	if err != nil {
 		if os.IsNotExist(err) {
 			config.Entitlements = make(map[string]EntitlementConfig)
@@ -129,17 +140,20 @@ func loadEntitlementsConfig(blimuDir string, config *BlimuConfig) error {
 		}
 		return fmt.Errorf("failed to read entitlements.yml: %w", err)
 	}
+	if composed.Node == nil {
+		return nil
+	}
 
-	if err := yaml.Unmarshal(data, &config.Entitlements); err != nil {
+	if err := composed.Node.Decode(&config.Entitlements); err != nil {
 		return fmt.Errorf("failed to parse entitlements.yml: %w", err)
 	}
+	config.Positions.recordComposed("entitlements.yml", blimuDir, composed)
 
 	return nil
 }
 
-func loadFeaturesConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "features.yml")
-	data, err := os.ReadFile(configPath)
+func loadFeaturesConfig(blimuDir string, config *BlimuConfig, values Values) error {
+	composed, err := ComposeDefinition(blimuDir, "features.yml", values)
 	if err != nil {
 		if os.IsNotExist(err) {
 			config.Features = make(map[string]FeatureConfig)
@@ -147,17 +161,20 @@ func loadFeaturesConfig(blimuDir string, config *BlimuConfig) error {
 		}
 		return fmt.Errorf("failed to read features.yml: %w", err)
 	}
+	if composed.Node == nil {
+		return nil
+	}
 
-	if err := yaml.Unmarshal(data, &config.Features); err != nil {
+	if err := composed.Node.Decode(&config.Features); err != nil {
 		return fmt.Errorf("failed to parse features.yml: %w", err)
 	}
+	config.Positions.recordComposed("features.yml", blimuDir, composed)
 
 	return nil
 }
 
-func loadPlansConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "plans.yml")
-	data, err := os.ReadFile(configPath)
+func loadPlansConfig(blimuDir string, config *BlimuConfig, values Values) error {
+	composed, err := ComposeDefinition(blimuDir, "plans.yml", values)
 	if err != nil {
 		if os.IsNotExist(err) {
 			config.Plans = make(map[string]PlanConfig)
@@ -165,10 +182,14 @@ func loadPlansConfig(blimuDir string, config *BlimuConfig) error {
 		}
 		return fmt.Errorf("failed to read plans.yml: %w", err)
 	}
+	if composed.Node == nil {
+		return nil
+	}
 
-	if err := yaml.Unmarshal(data, &config.Plans); err != nil {
+	if err := composed.Node.Decode(&config.Plans); err != nil {
 		return fmt.Errorf("failed to parse plans.yml: %w", err)
 	}
+	config.Positions.recordComposed("plans.yml", blimuDir, composed)
 
 	return nil
 }
@@ -190,6 +211,7 @@ func loadSDKConfig(blimuDir string, config *BlimuConfig) error {
 	}
 
 	config.SDKConfig = &sdkConfig
+	config.Positions.record("config.yml", configPath, data)
 	return nil
 }
 
@@ -201,27 +223,27 @@ func SaveBlimuConfig(dir string, config *BlimuConfig) error {
 	}
 
 	// Save resources.yml
-	if err := saveResourcesConfig(blimuDir, config); err != nil {
+	if err := saveDefinitionFile(blimuDir, "resources.yml", config.Resources); err != nil {
 		return err
 	}
 
 	// Save entitlements.yml if not empty
 	if len(config.Entitlements) > 0 {
-		if err := saveEntitlementsConfig(blimuDir, config); err != nil {
+		if err := saveDefinitionFile(blimuDir, "entitlements.yml", config.Entitlements); err != nil {
 			return err
 		}
 	}
 
 	// Save features.yml if not empty
 	if len(config.Features) > 0 {
-		if err := saveFeaturesConfig(blimuDir, config); err != nil {
+		if err := saveDefinitionFile(blimuDir, "features.yml", config.Features); err != nil {
 			return err
 		}
 	}
 
 	// Save plans.yml if not empty
 	if len(config.Plans) > 0 {
-		if err := savePlansConfig(blimuDir, config); err != nil {
+		if err := saveDefinitionFile(blimuDir, "plans.yml", config.Plans); err != nil {
 			return err
 		}
 	}
@@ -236,62 +258,6 @@ func SaveBlimuConfig(dir string, config *BlimuConfig) error {
 	return nil
 }
 
-func saveResourcesConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "resources.yml")
-	data, err := yaml.Marshal(config.Resources)
-	if err != nil {
-		return fmt.Errorf("failed to marshal resources config: %w", err)
-	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write resources.yml: %w", err)
-	}
-
-	return nil
-}
-
-func saveEntitlementsConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "entitlements.yml")
-	data, err := yaml.Marshal(config.Entitlements)
-	if err != nil {
-		return fmt.Errorf("failed to marshal entitlements config: %w", err)
-	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write entitlements.yml: %w", err)
-	}
-
-	return nil
-}
-
-func saveFeaturesConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "features.yml")
-	data, err := yaml.Marshal(config.Features)
-	if err != nil {
-		return fmt.Errorf("failed to marshal features config: %w", err)
-	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write features.yml: %w", err)
-	}
-
-	return nil
-}
-
-func savePlansConfig(blimuDir string, config *BlimuConfig) error {
-	configPath := filepath.Join(blimuDir, "plans.yml")
-	data, err := yaml.Marshal(config.Plans)
-	if err != nil {
-		return fmt.Errorf("failed to marshal plans config: %w", err)
-	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write plans.yml: %w", err)
-	}
-
-	return nil
-}
-
 func saveSDKConfig(blimuDir string, config *BlimuConfig) error {
 	configPath := filepath.Join(blimuDir, "config.yml")
 	data, err := yaml.Marshal(config.SDKConfig)
@@ -310,8 +276,7 @@ func saveSDKConfig(blimuDir string, config *BlimuConfig) error {
 func FindBlimuConfig(startDir string) (string, error) {
 	dir := startDir
 	for {
-		configPath := filepath.Join(dir, ".blimu", "resources.yml")
-		if _, err := os.Stat(configPath); err == nil {
+		if HasResources(filepath.Join(dir, ".blimu")) {
 			return dir, nil
 		}
 
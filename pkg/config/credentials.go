@@ -0,0 +1,260 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// CredentialsFileEnv points at the credentials file instead of credentials.yml in the config
+// directory, e.g. a secret mounted in CI. Every profile shares the file: the default profile's
+// credentials are at its top level and those of the others under profiles.
+const CredentialsFileEnv = "BLIMU_CREDENTIALS_FILE"
+
+// Credentials are the secrets of the CLI config. They are kept in credentials.yml, readable by
+// the user only, so config.yml can be committed or shared and the secrets mounted separately.
+type Credentials struct {
+	Environments map[string]EnvironmentCredentials `yaml:"environments,omitempty"`
+	// Profiles are the credentials of the non-default profiles, by name, in a file shared
+	// through CredentialsFileEnv
+	Profiles map[string]Credentials `yaml:"profiles,omitempty"`
+}
+
+// EnvironmentCredentials are the tokens of an environment, by its local name
+type EnvironmentCredentials struct {
+	AccessToken  string     `yaml:"access_token,omitempty"`
+	RefreshToken string     `yaml:"refresh_token,omitempty"`
+	ExpiresAt    *time.Time `yaml:"expires_at,omitempty"`
+	TokenType    string     `yaml:"token_type,omitempty"`
+	ClientSecret string     `yaml:"client_secret,omitempty"`
+}
+
+// empty reports whether there are no credentials to store
+func (c EnvironmentCredentials) empty() bool {
+	return c.AccessToken == "" && c.RefreshToken == "" && c.ExpiresAt == nil && c.TokenType == "" && c.ClientSecret == ""
+}
+
+// GetCredentialsPath returns the path to the credentials file of the active profile
+func GetCredentialsPath() (string, error) {
+	if path := os.Getenv(CredentialsFileEnv); path != "" {
+		return path, nil
+	}
+
+	profileDir, err := GetProfileDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(profileDir, "credentials.yml"), nil
+}
+
+// staleCredentialsLock is the age after which a credentials lock is considered abandoned by a
+// crashed process and taken over
+const staleCredentialsLock = 30 * time.Second
+
+// LockCredentials takes a lock on the credentials of the active profile shared by all CLI
+// processes, so only one of them refreshes tokens at a time. It waits until the lock is free or
+// ctx is done; the returned function releases it.
+func LockCredentials(ctx context.Context) (func(), error) {
+	profileDir, err := GetProfileDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(profileDir, "credentials.lock")
+
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock credentials: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleCredentialsLock {
+			os.Remove(path)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for another blimu process to refresh tokens: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// credentials returns the credentials of the configured environments
+func (c *CLIConfig) credentials() Credentials {
+	credentials := Credentials{Environments: make(map[string]EnvironmentCredentials)}
+	for name, env := range c.Environments {
+		if creds := env.credentials(); !creds.empty() {
+			credentials.Environments[name] = creds
+		}
+	}
+	return credentials
+}
+
+// applyCredentials sets the tokens of the configured environments from credentials
+func (c *CLIConfig) applyCredentials(credentials Credentials) {
+	for name, creds := range credentials.Environments {
+		env, ok := c.Environments[name]
+		if !ok || creds.empty() {
+			continue
+		}
+		env.setCredentials(creds)
+		c.Environments[name] = env
+	}
+}
+
+// credentials returns the tokens of the environment
+func (e *Environment) credentials() EnvironmentCredentials {
+	return EnvironmentCredentials{
+		AccessToken:  e.AccessToken,
+		RefreshToken: e.RefreshToken,
+		ExpiresAt:    e.ExpiresAt,
+		TokenType:    e.TokenType,
+		ClientSecret: e.ClientSecret,
+	}
+}
+
+// setCredentials replaces the tokens of the environment
+func (e *Environment) setCredentials(creds EnvironmentCredentials) {
+	e.AccessToken = creds.AccessToken
+	e.RefreshToken = creds.RefreshToken
+	e.ExpiresAt = creds.ExpiresAt
+	e.TokenType = creds.TokenType
+	e.ClientSecret = creds.ClientSecret
+}
+
+// sharedProfile returns the non-default profile whose credentials are kept under profiles of a
+// file shared through CredentialsFileEnv, or "" when the file holds the active profile's alone
+func sharedProfile() string {
+	if os.Getenv(CredentialsFileEnv) == "" || ActiveProfile() == DefaultProfile {
+		return ""
+	}
+	return ActiveProfile()
+}
+
+// loadCredentials reads the credentials of the active profile; a missing file has no credentials
+func loadCredentials() (Credentials, error) {
+	path, err := GetCredentialsPath()
+	if err != nil {
+		return Credentials{}, err
+	}
+	file, err := readCredentialsFile(path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if name := sharedProfile(); name != "" {
+		return file.Profiles[name], nil
+	}
+	file.Profiles = nil
+	return file, nil
+}
+
+// readCredentialsFile reads a credentials file as a whole; a missing file has no credentials
+func readCredentialsFile(path string) (Credentials, error) {
+	var credentials Credentials
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return credentials, nil
+	}
+	if err != nil {
+		return credentials, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &credentials); err != nil {
+		return credentials, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	return credentials, nil
+}
+
+// saveCredentials writes the credentials of the active profile, readable by the user only. In a
+// file shared through CredentialsFileEnv the credentials of the other profiles are kept.
+func saveCredentials(credentials Credentials) error {
+	path, err := GetCredentialsPath()
+	if err != nil {
+		return err
+	}
+
+	if os.Getenv(CredentialsFileEnv) != "" {
+		file, err := readCredentialsFile(path)
+		if err != nil {
+			return err
+		}
+		if name := sharedProfile(); name != "" {
+			if file.Profiles == nil {
+				file.Profiles = make(map[string]Credentials)
+			}
+			if len(credentials.Environments) == 0 {
+				delete(file.Profiles, name)
+			} else {
+				file.Profiles[name] = Credentials{Environments: credentials.Environments}
+			}
+			credentials = file
+		} else {
+			credentials.Profiles = file.Profiles
+		}
+	}
+
+	data, err := yaml.Marshal(credentials)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	// Unchanged credentials are not rewritten, so a read-only mounted file keeps working
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	// WriteFile keeps the permissions of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("failed to restrict credentials file permissions: %w", err)
+	}
+
+	return nil
+}
+
+// migrateCredentials moves tokens found in config.yml, where older versions stored them, to the
+// credentials file. Tokens already in the credentials file win.
+func (c *CLIConfig) migrateCredentials(configData []byte, credentials Credentials) error {
+	var legacy Credentials
+	if err := yaml.Unmarshal(configData, &legacy); err != nil {
+		return fmt.Errorf("failed to parse CLI config file: %w", err)
+	}
+
+	migrated := false
+	for name, creds := range legacy.Environments {
+		if creds.empty() {
+			continue
+		}
+		migrated = true
+		if _, ok := credentials.Environments[name]; ok {
+			continue
+		}
+		if env, ok := c.Environments[name]; ok {
+			env.setCredentials(creds)
+			c.Environments[name] = env
+		}
+	}
+	if !migrated {
+		return nil
+	}
+
+	if err := c.Save(); err != nil {
+		return fmt.Errorf("failed to move tokens to the credentials file: %w", err)
+	}
+	logging.Info("🔐 Moved tokens from config.yml to the credentials file")
+	return nil
+}
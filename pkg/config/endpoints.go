@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultRegion is the region of environments that do not name one
+const DefaultRegion = "us"
+
+// API environments are the deployments of the platform the CLI talks to. Production resolves
+// the endpoints of each environment's region; the others replace all of them.
+const (
+	APIEnvProd    = "prod"
+	APIEnvStaging = "staging"
+	APIEnvLocal   = "local"
+)
+
+// APIEnvs are the API environments, production first
+var APIEnvs = []string{APIEnvProd, APIEnvStaging, APIEnvLocal}
+
+// Endpoints are the URLs of a Blimu deployment
+type Endpoints struct {
+	// PlatformAPI is the API the CLI manages workspaces, environments and definitions with
+	PlatformAPI string `yaml:"platform_api,omitempty"`
+	// RuntimeAPI is the API applications call, e.g. through generated SDKs
+	RuntimeAPI string `yaml:"runtime_api,omitempty"`
+	// Docs is the documentation site
+	Docs string `yaml:"docs,omitempty"`
+}
+
+// Merge returns the endpoints with the non-empty fields of override applied. Either may be nil.
+func (e *Endpoints) Merge(override *Endpoints) Endpoints {
+	var merged Endpoints
+	if e != nil {
+		merged = *e
+	}
+	if override == nil {
+		return merged
+	}
+	if override.PlatformAPI != "" {
+		merged.PlatformAPI = override.PlatformAPI
+	}
+	if override.RuntimeAPI != "" {
+		merged.RuntimeAPI = override.RuntimeAPI
+	}
+	if override.Docs != "" {
+		merged.Docs = override.Docs
+	}
+	return merged
+}
+
+// regionEndpoints are the built-in endpoints of each region; the regions of the CLI config add to
+// and override them
+var regionEndpoints = map[string]Endpoints{
+	DefaultRegion: {
+		PlatformAPI: "https://platform-api.blimu.dev",
+		RuntimeAPI:  "https://api.blimu.dev",
+		Docs:        "https://docs.blimu.dev",
+	},
+}
+
+// apiEnvEndpoints are the built-in endpoints of the non-production API environments; the api_envs
+// of the CLI config override them
+var apiEnvEndpoints = map[string]Endpoints{
+	APIEnvStaging: {
+		PlatformAPI: "https://platform-api.staging.blimu.dev",
+		RuntimeAPI:  "https://api.staging.blimu.dev",
+		Docs:        "https://docs.blimu.dev",
+	},
+	APIEnvLocal: {
+		PlatformAPI: "http://localhost:3010",
+		RuntimeAPI:  "http://localhost:3000",
+		Docs:        "https://docs.blimu.dev",
+	},
+}
+
+// legacyPlatformURLs are deployment-specific URLs that older versions stored as the api_url of
+// environments; they resolve to the endpoints of the region instead
+var legacyPlatformURLs = map[string]bool{
+	"https://app-api-42118893108.us-central1.run.app":   true,
+	"https://blimu-api-42118893108.us-central1.run.app": true,
+}
+
+// EnvironmentRegion returns the region of an environment (which may be nil): its own, else the
+// CLI config's, else DefaultRegion
+func (c *CLIConfig) EnvironmentRegion(env *Environment) string {
+	if env != nil && env.Region != "" {
+		return env.Region
+	}
+	if c.Region != "" {
+		return c.Region
+	}
+	return DefaultRegion
+}
+
+// EnvironmentEndpoints resolves the endpoints of an environment (which may be nil): those of its
+// region, overridden by the endpoints of the CLI config, then by the environment's own, then by
+// its api_url. LoadCLIConfig rejects configs naming unknown regions.
+func (c *CLIConfig) EnvironmentEndpoints(env *Environment) Endpoints {
+	region := c.EnvironmentRegion(env)
+	builtin, ok := regionEndpoints[region]
+	if !ok {
+		// Regions of the CLI config share the docs of the default region unless they set their own
+		builtin = Endpoints{Docs: regionEndpoints[DefaultRegion].Docs}
+	}
+	endpoints := builtin.Merge(c.regionOverride(region))
+	endpoints = endpoints.Merge(c.Endpoints)
+	if env == nil {
+		return endpoints
+	}
+	endpoints = endpoints.Merge(env.Endpoints)
+	if env.APIURL != "" && !legacyPlatformURLs[env.APIURL] {
+		endpoints.PlatformAPI = env.APIURL
+	}
+	return endpoints
+}
+
+// APIEnvEndpoints returns the endpoints of a non-production API environment, which replace those
+// of every environment: the built-in ones with the api_envs of the CLI config applied
+func (c *CLIConfig) APIEnvEndpoints(apiEnv string) Endpoints {
+	builtin := apiEnvEndpoints[apiEnv]
+	var override *Endpoints
+	if endpoints, ok := c.APIEnvs[apiEnv]; ok {
+		override = &endpoints
+	}
+	return builtin.Merge(override)
+}
+
+// ValidateAPIEnv checks that an API environment exists
+func ValidateAPIEnv(apiEnv string) error {
+	for _, name := range APIEnvs {
+		if name == apiEnv {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown API environment '%s' (known: %s)", apiEnv, strings.Join(APIEnvs, ", "))
+}
+
+// regionOverride returns the endpoints the CLI config sets for a region, if any
+func (c *CLIConfig) regionOverride(region string) *Endpoints {
+	if endpoints, ok := c.Regions[region]; ok {
+		return &endpoints
+	}
+	return nil
+}
+
+// HasRegion reports whether a region is built in or defined by the CLI config
+func (c *CLIConfig) HasRegion(region string) bool {
+	if _, ok := regionEndpoints[region]; ok {
+		return true
+	}
+	_, ok := c.Regions[region]
+	return ok
+}
+
+// RegionNames returns the built-in regions and those of the CLI config, sorted
+func (c *CLIConfig) RegionNames() []string {
+	var names []string
+	for name := range regionEndpoints {
+		names = append(names, name)
+	}
+	for name := range c.Regions {
+		if _, ok := regionEndpoints[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateRegions checks that the regions named by the config and its environments exist, that
+// regions defined by the config alone set a platform API, and that api_envs only override the
+// non-production API environments
+func (c *CLIConfig) validateRegions() error {
+	for name := range c.APIEnvs {
+		if _, ok := apiEnvEndpoints[name]; !ok {
+			return fmt.Errorf("api_envs of the CLI config can only override %s and %s, not '%s'", APIEnvStaging, APIEnvLocal, name)
+		}
+	}
+	for name, endpoints := range c.Regions {
+		if _, ok := regionEndpoints[name]; !ok && endpoints.PlatformAPI == "" {
+			return fmt.Errorf("region '%s' of the CLI config has no platform_api endpoint", name)
+		}
+	}
+	if c.Region != "" && !c.HasRegion(c.Region) {
+		return fmt.Errorf("unknown region '%s' in the CLI config (known: %s)", c.Region, strings.Join(c.RegionNames(), ", "))
+	}
+	for name, env := range c.Environments {
+		if env.Region != "" && !c.HasRegion(env.Region) {
+			return fmt.Errorf("unknown region '%s' of environment '%s' (known: %s)", env.Region, name, strings.Join(c.RegionNames(), ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// historyLimit is the number of commands kept in the command history
+const historyLimit = 100
+
+// HistoryEntry records a command the CLI ran, for support bundles. Flag values and arguments are
+// not recorded: they may hold secrets.
+type HistoryEntry struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Flags    []string  `json:"flags,omitempty"`
+	Profile  string    `json:"profile,omitempty"`
+	Version  string    `json:"version"`
+	Duration string    `json:"duration"`
+	ExitCode int       `json:"exit_code"`
+	Error    string    `json:"error,omitempty"`
+	// RequestID is the X-Request-Id of the platform request that failed
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// GetHistoryPath returns the path of the command history, shared by all profiles
+func GetHistoryPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "logs", "history.jsonl"), nil
+}
+
+// AppendHistory adds entry to the command history, keeping the last 100 commands
+func AppendHistory(entry HistoryEntry) error {
+	path, err := GetHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create logs directory: %w", err)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	lines, err := readHistoryLines(path)
+	if err != nil {
+		return err
+	}
+	lines = append(lines, line)
+	if len(lines) > historyLimit {
+		lines = lines[len(lines)-historyLimit:]
+	}
+
+	data := append(bytes.Join(lines, []byte("\n")), '\n')
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write command history: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory returns the recorded commands, oldest first. Lines that do not parse are skipped.
+func LoadHistory() ([]HistoryEntry, error) {
+	path, err := GetHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	lines, err := readHistoryLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]HistoryEntry, 0, len(lines))
+	for _, line := range lines {
+		var entry HistoryEntry
+		if json.Unmarshal(line, &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func readHistoryLines(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read command history: %w", err)
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	return lines, nil
+}
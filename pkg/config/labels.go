@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// labelKeyPattern restricts label keys to names that are safe in selectors and YAML
+var labelKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]*[a-zA-Z0-9])?$`)
+
+// ParseLabelArgs parses label arguments as given to 'blimu env label': key=value sets a label and
+// key- removes it
+func ParseLabelArgs(args []string) (set map[string]string, remove []string, err error) {
+	set = map[string]string{}
+	for _, arg := range args {
+		if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+			if !labelKeyPattern.MatchString(key) {
+				return nil, nil, fmt.Errorf("invalid label key '%s'", key)
+			}
+			remove = append(remove, key)
+			continue
+		}
+
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid label '%s' (expected key=value to set or key- to remove)", arg)
+		}
+		if !labelKeyPattern.MatchString(key) {
+			return nil, nil, fmt.Errorf("invalid label key '%s'", key)
+		}
+		if strings.ContainsAny(value, ",=! ") {
+			return nil, nil, fmt.Errorf("invalid value for label '%s': values cannot contain ',', '=', '!' or spaces", key)
+		}
+		set[key] = value
+	}
+	return set, remove, nil
+}
+
+// FormatLabels joins labels as sorted key=value pairs
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Selector operators
+const (
+	SelectorEquals    = "="
+	SelectorNotEquals = "!="
+	SelectorExists    = "exists"
+	SelectorNotExists = "!exists"
+)
+
+// Requirement is one comma-separated term of a selector
+type Requirement struct {
+	Key      string
+	Operator string
+	Value    string
+}
+
+// Selector matches environments by label, e.g. "team=payments,tier!=dev". Every requirement must
+// hold; "key" requires the label to be set and "!key" requires it to be absent.
+type Selector []Requirement
+
+// ParseSelector parses a comma-separated label selector
+func ParseSelector(s string) (Selector, error) {
+	var selector Selector
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		var requirement Requirement
+		switch {
+		case strings.Contains(term, "!="):
+			key, value, _ := strings.Cut(term, "!=")
+			requirement = Requirement{Key: strings.TrimSpace(key), Operator: SelectorNotEquals, Value: strings.TrimSpace(value)}
+		case strings.Contains(term, "="):
+			key, value, _ := strings.Cut(strings.Replace(term, "==", "=", 1), "=")
+			requirement = Requirement{Key: strings.TrimSpace(key), Operator: SelectorEquals, Value: strings.TrimSpace(value)}
+		case strings.HasPrefix(term, "!"):
+			requirement = Requirement{Key: strings.TrimSpace(term[1:]), Operator: SelectorNotExists}
+		default:
+			requirement = Requirement{Key: term, Operator: SelectorExists}
+		}
+
+		if !labelKeyPattern.MatchString(requirement.Key) {
+			return nil, fmt.Errorf("invalid selector '%s': bad label key '%s'", s, requirement.Key)
+		}
+		selector = append(selector, requirement)
+	}
+
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("selector is empty")
+	}
+	return selector, nil
+}
+
+// Matches reports whether labels satisfy every requirement of the selector
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		value, ok := labels[requirement.Key]
+		switch requirement.Operator {
+		case SelectorEquals:
+			if !ok || value != requirement.Value {
+				return false
+			}
+		case SelectorNotEquals:
+			if ok && value == requirement.Value {
+				return false
+			}
+		case SelectorExists:
+			if !ok {
+				return false
+			}
+		case SelectorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ProtectedBy returns the first protected_environments selector matching the environment's labels,
+// or an empty string when the environment is not protected
+func (c *CLIConfig) ProtectedBy(env Environment) (string, error) {
+	for _, raw := range c.ProtectedEnvironments {
+		selector, err := ParseSelector(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid protected_environments entry in CLI config: %w", err)
+		}
+		if selector.Matches(env.Labels) {
+			return raw, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HeldLock records a definitions lock taken from this machine, so that pushes by its holder are
+// not refused by their own lock
+type HeldLock struct {
+	WorkspaceID   string    `json:"workspace_id"`
+	EnvironmentID string    `json:"environment_id"`
+	LockID        string    `json:"lock_id"`
+	LockedAt      time.Time `json:"locked_at"`
+}
+
+// getLockDir returns the held locks directory for a workspace
+func getLockDir(workspaceID string) (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	lockDir := filepath.Join(cacheDir, "locks", workspaceID)
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create locks directory: %w", err)
+	}
+
+	return lockDir, nil
+}
+
+// LoadHeldLock loads the lock held on an environment's definitions.
+// Returns nil without error if no lock was taken from this machine.
+func LoadHeldLock(workspaceID, environmentID string) (*HeldLock, error) {
+	lockDir, err := getLockDir(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(lockDir, environmentID+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read held lock: %w", err)
+	}
+
+	var lock HeldLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse held lock: %w", err)
+	}
+
+	return &lock, nil
+}
+
+// SaveHeldLock stores a lock taken on an environment's definitions
+func SaveHeldLock(lock *HeldLock) error {
+	lockDir, err := getLockDir(lock.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal held lock: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(lockDir, lock.EnvironmentID+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write held lock: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteHeldLock removes the record of a lock held on an environment's definitions, if any
+func DeleteHeldLock(workspaceID, environmentID string) error {
+	lockDir, err := getLockDir(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(lockDir, environmentID+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove held lock: %w", err)
+	}
+
+	return nil
+}
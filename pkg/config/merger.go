@@ -90,13 +90,14 @@ func MergeOpenAPISpecs(baseSpec, customSpec map[string]interface{}) (map[string]
 		mergedSpec[k] = v
 	}
 
-	// Merge paths
+	// Merge paths (into a copy so the base spec is never modified and repeated merges stay identical)
 	if customPaths, ok := customSpec["paths"].(map[string]interface{}); ok {
 		if basePaths, ok := mergedSpec["paths"].(map[string]interface{}); ok {
-			// Merge custom paths into base paths
+			mergedPaths := copyMap(basePaths)
 			for path, pathSpec := range customPaths {
-				basePaths[path] = pathSpec
+				mergedPaths[path] = pathSpec
 			}
+			mergedSpec["paths"] = mergedPaths
 		} else {
 			// If base spec has no paths, use custom paths
 			mergedSpec["paths"] = customPaths
@@ -106,20 +107,24 @@ func MergeOpenAPISpecs(baseSpec, customSpec map[string]interface{}) (map[string]
 	// Merge components (schemas, security schemes, etc.)
 	if customComponents, ok := customSpec["components"].(map[string]interface{}); ok {
 		if baseComponents, ok := mergedSpec["components"].(map[string]interface{}); ok {
+			mergedComponents := copyMap(baseComponents)
 			// Merge each component type
 			for componentType, customComponentSpecs := range customComponents {
 				if customSpecs, ok := customComponentSpecs.(map[string]interface{}); ok {
-					if baseSpecs, ok := baseComponents[componentType].(map[string]interface{}); ok {
+					if baseSpecs, ok := mergedComponents[componentType].(map[string]interface{}); ok {
 						// Merge custom component specs into base component specs
+						mergedSpecs := copyMap(baseSpecs)
 						for specName, specDef := range customSpecs {
-							baseSpecs[specName] = specDef
+							mergedSpecs[specName] = specDef
 						}
+						mergedComponents[componentType] = mergedSpecs
 					} else {
 						// If base doesn't have this component type, add it
-						baseComponents[componentType] = customSpecs
+						mergedComponents[componentType] = customSpecs
 					}
 				}
 			}
+			mergedSpec["components"] = mergedComponents
 		} else {
 			// If base spec has no components, use custom components
 			mergedSpec["components"] = customComponents
@@ -129,7 +134,8 @@ func MergeOpenAPISpecs(baseSpec, customSpec map[string]interface{}) (map[string]
 	// Merge tags (if present in custom spec)
 	if customTags, ok := customSpec["tags"].([]interface{}); ok {
 		if baseTags, ok := mergedSpec["tags"].([]interface{}); ok {
-			// Append custom tags to base tags (avoiding duplicates)
+			// Append custom tags to a copy of the base tags (avoiding duplicates)
+			baseTags = append([]interface{}{}, baseTags...)
 			existingTags := make(map[string]bool)
 			for _, tag := range baseTags {
 				if tagMap, ok := tag.(map[string]interface{}); ok {
@@ -157,3 +163,12 @@ func MergeOpenAPISpecs(baseSpec, customSpec map[string]interface{}) (map[string]
 
 	return mergedSpec, nil
 }
+
+// copyMap returns a shallow copy of a map
+func copyMap(data map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a location in a .blimu file
+type Position struct {
+	// File is the path of the file, e.g. .blimu/resources.yml
+	File string
+	// Line and Column are 1-based; zero when unknown
+	Line   int
+	Column int
+}
+
+// Positions maps keys and list items of the loaded .blimu files to where they were written.
+// Paths are the keys leading to a value, with list indexes as decimal strings.
+type Positions map[string]Position
+
+// positionKey joins a file name and path into a map key; NUL cannot appear in YAML keys
+func positionKey(name string, path []string) string {
+	return name + "\x00" + strings.Join(path, "\x00")
+}
+
+// itemKey addresses a scalar list item by value rather than index
+func itemKey(name, value string, path []string) string {
+	return positionKey(name, path) + "\x00=" + value
+}
+
+// record walks a parsed file and stores the position of every key and list item
+func (p Positions) record(name, file string, data []byte) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return
+	}
+	doc := root.Content[0]
+	p[positionKey(name, nil)] = Position{File: file, Line: doc.Line, Column: doc.Column}
+	p.walk(name, file, doc, nil)
+}
+
+// recordComposed stores the position of every key and list item of a composed definition file,
+// each in the file it was written in
+func (p Positions) recordComposed(name, blimuDir string, composed *ComposedDefinition) {
+	p[positionKey(name, nil)] = Position{File: filepath.Join(blimuDir, name), Line: 1, Column: 1}
+	for i := 0; i+1 < len(composed.Node.Content); i += 2 {
+		key, value := composed.Node.Content[i], composed.Node.Content[i+1]
+		file := filepath.Join(blimuDir, filepath.FromSlash(composed.Sources[key.Value]))
+		p[positionKey(name, []string{key.Value})] = Position{File: file, Line: key.Line, Column: key.Column}
+		p.walk(name, file, value, []string{key.Value})
+	}
+}
+
+func (p Positions) walk(name, file string, node *yaml.Node, path []string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := append(append([]string{}, path...), key.Value)
+			p[positionKey(name, keyPath)] = Position{File: file, Line: key.Line, Column: key.Column}
+			p.walk(name, file, value, keyPath)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := append(append([]string{}, path...), strconv.Itoa(i))
+			position := Position{File: file, Line: item.Line, Column: item.Column}
+			p[positionKey(name, itemPath)] = position
+			if item.Kind == yaml.ScalarNode {
+				p[itemKey(name, item.Value, path)] = position
+			}
+			p.walk(name, file, item, itemPath)
+		}
+	}
+}
+
+// Of returns the position of the value at path in a .blimu file (e.g. "resources.yml"). When the
+// path was not written, the position of its nearest written parent is returned.
+func (p Positions) Of(name string, path ...string) Position {
+	for n := len(path); n >= 0; n-- {
+		if position, ok := p[positionKey(name, path[:n])]; ok {
+			return position
+		}
+	}
+	return Position{}
+}
+
+// OfItem returns the position of the list item equal to value in the list at path, falling back
+// to the position of the list itself
+func (p Positions) OfItem(name, value string, path ...string) Position {
+	if position, ok := p[itemKey(name, value, path)]; ok {
+		return position
+	}
+	return p.Of(name, path...)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// ProfileEnv selects the profile when --profile is not given
+const ProfileEnv = "BLIMU_PROFILE"
+
+// DefaultProfile is the profile kept directly in the config directory, as before profiles existed
+const DefaultProfile = "default"
+
+// profileNamePattern restricts profile names to safe directory names
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// profile is the profile selected with --profile for this process
+var profile string
+
+// SetProfile makes this process use the named profile instead of BLIMU_PROFILE or the default
+// one (the global --profile flag)
+func SetProfile(name string) error {
+	if name != "" && !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s': use letters, digits, '.', '_' and '-'", name)
+	}
+	profile = name
+	return nil
+}
+
+// ActiveProfile returns the profile in use: --profile, else BLIMU_PROFILE, else the default one
+func ActiveProfile() string {
+	if profile != "" {
+		return profile
+	}
+	if name := os.Getenv(ProfileEnv); name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// GetProfileDir returns the directory holding the config and credentials of the active profile.
+// Each profile has its own environments and tokens, e.g. one per customer account; the default
+// profile lives in the config directory itself and the others in its profiles directory.
+func GetProfileDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	name := ActiveProfile()
+	if name == DefaultProfile {
+		return configDir, nil
+	}
+	if !profileNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid profile name '%s' in %s: use letters, digits, '.', '_' and '-'", name, ProfileEnv)
+	}
+
+	profileDir := filepath.Join(configDir, "profiles", name)
+	if err := os.MkdirAll(profileDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	return profileDir, nil
+}
+
+// ListProfiles returns the names of the profiles, the default one first
+func ListProfiles() ([]string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(configDir, "profiles"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultProfile && profileNamePattern.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...), nil
+}
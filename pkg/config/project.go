@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Branch guard modes
+const (
+	BranchGuardWarn = "warn"
+	BranchGuardFail = "fail"
+)
+
+// ProjectConfig represents project-wide settings stored in .blimu/project.yml
+type ProjectConfig struct {
+	Branches BranchGuardConfig `yaml:"branches,omitempty"`
+}
+
+// BranchGuardConfig maps environments to the git branches allowed to push to them.
+// Environment keys match a local environment name, an environment ID or a lookup key.
+//
+//	branches:
+//	  mode: fail
+//	  environments:
+//	    prod: main
+//	    staging: [develop, release/*]
+type BranchGuardConfig struct {
+	Mode         string                `yaml:"mode,omitempty"`
+	Environments map[string]BranchList `yaml:"environments,omitempty"`
+}
+
+// BranchList is a list of branch patterns that also accepts a single string in YAML
+type BranchList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (b *BranchList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*b = BranchList{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*b = list
+	return nil
+}
+
+// GuardMode returns the configured mode, defaulting to fail
+func (c BranchGuardConfig) GuardMode() string {
+	if c.Mode == "" {
+		return BranchGuardFail
+	}
+	return c.Mode
+}
+
+// LoadProjectConfig loads .blimu/project.yml from a directory.
+// A missing file yields an empty config.
+func LoadProjectConfig(dir string) (*ProjectConfig, error) {
+	configPath := filepath.Join(dir, ".blimu", "project.yml")
+	project := &ProjectConfig{}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return project, nil
+		}
+		return nil, fmt.Errorf("failed to read project.yml: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, project); err != nil {
+		return nil, fmt.Errorf("failed to parse project.yml: %w", err)
+	}
+
+	switch project.Branches.Mode {
+	case "", BranchGuardWarn, BranchGuardFail:
+	default:
+		return nil, fmt.Errorf("invalid branches.mode '%s' in project.yml (expected '%s' or '%s')",
+			project.Branches.Mode, BranchGuardWarn, BranchGuardFail)
+	}
+
+	return project, nil
+}
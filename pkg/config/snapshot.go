@@ -0,0 +1,90 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefinitionsSnapshot records the definitions last synced with an environment by a pull or push.
+// Sections maps each section name (resources, entitlements, features, plans) to the hash of its content.
+type DefinitionsSnapshot struct {
+	WorkspaceID   string            `json:"workspace_id"`
+	EnvironmentID string            `json:"environment_id"`
+	Sections      map[string]string `json:"sections"`
+	SyncedAt      time.Time         `json:"synced_at"`
+}
+
+// getSnapshotDir returns the definitions snapshot directory for a workspace
+func getSnapshotDir(workspaceID string) (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	snapshotDir := filepath.Join(cacheDir, "definitions", workspaceID)
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create definitions snapshot directory: %w", err)
+	}
+
+	return snapshotDir, nil
+}
+
+// LoadDefinitionsSnapshot loads the definitions snapshot for an environment.
+// Returns nil without error if nothing has been synced yet.
+func LoadDefinitionsSnapshot(workspaceID, environmentID string) (*DefinitionsSnapshot, error) {
+	snapshotDir, err := getSnapshotDir(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(snapshotDir, environmentID+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read definitions snapshot: %w", err)
+	}
+
+	var snapshot DefinitionsSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse definitions snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// SaveDefinitionsSnapshot stores the definitions snapshot for an environment
+func SaveDefinitionsSnapshot(snapshot *DefinitionsSnapshot) error {
+	snapshotDir, err := getSnapshotDir(snapshot.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal definitions snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(snapshotDir, snapshot.EnvironmentID+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write definitions snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDefinitionsSnapshot removes the definitions snapshot for an environment, if any
+func DeleteDefinitionsSnapshot(workspaceID, environmentID string) error {
+	snapshotDir, err := getSnapshotDir(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(snapshotDir, environmentID+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove definitions snapshot: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SplitDir returns the directory whose files are merged into a definition file, e.g. resources.d
+// for resources.yml
+func SplitDir(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".d"
+}
+
+// SplitFiles returns the YAML files of a definition file's split directory in the order they are
+// merged, relative to the .blimu directory with forward slashes. A missing directory has none.
+func SplitFiles(blimuDir, name string) ([]string, error) {
+	dir := SplitDir(name)
+	entries, err := os.ReadDir(filepath.Join(blimuDir, dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		files = append(files, dir+"/"+entry.Name())
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// HasResources reports whether a .blimu directory defines resources, in resources.yml or in
+// resources.d
+func HasResources(blimuDir string) bool {
+	if _, err := os.Stat(filepath.Join(blimuDir, "resources.yml")); err == nil {
+		return true
+	}
+	files, _ := SplitFiles(blimuDir, "resources.yml")
+	return len(files) > 0
+}
+
+// ComposeDefinition loads a definition file other than resources.yml (see ComposeResources) with
+// the files of its split directory merged in, e.g. entitlements.yml and entitlements.d/*.yml. Each
+// file maps item names to items; an item defined in two files is an error. When neither the file
+// nor its split directory exist, the error satisfies os.IsNotExist.
+func ComposeDefinition(blimuDir, name string, values Values) (*ComposedDefinition, error) {
+	files, err := SplitFiles(blimuDir, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(blimuDir, name)); err == nil || len(files) == 0 {
+		files = append([]string{name}, files...)
+	}
+
+	item := strings.TrimSuffix(strings.TrimSuffix(name, filepath.Ext(name)), "s")
+	composed := &ComposedDefinition{Sources: map[string]string{}}
+	for _, file := range files {
+		data, err := ReadDefinitionFile(filepath.Join(blimuDir, filepath.FromSlash(file)), values)
+		if err != nil {
+			return nil, err
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if len(root.Content) == 0 {
+			continue
+		}
+		doc := root.Content[0]
+		if doc.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s:%d: expected a mapping of %s names to %ss", file, doc.Line, item, item)
+		}
+
+		if composed.Node == nil {
+			composed.Node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for i := 0; i+1 < len(doc.Content); i += 2 {
+			key := doc.Content[i]
+			if source, ok := composed.Sources[key.Value]; ok {
+				return nil, fmt.Errorf("%s:%d: %s '%s' is already defined in %s", file, key.Line, item, key.Value, source)
+			}
+			composed.Sources[key.Value] = file
+			composed.Node.Content = append(composed.Node.Content, key, doc.Content[i+1])
+		}
+	}
+	return composed, nil
+}
+
+// splitSources maps the items a definition file's split directory defines to the file defining
+// them. Directories that fail to load are treated as defining nothing.
+func splitSources(blimuDir, name string) map[string]string {
+	files, err := SplitFiles(blimuDir, name)
+	if err != nil || len(files) == 0 {
+		return nil
+	}
+	values, err := LoadValues(blimuDir)
+	if err != nil {
+		return nil
+	}
+
+	var composed *ComposedDefinition
+	if name == "resources.yml" {
+		composed, err = ComposeResources(blimuDir, values)
+	} else {
+		composed, err = ComposeDefinition(blimuDir, name, values)
+	}
+	if err != nil {
+		return nil
+	}
+
+	sources := map[string]string{}
+	for key, source := range composed.Sources {
+		if strings.HasPrefix(source, SplitDir(name)+"/") {
+			sources[key] = source
+		}
+	}
+	return sources
+}
+
+// saveDefinitionFile writes the items of a definition file. Items that were loaded from the
+// file's split directory are written back to the file they came from; the others, including new
+// items, go to the definition file itself. Split files left without items are removed.
+func saveDefinitionFile(blimuDir, name string, items interface{}) error {
+	var node yaml.Node
+	if err := node.Encode(items); err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	sources := splitSources(blimuDir, name)
+	contents := map[string]*yaml.Node{name: {Kind: yaml.MappingNode, Tag: "!!map"}}
+	for _, source := range sources {
+		contents[source] = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		file := name
+		if source, ok := sources[node.Content[i].Value]; ok {
+			file = source
+		}
+		contents[file].Content = append(contents[file].Content, node.Content[i], node.Content[i+1])
+	}
+
+	for file, content := range contents {
+		path := filepath.Join(blimuDir, filepath.FromSlash(file))
+		if file != name && len(content.Content) == 0 {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+			continue
+		}
+		if file == name && len(content.Content) == 0 && len(sources) > 0 {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				// Everything lives in the split directory
+				continue
+			}
+		}
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", file, err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValuesFile is the .blimu file holding default values for ${VAR} references in definition files
+const ValuesFile = "values.yml"
+
+// DefinitionFiles are the .blimu files whose ${VAR} references are substituted when loaded
+var DefinitionFiles = []string{"resources.yml", "entitlements.yml", "features.yml", "plans.yml"}
+
+// variablePattern matches $${...} escapes and ${NAME} or ${NAME:-default} references
+var variablePattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// Values substitutes ${VAR} references in definition files. Environment variables take precedence
+// over the values of .blimu/values.yml.
+type Values map[string]string
+
+// LoadValues loads .blimu/values.yml, a flat mapping of variable names to scalar values.
+// A missing file yields no values; environment variables still apply.
+func LoadValues(blimuDir string) (Values, error) {
+	values := Values{}
+	data, err := os.ReadFile(filepath.Join(blimuDir, ValuesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return values, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ValuesFile, err)
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ValuesFile, err)
+	}
+	for name, node := range raw {
+		if node.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s:%d: value of '%s' must be a string, number or boolean", ValuesFile, node.Line, name)
+		}
+		values[name] = node.Value
+	}
+	return values, nil
+}
+
+// Lookup returns the value of a variable from the environment or values.yml
+func (v Values) Lookup(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	value, ok := v[name]
+	return value, ok
+}
+
+// Expand substitutes the ${NAME} and ${NAME:-default} references of a file's contents; $${ is
+// kept as a literal ${. Referencing an undefined variable without a default is an error.
+func (v Values) Expand(file string, data []byte) ([]byte, error) {
+	if !strings.Contains(string(data), "${") {
+		return data, nil
+	}
+
+	var undefined []string
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		lines[i] = variablePattern.ReplaceAllStringFunc(line, func(match string) string {
+			if match == "$${" {
+				return "${"
+			}
+			groups := variablePattern.FindStringSubmatch(match)
+			if value, ok := v.Lookup(groups[1]); ok {
+				return value
+			}
+			if groups[2] != "" {
+				return groups[3]
+			}
+			undefined = append(undefined, fmt.Sprintf("%s:%d: '%s'", file, i+1, groups[1]))
+			return match
+		})
+	}
+
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("undefined variables (set them in the environment or in .blimu/%s):\n  %s", ValuesFile, strings.Join(undefined, "\n  "))
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// ReadDefinitionFile reads a .blimu definition file with its ${VAR} references substituted
+func ReadDefinitionFile(path string, values Values) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return values.Expand(filepath.Base(path), data)
+}
+
+// TemplatedFiles returns the definition files of a .blimu directory that reference variables
+func TemplatedFiles(blimuDir string) []string {
+	var files []string
+	for _, name := range DefinitionFiles {
+		data, err := os.ReadFile(filepath.Join(blimuDir, name))
+		if err == nil && variablePattern.Match(data) {
+			files = append(files, name)
+		}
+	}
+	return files
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceFile is the .blimu file of a monorepo root that registers its definition directories
+const WorkspaceFile = "workspace.yml"
+
+// WorkspaceConfig represents the projects of a monorepo stored in .blimu/workspace.yml
+//
+//	projects:
+//	  billing:
+//	    path: services/billing
+//	    environment: billing-dev
+//	  auth:
+//	    path: services/auth
+type WorkspaceConfig struct {
+	Projects map[string]WorkspaceProject `yaml:"projects"`
+
+	// Root is the directory holding the .blimu directory the file was loaded from
+	Root string `yaml:"-"`
+}
+
+// WorkspaceProject is a definition directory of a monorepo
+type WorkspaceProject struct {
+	// Path is the directory holding the project's .blimu directory, relative to the workspace root
+	Path string `yaml:"path"`
+	// Environment is the local environment name, lookup key or ID the project pushes to; the
+	// active environment is used when empty
+	Environment string `yaml:"environment,omitempty"`
+}
+
+// LoadWorkspaceConfig loads .blimu/workspace.yml from a directory; nil when there is none
+func LoadWorkspaceConfig(dir string) (*WorkspaceConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ".blimu", WorkspaceFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", WorkspaceFile, err)
+	}
+
+	workspace := &WorkspaceConfig{Root: dir}
+	if err := yaml.Unmarshal(data, workspace); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", WorkspaceFile, err)
+	}
+	if len(workspace.Projects) == 0 {
+		return nil, fmt.Errorf("%s in %s lists no projects", WorkspaceFile, dir)
+	}
+	for name, project := range workspace.Projects {
+		if project.Path == "" {
+			return nil, fmt.Errorf("project '%s' in %s has no path", name, WorkspaceFile)
+		}
+		if filepath.IsAbs(project.Path) {
+			return nil, fmt.Errorf("project '%s' in %s: path must be relative to the workspace root", name, WorkspaceFile)
+		}
+	}
+	return workspace, nil
+}
+
+// FindWorkspaceConfig searches the directory and its parents for .blimu/workspace.yml; nil when
+// there is none
+func FindWorkspaceConfig(startDir string) (*WorkspaceConfig, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		workspace, err := LoadWorkspaceConfig(dir)
+		if workspace != nil || err != nil {
+			return workspace, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Names returns the project names in alphabetical order
+func (w *WorkspaceConfig) Names() []string {
+	names := make([]string, 0, len(w.Projects))
+	for name := range w.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dir returns the absolute directory of a project
+func (w *WorkspaceConfig) Dir(name string) string {
+	return filepath.Join(w.Root, filepath.FromSlash(w.Projects[name].Path))
+}
@@ -0,0 +1,124 @@
+package demo
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed datasets
+var datasets embed.FS
+
+// DefaultDataset is the dataset seeded when none is given
+const DefaultDataset = "saas"
+
+// seedFile lists the resources of a dataset; it is not a definition file
+const seedFile = "seed.yml"
+
+// Parent references the parent of a seeded resource
+type Parent struct {
+	Type string `yaml:"type"`
+	ID   string `yaml:"id"`
+}
+
+// Resource is a resource created when seeding a dataset
+type Resource struct {
+	Type    string   `yaml:"type"`
+	ID      string   `yaml:"id"`
+	Name    string   `yaml:"name"`
+	Parents []Parent `yaml:"parents"`
+}
+
+// User is an example user of a dataset. Users cannot be seeded by the CLI; they only appear in the tour.
+type User struct {
+	ID   string `yaml:"id"`
+	Role string `yaml:"role"`
+}
+
+// Dataset is a bundled example model: .blimu definition files plus the resources to seed
+type Dataset struct {
+	Name string
+	// Files maps definition file names (resources.yml, ...) to their contents
+	Files     map[string][]byte
+	Resources []Resource
+	Users     []User
+}
+
+// Names returns the names of the bundled datasets
+func Names() []string {
+	entries, err := datasets.ReadDir("datasets")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load loads a bundled dataset by name
+func Load(name string) (*Dataset, error) {
+	dir := path.Join("datasets", name)
+	entries, err := datasets.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unknown dataset '%s' (available: %v)", name, Names())
+	}
+
+	dataset := &Dataset{Name: name, Files: map[string][]byte{}}
+	for _, entry := range entries {
+		data, err := fs.ReadFile(datasets, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dataset file %s: %w", entry.Name(), err)
+		}
+		if entry.Name() != seedFile {
+			dataset.Files[entry.Name()] = data
+			continue
+		}
+
+		var seed struct {
+			Resources []Resource `yaml:"resources"`
+			Users     []User     `yaml:"users"`
+		}
+		if err := yaml.Unmarshal(data, &seed); err != nil {
+			return nil, fmt.Errorf("failed to parse dataset file %s: %w", entry.Name(), err)
+		}
+		dataset.Resources = seed.Resources
+		dataset.Users = seed.Users
+	}
+
+	return dataset, nil
+}
+
+// WriteFiles writes the dataset's definition files to directory/.blimu.
+// Existing definition files are only overwritten with force.
+func (d *Dataset) WriteFiles(directory string, force bool) error {
+	blimuDir := filepath.Join(directory, ".blimu")
+	if !force {
+		for name := range d.Files {
+			if _, err := os.Stat(filepath.Join(blimuDir, name)); err == nil {
+				return fmt.Errorf("%s already exists; use --force to overwrite it", filepath.Join(blimuDir, name))
+			}
+		}
+	}
+
+	if err := os.MkdirAll(blimuDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", blimuDir, err)
+	}
+	for name, data := range d.Files {
+		if err := os.WriteFile(filepath.Join(blimuDir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
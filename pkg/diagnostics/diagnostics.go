@@ -0,0 +1,200 @@
+// Package diagnostics renders validation problems in machine-readable formats: plain JSON for
+// scripts and SARIF 2.1.0 for GitHub code scanning and other CI annotators.
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Output formats
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Severities of diagnostics
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Sources of diagnostics
+const (
+	SourceSchema   = "schema"
+	SourceLocal    = "local"
+	SourcePlatform = "platform"
+)
+
+// Diagnostic is a single problem in a .blimu file
+type Diagnostic struct {
+	// Rule identifies the check, e.g. "inheritance-syntax"
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	// Source is the validation step that found the problem: schema, local or platform
+	Source string `json:"source"`
+	File   string `json:"file"`
+	// Line and Column are 1-based; zero when the position is unknown
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report is the outcome of a validation run
+type Report struct {
+	Valid       bool         `json:"valid"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// CheckFormat rejects unknown output formats
+func CheckFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON, FormatSARIF:
+		return nil
+	}
+	return fmt.Errorf("unknown format '%s' (expected %s, %s or %s)", format, FormatText, FormatJSON, FormatSARIF)
+}
+
+// Write renders the report as JSON or SARIF
+func Write(w io.Writer, format string, report *Report) error {
+	if report.Diagnostics == nil {
+		report.Diagnostics = []Diagnostic{}
+	}
+
+	var document interface{} = report
+	if format == FormatSARIF {
+		document = toSARIF(report)
+	}
+
+	// Messages quote inheritance such as 'organization->admin'; keep it readable
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to encode diagnostics: %w", err)
+	}
+	return nil
+}
+
+// sarifLog is the subset of the SARIF 2.1.0 format the CLI writes
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func toSARIF(report *Report) *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "blimu",
+			InformationURI: "https://blimu.dev",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	rules := map[string]bool{}
+	for _, d := range report.Diagnostics {
+		ruleID := d.Source + "/" + d.Rule
+		if !rules[ruleID] {
+			rules[ruleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               ruleID,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("%s validation: %s", d.Source, d.Rule)},
+			})
+		}
+
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: artifactURI(d.File)}}
+		if d.Line > 0 {
+			location.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+
+		level := "error"
+		if d.Severity == SeverityWarning {
+			level = "warning"
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			Level:     level,
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	return &sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
+
+// artifactURI returns a file path relative to the working directory with forward slashes, which
+// code scanning resolves against the repository root when the CLI runs there
+func artifactURI(file string) string {
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
@@ -0,0 +1,428 @@
+// Package docs renders an environment's OpenAPI spec into API reference pages: an index with
+// the authentication section and one page per resource (OpenAPI tag).
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/naming"
+	"github.com/blimu-dev/blimu-cli/pkg/spec"
+)
+
+// Supported output formats
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// untaggedResource groups operations without tags, matching sdk-gen's fallback service
+const untaggedResource = "misc"
+
+// Reference is the renderable model of a spec
+type Reference struct {
+	Title       string
+	Version     string
+	Description string
+	Servers     []string
+	Auth        []AuthScheme
+	Resources   []Resource
+}
+
+// AuthScheme describes an entry of components.securitySchemes
+type AuthScheme struct {
+	Name        string
+	Type        string
+	Description string
+	Details     []string
+}
+
+// Resource is a page of operations sharing a tag
+type Resource struct {
+	Name        string
+	Slug        string
+	Description string
+	Endpoints   []Endpoint
+}
+
+// Endpoint is a single documented operation
+type Endpoint struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Security    []string
+	Parameters  []Field
+	RequestBody *Body
+	Responses   []Response
+}
+
+// Field is a parameter or body property
+type Field struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// Body is a request body with its top-level fields and an example
+type Body struct {
+	ContentType string
+	Fields      []Field
+	Example     string
+}
+
+// Response is a documented response code
+type Response struct {
+	Code        string
+	Description string
+	Example     string
+}
+
+// Build converts an OpenAPI document into a Reference
+func Build(doc map[string]interface{}) *Reference {
+	info := mapValue(doc, "info")
+	ref := &Reference{
+		Title:       stringValue(info, "title"),
+		Version:     stringValue(info, "version"),
+		Description: stringValue(info, "description"),
+	}
+	if ref.Title == "" {
+		ref.Title = "API Reference"
+	}
+
+	servers, _ := doc["servers"].([]interface{})
+	for _, s := range servers {
+		if server, ok := s.(map[string]interface{}); ok && stringValue(server, "url") != "" {
+			ref.Servers = append(ref.Servers, stringValue(server, "url"))
+		}
+	}
+
+	ref.Auth = buildAuth(doc)
+
+	// Tag descriptions come from the top-level tags list
+	tagDescriptions := make(map[string]string)
+	tags, _ := doc["tags"].([]interface{})
+	for _, t := range tags {
+		if tag, ok := t.(map[string]interface{}); ok {
+			tagDescriptions[stringValue(tag, "name")] = stringValue(tag, "description")
+		}
+	}
+
+	defaultSecurity := securityNames(doc["security"])
+	resources := make(map[string]*Resource)
+	for _, op := range spec.Operations(doc) {
+		name := untaggedResource
+		if opTags, ok := op.Operation["tags"].([]interface{}); ok && len(opTags) > 0 {
+			if tag, ok := opTags[0].(string); ok && tag != "" {
+				name = tag
+			}
+		}
+
+		resource, ok := resources[name]
+		if !ok {
+			slug := Slug(name)
+			// Keep resource pages from overwriting the index pages
+			if slug == "index" || slug == "readme" {
+				slug += "-resource"
+			}
+			resource = &Resource{Name: name, Slug: slug, Description: tagDescriptions[name]}
+			resources[name] = resource
+		}
+		resource.Endpoints = append(resource.Endpoints, buildEndpoint(doc, op, defaultSecurity))
+	}
+
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref.Resources = append(ref.Resources, *resources[name])
+	}
+
+	return ref
+}
+
+// buildAuth lists the spec's security schemes
+func buildAuth(doc map[string]interface{}) []AuthScheme {
+	schemes := mapValue(mapValue(doc, "components"), "securitySchemes")
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []AuthScheme
+	for _, name := range names {
+		scheme := spec.ResolveRef(doc, mapValue(schemes, name))
+		auth := AuthScheme{
+			Name:        name,
+			Type:        stringValue(scheme, "type"),
+			Description: stringValue(scheme, "description"),
+		}
+
+		switch auth.Type {
+		case "http":
+			detail := "Scheme: " + stringValue(scheme, "scheme")
+			if format := stringValue(scheme, "bearerFormat"); format != "" {
+				detail += " (" + format + ")"
+			}
+			auth.Details = append(auth.Details, detail)
+		case "apiKey":
+			auth.Details = append(auth.Details, fmt.Sprintf("Send the key in the %s %s", stringValue(scheme, "name"), stringValue(scheme, "in")))
+		case "oauth2":
+			flows := mapValue(scheme, "flows")
+			for _, flowName := range sortedMapKeys(flows) {
+				flow := mapValue(flows, flowName)
+				detail := "Flow: " + flowName
+				if url := stringValue(flow, "authorizationUrl"); url != "" {
+					detail += ", authorization URL: " + url
+				}
+				if url := stringValue(flow, "tokenUrl"); url != "" {
+					detail += ", token URL: " + url
+				}
+				auth.Details = append(auth.Details, detail)
+			}
+		case "openIdConnect":
+			auth.Details = append(auth.Details, "Discovery URL: "+stringValue(scheme, "openIdConnectUrl"))
+		}
+
+		result = append(result, auth)
+	}
+	return result
+}
+
+// buildEndpoint documents a single operation
+func buildEndpoint(doc map[string]interface{}, op spec.Operation, defaultSecurity []string) Endpoint {
+	endpoint := Endpoint{
+		Method:      op.Method,
+		Path:        op.Path,
+		OperationID: stringValue(op.Operation, "operationId"),
+		Summary:     stringValue(op.Operation, "summary"),
+		Description: stringValue(op.Operation, "description"),
+		Security:    defaultSecurity,
+	}
+	endpoint.Deprecated, _ = op.Operation["deprecated"].(bool)
+	if _, ok := op.Operation["security"]; ok {
+		endpoint.Security = securityNames(op.Operation["security"])
+	}
+
+	// Path-level parameters apply to every operation of the path
+	pathItem := mapValue(mapValue(doc, "paths"), op.Path)
+	params, _ := pathItem["parameters"].([]interface{})
+	opParams, _ := op.Operation["parameters"].([]interface{})
+	for _, p := range append(append([]interface{}{}, params...), opParams...) {
+		param := spec.ResolveRef(doc, asMap(p))
+		if param == nil {
+			continue
+		}
+		required, _ := param["required"].(bool)
+		endpoint.Parameters = append(endpoint.Parameters, Field{
+			Name:        stringValue(param, "name"),
+			In:          stringValue(param, "in"),
+			Type:        TypeName(doc, mapValue(param, "schema")),
+			Required:    required,
+			Description: stringValue(param, "description"),
+		})
+	}
+
+	if requestBody := spec.ResolveRef(doc, mapValue(op.Operation, "requestBody")); requestBody != nil {
+		content := mapValue(requestBody, "content")
+		for _, contentType := range sortedMapKeys(content) {
+			schema := mapValue(mapValue(content, contentType), "schema")
+			endpoint.RequestBody = &Body{
+				ContentType: contentType,
+				Fields:      schemaFields(doc, schema),
+				Example:     formatExample(spec.Example(doc, schema)),
+			}
+			break
+		}
+	}
+
+	responses := mapValue(op.Operation, "responses")
+	_, example := spec.ResponseExample(doc, op.Operation)
+	exampleCode := spec.SuccessResponseCode(responses)
+	for _, code := range sortedMapKeys(responses) {
+		response := spec.ResolveRef(doc, mapValue(responses, code))
+		r := Response{Code: code, Description: stringValue(response, "description")}
+		if code == exampleCode && example != nil {
+			r.Example = formatExample(example)
+		}
+		endpoint.Responses = append(endpoint.Responses, r)
+	}
+
+	return endpoint
+}
+
+// schemaFields lists the top-level properties of an object schema
+func schemaFields(doc, schema map[string]interface{}) []Field {
+	schema = spec.ResolveRef(doc, schema)
+	properties := mapValue(schema, "properties")
+
+	required := make(map[string]bool)
+	if list, ok := schema["required"].([]interface{}); ok {
+		for _, r := range list {
+			if name, ok := r.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	var fields []Field
+	for _, name := range sortedMapKeys(properties) {
+		prop := asMap(properties[name])
+		resolved := spec.ResolveRef(doc, prop)
+		fields = append(fields, Field{
+			Name:        name,
+			Type:        TypeName(doc, prop),
+			Required:    required[name],
+			Description: stringValue(resolved, "description"),
+		})
+	}
+	return fields
+}
+
+// TypeName returns a short human-readable type for a schema, e.g. "string (uuid)" or "User[]"
+func TypeName(doc, schema map[string]interface{}) string {
+	if schema == nil {
+		return ""
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		return ref[strings.LastIndex(ref, "/")+1:]
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		values := make([]string, len(enum))
+		for i, v := range enum {
+			values[i] = fmt.Sprintf("%v", v)
+		}
+		return "enum: " + strings.Join(values, " | ")
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[key].([]interface{}); ok {
+			names := make([]string, len(options))
+			for i, option := range options {
+				names[i] = TypeName(doc, asMap(option))
+			}
+			return strings.Join(names, " | ")
+		}
+	}
+
+	typeName, _ := schema["type"].(string)
+	switch typeName {
+	case "array":
+		return TypeName(doc, mapValue(schema, "items")) + "[]"
+	case "":
+		return "object"
+	}
+	if format := stringValue(schema, "format"); format != "" {
+		return typeName + " (" + format + ")"
+	}
+	return typeName
+}
+
+// securityNames extracts scheme names from a security requirement list
+func securityNames(value interface{}) []string {
+	requirements, _ := value.([]interface{})
+	names := []string{}
+	for _, r := range requirements {
+		for name := range asMap(r) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Slug turns a resource name into a file name
+func Slug(name string) string {
+	slug := naming.Kebab(name)
+	if slug == "" {
+		return untaggedResource
+	}
+	return slug
+}
+
+// Write renders the reference into outDir and returns the written file paths
+func Write(ref *Reference, outDir, format string) ([]string, error) {
+	var files map[string]string
+	switch format {
+	case FormatMarkdown, "":
+		files = RenderMarkdown(ref)
+	case FormatHTML:
+		rendered, err := RenderHTML(ref)
+		if err != nil {
+			return nil, err
+		}
+		files = rendered
+	default:
+		return nil, fmt.Errorf("unsupported format '%s' (supported: %s, %s)", format, FormatMarkdown, FormatHTML)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var written []string
+	for _, name := range names {
+		path := filepath.Join(outDir, name)
+		if err := os.WriteFile(path, []byte(files[name]), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+func formatExample(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+func mapValue(data map[string]interface{}, key string) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	return asMap(data[key])
+}
+
+func stringValue(data map[string]interface{}, key string) string {
+	if data == nil {
+		return ""
+	}
+	s, _ := data[key].(string)
+	return s
+}
+
+func sortedMapKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
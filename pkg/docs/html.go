@@ -0,0 +1,111 @@
+package docs
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+const htmlLayout = `{{define "head"}}<!DOCTYPE html>
+<!-- Code generated by blimu generate docs. DO NOT EDIT. -->
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 960px; margin: 2rem auto; padding: 0 1rem; color: #1f2328; line-height: 1.5; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.9em; }
+pre { background: #f6f8fa; padding: 1rem; overflow-x: auto; border-radius: 6px; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.4rem 0.6rem; text-align: left; vertical-align: top; }
+.method { display: inline-block; min-width: 4rem; font-weight: bold; }
+.deprecated { color: #9a6700; font-weight: bold; }
+</style>
+</head>
+<body>
+{{end}}
+
+{{define "index"}}{{template "head" .Title}}
+<h1>{{.Title}}</h1>
+{{if .Version}}<p>Version: <code>{{.Version}}</code></p>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .Servers}}<h2>Base URLs</h2>
+<ul>{{range .Servers}}<li><code>{{.}}</code></li>{{end}}</ul>{{end}}
+<h2>Authentication</h2>
+{{if not .Auth}}<p>This API does not declare any authentication schemes.</p>{{end}}
+{{range .Auth}}<h3>{{.Name}}</h3>
+<p>Type: <code>{{.Type}}</code></p>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .Details}}<ul>{{range .Details}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{end}}
+<h2>Resources</h2>
+<ul>{{range .Resources}}<li><a href="{{.Slug}}.html">{{.Name}}</a> ({{len .Endpoints}} endpoint(s))</li>{{end}}</ul>
+</body>
+</html>
+{{end}}
+
+{{define "resource"}}{{template "head" .Resource.Name}}
+<p><a href="index.html">&larr; {{.Title}}</a></p>
+<h1>{{.Resource.Name}}</h1>
+{{if .Resource.Description}}<p>{{.Resource.Description}}</p>{{end}}
+{{range .Resource.Endpoints}}
+<h2 id="{{anchor .}}">{{title .}}</h2>
+<pre><span class="method">{{.Method}}</span> {{.Path}}</pre>
+{{if .Deprecated}}<p class="deprecated">Deprecated</p>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .OperationID}}<p>Operation ID: <code>{{.OperationID}}</code></p>{{end}}
+{{if .Security}}<p>Authentication: {{range $i, $s := .Security}}{{if $i}}, {{end}}<code>{{$s}}</code>{{end}}</p>{{end}}
+{{if .Parameters}}<h3>Parameters</h3>
+<table><tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{range .Parameters}}<tr><td><code>{{.Name}}</code></td><td>{{.In}}</td><td>{{.Type}}</td><td>{{yesNo .Required}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>{{end}}
+{{with .RequestBody}}<h3>Request body</h3>
+<p>Content type: <code>{{.ContentType}}</code></p>
+{{if .Fields}}<table><tr><th>Field</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{range .Fields}}<tr><td><code>{{.Name}}</code></td><td>{{.Type}}</td><td>{{yesNo .Required}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Example}}<pre>{{.Example}}</pre>{{end}}{{end}}
+{{if .Responses}}<h3>Responses</h3>
+<table><tr><th>Status</th><th>Description</th></tr>
+{{range .Responses}}<tr><td><code>{{.Code}}</code></td><td>{{.Description}}</td></tr>
+{{end}}</table>
+{{range .Responses}}{{if .Example}}<p>Example <code>{{.Code}}</code> response:</p>
+<pre>{{.Example}}</pre>{{end}}{{end}}{{end}}
+{{end}}
+</body>
+</html>
+{{end}}`
+
+var htmlTemplates = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"title": endpointTitle,
+	"yesNo": yesNo,
+	"anchor": func(endpoint Endpoint) string {
+		return Slug(endpoint.Method + "-" + endpoint.Path)
+	},
+}).Parse(htmlLayout))
+
+// RenderHTML renders index.html plus one <resource>.html page per resource
+func RenderHTML(ref *Reference) (map[string]string, error) {
+	files := make(map[string]string)
+
+	var index strings.Builder
+	if err := htmlTemplates.ExecuteTemplate(&index, "index", ref); err != nil {
+		return nil, fmt.Errorf("failed to render index page: %w", err)
+	}
+	files["index.html"] = index.String()
+
+	for _, resource := range ref.Resources {
+		var page strings.Builder
+		data := struct {
+			Title    string
+			Resource Resource
+		}{ref.Title, resource}
+		if err := htmlTemplates.ExecuteTemplate(&page, "resource", data); err != nil {
+			return nil, fmt.Errorf("failed to render %s page: %w", resource.Name, err)
+		}
+		files[resource.Slug+".html"] = page.String()
+	}
+
+	return files, nil
+}
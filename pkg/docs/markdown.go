@@ -0,0 +1,161 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderMarkdown renders README.md plus one <resource>.md page per resource
+func RenderMarkdown(ref *Reference) map[string]string {
+	files := map[string]string{"README.md": renderMarkdownIndex(ref)}
+	for _, resource := range ref.Resources {
+		files[resource.Slug+".md"] = renderMarkdownResource(ref, resource)
+	}
+	return files
+}
+
+func renderMarkdownIndex(ref *Reference) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<!-- Code generated by blimu generate docs. DO NOT EDIT. -->\n\n")
+	fmt.Fprintf(&b, "# %s\n\n", ref.Title)
+	if ref.Version != "" {
+		fmt.Fprintf(&b, "Version: `%s`\n\n", ref.Version)
+	}
+	if ref.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", ref.Description)
+	}
+
+	if len(ref.Servers) > 0 {
+		fmt.Fprintf(&b, "## Base URLs\n\n")
+		for _, server := range ref.Servers {
+			fmt.Fprintf(&b, "- `%s`\n", server)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "## Authentication\n\n")
+	if len(ref.Auth) == 0 {
+		fmt.Fprintf(&b, "This API does not declare any authentication schemes.\n\n")
+	}
+	for _, auth := range ref.Auth {
+		fmt.Fprintf(&b, "### %s\n\n", auth.Name)
+		fmt.Fprintf(&b, "Type: `%s`\n\n", auth.Type)
+		if auth.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", auth.Description)
+		}
+		for _, detail := range auth.Details {
+			fmt.Fprintf(&b, "- %s\n", detail)
+		}
+		if len(auth.Details) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "## Resources\n\n")
+	for _, resource := range ref.Resources {
+		fmt.Fprintf(&b, "- [%s](%s.md) (%d endpoint(s))\n", resource.Name, resource.Slug, len(resource.Endpoints))
+	}
+
+	return b.String()
+}
+
+func renderMarkdownResource(ref *Reference, resource Resource) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<!-- Code generated by blimu generate docs. DO NOT EDIT. -->\n\n")
+	fmt.Fprintf(&b, "# %s\n\n", resource.Name)
+	fmt.Fprintf(&b, "[← %s](README.md)\n\n", ref.Title)
+	if resource.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", resource.Description)
+	}
+
+	for _, endpoint := range resource.Endpoints {
+		fmt.Fprintf(&b, "## %s\n\n", endpointTitle(endpoint))
+		fmt.Fprintf(&b, "```http\n%s %s\n```\n\n", endpoint.Method, endpoint.Path)
+		if endpoint.Deprecated {
+			fmt.Fprintf(&b, "> **Deprecated**\n\n")
+		}
+		if endpoint.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", endpoint.Description)
+		}
+		if endpoint.OperationID != "" {
+			fmt.Fprintf(&b, "Operation ID: `%s`\n\n", endpoint.OperationID)
+		}
+		if len(endpoint.Security) > 0 {
+			fmt.Fprintf(&b, "Authentication: %s\n\n", codeList(endpoint.Security))
+		}
+
+		if len(endpoint.Parameters) > 0 {
+			fmt.Fprintf(&b, "### Parameters\n\n")
+			fmt.Fprintf(&b, "| Name | In | Type | Required | Description |\n|---|---|---|---|---|\n")
+			for _, p := range endpoint.Parameters {
+				fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", p.Name, p.In, cell(p.Type), yesNo(p.Required), cell(p.Description))
+			}
+			b.WriteString("\n")
+		}
+
+		if body := endpoint.RequestBody; body != nil {
+			fmt.Fprintf(&b, "### Request body\n\n")
+			fmt.Fprintf(&b, "Content type: `%s`\n\n", body.ContentType)
+			if len(body.Fields) > 0 {
+				fmt.Fprintf(&b, "| Field | Type | Required | Description |\n|---|---|---|---|\n")
+				for _, f := range body.Fields {
+					fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", f.Name, cell(f.Type), yesNo(f.Required), cell(f.Description))
+				}
+				b.WriteString("\n")
+			}
+			if body.Example != "" {
+				fmt.Fprintf(&b, "```json\n%s\n```\n\n", body.Example)
+			}
+		}
+
+		if len(endpoint.Responses) > 0 {
+			fmt.Fprintf(&b, "### Responses\n\n")
+			fmt.Fprintf(&b, "| Status | Description |\n|---|---|\n")
+			for _, r := range endpoint.Responses {
+				fmt.Fprintf(&b, "| `%s` | %s |\n", r.Code, cell(r.Description))
+			}
+			b.WriteString("\n")
+			for _, r := range endpoint.Responses {
+				if r.Example != "" {
+					fmt.Fprintf(&b, "Example `%s` response:\n\n```json\n%s\n```\n\n", r.Code, r.Example)
+				}
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// endpointTitle prefers the summary, then the operation ID, then the method and path
+func endpointTitle(endpoint Endpoint) string {
+	if endpoint.Summary != "" {
+		return endpoint.Summary
+	}
+	if endpoint.OperationID != "" {
+		return endpoint.OperationID
+	}
+	return endpoint.Method + " " + endpoint.Path
+}
+
+// cell escapes a value for a Markdown table cell
+func cell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func codeList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "`" + v + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// auth0Export is the subset of an Auth0 tenant export (Deploy CLI or Management API) used for import
+type auth0Export struct {
+	Roles []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Permissions []struct {
+			PermissionName           string `json:"permission_name"`
+			ResourceServerIdentifier string `json:"resource_server_identifier"`
+		} `json:"permissions"`
+	} `json:"roles"`
+}
+
+// ConvertAuth0 converts an Auth0 roles export. Roles become roles on the root resource.
+// Permissions following Auth0's "action:target" convention become "target:action" entitlements
+// on a child resource of the root resource that inherits the granting roles.
+func ConvertAuth0(data []byte, opts Options) (*Result, error) {
+	var export auth0Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse Auth0 export: %w", err)
+	}
+	if len(export.Roles) == 0 {
+		return nil, fmt.Errorf("Auth0 export contains no roles")
+	}
+
+	result := newResult()
+	result.addRoles(opts.RootResource)
+
+	for _, role := range export.Roles {
+		roleName := normalizeName(role.Name)
+		if roleName == "" {
+			result.warnf("skipped Auth0 role with empty name")
+			continue
+		}
+		result.addRoles(opts.RootResource, roleName)
+
+		for _, permission := range role.Permissions {
+			action, target, ok := strings.Cut(permission.PermissionName, ":")
+			if !ok {
+				// Permissions without a target apply to the root resource
+				action, target = permission.PermissionName, opts.RootResource
+			}
+			action, target = normalizeName(action), normalizeName(target)
+			if action == "" || target == "" {
+				result.warnf("skipped Auth0 permission '%s' on role '%s'", permission.PermissionName, role.Name)
+				continue
+			}
+
+			if target != opts.RootResource {
+				addInheritingRole(result, target, opts.RootResource, roleName)
+			}
+			result.addEntitlementRoles(target+":"+action, roleName)
+		}
+	}
+
+	return result, nil
+}
+
+// addInheritingRole adds a role to a child resource that inherits it from the same role on parent
+func addInheritingRole(result *Result, resource, parent, role string) {
+	result.addRoles(resource, role)
+
+	resourceConfig := result.Config.Resources[resource]
+	if resourceConfig.Parents == nil {
+		resourceConfig.Parents = make(map[string]config.ParentConfig)
+	}
+	resourceConfig.Parents[parent] = config.ParentConfig{Required: true}
+	if resourceConfig.RolesInheritance == nil {
+		resourceConfig.RolesInheritance = make(map[string][]string)
+	}
+	resourceConfig.RolesInheritance[role] = appendUnique(resourceConfig.RolesInheritance[role], parent+"->"+role)
+	result.Config.Resources[resource] = resourceConfig
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// cerbosPolicy is the subset of a Cerbos policy document used for import
+type cerbosPolicy struct {
+	ResourcePolicy *struct {
+		Resource string `yaml:"resource"`
+		Rules    []struct {
+			Actions      []string `yaml:"actions"`
+			Effect       string   `yaml:"effect"`
+			Roles        []string `yaml:"roles"`
+			DerivedRoles []string `yaml:"derivedRoles"`
+		} `yaml:"rules"`
+	} `yaml:"resourcePolicy"`
+	DerivedRoles *struct{} `yaml:"derivedRoles"`
+}
+
+// ConvertCerbos converts Cerbos resource policies (YAML or JSON, multiple documents allowed).
+// Each policy resource becomes a child of the root resource, the roles used in its rules become
+// resource roles, and allowed actions become "resource:action" entitlements.
+func ConvertCerbos(data []byte, opts Options) (*Result, error) {
+	result := newResult()
+	result.addRoles(opts.RootResource)
+	policies := 0
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var policy cerbosPolicy
+		if err := decoder.Decode(&policy); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse Cerbos policy: %w", err)
+		}
+
+		if policy.ResourcePolicy == nil {
+			if policy.DerivedRoles != nil {
+				result.warnf("skipped derived roles policy; derived role conditions must be modeled manually")
+			}
+			continue
+		}
+		policies++
+
+		resource := normalizeName(policy.ResourcePolicy.Resource)
+		if resource == "" {
+			result.warnf("skipped Cerbos resource policy with empty resource")
+			continue
+		}
+
+		resourceConfig := result.Config.Resources[resource]
+		if resource != opts.RootResource && resourceConfig.Parents == nil {
+			resourceConfig.Parents = map[string]config.ParentConfig{opts.RootResource: {Required: true}}
+			result.Config.Resources[resource] = resourceConfig
+		}
+
+		for _, rule := range policy.ResourcePolicy.Rules {
+			if rule.Effect != "" && rule.Effect != "EFFECT_ALLOW" {
+				result.warnf("skipped %s rule on '%s'; deny rules have no .blimu equivalent", rule.Effect, resource)
+				continue
+			}
+
+			var roles []string
+			for _, role := range rule.Roles {
+				if role == "*" {
+					result.warnf("wildcard role on '%s' was not imported", resource)
+					continue
+				}
+				roles = append(roles, normalizeName(role))
+			}
+			for _, role := range rule.DerivedRoles {
+				result.warnf("derived role '%s' on '%s' imported as a plain role", role, resource)
+				roles = append(roles, normalizeName(role))
+			}
+			result.addRoles(resource, roles...)
+
+			for _, action := range rule.Actions {
+				if action == "*" {
+					result.warnf("wildcard action on '%s' was not imported", resource)
+					continue
+				}
+				if name := normalizeName(action); name != "" {
+					result.addEntitlementRoles(resource+":"+name, roles...)
+				}
+			}
+		}
+	}
+
+	if policies == 0 {
+		return nil, fmt.Errorf("Cerbos export contains no resource policies")
+	}
+
+	return result, nil
+}
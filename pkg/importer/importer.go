@@ -0,0 +1,151 @@
+// Package importer converts role and permission exports from third-party authorization
+// systems, and the paths of OpenAPI documents, into .blimu definition scaffolding.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Options configures a conversion
+type Options struct {
+	// RootResource is the resource that receives tenant-wide roles (defaults to "organization")
+	RootResource string
+}
+
+// Result is the scaffolding produced by a converter along with any warnings about
+// parts of the export that could not be translated
+type Result struct {
+	Config   *config.BlimuConfig
+	Warnings []string
+}
+
+// Converter translates a third-party export into .blimu definitions
+type Converter func(data []byte, opts Options) (*Result, error)
+
+// converters maps --from values to their converter
+var converters = map[string]Converter{
+	"auth0":   ConvertAuth0,
+	"okta":    ConvertOkta,
+	"cerbos":  ConvertCerbos,
+	"openapi": ConvertOpenAPI,
+}
+
+// Sources returns the supported import sources in sorted order
+func Sources() []string {
+	sources := make([]string, 0, len(converters))
+	for source := range converters {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// Convert runs the converter registered for source
+func Convert(source string, data []byte, opts Options) (*Result, error) {
+	converter, ok := converters[strings.ToLower(source)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported import source '%s' (supported: %s)", source, strings.Join(Sources(), ", "))
+	}
+
+	if opts.RootResource == "" {
+		opts.RootResource = "organization"
+	}
+
+	return converter(data, opts)
+}
+
+// Print writes the generated definition files to w
+func (r *Result) Print(w io.Writer) error {
+	sections := []struct {
+		file string
+		data interface{}
+		size int
+	}{
+		{"resources.yml", r.Config.Resources, len(r.Config.Resources)},
+		{"entitlements.yml", r.Config.Entitlements, len(r.Config.Entitlements)},
+	}
+
+	for _, section := range sections {
+		if section.size == 0 {
+			continue
+		}
+		data, err := yaml.Marshal(section.data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", section.file, err)
+		}
+		fmt.Fprintf(w, "\n# .blimu/%s\n%s", section.file, data)
+	}
+
+	return nil
+}
+
+// newResult creates an empty result ready to be filled by a converter
+func newResult() *Result {
+	return &Result{
+		Config: &config.BlimuConfig{
+			Resources:    make(map[string]config.ResourceConfig),
+			Entitlements: make(map[string]config.EntitlementConfig),
+			Features:     make(map[string]config.FeatureConfig),
+			Plans:        make(map[string]config.PlanConfig),
+		},
+	}
+}
+
+// warnf records a warning on the result
+func (r *Result) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// addRoles adds roles to a resource, creating it if needed and keeping roles unique and sorted
+func (r *Result) addRoles(resource string, roles ...string) {
+	resourceConfig := r.Config.Resources[resource]
+	resourceConfig.Roles = appendUnique(resourceConfig.Roles, roles...)
+	r.Config.Resources[resource] = resourceConfig
+}
+
+// addEntitlementRoles grants roles to an entitlement, creating it if needed
+func (r *Result) addEntitlementRoles(entitlement string, roles ...string) {
+	entitlementConfig := r.Config.Entitlements[entitlement]
+	entitlementConfig.Roles = appendUnique(entitlementConfig.Roles, roles...)
+	r.Config.Entitlements[entitlement] = entitlementConfig
+}
+
+// appendUnique appends values that are not already present and returns the sorted result
+func appendUnique(existing []string, values ...string) []string {
+	seen := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		seen[v] = true
+	}
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			existing = append(existing, v)
+			seen[v] = true
+		}
+	}
+	sort.Strings(existing)
+	return existing
+}
+
+// normalizeName converts an external role, resource or action name into a .blimu identifier
+// (lowercase letters, digits and underscores)
+func normalizeName(name string) string {
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			lastUnderscore = false
+		case !lastUnderscore && b.Len() > 0:
+			b.WriteRune('_')
+			lastUnderscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
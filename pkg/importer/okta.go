@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// oktaGroup is the subset of an Okta group object used for import
+type oktaGroup struct {
+	Type    string `json:"type"`
+	Profile struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"profile"`
+}
+
+// ConvertOkta converts an Okta groups export (the /api/v1/groups response, or an object with a
+// "groups" array). Each group becomes a role on the root resource; Okta has no portable
+// permission model, so entitlements are left for the user to define.
+func ConvertOkta(data []byte, opts Options) (*Result, error) {
+	var groups []oktaGroup
+	if err := json.Unmarshal(data, &groups); err != nil {
+		var wrapped struct {
+			Groups []oktaGroup `json:"groups"`
+		}
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return nil, fmt.Errorf("failed to parse Okta export: %w", err)
+		}
+		groups = wrapped.Groups
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("Okta export contains no groups")
+	}
+
+	result := newResult()
+	result.addRoles(opts.RootResource)
+
+	for _, group := range groups {
+		if group.Type == "BUILT_IN" {
+			result.warnf("skipped built-in Okta group '%s'", group.Profile.Name)
+			continue
+		}
+
+		roleName := normalizeName(group.Profile.Name)
+		if roleName == "" {
+			result.warnf("skipped Okta group with empty name")
+			continue
+		}
+		result.addRoles(opts.RootResource, roleName)
+	}
+
+	result.warnf("Okta groups carry no permissions; define entitlements in entitlements.yml")
+
+	return result, nil
+}
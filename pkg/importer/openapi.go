@@ -0,0 +1,260 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISpec is the subset of an OpenAPI 3 or Swagger 2 document used for import
+type openAPISpec struct {
+	OpenAPI string                          `yaml:"openapi"`
+	Swagger string                          `yaml:"swagger"`
+	Paths   map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+// openAPIRoles are the roles proposed for every resource, from least to most privileged
+var openAPIRoles = []string{"viewer", "editor", "admin"}
+
+// openAPIGrants maps CRUD actions to the least privileged role allowed to perform them
+var openAPIGrants = map[string]string{
+	"read":   "viewer",
+	"create": "editor",
+	"update": "editor",
+	"delete": "admin",
+}
+
+// versionSegment matches path prefixes that carry no resource, such as api, v1 or v2beta
+var versionSegment = regexp.MustCompile(`^(api|v\d+[a-z0-9]*)$`)
+
+// ConvertOpenAPI proposes resources and entitlements from the paths of an OpenAPI (or Swagger)
+// document, in YAML or JSON. A collection segment followed by a path parameter is a resource
+// ("/organizations/{orgId}" is organization), and resources nested in a path get the enclosing
+// resource as a required parent. Every resource gets viewer, editor and admin roles inherited
+// from its parent. Operations become "resource:action" entitlements: GET is read, POST on a
+// collection is create, PUT and PATCH are update and DELETE is delete; other trailing segments
+// ("/projects/{id}/archive") become custom actions.
+func ConvertOpenAPI(data []byte, opts Options) (*Result, error) {
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if spec.OpenAPI == "" && spec.Swagger == "" {
+		return nil, fmt.Errorf("not an OpenAPI document: missing 'openapi' or 'swagger' version")
+	}
+	if len(spec.Paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI document has no paths")
+	}
+
+	result := newResult()
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		route := parseOpenAPIPath(path)
+		if route.resource == "" {
+			result.warnf("skipped %s: no resource in the path", path)
+			continue
+		}
+
+		// Declare every resource of the path with its parent
+		parent := ""
+		for _, resource := range route.chain {
+			if parent == "" {
+				result.addRoles(resource, openAPIRoles...)
+			} else {
+				for _, role := range openAPIRoles {
+					addInheritingRole(result, resource, parent, role)
+				}
+			}
+			parent = resource
+		}
+
+		for _, method := range sortedOperations(spec.Paths[path]) {
+			action := openAPIAction(method, route)
+			if action == "" {
+				result.warnf("skipped %s %s: no matching action", strings.ToUpper(method), path)
+				continue
+			}
+			result.addEntitlementRoles(route.resource+":"+action, grantedRoles(action, method)...)
+		}
+	}
+
+	if len(result.Config.Resources) == 0 {
+		return nil, fmt.Errorf("no resources found in the OpenAPI paths")
+	}
+
+	// A resource nested under several resources can only require one of them
+	for _, name := range sortedResourceNames(result) {
+		resourceConfig := result.Config.Resources[name]
+		if len(resourceConfig.Parents) < 2 {
+			continue
+		}
+		for parent := range resourceConfig.Parents {
+			resourceConfig.Parents[parent] = config.ParentConfig{Required: false}
+		}
+		result.warnf("'%s' appears under several resources; its parents were made optional", name)
+	}
+	result.warnf("roles and entitlements are proposals derived from the paths; adjust them to your access model")
+
+	return result, nil
+}
+
+// openAPIRoute is the resource structure of one path
+type openAPIRoute struct {
+	// chain lists the resources of the path from outermost to innermost
+	chain []string
+	// resource is the resource the path's operations act on
+	resource string
+	// item is set when the path addresses a single resource rather than a collection
+	item bool
+	// action is a trailing custom action segment, e.g. "archive"
+	action string
+}
+
+// parseOpenAPIPath splits a path into resources: a static segment followed by a {parameter} is a
+// collection addressing one resource. A trailing static segment is a collection too when it is
+// plural ("/organizations/{id}/projects"), and a custom action on the addressed resource
+// otherwise ("/projects/{id}/archive"). Leading api and version segments are ignored.
+func parseOpenAPIPath(path string) openAPIRoute {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment == "" || (len(segments) == 0 && versionSegment.MatchString(segment)) {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+
+	var route openAPIRoute
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if isPathParameter(segment) {
+			// A parameter without a preceding collection addresses nothing we can name
+			continue
+		}
+		name := singular(normalizeName(segment))
+		if name == "" {
+			continue
+		}
+
+		followedByParameter := i+1 < len(segments) && isPathParameter(segments[i+1])
+		last := i == len(segments)-1
+		switch {
+		case followedByParameter:
+			route.chain = append(route.chain, name)
+			route.resource = name
+			route.item = true
+			i++
+		case last && !isCollectionName(segment):
+			// "/projects/{id}/archive" is an action; "/health" addresses no resource
+			if route.item {
+				route.action = normalizeName(segment)
+			}
+		case last:
+			route.chain = append(route.chain, name)
+			route.resource = name
+			route.item = false
+		default:
+			// A static prefix such as /admin/users: not a resource on its own
+		}
+	}
+	return route
+}
+
+// openAPIAction returns the entitlement action of an operation on a route
+func openAPIAction(method string, route openAPIRoute) string {
+	if route.action != "" {
+		return route.action
+	}
+	switch method {
+	case "get", "head":
+		return "read"
+	case "post":
+		if route.item {
+			return "update"
+		}
+		return "create"
+	case "put", "patch":
+		if !route.item {
+			return "create"
+		}
+		return "update"
+	case "delete":
+		return "delete"
+	}
+	return ""
+}
+
+// grantedRoles returns the roles proposed for an action: the least privileged role allowed to
+// perform it and every role above it. Custom actions are granted like reads when they are GET
+// operations and like updates otherwise.
+func grantedRoles(action, method string) []string {
+	least, ok := openAPIGrants[action]
+	if !ok {
+		least = "editor"
+		if method == "get" || method == "head" {
+			least = "viewer"
+		}
+	}
+	for i, role := range openAPIRoles {
+		if role == least {
+			return openAPIRoles[i:]
+		}
+	}
+	return openAPIRoles
+}
+
+// sortedOperations returns the HTTP methods of a path item, ignoring shared fields such as
+// parameters or summary
+func sortedOperations(item map[string]yaml.Node) []string {
+	var methods []string
+	for key := range item {
+		switch strings.ToLower(key) {
+		case "get", "head", "post", "put", "patch", "delete":
+			methods = append(methods, strings.ToLower(key))
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// sortedResourceNames returns the resource names of a result in sorted order
+func sortedResourceNames(result *Result) []string {
+	names := make([]string, 0, len(result.Config.Resources))
+	for name := range result.Config.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isPathParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// isCollectionName reports whether a segment looks like a plural collection name
+func isCollectionName(segment string) bool {
+	name := normalizeName(segment)
+	return singular(name) != name
+}
+
+// singular returns the singular of a plural English collection name ("categories" -> "category",
+// "addresses" -> "address", "projects" -> "project"); other names are returned unchanged
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && !strings.HasSuffix(name, "us") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
@@ -0,0 +1,128 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/naming"
+	"gopkg.in/yaml.v3"
+)
+
+// Naming conventions accepted in lint.yml
+const (
+	SnakeCase  = "snake_case"
+	KebabCase  = "kebab-case"
+	CamelCase  = "camelCase"
+	PascalCase = "PascalCase"
+)
+
+// conventions maps each naming convention to the function converting a name to it
+var conventions = map[string]func(string) string{
+	SnakeCase:  naming.Snake,
+	KebabCase:  naming.Kebab,
+	CamelCase:  naming.Camel,
+	PascalCase: naming.Pascal,
+}
+
+// Config is the lint configuration stored in .blimu/lint.yml
+//
+//	rules:
+//	  unused-plan: error
+//	  unused-role: off
+//	naming:
+//	  roles: kebab-case
+type Config struct {
+	// Rules overrides the severity (error, warn or off) of rules by name
+	Rules map[string]string `yaml:"rules,omitempty"`
+	// Naming sets the convention names must follow, per kind of name
+	Naming NamingConfig `yaml:"naming,omitempty"`
+}
+
+// NamingConfig sets the naming convention of each kind of name; empty fields default to snake_case
+type NamingConfig struct {
+	Resources string `yaml:"resources,omitempty"`
+	Roles     string `yaml:"roles,omitempty"`
+	// Actions is the part of an entitlement name after the colon (resource:action)
+	Actions  string `yaml:"actions,omitempty"`
+	Features string `yaml:"features,omitempty"`
+	Plans    string `yaml:"plans,omitempty"`
+}
+
+// Severity returns the configured severity of a rule, falling back to its default
+func (c *Config) Severity(rule Rule) string {
+	if severity, ok := c.Rules[rule.Name]; ok {
+		return severity
+	}
+	return rule.Severity
+}
+
+// LoadConfig loads .blimu/lint.yml from a directory. A missing file yields the default config.
+func LoadConfig(dir string) (*Config, error) {
+	configPath := filepath.Join(dir, ".blimu", "lint.yml")
+	lintConfig := &Config{}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lintConfig, nil
+		}
+		return nil, fmt.Errorf("failed to read lint.yml: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, lintConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse lint.yml: %w", err)
+	}
+
+	if err := lintConfig.check(); err != nil {
+		return nil, fmt.Errorf("invalid lint.yml: %w", err)
+	}
+
+	return lintConfig, nil
+}
+
+// check rejects unknown rules, severities and naming conventions
+func (c *Config) check() error {
+	names := make([]string, 0, len(c.Rules))
+	for name := range c.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := FindRule(name); !ok {
+			return fmt.Errorf("unknown rule '%s' (run 'blimu lint --list-rules' to see the available rules)", name)
+		}
+		switch c.Rules[name] {
+		case SeverityError, SeverityWarn, SeverityOff:
+		default:
+			return fmt.Errorf("rule '%s' has invalid severity '%s' (expected %s, %s or %s)",
+				name, c.Rules[name], SeverityError, SeverityWarn, SeverityOff)
+		}
+	}
+
+	for kind, convention := range map[string]string{
+		"resources": c.Naming.Resources,
+		"roles":     c.Naming.Roles,
+		"actions":   c.Naming.Actions,
+		"features":  c.Naming.Features,
+		"plans":     c.Naming.Plans,
+	} {
+		if _, ok := conventions[convention]; convention != "" && !ok {
+			return fmt.Errorf("naming.%s has unknown convention '%s' (expected %s)", kind, convention,
+				strings.Join([]string{SnakeCase, KebabCase, CamelCase, PascalCase}, ", "))
+		}
+	}
+
+	return nil
+}
+
+// convention returns the converter of a configured convention, defaulting to snake_case
+func convention(name string) (string, func(string) string) {
+	if name == "" {
+		name = SnakeCase
+	}
+	return name, conventions[name]
+}
@@ -0,0 +1,127 @@
+// Package lint checks .blimu definitions for valid but suspicious configurations, such as plans
+// nothing grants or names that break the project's conventions. Each rule has a default severity
+// that .blimu/lint.yml can raise, lower or turn off.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// Severities of lint rules
+const (
+	SeverityError = "error"
+	SeverityWarn  = "warn"
+	SeverityOff   = "off"
+)
+
+// Rule is a lint check
+type Rule struct {
+	Name        string
+	Description string
+	// Severity is the default severity, used unless lint.yml overrides it
+	Severity string
+	check    func(c *checker)
+}
+
+// Finding is a problem reported by a rule
+type Finding struct {
+	Rule     string
+	Severity string
+	Message  string
+	config.Position
+}
+
+// String formats the finding as file:line:column: severity [rule] message
+func (f Finding) String() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s [%s] %s", f.File, f.Line, f.Column, f.Severity, f.Rule, f.Message)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", f.File, f.Severity, f.Rule, f.Message)
+}
+
+// Result holds the findings of a lint run
+type Result struct {
+	Findings []Finding
+}
+
+// Errors returns the number of error findings
+func (r *Result) Errors() int {
+	return r.count(SeverityError)
+}
+
+// Warnings returns the number of warning findings
+func (r *Result) Warnings() int {
+	return r.count(SeverityWarn)
+}
+
+func (r *Result) count(severity string) int {
+	n := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// FindRule returns the rule with the given name
+func FindRule(name string) (Rule, bool) {
+	for _, rule := range Rules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Run checks a loaded configuration against every rule that is not turned off. Findings are
+// sorted by file and position.
+func Run(blimuConfig *config.BlimuConfig, lintConfig *Config) *Result {
+	result := &Result{}
+	for _, rule := range Rules {
+		severity := lintConfig.Severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		rule.check(&checker{
+			config:   blimuConfig,
+			lint:     lintConfig,
+			rule:     rule.Name,
+			severity: severity,
+			result:   result,
+		})
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return result
+}
+
+// checker is what a rule reports its findings through
+type checker struct {
+	config   *config.BlimuConfig
+	lint     *Config
+	rule     string
+	severity string
+	result   *Result
+}
+
+func (c *checker) report(position config.Position, format string, args ...interface{}) {
+	c.result.Findings = append(c.result.Findings, Finding{
+		Rule:     c.rule,
+		Severity: c.severity,
+		Message:  fmt.Sprintf(format, args...),
+		Position: position,
+	})
+}
@@ -0,0 +1,157 @@
+package lint
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// Rules lists every lint rule in the order they run
+var Rules = []Rule{
+	{
+		Name:        "unused-plan",
+		Description: "Plans that no entitlement or feature grants",
+		Severity:    SeverityWarn,
+		check:       checkUnusedPlans,
+	},
+	{
+		Name:        "unreferenced-entitlement",
+		Description: "Entitlements that are not part of any feature",
+		Severity:    SeverityWarn,
+		check:       checkUnreferencedEntitlements,
+	},
+	{
+		Name:        "unused-role",
+		Description: "Roles that never appear in any roles_inheritance",
+		Severity:    SeverityWarn,
+		check:       checkUnusedRoles,
+	},
+	{
+		Name:        "naming-convention",
+		Description: "Resource, role, action, feature and plan names that break the naming conventions in lint.yml",
+		Severity:    SeverityWarn,
+		check:       checkNaming,
+	},
+}
+
+func checkUnusedPlans(c *checker) {
+	used := map[string]bool{}
+	for _, entitlement := range c.config.Entitlements {
+		for _, plan := range entitlement.Plans {
+			used[plan] = true
+		}
+	}
+	for _, feature := range c.config.Features {
+		for _, plan := range feature.Plans {
+			used[plan] = true
+		}
+	}
+
+	for _, name := range sortedKeys(c.config.Plans) {
+		if !used[name] {
+			c.report(c.at("plans.yml", name), "plan '%s' is not granted by any entitlement or feature", name)
+		}
+	}
+}
+
+func checkUnreferencedEntitlements(c *checker) {
+	referenced := map[string]bool{}
+	for _, feature := range c.config.Features {
+		for _, entitlement := range feature.Entitlements {
+			referenced[entitlement] = true
+		}
+	}
+
+	for _, name := range sortedKeys(c.config.Entitlements) {
+		if !referenced[name] {
+			c.report(c.at("entitlements.yml", name), "entitlement '%s' is not referenced by any feature", name)
+		}
+	}
+}
+
+func checkUnusedRoles(c *checker) {
+	// A role is used when it inherits from other roles or other roles inherit from it (resource->role)
+	used := map[string]bool{}
+	for resourceName, resource := range c.config.Resources {
+		for role, inheritances := range resource.RolesInheritance {
+			used[resourceName+"->"+role] = true
+			for _, inheritance := range inheritances {
+				parts := strings.Split(inheritance, "->")
+				if len(parts) == 2 {
+					used[strings.TrimSpace(parts[0])+"->"+strings.TrimSpace(parts[1])] = true
+				}
+			}
+		}
+	}
+
+	for _, resourceName := range sortedKeys(c.config.Resources) {
+		for _, role := range c.config.Resources[resourceName].Roles {
+			if !used[resourceName+"->"+role] {
+				c.report(c.atItem("resources.yml", role, resourceName, "roles"),
+					"role '%s' of resource '%s' is never used in roles_inheritance", role, resourceName)
+			}
+		}
+	}
+}
+
+func checkNaming(c *checker) {
+	naming := c.lint.Naming
+
+	for _, resourceName := range sortedKeys(c.config.Resources) {
+		c.checkName("resource", resourceName, naming.Resources, c.at("resources.yml", resourceName))
+		for _, role := range c.config.Resources[resourceName].Roles {
+			c.checkName("role", role, naming.Roles, c.atItem("resources.yml", role, resourceName, "roles"))
+		}
+	}
+
+	for _, name := range sortedKeys(c.config.Entitlements) {
+		// Malformed entitlement names are reported by validation
+		if parts := strings.Split(name, ":"); len(parts) == 2 {
+			c.checkName("action", parts[1], naming.Actions, c.at("entitlements.yml", name))
+		}
+	}
+
+	for _, name := range sortedKeys(c.config.Features) {
+		c.checkName("feature", name, naming.Features, c.at("features.yml", name))
+	}
+
+	for _, name := range sortedKeys(c.config.Plans) {
+		c.checkName("plan", name, naming.Plans, c.at("plans.yml", name))
+	}
+}
+
+// checkName reports a name that does not follow its convention, suggesting the converted name
+func (c *checker) checkName(kind, name, configured string, position config.Position) {
+	conventionName, convert := convention(configured)
+	if expected := convert(name); expected != name {
+		c.report(position, "%s name '%s' is not %s (expected '%s')", kind, name, conventionName, expected)
+	}
+}
+
+// at returns the position of a key, falling back to the file when positions are unknown
+func (c *checker) at(name string, path ...string) config.Position {
+	return withFile(c.config.Positions.Of(name, path...), name)
+}
+
+// atItem returns the position of a list item, falling back to the file when positions are unknown
+func (c *checker) atItem(name, value string, path ...string) config.Position {
+	return withFile(c.config.Positions.OfItem(name, value, path...), name)
+}
+
+func withFile(position config.Position, name string) config.Position {
+	if position.File == "" {
+		position.File = filepath.Join(".blimu", name)
+	}
+	return position
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+// Package logging is the leveled logger of the CLI. Diagnostics and progress notes go to stderr,
+// as plain lines by default or as JSON with BLIMU_LOG=json, so the output of commands on stdout
+// stays clean for pipes.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FormatEnv selects the log format: "text" (the default) or "json"
+const FormatEnv = "BLIMU_LOG"
+
+var logger = slog.New(newTextHandler(os.Stderr, slog.LevelInfo))
+
+// Setup configures the logger from the global flags and BLIMU_LOG. Quiet only logs warnings and
+// errors, for CI; verbose adds debug diagnostics.
+func Setup(w io.Writer, verbose, quiet bool) error {
+	level := slog.LevelInfo
+	switch {
+	case quiet:
+		level = slog.LevelWarn
+	case verbose:
+		level = slog.LevelDebug
+	}
+
+	switch format := strings.ToLower(strings.TrimSpace(os.Getenv(FormatEnv))); format {
+	case "", "text":
+		logger = slog.New(newTextHandler(w, level))
+	case "json":
+		logger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+	default:
+		return fmt.Errorf("invalid %s value '%s': use text or json", FormatEnv, format)
+	}
+	return nil
+}
+
+// Logger returns the configured logger, e.g. to log with attributes shared by several records
+func Logger() *slog.Logger {
+	return logger
+}
+
+// Enabled reports whether records of the level are logged, e.g. to skip building expensive
+// debug output
+func Enabled(level slog.Level) bool {
+	return logger.Enabled(context.Background(), level)
+}
+
+// Debug logs diagnostic detail shown with --verbose, with alternating key/value attributes
+func Debug(msg string, args ...any) {
+	logger.Debug(msg, args...)
+}
+
+// Info logs a progress note, hidden with --quiet
+func Info(msg string, args ...any) {
+	logger.Info(msg, args...)
+}
+
+// Warn logs a problem the command works around
+func Warn(msg string, args ...any) {
+	logger.Warn(msg, args...)
+}
+
+// textHandler writes records as "message key=value ...", the way the CLI prints its own output,
+// with the warning emoji of the CLI in front of warnings. Groups are flattened.
+type textHandler struct {
+	w     io.Writer
+	mu    *sync.Mutex
+	level slog.Leveler
+	attrs []slog.Attr
+}
+
+func newTextHandler(w io.Writer, level slog.Leveler) *textHandler {
+	return &textHandler{w: w, mu: &sync.Mutex{}, level: level}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	switch {
+	case r.Level >= slog.LevelError:
+		buf.WriteString("❌ ")
+	case r.Level >= slog.LevelWarn:
+		buf.WriteString("⚠️  ")
+	}
+	buf.WriteString(r.Message)
+
+	writeAttr := func(attr slog.Attr) bool {
+		value := attr.Value.Resolve().String()
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&buf, " %s=%s", attr.Key, value)
+		return true
+	}
+	for _, attr := range h.attrs {
+		writeAttr(attr)
+	}
+	r.Attrs(writeAttr)
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &clone
+}
+
+func (h *textHandler) WithGroup(string) slog.Handler {
+	return h
+}
@@ -0,0 +1,367 @@
+package lsp
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/blimu"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/lint"
+	"github.com/blimu-dev/blimu-cli/pkg/schema"
+)
+
+// analysis is the outcome of checking a project with its open documents
+type analysis struct {
+	// diagnostics maps file paths to their problems
+	diagnostics map[string][]Diagnostic
+	// config is the loaded configuration; nil when the files could not be loaded
+	config *config.BlimuConfig
+	// loadedFrom is the .blimu copy the configuration was loaded from, which its positions refer to
+	loadedFrom string
+	blimuDir   string
+}
+
+// errorLocation finds a .blimu file and optional line in a loader error, e.g. plans.yml:12
+var errorLocation = regexp.MustCompile(`([\w./-]+\.ya?ml)(?::(\d+))?`)
+
+// yamlErrorLine finds the line of a YAML syntax error
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// analyze checks a project directory the way 'blimu validate --offline' and 'blimu lint' do,
+// with the text of open documents in place of the files on disk. Checks stop at the first stage
+// that fails: YAML and schema problems, then semantic validation, then lint.
+func analyze(dir string, documents map[string]string, current string) (*analysis, error) {
+	blimuDir := filepath.Join(dir, ".blimu")
+	tempDir, err := os.MkdirTemp("", "blimu-lsp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	tempBlimu := filepath.Join(tempDir, ".blimu")
+
+	// Copy the YAML files of the project, then write the open documents over them
+	err = filepath.WalkDir(blimuDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isYAML(path) {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return writeCopy(blimuDir, tempBlimu, path, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for path, text := range documents {
+		if rel, err := filepath.Rel(blimuDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			if err := writeCopy(blimuDir, tempBlimu, path, []byte(text)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	a := &analysis{diagnostics: map[string][]Diagnostic{}, loadedFrom: tempBlimu, blimuDir: blimuDir}
+
+	problems, err := schema.ValidateDirectory(tempBlimu)
+	if err != nil {
+		a.addError(err, current)
+		return a, nil
+	}
+	for _, problem := range problems {
+		message := problem.Message
+		if problem.Path != "" {
+			message = problem.Path + ": " + message
+		}
+		a.add(filepath.Join(blimuDir, filepath.FromSlash(problem.File)), problem.Line, problem.Column, Diagnostic{
+			Severity: severityError,
+			Code:     "schema",
+			Message:  message,
+		})
+	}
+	if len(problems) > 0 {
+		return a, nil
+	}
+
+	blimuConfig, err := config.LoadBlimuConfig(tempDir)
+	if err != nil {
+		a.addError(err, current)
+		return a, nil
+	}
+	a.config = blimuConfig
+
+	result := blimu.ValidateConfig(blimuConfig)
+	for _, validationErr := range result.Errors {
+		a.add(a.realPath(validationErr.File), validationErr.Line, validationErr.Column, Diagnostic{
+			Severity: severityError,
+			Code:     validationErr.Rule,
+			Message:  strings.TrimPrefix(validationErr.Resource+"."+validationErr.Field, ".") + ": " + validationErr.Message,
+		})
+	}
+	if !result.Valid {
+		return a, nil
+	}
+
+	lintConfig, err := lint.LoadConfig(dir)
+	if err != nil {
+		a.addError(err, current)
+		return a, nil
+	}
+	for _, finding := range lint.Run(blimuConfig, lintConfig).Findings {
+		severity := severityWarning
+		if finding.Severity == lint.SeverityError {
+			severity = severityError
+		}
+		a.add(a.realPath(finding.File), finding.Line, finding.Column, Diagnostic{
+			Severity: severity,
+			Code:     finding.Rule,
+			Message:  finding.Message,
+		})
+	}
+	return a, nil
+}
+
+// add records a diagnostic at a 1-based line and column; unknown positions use the first line
+func (a *analysis) add(path string, line, column int, diagnostic Diagnostic) {
+	if line < 1 {
+		line = 1
+	}
+	if column < 1 {
+		column = 1
+	}
+	start := Position{Line: line - 1, Character: column - 1}
+	diagnostic.Range = Range{Start: start, End: Position{Line: start.Line, Character: start.Character + 1}}
+	diagnostic.Source = "blimu"
+	a.diagnostics[path] = append(a.diagnostics[path], diagnostic)
+}
+
+// addError reports an error that stopped the checks, in the file and line it names if any and
+// otherwise in the current document
+func (a *analysis) addError(err error, current string) {
+	message := strings.ReplaceAll(err.Error(), a.loadedFrom+string(filepath.Separator), "")
+	path, line := current, 0
+	if match := errorLocation.FindStringSubmatch(message); match != nil {
+		candidate := filepath.Join(a.blimuDir, filepath.FromSlash(strings.TrimPrefix(match[1], ".blimu/")))
+		if _, statErr := os.Stat(candidate); statErr == nil {
+			path = candidate
+			line, _ = strconv.Atoi(match[2])
+		}
+	}
+	if match := yamlErrorLine.FindStringSubmatch(message); line == 0 && match != nil {
+		line, _ = strconv.Atoi(match[1])
+	}
+	a.add(path, line, 1, Diagnostic{Severity: severityError, Message: message})
+}
+
+// realPath maps a path in the analyzed copy back to the project
+func (a *analysis) realPath(path string) string {
+	if rel, err := filepath.Rel(a.loadedFrom, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join(a.blimuDir, rel)
+	}
+	return path
+}
+
+// location returns where a position of the loaded configuration is in the project
+func (a *analysis) location(position config.Position) (string, Position) {
+	line, column := position.Line, position.Column
+	if line < 1 {
+		line = 1
+	}
+	if column < 1 {
+		column = 1
+	}
+	return a.realPath(position.File), Position{Line: line - 1, Character: column - 1}
+}
+
+// completions returns the names that fit the YAML path at the cursor of a file
+func (a *analysis) completions(file string, path []string) []CompletionItem {
+	if a.config == nil || len(path) == 0 {
+		return nil
+	}
+	cfg := a.config
+	last := path[len(path)-1]
+
+	var items []CompletionItem
+	addAll := func(names []string, kind int, detail string) {
+		sort.Strings(names)
+		for _, name := range names {
+			items = append(items, CompletionItem{Label: name, Kind: kind, Detail: detail})
+		}
+	}
+
+	switch {
+	case file == "entitlements.yml" && last == "roles":
+		resource, _, _ := strings.Cut(path[0], ":")
+		if resourceConfig, ok := cfg.Resources[resource]; ok {
+			addAll(append([]string{}, resourceConfig.Roles...), kindConstant, "role of "+resource)
+			break
+		}
+		for _, name := range keys(cfg.Resources) {
+			addAll(append([]string{}, cfg.Resources[name].Roles...), kindConstant, "role of "+name)
+		}
+	case (file == "entitlements.yml" || file == "features.yml") && last == "plans":
+		addAll(keys(cfg.Plans), kindValue, "plan")
+	case file == "features.yml" && last == "entitlements":
+		addAll(keys(cfg.Entitlements), kindValue, "entitlement")
+	case file == "resources.yml" && len(path) == 3 && path[1] == "roles_inheritance":
+		parents := keys(cfg.Resources[path[0]].Parents)
+		if len(parents) == 0 {
+			parents = keys(cfg.Resources)
+		}
+		for _, parent := range parents {
+			var names []string
+			for _, role := range cfg.Resources[parent].Roles {
+				names = append(names, parent+"->"+role)
+			}
+			addAll(names, kindConstant, "role of "+parent)
+		}
+	case file == "resources.yml" && len(path) == 2 && path[1] == "parents":
+		addAll(keys(cfg.Resources), kindValue, "resource")
+	}
+	return items
+}
+
+// definition returns where the name under the cursor is defined: roles, resources, plans and
+// entitlements referenced from another key or list
+func (a *analysis) definition(file string, path []string, word string) (string, Position, bool) {
+	if a.config == nil || word == "" {
+		return "", Position{}, false
+	}
+	cfg := a.config
+	positions := cfg.Positions
+
+	if resource, role, ok := strings.Cut(word, "->"); ok {
+		if resourceConfig, exists := cfg.Resources[resource]; exists && contains(resourceConfig.Roles, role) {
+			target, position := a.location(positions.OfItem("resources.yml", role, resource, "roles"))
+			return target, position, true
+		}
+		return "", Position{}, false
+	}
+
+	if len(path) > 0 && path[len(path)-1] == "roles" && file == "entitlements.yml" {
+		resource, _, _ := strings.Cut(path[0], ":")
+		if resourceConfig, exists := cfg.Resources[resource]; exists && contains(resourceConfig.Roles, word) {
+			target, position := a.location(positions.OfItem("resources.yml", word, resource, "roles"))
+			return target, position, true
+		}
+	}
+
+	// A key defines the name under the cursor itself; only references jump
+	if _, ok := cfg.Entitlements[word]; ok && file != "entitlements.yml" {
+		target, position := a.location(positions.Of("entitlements.yml", word))
+		return target, position, true
+	}
+	if _, ok := cfg.Plans[word]; ok && file != "plans.yml" {
+		target, position := a.location(positions.Of("plans.yml", word))
+		return target, position, true
+	}
+	if _, ok := cfg.Resources[word]; ok && (file != "resources.yml" || len(path) > 1) {
+		target, position := a.location(positions.Of("resources.yml", word))
+		return target, position, true
+	}
+	return "", Position{}, false
+}
+
+// keyPattern matches a YAML mapping key at the start of a line, including list items "- key:"
+var keyPattern = regexp.MustCompile(`^(\s*)(?:-\s+)?("[^"]*"|'[^']*'|[^\s#-][^#]*?):(\s|$)`)
+
+// yamlPath returns the keys leading to the cursor, found from indentation: the key on the
+// cursor's line (when the cursor is after it) followed by the keys of less indented lines above
+func yamlPath(lines []string, line, character int) []string {
+	if line >= len(lines) {
+		return nil
+	}
+
+	var path []string
+	indent := -1
+	current := lines[line]
+	if character > len(current) {
+		character = len(current)
+	}
+	if match := keyPattern.FindStringSubmatchIndex(current); match != nil && character > match[5] {
+		path = append(path, unquote(current[match[4]:match[5]]))
+		indent = match[3] - match[2]
+	} else {
+		indent = len(current) - len(strings.TrimLeft(current, " "))
+		if strings.TrimSpace(current) == "" {
+			indent = character
+		}
+		indent++
+	}
+
+	for i := line - 1; i >= 0 && indent > 0; i-- {
+		match := keyPattern.FindStringSubmatchIndex(lines[i])
+		if match == nil {
+			continue
+		}
+		keyIndent := match[3] - match[2]
+		if keyIndent < indent {
+			path = append([]string{unquote(lines[i][match[4]:match[5]])}, path...)
+			indent = keyIndent
+		}
+	}
+	return path
+}
+
+// wordAt returns the name under the cursor: letters, digits and _ - . : > as used in .blimu names
+func wordAt(line string, character int) string {
+	isName := func(b byte) bool {
+		return b == '_' || b == '-' || b == '.' || b == ':' || b == '>' ||
+			(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+	}
+	if character > len(line) {
+		character = len(line)
+	}
+	start, end := character, character
+	for start > 0 && isName(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isName(line[end]) {
+		end++
+	}
+	return strings.TrimSuffix(line[start:end], ":")
+}
+
+func unquote(key string) string {
+	return strings.Trim(key, `"'`)
+}
+
+func isYAML(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yml" || ext == ".yaml"
+}
+
+func writeCopy(blimuDir, tempBlimu, path string, data []byte) error {
+	rel, err := filepath.Rel(blimuDir, path)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(tempBlimu, rel)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0644)
+}
+
+func keys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
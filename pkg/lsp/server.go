@@ -0,0 +1,275 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// Server is a language server for the YAML files of .blimu directories. It reports the problems
+// 'blimu validate --offline' and 'blimu lint' find, completes role, plan and entitlement names and
+// jumps from references to their definitions.
+type Server struct {
+	conn *conn
+	// documents holds the text of the open documents by path
+	documents map[string]string
+	// analyses holds the last analysis of each project directory
+	analyses map[string]*analysis
+	// published holds the files of each project directory that were sent diagnostics
+	published map[string][]string
+}
+
+// Serve runs a language server over r and w, usually stdin and stdout, until the client sends
+// exit, closes the stream or ctx is done
+func Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s := &Server{
+		conn:      newConn(r, w),
+		documents: map[string]string{},
+		analyses:  map[string]*analysis{},
+		published: map[string][]string{},
+	}
+
+	for ctx.Err() == nil {
+		msg, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var rpcErr *responseError
+		if errors.As(err, &rpcErr) {
+			if err := s.conn.write(&errorResponse{JSONRPC: "2.0", ID: msg.ID, Error: rpcErr}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+		result, err := s.handle(msg)
+		if msg.ID == nil {
+			continue
+		}
+		var reply interface{} = &response{JSONRPC: "2.0", ID: msg.ID, Result: result}
+		if errors.As(err, &rpcErr) {
+			reply = &errorResponse{JSONRPC: "2.0", ID: msg.ID, Error: rpcErr}
+		} else if err != nil {
+			reply = &errorResponse{JSONRPC: "2.0", ID: msg.ID, Error: &responseError{Code: codeInvalidParams, Message: err.Error()}}
+		}
+		if err := s.conn.write(reply); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// handle dispatches a request or notification and returns the result of requests
+func (s *Server) handle(msg *message) (interface{}, error) {
+	switch msg.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": map[string]interface{}{
+					"openClose": true,
+					"change":    1, // full text
+					"save":      map[string]interface{}{"includeText": false},
+				},
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{" ", "[", ",", "-"},
+				},
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]interface{}{"name": "blimu"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		path := uriToPath(params.TextDocument.URI)
+		s.documents[path] = params.TextDocument.Text
+		s.check(path)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		path := uriToPath(params.TextDocument.URI)
+		if n := len(params.ContentChanges); n > 0 {
+			s.documents[path] = params.ContentChanges[n-1].Text
+		}
+		s.check(path)
+	case "textDocument/didSave":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		s.check(uriToPath(params.TextDocument.URI))
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		path := uriToPath(params.TextDocument.URI)
+		delete(s.documents, path)
+		s.check(path)
+
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		path := uriToPath(params.TextDocument.URI)
+		a, file, lines := s.lookup(path)
+		if a == nil {
+			return []CompletionItem{}, nil
+		}
+		items := a.completions(file, yamlPath(lines, params.Position.Line, params.Position.Character))
+		if items == nil {
+			items = []CompletionItem{}
+		}
+		return items, nil
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		path := uriToPath(params.TextDocument.URI)
+		a, file, lines := s.lookup(path)
+		if a == nil || params.Position.Line >= len(lines) {
+			return nil, nil
+		}
+		word := wordAt(lines[params.Position.Line], params.Position.Character)
+		target, position, ok := a.definition(file, yamlPath(lines, params.Position.Line, params.Position.Character), word)
+		if !ok {
+			return nil, nil
+		}
+		return Location{URI: pathToURI(target), Range: Range{Start: position, End: position}}, nil
+
+	default:
+		if msg.ID != nil && !strings.HasPrefix(msg.Method, "$/") {
+			return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+		}
+	}
+	return nil, nil
+}
+
+// check analyzes the project of a document and publishes its diagnostics, clearing those of
+// files that no longer have problems
+func (s *Server) check(path string) {
+	dir := projectDir(path)
+	if dir == "" {
+		return
+	}
+
+	a, err := analyze(dir, s.documents, path)
+	if err != nil {
+		s.notify("window/logMessage", map[string]interface{}{"type": 1, "message": fmt.Sprintf("blimu: %v", err)})
+		return
+	}
+	if a.config == nil && s.analyses[dir] != nil {
+		// Keep completing from the last configuration that loaded
+		a.config, a.loadedFrom = s.analyses[dir].config, s.analyses[dir].loadedFrom
+	}
+	s.analyses[dir] = a
+
+	var published []string
+	for file, diagnostics := range a.diagnostics {
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: pathToURI(file), Diagnostics: diagnostics})
+		published = append(published, file)
+	}
+	for _, file := range s.published[dir] {
+		if !slices.Contains(published, file) {
+			s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: pathToURI(file), Diagnostics: []Diagnostic{}})
+		}
+	}
+	s.published[dir] = published
+}
+
+// lookup returns the analysis of a document's project, the definition file the document is
+// read as and the document's lines
+func (s *Server) lookup(path string) (*analysis, string, []string) {
+	dir := projectDir(path)
+	a := s.analyses[dir]
+	if a == nil {
+		return nil, "", nil
+	}
+
+	text, ok := s.documents[path]
+	if !ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", nil
+		}
+		text = string(data)
+	}
+	return a, fileKind(filepath.Join(dir, ".blimu"), path), strings.Split(text, "\n")
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	_ = s.conn.write(&notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// fileKind returns the definition file a document is read as: overlays are read as the file they
+// patch, the files of split directories as the file they are merged into and the other files of
+// subdirectories as included resources
+func fileKind(blimuDir, path string) string {
+	name := filepath.Base(path)
+	if slices.Contains(config.DefinitionFiles, name) {
+		return name
+	}
+	rel, err := filepath.Rel(blimuDir, path)
+	if err != nil {
+		return name
+	}
+	rel = filepath.ToSlash(rel)
+	for _, file := range config.DefinitionFiles {
+		if strings.HasPrefix(rel, config.SplitDir(file)+"/") {
+			return file
+		}
+	}
+	if strings.Contains(rel, "/") && !strings.HasPrefix(rel, "overlays/") {
+		return "resources.yml"
+	}
+	return name
+}
+
+// projectDir returns the directory holding the .blimu directory a file is in, or ""
+func projectDir(path string) string {
+	for dir := filepath.Dir(path); ; {
+		if filepath.Base(dir) == ".blimu" {
+			return filepath.Dir(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
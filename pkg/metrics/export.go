@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultTextfileInterval is how often the textfile is rewritten when no interval is configured
+const DefaultTextfileInterval = 15 * time.Second
+
+// Options configures how metrics are exposed. Both outputs are optional and may be combined.
+type Options struct {
+	// Addr serves GET /metrics on this address (e.g. ":9464")
+	Addr string
+	// File is rewritten periodically for the node_exporter textfile collector (should end in .prom)
+	File string
+	// Interval between textfile writes
+	Interval time.Duration
+}
+
+// Enabled reports whether any output is configured
+func (o Options) Enabled() bool {
+	return o.Addr != "" || o.File != ""
+}
+
+// Handler serves the registered metrics in the Prometheus text format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w)
+	})
+}
+
+// WriteTextfile atomically replaces path with the current metrics so collectors never read a
+// partially written file
+func WriteTextfile(path string) error {
+	var buf bytes.Buffer
+	Write(&buf)
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".blimu-metrics-*")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	return nil
+}
+
+// Start exposes metrics as configured and returns a function that stops the endpoint and writes
+// the textfile one last time
+func Start(opts Options) (stop func(), err error) {
+	var server *http.Server
+	if opts.Addr != "" {
+		listener, err := net.Listen("tcp", opts.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", Handler())
+		server = &http.Server{Handler: mux}
+		go server.Serve(listener)
+
+		fmt.Printf("📈 Metrics available at http://%s/metrics\n", listener.Addr())
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	if opts.File != "" {
+		if err := WriteTextfile(opts.File); err != nil {
+			if server != nil {
+				server.Close()
+			}
+			return nil, err
+		}
+
+		interval := opts.Interval
+		if interval <= 0 {
+			interval = DefaultTextfileInterval
+		}
+
+		go func() {
+			defer close(finished)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := WriteTextfile(opts.File); err != nil {
+						fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+					}
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		fmt.Printf("📈 Writing metrics to %s every %s\n", opts.File, interval)
+	} else {
+		close(finished)
+	}
+
+	return func() {
+		close(done)
+		<-finished
+		if opts.File != "" {
+			if err := WriteTextfile(opts.File); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+			}
+		}
+		if server != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			server.Shutdown(ctx)
+		}
+	}, nil
+}
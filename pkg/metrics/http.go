@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// instrumentedTransport counts requests and records their latency
+type instrumentedTransport struct {
+	base http.RoundTripper
+}
+
+// Transport wraps base so every request is counted in APIRequests and timed in APIRequestDuration
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &instrumentedTransport{base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	APIRequestDuration.ObserveSince(start, req.Method)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	APIRequests.Inc(req.Method, code)
+
+	return resp, err
+}
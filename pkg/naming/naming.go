@@ -0,0 +1,131 @@
+// Package naming converts resource and project names between casing styles.
+// All functions work on runes, so non-ASCII names are preserved, and acronyms
+// such as "ID" or "HTTP" are kept intact when splitting words.
+package naming
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Words splits a name into words on separators and case boundaries:
+// "user_group", "userGroup" and "UserGroup" all give [user Group]-style words,
+// and "HTTPServer" gives [HTTP Server]. Digits stay attached to the preceding word.
+func Words(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := -1
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// userGroup -> user | Group
+			words = append(words, string(runes[start:i]))
+			start = i
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// HTTPServer -> HTTP | Server
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// Capitalize upper-cases the first letter of s and leaves the rest unchanged
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToTitle(r)) + s[size:]
+}
+
+// Pascal joins the words of s as PascalCase ("user_group" -> "UserGroup", "api-key" -> "ApiKey")
+func Pascal(s string) string {
+	var b strings.Builder
+	for _, word := range Words(s) {
+		b.WriteString(capitalizeWord(word))
+	}
+	return b.String()
+}
+
+// Camel joins the words of s as camelCase; a leading acronym is lower-cased ("HTTPServer" -> "httpServer")
+func Camel(s string) string {
+	words := Words(s)
+	if len(words) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(words[0]))
+	for _, word := range words[1:] {
+		b.WriteString(capitalizeWord(word))
+	}
+	return b.String()
+}
+
+// Title joins the words of s with spaces, capitalizing each ("user_group" -> "User Group")
+func Title(s string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = capitalizeWord(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Snake joins the lower-cased words of s with underscores ("UserGroup" -> "user_group")
+func Snake(s string) string {
+	return joinLower(s, "_")
+}
+
+// Kebab joins the lower-cased words of s with dashes ("UserGroup" -> "user-group")
+func Kebab(s string) string {
+	return joinLower(s, "-")
+}
+
+// capitalizeWord title-cases a word, keeping acronyms (all upper-case words) as they are
+func capitalizeWord(word string) string {
+	if isAcronym(word) {
+		return word
+	}
+	return Capitalize(strings.ToLower(word))
+}
+
+// isAcronym reports whether a word of two or more letters has no lower-case letters
+func isAcronym(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters > 1
+}
+
+func joinLower(s, sep string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
@@ -0,0 +1,264 @@
+// Package picker lets users choose from a list on the terminal, filtering it as they type and
+// moving through it with the arrow keys
+package picker
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrUnsupported is returned when the terminal cannot read single key presses; callers fall back
+// to a plain prompt
+var ErrUnsupported = errors.New("interactive picker is not supported on this terminal")
+
+// ErrCancelled is returned when the user leaves the picker with Esc
+var ErrCancelled = errors.New("selection cancelled")
+
+// visibleItems is the number of matches shown at once
+const visibleItems = 10
+
+// Key presses the picker handles, as read from a terminal in raw mode
+const (
+	keyCtrlC     = 3
+	keyBackspace = 8
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// Select shows title and items on the terminal and returns the index of the item chosen. Typing
+// filters the items: every space-separated term must appear in an item, its characters in order
+// but not necessarily adjacent, so "stg pay" matches "staging  ws_payments  env_123". Ctrl-C
+// returns context.Canceled and Esc ErrCancelled.
+func Select(title string, items []string) (int, error) {
+	if len(items) == 0 {
+		return -1, errors.New("nothing to select")
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return -1, ErrUnsupported
+	}
+	defer restore()
+
+	s := &state{items: items, width: termWidth(os.Stdout)}
+	s.filter()
+
+	in := bufio.NewReader(os.Stdin)
+	out := bufio.NewWriter(os.Stdout)
+	for {
+		s.render(out, title)
+		if err := out.Flush(); err != nil {
+			return -1, err
+		}
+
+		r, _, err := in.ReadRune()
+		if err != nil {
+			s.clear(out)
+			out.Flush()
+			return -1, err
+		}
+
+		switch {
+		case r == '\r' || r == '\n':
+			if len(s.matches) == 0 {
+				continue
+			}
+			chosen := s.matches[s.cursor]
+			s.clear(out)
+			fmt.Fprintf(out, "%s: %s\n", title, s.truncate(strings.TrimSpace(items[chosen]), len(title)+2))
+			return chosen, out.Flush()
+		case r == keyCtrlC:
+			s.clear(out)
+			out.Flush()
+			return -1, context.Canceled
+		case r == keyEscape:
+			// Arrow keys arrive as escape sequences; a lone Esc cancels
+			if in.Buffered() == 0 {
+				s.clear(out)
+				out.Flush()
+				return -1, ErrCancelled
+			}
+			if next, _ := in.ReadByte(); next != '[' && next != 'O' {
+				continue
+			}
+			switch code, _ := in.ReadByte(); code {
+			case 'A':
+				s.move(-1)
+			case 'B':
+				s.move(1)
+			}
+		case r == keyCtrlP:
+			s.move(-1)
+		case r == keyCtrlN:
+			s.move(1)
+		case r == keyBackspace || r == keyDelete:
+			if len(s.query) > 0 {
+				s.query = s.query[:len(s.query)-1]
+				s.filter()
+			}
+		case r == keyCtrlU:
+			s.query = nil
+			s.filter()
+		case unicode.IsPrint(r):
+			s.query = append(s.query, r)
+			s.filter()
+		}
+	}
+}
+
+// state is what the picker shows: the query typed so far and the items matching it
+type state struct {
+	items []string
+	query []rune
+	// matches are the indexes of the items matching the query, best first
+	matches []int
+	// cursor is the highlighted match and offset the first one shown
+	cursor, offset int
+	// lines is the number of lines drawn by the last render
+	lines int
+	width int
+}
+
+// filter recomputes the matches of the query, moving the cursor back to the best one
+func (s *state) filter() {
+	terms := strings.Fields(strings.ToLower(string(s.query)))
+
+	type scored struct{ index, score int }
+	var found []scored
+	for i, item := range s.items {
+		if score, ok := match(strings.ToLower(item), terms); ok {
+			found = append(found, scored{i, score})
+		}
+	}
+	sort.SliceStable(found, func(a, b int) bool { return found[a].score < found[b].score })
+
+	s.matches = s.matches[:0]
+	for _, f := range found {
+		s.matches = append(s.matches, f.index)
+	}
+	s.cursor, s.offset = 0, 0
+}
+
+// move moves the cursor by delta matches, wrapping around, and scrolls to keep it visible
+func (s *state) move(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.cursor = (s.cursor + delta + len(s.matches)) % len(s.matches)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+visibleItems {
+		s.offset = s.cursor - visibleItems + 1
+	}
+}
+
+// render draws the prompt and the visible matches over the previous render
+func (s *state) render(w io.Writer, title string) {
+	s.clear(w)
+
+	lines := []string{"\x1b[2m(↑/↓ move, type to filter, enter selects, esc cancels)\x1b[0m"}
+	if len(s.matches) == 0 {
+		lines = append(lines, "  No matches")
+	}
+	end := s.offset + visibleItems
+	if end > len(s.matches) {
+		end = len(s.matches)
+	}
+	for i := s.offset; i < end; i++ {
+		item := s.truncate(s.items[s.matches[i]], 2)
+		if i == s.cursor {
+			lines = append(lines, "\x1b[36m❯ "+item+"\x1b[0m")
+		} else {
+			lines = append(lines, "  "+item)
+		}
+	}
+	if hidden := len(s.matches) - end; hidden > 0 {
+		lines = append(lines, fmt.Sprintf("  … %d more", hidden))
+	}
+
+	// The prompt goes last, so the terminal cursor is left after the query
+	for _, line := range lines {
+		fmt.Fprintf(w, "%s\n", line)
+	}
+	fmt.Fprintf(w, "%s: %s", title, string(s.query))
+	s.lines = len(lines) + 1
+}
+
+// clear erases the previous render
+func (s *state) clear(w io.Writer) {
+	if s.lines == 0 {
+		return
+	}
+	fmt.Fprint(w, "\r")
+	if s.lines > 1 {
+		fmt.Fprintf(w, "\x1b[%dA", s.lines-1)
+	}
+	fmt.Fprint(w, "\x1b[J")
+	s.lines = 0
+}
+
+// truncate shortens item so that, after indent columns, it fits on one line of the terminal:
+// wrapped lines would break redrawing
+func (s *state) truncate(item string, indent int) string {
+	runes := []rune(item)
+	if max := s.width - indent - 1; max > 0 && len(runes) > max {
+		return string(runes[:max-1]) + "…"
+	}
+	return item
+}
+
+// match reports whether every term appears in item and scores the match, lower being better:
+// terms found as a whole beat terms whose characters are scattered, and earlier beats later
+func match(item string, terms []string) (int, bool) {
+	score := 0
+	for _, term := range terms {
+		if i := strings.Index(item, term); i >= 0 {
+			score += i
+			continue
+		}
+		gaps, ok := subsequence(item, term)
+		if !ok {
+			return 0, false
+		}
+		score += 1000 + gaps
+	}
+	return score, true
+}
+
+// subsequence reports whether the characters of term appear in item in order, and the number of
+// characters skipped between the first and the last of them
+func subsequence(item, term string) (int, bool) {
+	want := []rune(term)
+	matched, gaps, started := 0, 0, false
+	for _, r := range item {
+		if matched == len(want) {
+			break
+		}
+		if r == want[matched] {
+			matched++
+			started = true
+		} else if started {
+			gaps++
+		}
+	}
+	return gaps, matched == len(want)
+}
+
+// termWidth returns the width of the terminal of f in columns, 80 when unknown
+func termWidth(f *os.File) int {
+	if width := terminalColumns(f); width > 0 {
+		return width
+	}
+	return 80
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package picker
+
+import "os"
+
+// makeRaw is not supported on this platform; callers fall back to a numbered prompt
+func makeRaw(f *os.File) (func(), error) {
+	return nil, ErrUnsupported
+}
+
+// terminalColumns is unknown on this platform
+func terminalColumns(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,47 @@
+//go:build linux || darwin
+
+package picker
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw switches the terminal of f to reading single key presses without echo and returns a
+// function restoring its previous state
+func makeRaw(f *os.File) (func(), error) {
+	var state syscall.Termios
+	if err := ioctlTermios(f, ioctlGetTermios, &state); err != nil {
+		return nil, err
+	}
+
+	raw := state
+	// Ctrl-C is read as a key, so the picker can restore the terminal before cancelling
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctlTermios(f, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		ioctlTermios(f, ioctlSetTermios, &state)
+	}, nil
+}
+
+// terminalColumns returns the width of the terminal of f, 0 when unknown
+func terminalColumns(f *os.File) int {
+	var size struct{ rows, cols, xpixel, ypixel uint16 }
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); errno != 0 {
+		return 0
+	}
+	return int(size.cols)
+}
+
+func ioctlTermios(f *os.File, request uintptr, state *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), request, uintptr(unsafe.Pointer(state))); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package schema holds the JSON Schemas of the .blimu YAML files. They are embedded so the CLI
+// can validate files offline and export them for editors (yaml-language-server) and CI.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//go:embed schemas/*.schema.json
+var schemaFS embed.FS
+
+// Files lists the .blimu files that have a schema, in the order they are usually edited
+var Files = []string{"resources.yml", "entitlements.yml", "features.yml", "plans.yml", "sdk.yml"}
+
+// FileName returns the schema file name of a .blimu file, e.g. resources.schema.json.
+// name may be given with or without the .yml extension.
+func FileName(name string) string {
+	return strings.TrimSuffix(name, ".yml") + ".schema.json"
+}
+
+// Get returns the JSON Schema of a .blimu file
+func Get(name string) ([]byte, error) {
+	if !hasSchema(name) {
+		return nil, fmt.Errorf("no schema for '%s' (available: %s)", name, strings.Join(Files, ", "))
+	}
+	data, err := schemaFS.ReadFile("schemas/" + FileName(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema for %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// load parses the schema of a .blimu file
+func load(name string) (*Schema, error) {
+	data, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse schema for %s: %w", name, err)
+	}
+	return &s, nil
+}
+
+func hasSchema(name string) bool {
+	for _, file := range Files {
+		if file == name || strings.TrimSuffix(file, ".yml") == name {
+			return true
+		}
+	}
+	return false
+}
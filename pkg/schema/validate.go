@@ -0,0 +1,317 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the subset of JSON Schema (draft-07) used by the embedded schemas
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Additional        `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinItems             int                `json:"minItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Additional is an additionalProperties value: either a boolean or a schema for the extra keys
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+// UnmarshalJSON accepts both forms of additionalProperties
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// Problem is a place where a file does not match its schema
+type Problem struct {
+	File string
+	// Path locates the value, e.g. workspace.roles_inheritance.editor[1]
+	Path string
+	// Line and Column are 1-based positions in the file
+	Line    int
+	Column  int
+	Message string
+}
+
+// String formats the problem as file:line: path: message
+func (p Problem) String() string {
+	location := fmt.Sprintf("%s:%d", p.File, p.Line)
+	if p.Path == "" {
+		return fmt.Sprintf("%s: %s", location, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, p.Path, p.Message)
+}
+
+// ValidateDirectory checks every .blimu file that has a schema; missing files are skipped.
+// Definition files are checked with their ${VAR} references substituted.
+func ValidateDirectory(blimuDir string) ([]Problem, error) {
+	values, err := config.LoadValues(blimuDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	for _, name := range Files {
+		if slices.Contains(config.DefinitionFiles, name) {
+			definitionProblems, err := validateDefinition(blimuDir, name, values)
+			if err != nil {
+				return nil, err
+			}
+			problems = append(problems, definitionProblems...)
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(blimuDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		fileProblems, err := ValidateFile(name, data)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, fileProblems...)
+	}
+	return problems, nil
+}
+
+// validateDefinition checks a definition file merged with the files of its split directory and,
+// for resources.yml, with its includes and templates resolved. Problems are reported in the file
+// each item was written in.
+func validateDefinition(blimuDir, name string, values config.Values) ([]Problem, error) {
+	var composed *config.ComposedDefinition
+	var err error
+	if name == "resources.yml" {
+		composed, err = config.ComposeResources(blimuDir, values)
+	} else {
+		composed, err = config.ComposeDefinition(blimuDir, name, values)
+	}
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if composed.Node == nil {
+		return nil, nil
+	}
+
+	s, err := load(name)
+	if err != nil {
+		return nil, err
+	}
+	var problems []Problem
+	for i := 0; i+1 < len(composed.Node.Content); i += 2 {
+		item := &yaml.Node{Kind: yaml.MappingNode, Content: composed.Node.Content[i : i+2]}
+		v := &validator{file: composed.Sources[composed.Node.Content[i].Value], root: s, patterns: map[string]*regexp.Regexp{}}
+		v.validate(item, s, "")
+		problems = append(problems, v.problems...)
+	}
+	return problems, nil
+}
+
+// ValidateFile checks the contents of a .blimu file against its embedded schema. Empty files
+// have nothing to check; files that are not valid YAML return an error.
+func ValidateFile(name string, data []byte) ([]Problem, error) {
+	s, err := load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid YAML in %s: %w", name, err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{file: name, root: s, patterns: map[string]*regexp.Regexp{}}
+	v.validate(root.Content[0], s, "")
+	return v.problems, nil
+}
+
+type validator struct {
+	file     string
+	root     *Schema
+	patterns map[string]*regexp.Regexp
+	problems []Problem
+}
+
+func (v *validator) report(node *yaml.Node, path, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{
+		File:    v.file,
+		Path:    path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(node *yaml.Node, s *Schema, path string) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+
+	if s.Type != "" {
+		if actual := nodeType(node); !typeMatches(s.Type, actual) {
+			v.report(node, path, "expected %s, got %s", s.Type, actual)
+			return
+		}
+	}
+	if len(s.Enum) > 0 && node.Kind == yaml.ScalarNode && !containsString(s.Enum, node.Value) {
+		v.report(node, path, "'%s' must be one of: %s", node.Value, strings.Join(s.Enum, ", "))
+	}
+	if s.Pattern != "" && node.Kind == yaml.ScalarNode && !v.matches(s.Pattern, node.Value) {
+		v.report(node, path, "'%s' does not match the pattern %s", node.Value, s.Pattern)
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(node, s, path)
+	case yaml.SequenceNode:
+		v.validateSequence(node, s, path)
+	}
+}
+
+func (v *validator) validateMapping(node *yaml.Node, s *Schema, path string) {
+	present := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		present[key] = true
+		keyPath := joinPath(path, key)
+
+		if s.PropertyNames != nil {
+			if names := v.resolve(s.PropertyNames); names != nil && names.Pattern != "" && !v.matches(names.Pattern, key) {
+				v.report(keyNode, keyPath, "key '%s' does not match the pattern %s", key, names.Pattern)
+			}
+		}
+
+		if prop, ok := s.Properties[key]; ok {
+			v.validate(value, prop, keyPath)
+			continue
+		}
+		switch {
+		case s.AdditionalProperties == nil:
+		case s.AdditionalProperties.Schema != nil:
+			v.validate(value, s.AdditionalProperties.Schema, keyPath)
+		case !s.AdditionalProperties.Allowed:
+			v.report(keyNode, keyPath, "unknown key '%s'", key)
+		}
+	}
+
+	for _, required := range s.Required {
+		if !present[required] {
+			v.report(node, path, "missing required key '%s'", required)
+		}
+	}
+}
+
+func (v *validator) validateSequence(node *yaml.Node, s *Schema, path string) {
+	if len(node.Content) < s.MinItems {
+		v.report(node, path, "must have at least %d item(s)", s.MinItems)
+	}
+
+	seen := map[string]bool{}
+	for i, item := range node.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if s.UniqueItems && item.Kind == yaml.ScalarNode {
+			if seen[item.Value] {
+				v.report(item, itemPath, "duplicate item '%s'", item.Value)
+			}
+			seen[item.Value] = true
+		}
+		if s.Items != nil {
+			v.validate(item, s.Items, itemPath)
+		}
+	}
+}
+
+// resolve follows local $refs (#/definitions/name)
+func (v *validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		s = v.root.Definitions[name]
+	}
+	return s
+}
+
+func (v *validator) matches(pattern, value string) bool {
+	re, ok := v.patterns[pattern]
+	if !ok {
+		re = regexp.MustCompile(pattern)
+		v.patterns[pattern] = re
+	}
+	return re.MatchString(value)
+}
+
+// nodeType returns the JSON type of a YAML node
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.Tag {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+func typeMatches(expected, actual string) bool {
+	return expected == actual || (expected == "number" && actual == "integer")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package shared
+
+import (
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// APIEnvEnv is the environment variable that selects the API environment when --api-env is not given
+const APIEnvEnv = "BLIMU_API_ENV"
+
+// apiEnv is the API environment of this process, set by the global --api-env flag
+var apiEnv = config.APIEnvProd
+
+// SetAPIEnvironment points every endpoint of this process at an API environment (the global
+// --api-env flag); empty falls back to BLIMU_API_ENV, then production
+func SetAPIEnvironment(name string) error {
+	if name == "" {
+		name = os.Getenv(APIEnvEnv)
+	}
+	if name == "" {
+		name = config.APIEnvProd
+	}
+	if err := config.ValidateAPIEnv(name); err != nil {
+		return err
+	}
+	apiEnv = name
+	return nil
+}
+
+// APIEnvironment returns the API environment of this process
+func APIEnvironment() string {
+	return apiEnv
+}
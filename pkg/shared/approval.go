@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// AutoApproveEnv is the environment variable that answers confirmation prompts with yes when set
+// to a true value (1, true)
+const AutoApproveEnv = "BLIMU_AUTO_APPROVE"
+
+// autoApprove is set by the global --auto-approve flag
+var autoApprove bool
+
+// nonInteractive is set by the global --non-interactive flag
+var nonInteractive bool
+
+// SetAutoApprove makes every confirmation prompt of this process answer yes (the global
+// --auto-approve flag)
+func SetAutoApprove(approve bool) {
+	autoApprove = approve
+}
+
+// SetNonInteractive disables every prompt of this process (the global --non-interactive flag):
+// commands that would ask fail with an error telling how to answer with flags instead
+func SetNonInteractive(disable bool) {
+	nonInteractive = disable
+}
+
+// AutoApproved reports whether confirmation prompts for a change to an environment are answered
+// without asking. The first of these that is set decides:
+//
+//  1. yes (the command's --yes flag) or the global --auto-approve flag
+//  2. the environment's auto_approve setting in the CLI config
+//  3. BLIMU_AUTO_APPROVE
+//
+// so auto_approve: false keeps an environment prompting in automation that sets
+// BLIMU_AUTO_APPROVE. The environment is looked up by local name, ID or lookup key; pass "" for
+// prompts that do not concern an environment. cliConfig may be nil. Protected environments are
+// stricter, see ConfirmProtectedEnvironment.
+func AutoApproved(cliConfig *config.CLIConfig, environment string, yes bool) (bool, error) {
+	if yes || autoApprove {
+		return true, nil
+	}
+	if cliConfig != nil && environment != "" {
+		if name, ok := cliConfig.FindEnvironment(environment); ok {
+			if setting := cliConfig.Environments[name].AutoApprove; setting != nil {
+				return *setting, nil
+			}
+		}
+	}
+
+	value := strings.TrimSpace(os.Getenv(AutoApproveEnv))
+	if value == "" {
+		return false, nil
+	}
+	approve, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value '%s': use true or false", AutoApproveEnv, value)
+	}
+	return approve, nil
+}
+
+// Confirm asks a [y/N] question unless approved. Without a terminal to ask on, it refuses with an
+// error naming the action (e.g. "delete 3 resource(s)") and how to approve it non-interactively.
+func Confirm(question, action string, approved bool) (bool, error) {
+	if approved {
+		return true, nil
+	}
+	if !IsInteractive() {
+		return false, fmt.Errorf("refusing to %s without confirmation; %s", action, approveHint)
+	}
+
+	fmt.Printf("%s [y/N]: ", question)
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes", nil
+}
+
+// IsInteractive reports whether prompts may be asked: not --non-interactive, and both stdin and
+// stdout are terminals, so a CI job or piped output never waits for an answer
+func IsInteractive() bool {
+	return !nonInteractive && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// approveHint tells how to answer confirmation prompts without a terminal
+const approveHint = "pass --yes or --auto-approve, or set " + AutoApproveEnv + "=1, to proceed non-interactively"
@@ -6,12 +6,13 @@ import (
 	"time"
 
 	"github.com/blimu-dev/blimu-cli/internal/oauth"
-	platform "github.com/blimu-dev/blimu-cli/internal/sdk"
-	"github.com/blimu-dev/blimu-cli/pkg/auth"
 	"github.com/blimu-dev/blimu-cli/pkg/config"
-	// runtime "github.com/blimu-dev/blimu-go" // Will be used for token refresh
+	platform "github.com/blimu-dev/blimu-cli/platform"
 )
 
+// environmentOverride is the environment selected with --env for this process, if any
+var environmentOverride string
+
 // GetSDKClient returns a configured platform SDK client using the current environment
 func GetSDKClient() (*platform.Client, error) {
 	return GetSDKClientWithDevMode(false)
@@ -24,98 +25,86 @@ func GetSDKClientWithDevMode(devMode bool) (*platform.Client, error) {
 		return nil, fmt.Errorf("failed to load CLI config: %w", err)
 	}
 
-	currentEnv, err := cliConfig.GetCurrentEnvironment()
+	currentEnv, err := activeEnvironment(cliConfig)
 	if err != nil {
-		return nil, fmt.Errorf("no current environment configured. Please configure an environment first")
+		return nil, err
 	}
 
-	// Determine platform API URL
-	platformURL := "https://app-api-42118893108.us-central1.run.app"
-	if devMode {
-		platformURL = "http://localhost:3010"
-	} else if currentEnv.APIURL != "" && currentEnv.APIURL != "https://blimu-api-42118893108.us-central1.run.app" {
-		// If user has custom platform URL configured
-		platformURL = currentEnv.APIURL
+	return newPlatformClient(context.Background(), cliConfig, ActiveEnvironmentName(cliConfig), currentEnv, devMode)
+}
+
+// GetSDKClientForEnvironment returns a platform SDK client authenticated with a named local
+// environment instead of the current one, along with that environment's configuration
+func GetSDKClientForEnvironment(name string, devMode bool) (*platform.Client, *config.Environment, error) {
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load CLI config: %w", err)
+	}
+
+	env, ok := cliConfig.Environments[name]
+	if !ok {
+		return nil, nil, Errorf(ErrNotFound, "environment '%s' not found. Use 'blimu env list' to see configured environments", name)
 	}
 
+	client, err := newPlatformClient(context.Background(), cliConfig, name, &env, devMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, &env, nil
+}
+
+// newPlatformClient builds a platform SDK client from an environment's OAuth tokens,
+// refreshing them first when they are about to expire. Cancelling ctx aborts the refresh and
+// every request of the client.
+func newPlatformClient(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, devMode bool) (*platform.Client, error) {
+	platformURL := PlatformURL(cliConfig, env, devMode)
+
 	// Check if we have Clerk OAuth tokens
-	if currentEnv.IsOAuthAuthenticated() {
-		// Check if token needs refresh
-		if currentEnv.NeedsTokenRefresh() && currentEnv.RefreshToken != "" {
-			fmt.Printf("🔄 Refreshing expired access token...\n")
-			if err := refreshPlatformTokens(cliConfig, currentEnv, platformURL); err != nil {
-				fmt.Printf("⚠️  Failed to refresh token: %v\n", err)
-				fmt.Printf("Please run 'blimu auth login' to re-authenticate\n")
-				return nil, fmt.Errorf("token refresh failed: %w", err)
-			}
-			// Reload the environment after refresh
-			currentEnv, err = cliConfig.GetCurrentEnvironment()
-			if err != nil {
-				return nil, fmt.Errorf("failed to reload environment after token refresh: %w", err)
-			}
-			fmt.Printf("✅ Token refreshed successfully\n")
+	if env.IsOAuthAuthenticated() {
+		// The token source refreshes the token ahead of its expiry, for this and every later
+		// request of the client; a first refresh here reports a dead session straight away
+		tokens := tokenSourceFor(cliConfig, name, env, platformURL)
+		if _, err := tokens.Token(ctx); err != nil {
+			return nil, err
+		}
+
+		httpClient, err := HTTPClient(cliConfig, env)
+		if err != nil {
+			return nil, err
 		}
 
 		// Use Clerk JWT token with platform SDK
 		client := platform.NewClient(
 			platform.WithBaseURL(platformURL),
-			platform.WithBearer(currentEnv.AccessToken),
+			platform.WithTokenSource(tokens),
+			platform.WithHTTPClient(httpClient),
+			platform.WithRetry(retryPolicy()),
+			platform.WithMiddleware(logRateLimit),
+			platform.WithContext(ctx),
 		)
 		return client, nil
 	}
 
-	return nil, fmt.Errorf("no valid authentication found. Please run 'blimu auth login' to authenticate")
+	return nil, Errorf(ErrAuth, "no valid authentication found. Please run 'blimu auth login' to authenticate")
 }
 
-// GetAuthClient returns a configured auth client using the current environment
-func GetAuthClient() (*auth.Client, error) {
-	return GetAuthClientWithDevMode(false)
-}
-
-// GetAuthClientWithDevMode returns a configured auth client with optional dev mode
-func GetAuthClientWithDevMode(devMode bool) (*auth.Client, error) {
-	cliConfig, err := config.LoadCLIConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load CLI config: %w", err)
-	}
-
-	currentEnv, err := cliConfig.GetCurrentEnvironment()
-	if err != nil {
-		return nil, fmt.Errorf("no current environment configured. Please configure an environment first")
-	}
-
-	// Determine platform API URL
-	platformURL := "https://app-api-42118893108.us-central1.run.app"
+// Endpoints resolves the endpoints of an environment: in production those of its region and
+// settings (see config.CLIConfig.EnvironmentEndpoints), otherwise those of the API environment.
+// Dev mode is the local API environment.
+func Endpoints(cliConfig *config.CLIConfig, env *config.Environment, devMode bool) config.Endpoints {
+	name := APIEnvironment()
 	if devMode {
-		platformURL = "http://localhost:3010"
-	} else if currentEnv.APIURL != "" && currentEnv.APIURL != "https://blimu-api-42118893108.us-central1.run.app" {
-		// If user has custom platform URL configured
-		platformURL = currentEnv.APIURL
+		name = config.APIEnvLocal
 	}
-
-	// Check if we have Clerk OAuth tokens
-	if currentEnv.IsOAuthAuthenticated() {
-		// Check if token needs refresh
-		if currentEnv.NeedsTokenRefresh() && currentEnv.RefreshToken != "" {
-			fmt.Printf("🔄 Refreshing expired access token...\n")
-			if err := refreshPlatformTokens(cliConfig, currentEnv, platformURL); err != nil {
-				fmt.Printf("⚠️  Failed to refresh token: %v\n", err)
-				fmt.Printf("Please run 'blimu auth login' to re-authenticate\n")
-				return nil, fmt.Errorf("token refresh failed: %w", err)
-			}
-			// Reload the environment after refresh
-			currentEnv, err = cliConfig.GetCurrentEnvironment()
-			if err != nil {
-				return nil, fmt.Errorf("failed to reload environment after token refresh: %w", err)
-			}
-			fmt.Printf("✅ Token refreshed successfully\n")
-		}
-
-		// Create client with Clerk token for platform operations
-		return auth.NewClientWithClerkToken(platformURL, currentEnv.AccessToken), nil
+	if name == config.APIEnvProd {
+		return cliConfig.EnvironmentEndpoints(env)
 	}
+	return cliConfig.APIEnvEndpoints(name)
+}
 
-	return nil, fmt.Errorf("no valid authentication found. Please run 'blimu auth login' to authenticate")
+// PlatformURL determines the platform API URL for an environment
+func PlatformURL(cliConfig *config.CLIConfig, env *config.Environment, devMode bool) string {
+	return Endpoints(cliConfig, env, devMode).PlatformAPI
 }
 
 // GetCurrentEnvironmentInfo returns the current environment configuration and metadata
@@ -125,18 +114,57 @@ func GetCurrentEnvironmentInfo() (*config.CLIConfig, *config.Environment, error)
 		return nil, nil, fmt.Errorf("failed to load CLI config: %w", err)
 	}
 
-	currentEnv, err := cliConfig.GetCurrentEnvironment()
+	currentEnv, err := activeEnvironment(cliConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("no current environment configured. Please configure an environment first")
+		return nil, nil, err
 	}
 
 	return cliConfig, currentEnv, nil
 }
 
+// SetEnvironmentOverride makes commands in this process use the named local environment instead
+// of the current one (the global --env flag). The current environment in the CLI config is not changed.
+func SetEnvironmentOverride(name string) {
+	environmentOverride = name
+}
+
+// EnvironmentOverride returns the environment selected with --env, or "" when commands use the current one
+func EnvironmentOverride() string {
+	return environmentOverride
+}
+
+// ActiveEnvironmentName returns the name of the environment commands run against:
+// the --env override when set, otherwise the current environment
+func ActiveEnvironmentName(cliConfig *config.CLIConfig) string {
+	if environmentOverride != "" {
+		return environmentOverride
+	}
+	return cliConfig.CurrentEnvironment
+}
+
+// activeEnvironment returns the environment commands run against, the --env override or else the
+// current environment, with the BLIMU_* environment variables applied
+func activeEnvironment(cliConfig *config.CLIConfig) (*config.Environment, error) {
+	if environmentOverride == "" {
+		currentEnv, _ := cliConfig.GetCurrentEnvironment()
+		env := environmentFromEnv(currentEnv)
+		if env == nil {
+			return nil, Errorf(ErrAuth, "no current environment configured. Run 'blimu auth login', or set %s", AccessTokenEnv)
+		}
+		return env, nil
+	}
+
+	env, ok := cliConfig.Environments[environmentOverride]
+	if !ok {
+		return nil, Errorf(ErrNotFound, "environment '%s' (from --env) not found. Use 'blimu env list' to see configured environments", environmentOverride)
+	}
+	return environmentFromEnv(&env), nil
+}
+
 // refreshTokens handles OAuth token refresh for runtime API
 func refreshTokens(cliConfig *config.CLIConfig, env *config.Environment, apiURL string) error {
 	oauthConfig := oauth.Config{
-		ClientID: "blimu_cli",
+		ClientID: oauth.DefaultClientID,
 		TokenURL: fmt.Sprintf("%s/v1/%s/oauth/token", apiURL, env.ID),
 	}
 
@@ -162,31 +190,53 @@ func refreshTokens(cliConfig *config.CLIConfig, env *config.Environment, apiURL
 }
 
 // refreshPlatformTokens handles OAuth token refresh for platform API
-func refreshPlatformTokens(cliConfig *config.CLIConfig, env *config.Environment, platformURL string) error {
-	oauthConfig := oauth.Config{
-		ClientID: "blimu_cli",
-		TokenURL: fmt.Sprintf("%s/oauth/token", platformURL),
-	}
+func refreshPlatformTokens(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) error {
+	return refreshEnvironment(ctx, cliConfig, name, env, func(ctx context.Context, env *config.Environment) (*oauth.TokenResponse, error) {
+		oauthClient := oauth.NewClient(OAuthConfig(cliConfig, env, platformURL))
 
-	oauthClient := oauth.NewClient(oauthConfig)
+		tokenResp, err := oauthClient.RefreshToken(ctx, env.RefreshToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refresh platform token: %w", err)
+		}
+		return tokenResp, nil
+	})
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// refreshServiceAccountToken requests a new access token for a service account environment
+func refreshServiceAccountToken(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) error {
+	return refreshEnvironment(ctx, cliConfig, name, env, func(ctx context.Context, env *config.Environment) (*oauth.TokenResponse, error) {
+		// The service account is the client; only the endpoint comes from the OAuth settings
+		oauthConfig := OAuthConfig(cliConfig, env, platformURL)
+		oauthConfig.ClientID = env.ClientID
+		oauthClient := oauth.NewClient(oauthConfig)
+
+		tokenResp, err := oauthClient.ClientCredentialsToken(ctx, env.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request service account token: %w", err)
+		}
+		return tokenResp, nil
+	})
+}
 
-	tokenResp, err := oauthClient.RefreshToken(ctx, env.RefreshToken)
+// refreshGitHubOIDCToken exchanges a new GitHub Actions ID token for an access token
+func refreshGitHubOIDCToken(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) error {
+	return refreshEnvironment(ctx, cliConfig, name, env, func(ctx context.Context, env *config.Environment) (*oauth.TokenResponse, error) {
+		return ExchangeGitHubOIDCToken(ctx, OAuthConfig(cliConfig, env, platformURL), env.OIDCAudience)
+	})
+}
+
+// ExchangeGitHubOIDCToken requests an ID token for audience from the GitHub Actions job and
+// exchanges it for a platform access token with the OAuth client of oauthConfig
+func ExchangeGitHubOIDCToken(ctx context.Context, oauthConfig oauth.Config, audience string) (*oauth.TokenResponse, error) {
+	idToken, err := oauth.GitHubActionsIDToken(ctx, audience)
 	if err != nil {
-		return fmt.Errorf("failed to refresh platform token: %w", err)
+		return nil, err
 	}
 
-	// Update environment with new tokens
-	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-	env.AccessToken = tokenResp.AccessToken
-	if tokenResp.RefreshToken != "" {
-		env.RefreshToken = tokenResp.RefreshToken
+	oauthClient := oauth.NewClient(oauthConfig)
+	tokenResp, err := oauthClient.ExchangeIDToken(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange GitHub Actions ID token: %w", err)
 	}
-	env.ExpiresAt = &expiresAt
-	env.TokenType = "Bearer"
-
-	// Save updated environment to config
-	return cliConfig.AddEnvironment(*env)
+	return tokenResp, nil
 }
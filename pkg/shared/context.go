@@ -0,0 +1,121 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	platform "github.com/blimu-dev/blimu-cli/platform"
+)
+
+// Context is the runtime state shared by the commands of one CLI invocation: the global flags,
+// the CLI config and the platform client of the active environment. Its clients run their
+// requests with the command's context, so they are aborted when the command is cancelled. The config is loaded and
+// clients are created on first use, so commands that never call the API never need credentials.
+type Context struct {
+	// DevMode is the global --dev flag
+	DevMode bool
+	// Out receives command output
+	Out io.Writer
+	// WorkspaceID and EnvironmentID are the global --workspace-id and --environment-id flags;
+	// commands resolve them with IDs
+	WorkspaceID   string
+	EnvironmentID string
+
+	ctx       context.Context
+	cliConfig *config.CLIConfig
+	// clients caches the platform client of each environment used, by local name
+	clients map[string]*platform.Client
+}
+
+// NewContext creates a command context whose clients make their requests with ctx
+func NewContext(ctx context.Context, devMode bool, out io.Writer) *Context {
+	return &Context{DevMode: devMode, Out: out, ctx: ctx, clients: make(map[string]*platform.Client)}
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying the command context
+func WithContext(ctx context.Context, sc *Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, sc)
+}
+
+// FromContext returns the command context carried by ctx, or a default one (no dev mode, output
+// to stdout) when there is none, e.g. for commands run outside the root command
+func FromContext(ctx context.Context) *Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if sc, ok := ctx.Value(contextKey{}).(*Context); ok {
+		return sc
+	}
+	return NewContext(ctx, false, os.Stdout)
+}
+
+// Config returns the CLI config, loading it on first use
+func (c *Context) Config() (*config.CLIConfig, error) {
+	if c.cliConfig == nil {
+		cliConfig, err := config.LoadCLIConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CLI config: %w", err)
+		}
+		c.cliConfig = cliConfig
+	}
+	return c.cliConfig, nil
+}
+
+// EnvironmentInfo returns the CLI config and the environment commands run against: the --env
+// override when set, otherwise the current environment
+func (c *Context) EnvironmentInfo() (*config.CLIConfig, *config.Environment, error) {
+	cliConfig, err := c.Config()
+	if err != nil {
+		return nil, nil, err
+	}
+	env, err := activeEnvironment(cliConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cliConfig, env, nil
+}
+
+// Client returns the platform client of the active environment, creating it (and refreshing its
+// tokens) on first use. The active environment may change between calls, e.g. per project of a
+// monorepo, so clients are kept per environment.
+func (c *Context) Client() (*platform.Client, error) {
+	cliConfig, env, err := c.EnvironmentInfo()
+	if err != nil {
+		return nil, err
+	}
+	name := ActiveEnvironmentName(cliConfig)
+	if client, ok := c.clients[name]; ok {
+		return client, nil
+	}
+
+	client, err := newPlatformClient(c.ctx, cliConfig, name, env, c.DevMode)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[name] = client
+	return client, nil
+}
+
+// AccessToken returns the active environment with a valid access token, refreshing it first when
+// it is about to expire, for tools that call the API themselves
+func (c *Context) AccessToken() (*config.Environment, error) {
+	cliConfig, env, err := c.EnvironmentInfo()
+	if err != nil {
+		return nil, err
+	}
+	if !env.IsOAuthAuthenticated() {
+		return nil, Errorf(ErrAuth, "no valid authentication found. Please run 'blimu auth login' to authenticate")
+	}
+
+	name := ActiveEnvironmentName(cliConfig)
+	fresh, err := tokenSourceFor(cliConfig, name, env, PlatformURL(cliConfig, env, c.DevMode)).Environment(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &fresh, nil
+}
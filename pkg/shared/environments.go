@@ -1,11 +1,18 @@
 package shared
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+	"github.com/blimu-dev/blimu-cli/pkg/picker"
 )
 
 // EnvironmentInfo represents an environment with its metadata
@@ -17,8 +24,9 @@ type EnvironmentInfo struct {
 	IsActive    bool
 }
 
-// FetchUserEnvironments fetches environments available to the user
-func FetchUserEnvironments(devMode bool) ([]EnvironmentInfo, error) {
+// FetchUserEnvironments fetches environments available to the user; cancelling ctx aborts the
+// API requests
+func FetchUserEnvironments(ctx context.Context, devMode bool) ([]EnvironmentInfo, error) {
 	var environments []EnvironmentInfo
 
 	// Get current CLI config
@@ -45,9 +53,9 @@ func FetchUserEnvironments(devMode bool) ([]EnvironmentInfo, error) {
 		// Get the current environment to use for API calls
 		currentEnv := cliConfig.Environments[currentEnvName]
 		if currentEnv.IsOAuthAuthenticated() {
-			remoteEnvs, err := fetchRemoteEnvironments(devMode)
+			remoteEnvs, err := fetchRemoteEnvironments(ctx, cliConfig, devMode)
 			if err != nil {
-				fmt.Printf("⚠️  Could not fetch remote environments: %v\n", err)
+				logging.Warn(fmt.Sprintf("Could not fetch remote environments: %v", err))
 			} else {
 				// Add remote environments that aren't already local
 				for _, remoteEnv := range remoteEnvs {
@@ -71,8 +79,12 @@ func FetchUserEnvironments(devMode bool) ([]EnvironmentInfo, error) {
 }
 
 // fetchRemoteEnvironments fetches environments from user's effective resources
-func fetchRemoteEnvironments(devMode bool) ([]EnvironmentInfo, error) {
-	client, err := GetSDKClientWithDevMode(devMode)
+func fetchRemoteEnvironments(ctx context.Context, cliConfig *config.CLIConfig, devMode bool) ([]EnvironmentInfo, error) {
+	env, err := activeEnvironment(cliConfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newPlatformClient(ctx, cliConfig, ActiveEnvironmentName(cliConfig), env, devMode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get API client: %w", err)
 	}
@@ -83,7 +95,7 @@ func fetchRemoteEnvironments(devMode bool) ([]EnvironmentInfo, error) {
 		return nil, fmt.Errorf("failed to get user's active resources: %w", err)
 	}
 
-	fmt.Printf("🔍 Found %d workspaces for user\n", len(userAccess.Workspaces))
+	logging.Debug("fetched user access", "workspaces", len(userAccess.Workspaces))
 
 	var environments []EnvironmentInfo
 
@@ -92,25 +104,25 @@ func fetchRemoteEnvironments(devMode bool) ([]EnvironmentInfo, error) {
 		workspaceID := getStringFromMap(workspaceData, "id")
 		workspaceName := getStringFromMap(workspaceData, "name")
 
-		fmt.Printf("   Workspace %d: id=%s, name=%s\n", i+1, workspaceID, workspaceName)
+		logging.Debug("workspace", "index", i+1, "id", workspaceID, "name", workspaceName)
 
 		// Extract environments from this workspace
 		envsRaw, exists := workspaceData["environments"]
 		if !exists {
-			fmt.Printf("      No environments found in workspace\n")
+			logging.Debug("no environments in workspace", "workspace", workspaceID)
 			continue
 		}
 
 		envsArray, ok := envsRaw.([]interface{})
 		if !ok {
-			fmt.Printf("      ⚠️  Environments field is not an array\n")
+			logging.Debug("environments field is not an array", "workspace", workspaceID)
 			continue
 		}
 
 		for j, envRaw := range envsArray {
 			envData, ok := envRaw.(map[string]interface{})
 			if !ok {
-				fmt.Printf("      ⚠️  Environment %d: invalid format\n", j+1)
+				logging.Debug("skipped environment with invalid format", "workspace", workspaceID, "index", j+1)
 				continue
 			}
 
@@ -120,11 +132,11 @@ func fetchRemoteEnvironments(devMode bool) ([]EnvironmentInfo, error) {
 
 			// Verify this is an environment resource
 			if envType != "environment" {
-				fmt.Printf("      ⚠️  Environment %d: invalid type '%s'\n", j+1, envType)
+				logging.Debug("skipped environment with invalid type", "workspace", workspaceID, "index", j+1, "type", envType)
 				continue
 			}
 
-			fmt.Printf("      ✅ Found environment: id=%s, name=%s, workspaceId=%s\n", envID, envName, workspaceID)
+			logging.Debug("environment", "id", envID, "name", envName, "workspace", workspaceID)
 
 			// If no name is provided, use the ID as name
 			if envName == "" {
@@ -185,6 +197,18 @@ func PromptEnvironmentSelection(environments []EnvironmentInfo) (*EnvironmentInf
 	if len(environments) == 0 {
 		return nil, fmt.Errorf("no environments available")
 	}
+	if !IsInteractive() {
+		return nil, fmt.Errorf("cannot prompt for an environment without a terminal; name the environment instead, e.g. 'blimu env switch <environment>'")
+	}
+
+	// Type to filter by name, workspace or ID; terminals without raw mode get the numbered list
+	selection, err := picker.Select("Select an environment", environmentLabels(environments))
+	if err == nil {
+		return &environments[selection], nil
+	}
+	if !errors.Is(err, picker.ErrUnsupported) {
+		return nil, err
+	}
 
 	fmt.Println("\nAvailable environments:")
 	DisplayEnvironments(environments)
@@ -194,7 +218,7 @@ func PromptEnvironmentSelection(environments []EnvironmentInfo) (*EnvironmentInf
 	fmt.Scanln(&input)
 
 	// Parse selection
-	selection, err := strconv.Atoi(strings.TrimSpace(input))
+	selection, err = strconv.Atoi(strings.TrimSpace(input))
 	if err != nil || selection < 1 || selection > len(environments) {
 		return nil, fmt.Errorf("invalid selection: %s", input)
 	}
@@ -202,6 +226,25 @@ func PromptEnvironmentSelection(environments []EnvironmentInfo) (*EnvironmentInf
 	return &environments[selection-1], nil
 }
 
+// environmentLabels returns a line per environment for the picker, in aligned columns
+func environmentLabels(environments []EnvironmentInfo) []string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, env := range environments {
+		source := "remote"
+		if env.IsLocal {
+			source = "local"
+		}
+		if env.IsActive {
+			source += ", active"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t(%s)\n", env.Name, env.WorkspaceID, env.ID, source)
+	}
+	w.Flush()
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
 // getStringFromMap safely extracts a string value from a map[string]interface{}
 func getStringFromMap(data map[string]interface{}, key string) string {
 	if val, ok := data[key]; ok {
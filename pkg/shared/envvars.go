@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/blimu-dev/blimu-cli/internal/oauth"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// Environment variables that commands fall back to when the corresponding flags are not given.
+// They take precedence over the CLI config, so CI jobs can run without one.
+const (
+	// WorkspaceIDEnv is the workspace ID of --workspace-id
+	WorkspaceIDEnv = "BLIMU_WORKSPACE_ID"
+	// EnvironmentIDEnv is the environment ID of --environment-id
+	EnvironmentIDEnv = "BLIMU_ENVIRONMENT_ID"
+	// AccessTokenEnv is a platform access token used instead of the tokens of 'blimu auth login'
+	AccessTokenEnv = "BLIMU_ACCESS_TOKEN"
+	// APIURLEnv is the platform API URL
+	APIURLEnv = "BLIMU_API_URL"
+	// ClientIDEnv is the service account client ID of 'blimu auth login --client-id'
+	ClientIDEnv = "BLIMU_CLIENT_ID"
+	// ClientSecretEnv is the service account secret of 'blimu auth login --client-secret'
+	ClientSecretEnv = "BLIMU_CLIENT_SECRET"
+	// OAuthClientIDEnv is the OAuth client ID of logins and token refreshes
+	OAuthClientIDEnv = "BLIMU_OAUTH_CLIENT_ID"
+	// OAuthAuthURLEnv is the OAuth authorization endpoint
+	OAuthAuthURLEnv = "BLIMU_OAUTH_AUTH_URL"
+	// OAuthTokenURLEnv is the OAuth token endpoint
+	OAuthTokenURLEnv = "BLIMU_OAUTH_TOKEN_URL"
+)
+
+// environmentFromEnv applies the environment variables to env, the configured environment or
+// nil when there is none. It returns nil when neither provides credentials.
+func environmentFromEnv(env *config.Environment) *config.Environment {
+	token := os.Getenv(AccessTokenEnv)
+	if env == nil && token == "" {
+		return nil
+	}
+
+	var merged config.Environment
+	if env != nil {
+		merged = *env
+	}
+	if token != "" {
+		// A token from the environment is used as is: it is never refreshed nor saved
+		merged.AccessToken = token
+		merged.TokenType = "Bearer"
+		merged.RefreshToken = ""
+		merged.ExpiresAt = nil
+		merged.ReauthRequired = false
+		merged.AuthType = ""
+	}
+	if workspaceID := os.Getenv(WorkspaceIDEnv); workspaceID != "" {
+		merged.WorkspaceID = workspaceID
+	}
+	if environmentID := os.Getenv(EnvironmentIDEnv); environmentID != "" {
+		merged.ID = environmentID
+	}
+	if apiURL := os.Getenv(APIURLEnv); apiURL != "" {
+		merged.APIURL = apiURL
+	}
+	return &merged
+}
+
+// OAuthConfig returns the OAuth client of logins and token refreshes against platformURL: the
+// BLIMU_OAUTH_* variables over the settings of env (which may be nil) over those of the CLI
+// config, with the CLI's client ID and the /oauth endpoints of platformURL for the rest
+func OAuthConfig(cliConfig *config.CLIConfig, env *config.Environment, platformURL string) oauth.Config {
+	var envSettings *config.OAuthSettings
+	if env != nil {
+		envSettings = env.OAuth
+	}
+	settings := cliConfig.OAuth.Merge(envSettings)
+	settings = settings.Merge(&config.OAuthSettings{
+		ClientID: os.Getenv(OAuthClientIDEnv),
+		AuthURL:  os.Getenv(OAuthAuthURLEnv),
+		TokenURL: os.Getenv(OAuthTokenURLEnv),
+	})
+
+	oauthConfig := oauth.Config{
+		ClientID: settings.ClientID,
+		AuthURL:  settings.AuthURL,
+		TokenURL: settings.TokenURL,
+	}
+	if oauthConfig.ClientID == "" {
+		oauthConfig.ClientID = oauth.DefaultClientID
+	}
+	if oauthConfig.AuthURL == "" {
+		oauthConfig.AuthURL = fmt.Sprintf("%s/oauth/authorize", platformURL)
+	}
+	if oauthConfig.TokenURL == "" {
+		oauthConfig.TokenURL = fmt.Sprintf("%s/oauth/token", platformURL)
+	}
+	return oauthConfig
+}
@@ -0,0 +1,100 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	platform "github.com/blimu-dev/blimu-cli/platform"
+)
+
+// Classes of command failures. Commands attach them to their errors with Errorf or WithClass,
+// errors of the platform client map to them by status code, and ExitCode turns them into the
+// exit code of the process.
+var (
+	// ErrValidation is a failed validation, lint or format check, or a request the API rejected as invalid
+	ErrValidation = errors.New("validation failed")
+	// ErrAuth is a missing or expired login, or a request the API rejected as unauthorized
+	ErrAuth = errors.New("authentication failed")
+	// ErrConflict is a change blocked by the state of the platform, e.g. a definitions lock
+	ErrConflict = errors.New("conflict")
+	// ErrNotFound is a resource, environment or file that does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrNetwork is a platform that could not be reached or failed to answer
+	ErrNetwork = errors.New("network error")
+)
+
+// Exit codes of the CLI, documented in the README so scripts can branch on the failure class
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitValidation  = 2
+	ExitAuth        = 3
+	ExitConflict    = 4
+	ExitNotFound    = 5
+	ExitNetwork     = 6
+	ExitInterrupted = 130
+)
+
+// classifiedError adds a class to an error without changing its message
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.err, e.class}
+}
+
+// WithClass marks err as a failure of the class, e.g. WithClass(ErrNotFound, err)
+func WithClass(class, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// Errorf is fmt.Errorf for an error of the class
+func Errorf(class error, format string, args ...any) error {
+	return WithClass(class, fmt.Errorf(format, args...))
+}
+
+// ExitCode returns the exit code of the process for the error of a command
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, ErrAuth), errors.Is(err, platform.ErrUnauthorized), errors.Is(err, platform.ErrForbidden):
+		return ExitAuth
+	case errors.Is(err, ErrValidation), errors.Is(err, platform.ErrBadRequest):
+		return ExitValidation
+	case errors.Is(err, ErrConflict), errors.Is(err, platform.ErrConflict):
+		return ExitConflict
+	case errors.Is(err, ErrNotFound), errors.Is(err, platform.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, ErrNetwork), errors.Is(err, platform.ErrNetwork), errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, platform.ErrServer), errors.Is(err, platform.ErrRateLimited):
+		return ExitNetwork
+	}
+	return ExitError
+}
+
+// RequestID returns the X-Request-Id of the platform request that failed with err, if any, for
+// support to find the request in the platform logs
+func RequestID(err error) string {
+	var apiErr *platform.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RequestID
+	}
+	var networkErr *platform.NetworkError
+	if errors.As(err, &networkErr) {
+		return networkErr.RequestID
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/internal/oauth"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	platform "github.com/blimu-dev/blimu-cli/platform"
+)
+
+// EnvironmentState classifies a local environment against the platform
+type EnvironmentState string
+
+const (
+	// EnvironmentHealthy means the environment exists and its tokens work
+	EnvironmentHealthy EnvironmentState = "healthy"
+	// EnvironmentNotFound means the platform no longer knows the environment
+	EnvironmentNotFound EnvironmentState = "not found"
+	// EnvironmentTokenExpired means the tokens are expired and cannot be refreshed
+	EnvironmentTokenExpired EnvironmentState = "token expired"
+	// EnvironmentUnknown means the environment could not be checked (offline, never authenticated, ...)
+	EnvironmentUnknown EnvironmentState = "unknown"
+)
+
+// EnvironmentHealth is the outcome of checking a local environment
+type EnvironmentHealth struct {
+	Name   string
+	State  EnvironmentState
+	Reason string
+}
+
+// Dead reports whether the environment can safely be removed from the local config
+func (h EnvironmentHealth) Dead() bool {
+	return h.State == EnvironmentNotFound || h.State == EnvironmentTokenExpired
+}
+
+// CheckEnvironmentHealth checks whether a local environment still exists in the cloud and whether
+// its tokens are usable, refreshing them when needed. Transient failures are reported as unknown
+// so callers never treat an unreachable platform as a dead environment. Cancelling ctx aborts the
+// check.
+func CheckEnvironmentHealth(ctx context.Context, cliConfig *config.CLIConfig, name string, devMode bool) EnvironmentHealth {
+	health := EnvironmentHealth{Name: name, State: EnvironmentUnknown}
+
+	env, ok := cliConfig.Environments[name]
+	if !ok {
+		health.Reason = "not in local config"
+		return health
+	}
+	if env.AccessToken == "" {
+		health.Reason = "never authenticated"
+		return health
+	}
+
+	if env.ReauthRequired {
+		health.State = EnvironmentTokenExpired
+		health.Reason = "refresh token was rejected earlier; run 'blimu auth login'"
+		return health
+	}
+
+	platformURL := PlatformURL(cliConfig, &env, devMode)
+
+	if env.NeedsTokenRefresh() {
+		if env.RefreshToken == "" {
+			if time.Now().After(*env.ExpiresAt) {
+				health.State = EnvironmentTokenExpired
+				health.Reason = "access token expired and no refresh token is stored"
+			} else {
+				health.State = EnvironmentHealthy
+				health.Reason = "access token expires soon"
+			}
+			return health
+		}
+
+		if err := refreshPlatformTokens(ctx, cliConfig, name, &env, platformURL); err != nil {
+			var refreshErr *oauth.RefreshError
+			if errors.As(err, &refreshErr) && refreshErr.Revoked() {
+				markReauthRequired(cliConfig, name, &env)
+				health.State = EnvironmentTokenExpired
+				health.Reason = "refresh token was rejected"
+			} else {
+				health.Reason = err.Error()
+			}
+			return health
+		}
+	}
+
+	if env.ID == "" || env.WorkspaceID == "" {
+		health.Reason = "missing environment or workspace ID"
+		return health
+	}
+
+	httpClient, err := HTTPClient(cliConfig, &env)
+	if err != nil {
+		health.Reason = err.Error()
+		return health
+	}
+
+	client := platform.NewClient(
+		platform.WithBaseURL(platformURL),
+		platform.WithBearer(env.AccessToken),
+		platform.WithHTTPClient(httpClient),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if _, err := client.Environments.ReadWithContext(ctx, env.WorkspaceID, env.ID); err != nil {
+		var apiErr *platform.APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			health.State = EnvironmentNotFound
+			health.Reason = "environment no longer exists"
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized && env.RefreshToken == "":
+			health.State = EnvironmentTokenExpired
+			health.Reason = "access token was rejected and no refresh token is stored"
+		default:
+			health.Reason = err.Error()
+		}
+		return health
+	}
+
+	health.State = EnvironmentHealthy
+	return health
+}
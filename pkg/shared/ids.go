@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+)
+
+// SetIDs sets the workspace and environment IDs given with the global --workspace-id and
+// --environment-id flags
+func (c *Context) SetIDs(workspaceID, environmentID string) {
+	c.WorkspaceID = workspaceID
+	c.EnvironmentID = environmentID
+}
+
+// PinnedIDs reports whether the workspace or environment ID was given with a flag rather than
+// taken from the active environment
+func (c *Context) PinnedIDs() bool {
+	return c.WorkspaceID != "" || c.EnvironmentID != ""
+}
+
+// IDs returns the workspace and environment IDs commands run against: the global flags, or else
+// the IDs of the active environment, which BLIMU_WORKSPACE_ID and BLIMU_ENVIRONMENT_ID override.
+// IDs that cannot be resolved, e.g. without any configured environment, are empty. An
+// --environment-id given as a lookup key or name is returned as is when it cannot be resolved.
+func (c *Context) IDs() (workspaceID, environmentID string) {
+	workspaceID, environmentID, err := c.ids()
+	if err != nil {
+		logging.Warn(fmt.Sprintf("Could not resolve environment '%s': %v", environmentID, err))
+	}
+	return workspaceID, environmentID
+}
+
+// ids is IDs, with the error of resolving --environment-id
+func (c *Context) ids() (workspaceID, environmentID string, err error) {
+	workspaceID, environmentID = c.WorkspaceID, c.EnvironmentID
+	if workspaceID == "" || environmentID == "" {
+		if _, env, err := c.EnvironmentInfo(); err == nil {
+			if workspaceID == "" && env.WorkspaceID != "" {
+				workspaceID = env.WorkspaceID
+				logging.Info("📋 Using workspace ID from current environment: " + workspaceID)
+			}
+			if environmentID == "" && env.ID != "" {
+				environmentID = env.ID
+				logging.Info("📋 Using environment ID from current environment: " + environmentID)
+			}
+		}
+	}
+
+	// --environment-id also takes the lookup key or name of an environment of the workspace
+	if environmentID != "" && environmentID == c.EnvironmentID && !IsEnvironmentID(environmentID) && workspaceID != "" {
+		client, err := c.Client()
+		if err != nil {
+			return workspaceID, environmentID, err
+		}
+		env, err := ResolveEnvironment(c.ctx, client, workspaceID, environmentID)
+		if err != nil {
+			return workspaceID, environmentID, err
+		}
+		logging.Info(fmt.Sprintf("📋 Using environment %s (%s)", env.ID, env.Name))
+		// Resolve once per command
+		c.EnvironmentID = env.ID
+		environmentID = env.ID
+	}
+	return workspaceID, environmentID, nil
+}
+
+// RequireIDs is IDs for commands that need both IDs. action names the command in the error
+// returned when one is missing, e.g. "push".
+func (c *Context) RequireIDs(action string) (workspaceID, environmentID string, err error) {
+	workspaceID, environmentID, err = c.ids()
+	if err != nil {
+		return "", "", err
+	}
+	if workspaceID == "" || environmentID == "" {
+		// Without an environment to fall back to, say why
+		if _, _, err := c.EnvironmentInfo(); err != nil {
+			return "", "", fmt.Errorf("failed to get current environment info: %w", err)
+		}
+	}
+	if environmentID == "" {
+		return "", "", fmt.Errorf("environment-id is required for %s. Either:\n"+
+			"  1. Provide --environment-id flag (or set %s)\n"+
+			"  2. Select an environment with an ID using 'blimu env switch' (run 'blimu auth login' first)", action, EnvironmentIDEnv)
+	}
+	if workspaceID == "" {
+		return "", "", fmt.Errorf("workspace-id is required for %s. Provide --workspace-id flag (or set %s).\n"+
+			"Use 'blimu workspaces list' to find your workspace ID (when available)", action, WorkspaceIDEnv)
+	}
+	return workspaceID, environmentID, nil
+}
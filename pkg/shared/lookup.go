@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	platform "github.com/blimu-dev/blimu-cli/platform"
+)
+
+// environmentIDPrefix starts every environment ID; other references are lookup keys or names
+const environmentIDPrefix = "env_"
+
+// environmentPageSize is the number of environments fetched per request when resolving a lookup
+// key or name
+const environmentPageSize = 100
+
+// IsEnvironmentID reports whether ref is an environment ID rather than a lookup key or name
+func IsEnvironmentID(ref string) bool {
+	return strings.HasPrefix(ref, environmentIDPrefix)
+}
+
+// ResolveEnvironment returns the environment of the workspace that ref refers to by its ID,
+// lookup key or name. A name shared by several environments is rejected with their IDs, so the
+// user can pick one.
+func ResolveEnvironment(ctx context.Context, client *platform.Client, workspaceID, ref string) (EnvironmentInfo, error) {
+	var matches []EnvironmentInfo
+	limit := int64(environmentPageSize)
+	for page := int64(1); ; page++ {
+		current := page
+		response, err := client.Environments.ListWithContext(ctx, workspaceID, &platform.EnvironmentsListQuery{Limit: &limit, Page: &current})
+		if err != nil {
+			return EnvironmentInfo{}, fmt.Errorf("failed to list environments of workspace %s: %w", workspaceID, err)
+		}
+
+		for _, envData := range response.Data {
+			id := getStringFromMap(envData, "id")
+			name := getStringFromMap(envData, "name")
+			if id == ref {
+				// An ID is unambiguous
+				return EnvironmentInfo{ID: id, Name: name, WorkspaceID: workspaceID}, nil
+			}
+			if getStringFromMap(envData, "lookupKey") == ref || name == ref {
+				matches = append(matches, EnvironmentInfo{ID: id, Name: name, WorkspaceID: workspaceID})
+			}
+		}
+
+		if len(response.Data) < environmentPageSize {
+			break
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return EnvironmentInfo{}, Errorf(ErrNotFound, "environment '%s' not found in workspace %s. Run 'blimu env list' to see its environments", ref, workspaceID)
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, match := range matches {
+		candidates = append(candidates, fmt.Sprintf("  %s (%s)", match.ID, match.Name))
+	}
+	return EnvironmentInfo{}, Errorf(ErrValidation, "'%s' matches %d environments in workspace %s, use the ID of one of them:\n%s",
+		ref, len(matches), workspaceID, strings.Join(candidates, "\n"))
+}
+
+// ResolveEnvironmentID is ResolveEnvironment for references that may already be IDs, which are
+// returned without a request
+func ResolveEnvironmentID(ctx context.Context, client *platform.Client, workspaceID, ref string) (string, error) {
+	if ref == "" || IsEnvironmentID(ref) {
+		return ref, nil
+	}
+
+	env, err := ResolveEnvironment(ctx, client, workspaceID, ref)
+	if err != nil {
+		return "", err
+	}
+	return env.ID, nil
+}
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// Project is a definition directory a command runs on
+type Project struct {
+	// Name is the project's name in .blimu/workspace.yml; empty for a plain directory
+	Name string
+	// Directory holds the project's .blimu directory
+	Directory string
+	// Environment is the local environment name, lookup key or ID the project maps to; empty to
+	// use the active environment
+	Environment string
+}
+
+// ResolveProjects returns the directories a command runs on. An explicit directory argument is
+// used as is. Otherwise the projects named with --project are looked up in the nearest
+// .blimu/workspace.yml; without names, every project of the workspace is returned when the
+// working directory has no definitions of its own, and the working directory otherwise.
+func ResolveProjects(args []string, names []string) ([]Project, error) {
+	if len(args) > 0 {
+		if len(names) > 0 {
+			return nil, fmt.Errorf("--project cannot be combined with a directory argument")
+		}
+		return []Project{{Directory: args[0]}}, nil
+	}
+
+	workspace, err := config.FindWorkspaceConfig(".")
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 && (workspace == nil || config.HasResources(".blimu")) {
+		return []Project{{Directory: "."}}, nil
+	}
+	if workspace == nil {
+		return nil, fmt.Errorf("--project needs a .blimu/%s in this or a parent directory", config.WorkspaceFile)
+	}
+
+	available := workspace.Names()
+	if len(names) == 0 {
+		names = available
+	}
+	cwd, _ := os.Getwd()
+	projects := make([]Project, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(available, name) {
+			return nil, fmt.Errorf("project '%s' is not in %s (available: %s)", name, filepath.Join(workspace.Root, ".blimu", config.WorkspaceFile), strings.Join(available, ", "))
+		}
+		dir := workspace.Dir(name)
+		if rel, err := filepath.Rel(cwd, dir); err == nil {
+			dir = rel
+		}
+		projects = append(projects, Project{Name: name, Directory: dir, Environment: workspace.Projects[name].Environment})
+	}
+	return projects, nil
+}
+
+// RunProjects runs a command once per project with the project's environment active, as if it
+// had been selected with --env. An explicit --env applies to every project. All projects are run;
+// the error lists those that failed. pinnedIDs reports whether --workspace-id or --environment-id
+// were given, which is only allowed for a single project.
+func RunProjects(projects []Project, pinnedIDs bool, run func(Project) error) error {
+	if len(projects) == 1 && projects[0].Name == "" {
+		return run(projects[0])
+	}
+	if len(projects) > 1 && pinnedIDs {
+		return fmt.Errorf("--workspace-id and --environment-id apply to a single project; select one with --project")
+	}
+
+	cliConfig, err := config.LoadCLIConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load CLI config: %w", err)
+	}
+	override := EnvironmentOverride()
+	defer SetEnvironmentOverride(override)
+
+	var failed []string
+	for i, project := range projects {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("📦 Project %s (%s)\n", project.Name, project.Directory)
+
+		err := func() error {
+			if project.Environment != "" && override == "" {
+				name, ok := cliConfig.FindEnvironment(project.Environment)
+				if !ok {
+					return fmt.Errorf("project '%s' maps to environment '%s', which is not configured. Use 'blimu env list' to see configured environments", project.Name, project.Environment)
+				}
+				SetEnvironmentOverride(name)
+			}
+			defer SetEnvironmentOverride(override)
+			return run(project)
+		}()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Project %s: %v\n", project.Name, err)
+			failed = append(failed, project.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d project(s) failed: %s", len(failed), len(projects), strings.Join(failed, ", "))
+	}
+	return nil
+}
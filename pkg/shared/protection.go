@@ -0,0 +1,65 @@
+package shared
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// ConfirmProtectedEnvironment asks before an action (e.g. "push definitions to") changes an
+// environment whose labels match a protected_environments selector. The environment is looked up
+// like FindEnvironment, by local name, ID or lookup key, and is protected when any local name of
+// it is; environments that are not configured locally have no labels and are never protected.
+//
+// Only yes (the command's --yes flag) or the protected environment's own auto_approve: true skip
+// the question. The process-wide approvals, the global --auto-approve flag and
+// BLIMU_AUTO_APPROVE, are ignored here, and without a terminal to ask on the action is refused.
+func ConfirmProtectedEnvironment(cliConfig *config.CLIConfig, environment, action string, yes bool) error {
+	found, ok := cliConfig.FindEnvironment(environment)
+	if !ok {
+		return nil
+	}
+
+	// Other local names of the same environment may carry the labels that protect it
+	names := []string{found}
+	others := make([]string, 0, len(cliConfig.Environments))
+	for name, env := range cliConfig.Environments {
+		if name != found && env.ID != "" && env.ID == cliConfig.Environments[found].ID {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	names = append(names, others...)
+
+	for _, name := range names {
+		env := cliConfig.Environments[name]
+
+		selector, err := cliConfig.ProtectedBy(env)
+		if err != nil {
+			return err
+		}
+		if selector == "" {
+			continue
+		}
+
+		fmt.Printf("🔒 Environment '%s' is protected (labels %s match '%s')\n", name, config.FormatLabels(env.Labels), selector)
+		if yes || (env.AutoApprove != nil && *env.AutoApprove) {
+			return nil
+		}
+		if !IsInteractive() {
+			return fmt.Errorf("refusing to %s protected environment '%s' without confirmation; pass --yes, or run 'blimu env auto-approve %s true', to proceed non-interactively (--auto-approve and %s do not apply to protected environments)", action, name, name, AutoApproveEnv)
+		}
+
+		fmt.Printf("Type '%s' to confirm: ", name)
+		var response string
+		fmt.Scanln(&response)
+		if strings.TrimSpace(response) != name {
+			return fmt.Errorf("cancelled; protected environment '%s' was not changed", name)
+		}
+		return nil
+	}
+
+	return nil
+}
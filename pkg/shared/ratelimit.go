@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+	platform "github.com/blimu-dev/blimu-cli/platform"
+)
+
+// retryPolicy is the platform retry policy of the CLI: the default one, reporting pauses for
+// rate limits and retries
+func retryPolicy() platform.RetryPolicy {
+	policy := platform.DefaultRetryPolicy
+	policy.OnRetry = func(attempt int, wait time.Duration, resp *http.Response, err error) {
+		switch {
+		case resp != nil && resp.StatusCode == http.StatusTooManyRequests:
+			logging.Info("⏳ Rate limited by the platform, resuming in " + wait.Round(time.Second).String())
+		case resp != nil:
+			logging.Debug("retrying request", "attempt", attempt+1, "status", resp.StatusCode, "wait", wait.Round(time.Millisecond).String())
+		default:
+			logging.Debug("retrying request", "attempt", attempt+1, "error", err.Error(), "wait", wait.Round(time.Millisecond).String())
+		}
+	}
+	return policy
+}
+
+// logRateLimit is platform middleware logging the remaining rate limit quota with --verbose
+func logRateLimit(next http.RoundTripper) http.RoundTripper {
+	return platform.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+		if limit, ok := platform.ParseRateLimit(resp.Header); ok {
+			logging.Debug("rate limit", "remaining", limit.Remaining, "limit", limit.Limit, "reset", limit.Reset.Round(time.Second).String())
+		}
+		return resp, nil
+	})
+}
@@ -0,0 +1,131 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/internal/oauth"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+)
+
+// ReauthRequiredError is returned when an environment's tokens can no longer be refreshed and
+// the user has to log in again
+type ReauthRequiredError struct {
+	Environment string
+}
+
+func (e *ReauthRequiredError) Unwrap() error {
+	return ErrAuth
+}
+
+func (e *ReauthRequiredError) Error() string {
+	return fmt.Sprintf("your session for environment '%s' has expired. Run 'blimu auth login' to sign in again", e.Environment)
+}
+
+// ensureFreshToken refreshes an environment's access token when it is about to expire. When the
+// refresh token itself is rejected the environment is marked as needing re-authentication, so
+// later commands fail straight away instead of retrying the refresh on every invocation.
+func ensureFreshToken(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) error {
+	if env.ReauthRequired {
+		return &ReauthRequiredError{Environment: name}
+	}
+	if env.IsServiceAccount() {
+		return ensureServiceAccountToken(ctx, cliConfig, name, env, platformURL)
+	}
+	if env.IsGitHubOIDC() {
+		return ensureGitHubOIDCToken(ctx, cliConfig, name, env, platformURL)
+	}
+	if !env.NeedsTokenRefresh() {
+		return nil
+	}
+	if env.RefreshToken == "" {
+		if time.Now().After(*env.ExpiresAt) {
+			return markReauthRequired(cliConfig, name, env)
+		}
+		return nil
+	}
+
+	logging.Info("🔄 Refreshing expired access token...")
+	// refreshPlatformTokens updates env in place
+	if err := refreshPlatformTokens(ctx, cliConfig, name, env, platformURL); err != nil {
+		var refreshErr *oauth.RefreshError
+		if errors.As(err, &refreshErr) && refreshErr.Revoked() {
+			return markReauthRequired(cliConfig, name, env)
+		}
+		// Transient failures (network, server errors) leave the tokens alone so the next command retries
+		logging.Warn(fmt.Sprintf("Failed to refresh token: %v", err))
+		return fmt.Errorf("token refresh failed: %w", err)
+	}
+	logging.Info("✅ Token refreshed successfully")
+	return nil
+}
+
+// ensureServiceAccountToken requests a new access token with the client credentials of a
+// service account when the current one is about to expire. A rejected secret marks the
+// environment as needing re-authentication, like a rejected refresh token.
+func ensureServiceAccountToken(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) error {
+	if !env.NeedsTokenRefresh() {
+		return nil
+	}
+	if env.ClientID == "" || env.ClientSecret == "" {
+		if time.Now().After(*env.ExpiresAt) {
+			return markReauthRequired(cliConfig, name, env)
+		}
+		return nil
+	}
+
+	logging.Info("🔄 Requesting a new service account token...")
+	// refreshServiceAccountToken updates env in place
+	if err := refreshServiceAccountToken(ctx, cliConfig, name, env, platformURL); err != nil {
+		var refreshErr *oauth.RefreshError
+		if errors.As(err, &refreshErr) && refreshErr.Revoked() {
+			return markReauthRequired(cliConfig, name, env)
+		}
+		logging.Warn(fmt.Sprintf("Failed to request token: %v", err))
+		return fmt.Errorf("token refresh failed: %w", err)
+	}
+	logging.Info("✅ Token refreshed successfully")
+	return nil
+}
+
+// ensureGitHubOIDCToken exchanges a new ID token of the GitHub Actions job for an access token
+// when the current one is about to expire. Outside the job that logged in there is no ID token
+// to exchange, so an expired token needs another login.
+func ensureGitHubOIDCToken(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) error {
+	if !env.NeedsTokenRefresh() {
+		return nil
+	}
+	if !oauth.GitHubActionsAvailable() {
+		if time.Now().After(*env.ExpiresAt) {
+			return markReauthRequired(cliConfig, name, env)
+		}
+		return nil
+	}
+
+	logging.Info("🔄 Exchanging a new GitHub Actions ID token...")
+	// refreshGitHubOIDCToken updates env in place
+	if err := refreshGitHubOIDCToken(ctx, cliConfig, name, env, platformURL); err != nil {
+		var refreshErr *oauth.RefreshError
+		if errors.As(err, &refreshErr) && refreshErr.Revoked() {
+			return markReauthRequired(cliConfig, name, env)
+		}
+		logging.Warn(fmt.Sprintf("Failed to exchange ID token: %v", err))
+		return fmt.Errorf("token refresh failed: %w", err)
+	}
+	logging.Info("✅ Token refreshed successfully")
+	return nil
+}
+
+// markReauthRequired records that an environment needs 'blimu auth login' and returns the error
+// to report. Like refreshed tokens, only the authentication state is saved, so overrides from
+// environment variables never end up in the CLI config.
+func markReauthRequired(cliConfig *config.CLIConfig, name string, env *config.Environment) error {
+	env.ReauthRequired = true
+	if err := saveTokens(cliConfig, name, env); err != nil {
+		logging.Warn(fmt.Sprintf("Could not save authentication state: %v", err))
+	}
+	return &ReauthRequiredError{Environment: name}
+}
@@ -0,0 +1,168 @@
+package shared
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/internal/oauth"
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+)
+
+// refreshTimeout limits waiting for the credentials lock and the token request of a refresh
+const refreshTimeout = 30 * time.Second
+
+// tokenSource supplies the access token of one environment to every platform client of the
+// process. It refreshes the token ahead of its expiry when a request needs it, so long-running
+// commands (watch, lsp) outlive the token they started with.
+type tokenSource struct {
+	mu          sync.Mutex
+	cliConfig   *config.CLIConfig
+	name        string
+	env         config.Environment
+	platformURL string
+}
+
+var (
+	tokenSourcesMu sync.Mutex
+	// tokenSources are the token sources of the environments used by this process, by local name
+	tokenSources = make(map[string]*tokenSource)
+)
+
+// tokenSourceFor returns the token source of an environment, shared by all its clients. env is
+// only used the first time; afterwards the source holds the current tokens.
+func tokenSourceFor(cliConfig *config.CLIConfig, name string, env *config.Environment, platformURL string) *tokenSource {
+	tokenSourcesMu.Lock()
+	defer tokenSourcesMu.Unlock()
+
+	if ts, ok := tokenSources[name]; ok && ts.platformURL == platformURL {
+		return ts
+	}
+	ts := &tokenSource{cliConfig: cliConfig, name: name, env: *env, platformURL: platformURL}
+	tokenSources[name] = ts
+	return ts
+}
+
+// Token returns a valid access token, refreshing it first when it is about to expire
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	env, err := s.Environment(ctx)
+	if err != nil {
+		return "", err
+	}
+	return env.AccessToken, nil
+}
+
+// Environment returns a copy of the environment with a valid access token
+func (s *tokenSource) Environment(ctx context.Context) (config.Environment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// ensureFreshToken updates s.env in place
+	if err := ensureFreshToken(ctx, s.cliConfig, s.name, &s.env, s.platformURL); err != nil {
+		return config.Environment{}, err
+	}
+	return s.env, nil
+}
+
+// environmentLocks serialize the refreshes of each environment within the process, by local name
+var environmentLocks sync.Map
+
+// refreshEnvironment renews the tokens of an environment with request, at most once at a time
+// across the goroutines and CLI processes using the same credentials. A caller that waited for
+// another refresh takes over its tokens instead of refreshing again, and the new tokens are
+// persisted once. env is updated in place.
+func refreshEnvironment(ctx context.Context, cliConfig *config.CLIConfig, name string, env *config.Environment, request func(context.Context, *config.Environment) (*oauth.TokenResponse, error)) error {
+	lock, _ := environmentLocks.LoadOrStore(name, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	defer cancel()
+
+	unlock, err := config.LockCredentials(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Another goroutine or process may have refreshed while we waited; its refresh token may
+	// also have been rotated, making ours unusable
+	if stored, ok := storedEnvironment(name); ok && stored.AccessToken != env.AccessToken {
+		if !stored.ReauthRequired && !stored.NeedsTokenRefresh() {
+			copyTokens(env, stored)
+			updateCLITokens(cliConfig, name, stored)
+			return nil
+		}
+		if stored.RefreshToken != "" {
+			env.RefreshToken = stored.RefreshToken
+		}
+	}
+
+	tokenResp, err := request(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	env.AccessToken = tokenResp.AccessToken
+	if tokenResp.RefreshToken != "" {
+		env.RefreshToken = tokenResp.RefreshToken
+	}
+	env.ExpiresAt = &expiresAt
+	env.TokenType = "Bearer"
+	env.ReauthRequired = false
+
+	return saveTokens(cliConfig, name, env)
+}
+
+// storedEnvironment reads an environment as currently saved, including tokens written by other
+// processes since the config was loaded
+func storedEnvironment(name string) (*config.Environment, bool) {
+	stored, err := config.LoadCLIConfig()
+	if err != nil {
+		return nil, false
+	}
+	env, ok := stored.Environments[name]
+	if !ok {
+		return nil, false
+	}
+	return &env, true
+}
+
+// saveTokens persists the tokens of env onto the environment as saved, leaving its other settings
+// and the other environments as they are on disk. Environments that only exist in memory, e.g.
+// from BLIMU_* variables, are not saved.
+func saveTokens(cliConfig *config.CLIConfig, name string, env *config.Environment) error {
+	updateCLITokens(cliConfig, name, env)
+
+	stored, err := config.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+	storedEnv, ok := stored.Environments[name]
+	if !ok {
+		return nil
+	}
+	copyTokens(&storedEnv, env)
+	return stored.UpdateEnvironment(name, storedEnv)
+}
+
+// updateCLITokens copies the tokens of env to the in-memory environment of cliConfig, if any
+func updateCLITokens(cliConfig *config.CLIConfig, name string, env *config.Environment) {
+	cliEnv, ok := cliConfig.Environments[name]
+	if !ok {
+		return
+	}
+	copyTokens(&cliEnv, env)
+	cliConfig.Environments[name] = cliEnv
+}
+
+// copyTokens copies the tokens and authentication state of src to dst
+func copyTokens(dst, src *config.Environment) {
+	dst.AccessToken = src.AccessToken
+	dst.RefreshToken = src.RefreshToken
+	dst.ExpiresAt = src.ExpiresAt
+	dst.TokenType = src.TokenType
+	dst.ReauthRequired = src.ReauthRequired
+}
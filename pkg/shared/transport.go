@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	"github.com/blimu-dev/blimu-cli/pkg/transport"
+)
+
+// ConfigureTransport applies the TLS settings of the CLI config to every HTTP client created
+// afterwards. caCert (the global --ca-cert flag) overrides the configured ca_cert.
+func ConfigureTransport(caCert string) error {
+	opts := transport.Options{CACert: caCert}
+	// A config that does not load is reported by the commands that need it
+	if cliConfig, err := config.LoadCLIConfig(); err == nil {
+		if opts.CACert == "" {
+			opts.CACert = cliConfig.CACert
+		}
+		opts.InsecureSkipVerify = cliConfig.InsecureSkipVerify
+	}
+
+	if opts.InsecureSkipVerify {
+		logging.Warn("TLS certificate verification is disabled (insecure_skip_verify in the CLI config)")
+	}
+	if err := transport.Configure(opts); err != nil {
+		return fmt.Errorf("invalid TLS settings: %w", err)
+	}
+	return nil
+}
+
+// DefaultRequestTimeout limits API requests of environments without a request_timeout setting
+const DefaultRequestTimeout = 60 * time.Second
+
+// HTTPClient returns the HTTP client for the API requests of an environment, with the http
+// settings of the CLI config and of the environment applied
+func HTTPClient(cliConfig *config.CLIConfig, env *config.Environment) (*http.Client, error) {
+	settings := cliConfig.HTTP.Merge(env.HTTP)
+
+	base := transport.Default()
+	if settings.ConnectTimeout != 0 || settings.KeepAlive != 0 || settings.MaxIdleConns != 0 {
+		opts := transport.Configured()
+		opts.ConnectTimeout = settings.ConnectTimeout
+		opts.KeepAlive = settings.KeepAlive
+		opts.MaxIdleConns = settings.MaxIdleConns
+		custom, err := transport.New(opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP settings: %w", err)
+		}
+		base = custom
+	}
+
+	client := telemetry.HTTPClientFor(base)
+	client.Timeout = DefaultRequestTimeout
+	if settings.RequestTimeout > 0 {
+		client.Timeout = settings.RequestTimeout
+	}
+	return client, nil
+}
@@ -0,0 +1,58 @@
+package spec
+
+import "strings"
+
+// Breaking-change categories, in the order they are reported
+const (
+	CategoryRemovedPath   = "removed path"
+	CategoryRemovedSchema = "removed schema"
+	CategoryNarrowedType  = "narrowed type"
+	CategoryRemovedRole   = "removed role"
+)
+
+var categoryOrder = []string{CategoryRemovedPath, CategoryRemovedSchema, CategoryNarrowedType, CategoryRemovedRole}
+
+// CategoryChanges groups the breaking changes of one category
+type CategoryChanges struct {
+	Category string
+	Changes  []Change
+}
+
+// Category returns the breaking-change category of a change, or "" if it is not breaking
+func (c Change) Category() string {
+	if !c.Breaking {
+		return ""
+	}
+
+	switch c.Kind {
+	case PathRemoved, OperationRemoved:
+		return CategoryRemovedPath
+	case SchemaRemoved:
+		return CategoryRemovedSchema
+	case EnumValueRemoved:
+		// Roles are exposed as enums (e.g. OrganizationRole), other enums narrow the accepted values
+		if strings.Contains(strings.ToLower(c.Location), "role") {
+			return CategoryRemovedRole
+		}
+		return CategoryNarrowedType
+	default:
+		return CategoryNarrowedType
+	}
+}
+
+// BreakingByCategory groups the breaking changes by category, omitting empty categories
+func (d *Diff) BreakingByCategory() []CategoryChanges {
+	grouped := make(map[string][]Change)
+	for _, change := range d.Breaking() {
+		category := change.Category()
+		grouped[category] = append(grouped[category], change)
+	}
+
+	var result []CategoryChanges
+	for _, category := range categoryOrder {
+		if changes := grouped[category]; len(changes) > 0 {
+			result = append(result, CategoryChanges{Category: category, Changes: changes})
+		}
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package spec
+
+import (
+	"sort"
+)
+
+// literalKeywords hold example data rather than schema, so their contents are copied unchanged
+var literalKeywords = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"default":  true,
+	"const":    true,
+}
+
+// Canonicalize returns a deep copy of an OpenAPI document with order-insensitive lists sorted:
+// schema "required" and string "enum" values, and the top-level tag list by name. The platform
+// may build these lists from unordered data, so sorting them keeps generated code and cached
+// spec hashes stable across runs with unchanged definitions. Lists whose order carries meaning
+// (parameters, operation tags, oneOf/allOf) are left as they are.
+func Canonicalize(doc map[string]interface{}) map[string]interface{} {
+	canonical, _ := canonicalize(doc).(map[string]interface{})
+	if canonical == nil {
+		return nil
+	}
+
+	if tags, ok := canonical["tags"].([]interface{}); ok {
+		sort.SliceStable(tags, func(i, j int) bool {
+			return tagName(tags[i]) < tagName(tags[j])
+		})
+	}
+	return canonical
+}
+
+func canonicalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			switch {
+			case literalKeywords[key]:
+				result[key] = deepCopy(child)
+			case key == "required" || key == "enum":
+				result[key] = sortedStrings(canonicalize(child))
+			default:
+				result[key] = canonicalize(child)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			result[i] = canonicalize(child)
+		}
+		return result
+	}
+	return value
+}
+
+// sortedStrings sorts a list when every item is a string; other values are returned unchanged.
+// A "required" or "enum" key holding a schema (e.g. a property named "enum") is never a list.
+func sortedStrings(value interface{}) interface{} {
+	items, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	for _, item := range items {
+		if _, ok := item.(string); !ok {
+			return value
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].(string) < items[j].(string)
+	})
+	return items
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[key] = deepCopy(child)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			result[i] = deepCopy(child)
+		}
+		return result
+	}
+	return value
+}
+
+func tagName(tag interface{}) string {
+	if tagMap, ok := tag.(map[string]interface{}); ok {
+		if name, ok := tagMap["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
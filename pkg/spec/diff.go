@@ -0,0 +1,311 @@
+// Package spec provides helpers for loading and comparing OpenAPI specifications
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind identifies the kind of difference between two specs
+type ChangeKind string
+
+const (
+	PathAdded        ChangeKind = "path_added"
+	PathRemoved      ChangeKind = "path_removed"
+	OperationAdded   ChangeKind = "operation_added"
+	OperationRemoved ChangeKind = "operation_removed"
+	OperationChanged ChangeKind = "operation_changed"
+	SchemaAdded      ChangeKind = "schema_added"
+	SchemaRemoved    ChangeKind = "schema_removed"
+	SchemaChanged    ChangeKind = "schema_changed"
+	PropertyAdded    ChangeKind = "property_added"
+	PropertyRemoved  ChangeKind = "property_removed"
+	TypeChanged      ChangeKind = "type_changed"
+	EnumValueAdded   ChangeKind = "enum_value_added"
+	EnumValueRemoved ChangeKind = "enum_value_removed"
+	RequiredAdded    ChangeKind = "required_added"
+	RequiredRemoved  ChangeKind = "required_removed"
+)
+
+// httpMethods lists the operation keys of an OpenAPI path item
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Change describes a single difference between two specs
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Location string     `json:"location"`
+	Detail   string     `json:"detail,omitempty"`
+	Breaking bool       `json:"breaking"`
+}
+
+// Diff is the ordered list of changes from one spec to another
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// IsEmpty reports whether the specs are equivalent
+func (d *Diff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Breaking returns only the changes that can break existing API consumers
+func (d *Diff) Breaking() []Change {
+	var breaking []Change
+	for _, change := range d.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// Filter returns the changes of the given kinds
+func (d *Diff) Filter(kinds ...ChangeKind) []Change {
+	var filtered []Change
+	for _, change := range d.Changes {
+		for _, kind := range kinds {
+			if change.Kind == kind {
+				filtered = append(filtered, change)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// Compare computes the differences between two OpenAPI specs
+func Compare(from, to map[string]interface{}) *Diff {
+	diff := &Diff{}
+
+	comparePaths(getMap(from, "paths"), getMap(to, "paths"), diff)
+	compareSchemas(getMap(getMap(from, "components"), "schemas"), getMap(getMap(to, "components"), "schemas"), diff)
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].Location != diff.Changes[j].Location {
+			return diff.Changes[i].Location < diff.Changes[j].Location
+		}
+		return diff.Changes[i].Kind < diff.Changes[j].Kind
+	})
+
+	return diff
+}
+
+func comparePaths(fromPaths, toPaths map[string]interface{}, diff *Diff) {
+	for _, path := range sortedKeys(fromPaths) {
+		if _, exists := toPaths[path]; !exists {
+			diff.Changes = append(diff.Changes, Change{Kind: PathRemoved, Location: path, Breaking: true})
+			continue
+		}
+
+		fromItem, _ := fromPaths[path].(map[string]interface{})
+		toItem, _ := toPaths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			fromOp, inFrom := fromItem[method]
+			toOp, inTo := toItem[method]
+			location := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+
+			switch {
+			case inFrom && !inTo:
+				diff.Changes = append(diff.Changes, Change{Kind: OperationRemoved, Location: location, Breaking: true})
+			case !inFrom && inTo:
+				diff.Changes = append(diff.Changes, Change{Kind: OperationAdded, Location: location})
+			case inFrom && inTo && !reflect.DeepEqual(fromOp, toOp):
+				compareOperation(location, fromOp, toOp, diff)
+			}
+		}
+	}
+
+	for _, path := range sortedKeys(toPaths) {
+		if _, exists := fromPaths[path]; !exists {
+			diff.Changes = append(diff.Changes, Change{Kind: PathAdded, Location: path})
+		}
+	}
+}
+
+func compareOperation(location string, fromOp, toOp interface{}, diff *Diff) {
+	fromMap, _ := fromOp.(map[string]interface{})
+	toMap, _ := toOp.(map[string]interface{})
+
+	fromParams := requiredParameters(fromMap)
+	toParams := requiredParameters(toMap)
+	for _, name := range sortedKeys(toParams) {
+		if _, exists := fromParams[name]; !exists {
+			diff.Changes = append(diff.Changes, Change{
+				Kind:     RequiredAdded,
+				Location: location,
+				Detail:   fmt.Sprintf("parameter '%s' is now required", name),
+				Breaking: true,
+			})
+		}
+	}
+
+	diff.Changes = append(diff.Changes, Change{Kind: OperationChanged, Location: location})
+}
+
+// requiredParameters returns the required parameters of an operation keyed by "in:name"
+func requiredParameters(operation map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	params, _ := operation["parameters"].([]interface{})
+	for _, param := range params {
+		paramMap, ok := param.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if required, _ := paramMap["required"].(bool); required {
+			result[fmt.Sprintf("%v:%v", paramMap["in"], paramMap["name"])] = paramMap
+		}
+	}
+	return result
+}
+
+func compareSchemas(fromSchemas, toSchemas map[string]interface{}, diff *Diff) {
+	for _, name := range sortedKeys(fromSchemas) {
+		location := "#/components/schemas/" + name
+		toSchema, exists := toSchemas[name]
+		if !exists {
+			diff.Changes = append(diff.Changes, Change{Kind: SchemaRemoved, Location: location, Breaking: true})
+			continue
+		}
+
+		if reflect.DeepEqual(fromSchemas[name], toSchema) {
+			continue
+		}
+
+		fromMap, _ := fromSchemas[name].(map[string]interface{})
+		toMap, _ := toSchema.(map[string]interface{})
+		if !compareSchema(location, fromMap, toMap, diff) {
+			diff.Changes = append(diff.Changes, Change{Kind: SchemaChanged, Location: location})
+		}
+	}
+
+	for _, name := range sortedKeys(toSchemas) {
+		if _, exists := fromSchemas[name]; !exists {
+			diff.Changes = append(diff.Changes, Change{Kind: SchemaAdded, Location: "#/components/schemas/" + name})
+		}
+	}
+}
+
+// compareSchema records detailed changes for a schema and reports whether any were found
+func compareSchema(location string, from, to map[string]interface{}, diff *Diff) bool {
+	before := len(diff.Changes)
+
+	if fromType, toType := typeOf(from), typeOf(to); fromType != toType {
+		diff.Changes = append(diff.Changes, Change{
+			Kind:     TypeChanged,
+			Location: location,
+			Detail:   fmt.Sprintf("type changed from '%s' to '%s'", fromType, toType),
+			Breaking: true,
+		})
+	}
+
+	fromEnum := stringSet(from["enum"])
+	toEnum := stringSet(to["enum"])
+	for _, value := range sortedKeys(fromEnum) {
+		if _, exists := toEnum[value]; !exists {
+			diff.Changes = append(diff.Changes, Change{
+				Kind:     EnumValueRemoved,
+				Location: location,
+				Detail:   fmt.Sprintf("value '%s' removed", value),
+				Breaking: true,
+			})
+		}
+	}
+	for _, value := range sortedKeys(toEnum) {
+		if _, exists := fromEnum[value]; !exists {
+			diff.Changes = append(diff.Changes, Change{
+				Kind:     EnumValueAdded,
+				Location: location,
+				Detail:   fmt.Sprintf("value '%s' added", value),
+			})
+		}
+	}
+
+	fromRequired := stringSet(from["required"])
+	toRequired := stringSet(to["required"])
+	for _, field := range sortedKeys(toRequired) {
+		if _, exists := fromRequired[field]; !exists {
+			diff.Changes = append(diff.Changes, Change{
+				Kind:     RequiredAdded,
+				Location: location,
+				Detail:   fmt.Sprintf("property '%s' is now required", field),
+				Breaking: true,
+			})
+		}
+	}
+	for _, field := range sortedKeys(fromRequired) {
+		if _, exists := toRequired[field]; !exists {
+			diff.Changes = append(diff.Changes, Change{
+				Kind:     RequiredRemoved,
+				Location: location,
+				Detail:   fmt.Sprintf("property '%s' is no longer required", field),
+			})
+		}
+	}
+
+	fromProps := getMap(from, "properties")
+	toProps := getMap(to, "properties")
+	for _, prop := range sortedKeys(fromProps) {
+		propLocation := location + "/properties/" + prop
+		toProp, exists := toProps[prop]
+		if !exists {
+			diff.Changes = append(diff.Changes, Change{Kind: PropertyRemoved, Location: propLocation, Breaking: true})
+			continue
+		}
+		if !reflect.DeepEqual(fromProps[prop], toProp) {
+			fromPropMap, _ := fromProps[prop].(map[string]interface{})
+			toPropMap, _ := toProp.(map[string]interface{})
+			compareSchema(propLocation, fromPropMap, toPropMap, diff)
+		}
+	}
+	for _, prop := range sortedKeys(toProps) {
+		if _, exists := fromProps[prop]; !exists {
+			diff.Changes = append(diff.Changes, Change{Kind: PropertyAdded, Location: location + "/properties/" + prop})
+		}
+	}
+
+	return len(diff.Changes) > before
+}
+
+// typeOf returns a schema's type, or its $ref target when it has no explicit type
+func typeOf(schema map[string]interface{}) string {
+	if t, ok := schema["type"].(string); ok {
+		return t
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		return ref
+	}
+	return ""
+}
+
+// stringSet converts a []interface{} of values into a set keyed by their string form
+func stringSet(value interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		result[fmt.Sprintf("%v", item)] = true
+	}
+	return result
+}
+
+// getMap safely extracts a nested map from a map[string]interface{}
+func getMap(data map[string]interface{}, key string) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	if val, ok := data[key].(map[string]interface{}); ok {
+		return val
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,232 @@
+package spec
+
+import (
+	"strings"
+)
+
+// maxExampleDepth bounds recursion through nested and self-referencing schemas
+const maxExampleDepth = 8
+
+// Example builds an example value for a schema, preferring explicit examples and defaults and
+// otherwise synthesizing a value from the schema's type. $refs are resolved against doc.
+func Example(doc, schema map[string]interface{}) interface{} {
+	return exampleFor(doc, schema, 0, map[string]bool{})
+}
+
+// ResponseExample returns the status code and example body of an operation's success response.
+// The body is nil when the response has no JSON content.
+func ResponseExample(doc, operation map[string]interface{}) (int, interface{}) {
+	responses := getMap(operation, "responses")
+	code := SuccessResponseCode(responses)
+	response := ResolveRef(doc, getMap(responses, code))
+
+	status := 200
+	if len(code) == 3 && code[0] >= '1' && code[0] <= '5' {
+		status = int(code[0]-'0')*100 + int(code[1]-'0')*10 + int(code[2]-'0')
+	}
+
+	media := getMap(getMap(response, "content"), "application/json")
+	if media == nil {
+		return status, nil
+	}
+
+	if example, ok := media["example"]; ok {
+		return status, example
+	}
+	if examples := getMap(media, "examples"); len(examples) > 0 {
+		first := ResolveRef(doc, getMap(examples, sortedKeys(examples)[0]))
+		if value, ok := first["value"]; ok {
+			return status, value
+		}
+	}
+
+	return status, Example(doc, getMap(media, "schema"))
+}
+
+// SuccessResponseCode picks the response documented as an operation's result: 200, then 201,
+// then the lowest 2xx code, then "default"
+func SuccessResponseCode(responses map[string]interface{}) string {
+	for _, code := range []string{"200", "201"} {
+		if _, ok := responses[code]; ok {
+			return code
+		}
+	}
+	for _, code := range sortedKeys(responses) {
+		if strings.HasPrefix(code, "2") {
+			return code
+		}
+	}
+	if _, ok := responses["default"]; ok {
+		return "default"
+	}
+	return "200"
+}
+
+func exampleFor(doc, schema map[string]interface{}, depth int, visiting map[string]bool) interface{} {
+	if schema == nil || depth > maxExampleDepth {
+		return nil
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		if visiting[ref] {
+			return nil
+		}
+		visiting[ref] = true
+		defer delete(visiting, ref)
+		return exampleFor(doc, ResolveRef(doc, schema), depth+1, visiting)
+	}
+
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	if examples, ok := schema["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	if def, ok := schema["default"]; ok {
+		return def
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, sub := range allOf {
+			subMap, _ := sub.(map[string]interface{})
+			if value, ok := exampleFor(doc, subMap, depth+1, visiting).(map[string]interface{}); ok {
+				for k, v := range value {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[key].([]interface{}); ok && len(options) > 0 {
+			first, _ := options[0].(map[string]interface{})
+			return exampleFor(doc, first, depth+1, visiting)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		result := make(map[string]interface{})
+		properties := getMap(schema, "properties")
+		for _, name := range sortedKeys(properties) {
+			propSchema, _ := properties[name].(map[string]interface{})
+			result[name] = exampleFor(doc, propSchema, depth+1, visiting)
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok && len(properties) == 0 {
+			result["key"] = exampleFor(doc, additional, depth+1, visiting)
+		}
+		return result
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		item := exampleFor(doc, items, depth+1, visiting)
+		if item == nil {
+			return []interface{}{}
+		}
+		return []interface{}{item}
+	case "string":
+		return stringExample(schema)
+	case "integer":
+		if min, ok := schema["minimum"].(float64); ok {
+			return int(min)
+		}
+		return 0
+	case "number":
+		if min, ok := schema["minimum"].(float64); ok {
+			return min
+		}
+		return 0.0
+	case "boolean":
+		return true
+	case "null":
+		return nil
+	}
+
+	return nil
+}
+
+// schemaType returns the schema's type, handling OpenAPI 3.1 type arrays and implicit objects
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+// stringExample returns a plausible string for common formats
+func stringExample(schema map[string]interface{}) string {
+	format, _ := schema["format"].(string)
+	switch format {
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "date":
+		return "2024-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-4000-8000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	case "hostname":
+		return "example.com"
+	case "ipv4":
+		return "192.0.2.1"
+	}
+	return "string"
+}
+
+// ResolveRef follows a local "#/..." $ref, returning the schema unchanged if it has none
+func ResolveRef(doc, schema map[string]interface{}) map[string]interface{} {
+	ref, ok := schema["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") {
+		return schema
+	}
+
+	current := doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		current = getMap(current, part)
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// Operation is a single method/path pair of a spec
+type Operation struct {
+	Method    string
+	Path      string
+	Operation map[string]interface{}
+}
+
+// Operations lists the spec's operations ordered by path
+func Operations(doc map[string]interface{}) []Operation {
+	var operations []Operation
+	paths := getMap(doc, "paths")
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			if op, ok := item[method].(map[string]interface{}); ok {
+				operations = append(operations, Operation{Method: strings.ToUpper(method), Path: path, Operation: op})
+			}
+		}
+	}
+	return operations
+}
@@ -0,0 +1,55 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsFileReference reports whether a --from/--to value refers to a local spec file rather than an environment
+func IsFileReference(ref string) bool {
+	switch strings.ToLower(filepath.Ext(ref)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	_, err := os.Stat(ref)
+	return err == nil
+}
+
+// LoadFile loads an OpenAPI spec from a JSON or YAML file
+func LoadFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses an OpenAPI spec from JSON or YAML data
+func Parse(data []byte) (map[string]interface{}, error) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err == nil {
+		return spec, nil
+	}
+
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	// Round-trip through JSON so nested maps use map[string]interface{} like JSON-decoded specs
+	normalized, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize spec: %w", err)
+	}
+	spec = nil
+	if err := json.Unmarshal(normalized, &spec); err != nil {
+		return nil, fmt.Errorf("failed to normalize spec: %w", err)
+	}
+
+	return spec, nil
+}
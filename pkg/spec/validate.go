@@ -0,0 +1,340 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severities of spec validation issues. Errors break SDK generation; warnings are lint findings.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a problem found in a spec
+type Issue struct {
+	Severity string `json:"severity"`
+	// Rule names the check, e.g. "unresolved-ref"
+	Rule string `json:"rule"`
+	// Location is where the problem is, e.g. "GET /users/{id}" or "components.schemas.User"
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// ValidationReport lists the issues found in a spec
+type ValidationReport struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Errors returns the error-level issues
+func (r *ValidationReport) Errors() []Issue {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the warning-level issues
+func (r *ValidationReport) Warnings() []Issue {
+	return r.filter(SeverityWarning)
+}
+
+func (r *ValidationReport) filter(severity string) []Issue {
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (r *ValidationReport) add(severity, rule, location, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{
+		Severity: severity,
+		Rule:     rule,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+var (
+	openAPIVersionPattern = regexp.MustCompile(`^3\.[01]\.\d+$`)
+	statusCodePattern     = regexp.MustCompile(`^([1-5]\d\d|[1-5]XX|default)$`)
+	componentNamePattern  = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+	pathParamPattern      = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// schemaTypes are the types JSON Schema (and so OpenAPI 3.1) allows
+var schemaTypes = []string{"string", "number", "integer", "boolean", "array", "object", "null"}
+
+// parameterLocations are the valid values of a parameter's "in"
+var parameterLocations = []string{"path", "query", "header", "cookie"}
+
+// Validate checks a spec against the OpenAPI 3.0/3.1 structure and common lint rules. Errors are
+// problems sdk-gen cannot handle (missing responses, unresolved $refs, undeclared path parameters);
+// warnings are lint findings that lead to poorly named or hard to filter SDK methods.
+func Validate(doc map[string]interface{}) *ValidationReport {
+	report := &ValidationReport{}
+
+	version, _ := doc["openapi"].(string)
+	switch {
+	case version == "":
+		report.add(SeverityError, "openapi-version", "openapi", "the openapi version field is missing")
+	case !openAPIVersionPattern.MatchString(version):
+		report.add(SeverityError, "openapi-version", "openapi", "unsupported OpenAPI version '%s' (expected 3.0.x or 3.1.x)", version)
+	}
+
+	info := getMap(doc, "info")
+	if info == nil {
+		report.add(SeverityError, "info", "info", "the info object is missing")
+	} else {
+		for _, field := range []string{"title", "version"} {
+			if value, _ := info[field].(string); value == "" {
+				report.add(SeverityError, "info", "info."+field, "info.%s is required", field)
+			}
+		}
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		report.add(SeverityError, "paths", "paths", "the paths object is missing")
+	}
+	validatePaths(doc, paths, report)
+
+	schemas := getMap(getMap(doc, "components"), "schemas")
+	for _, name := range sortedKeys(schemas) {
+		location := "components.schemas." + name
+		if !componentNamePattern.MatchString(name) {
+			report.add(SeverityError, "component-name", location, "component names may only contain letters, digits, '.', '_' and '-'")
+		}
+		if schema, ok := schemas[name].(map[string]interface{}); ok {
+			validateSchema(location, schema, report)
+		}
+	}
+
+	validateRefs(doc, "", doc, report)
+	reportUnusedSchemas(doc, schemas, report)
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Severity == SeverityError && report.Issues[j].Severity != SeverityError
+	})
+	return report
+}
+
+// validatePaths checks every path item and operation
+func validatePaths(doc, paths map[string]interface{}, report *ValidationReport) {
+	operationIDs := map[string]string{}
+
+	for _, path := range sortedKeys(paths) {
+		if !strings.HasPrefix(path, "/") {
+			report.add(SeverityError, "path-format", path, "paths must start with '/'")
+		}
+		pathItem, ok := paths[path].(map[string]interface{})
+		if !ok {
+			report.add(SeverityError, "path-item", path, "path item must be an object")
+			continue
+		}
+		pathParams := parameterList(doc, pathItem["parameters"])
+
+		for _, method := range httpMethods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			location := strings.ToUpper(method) + " " + path
+
+			if operationID, _ := operation["operationId"].(string); operationID == "" {
+				report.add(SeverityWarning, "operation-id", location, "operation has no operationId; SDK method names will be derived from the path")
+			} else if previous, exists := operationIDs[operationID]; exists {
+				report.add(SeverityError, "operation-id-unique", location, "operationId '%s' is also used by %s", operationID, previous)
+			} else {
+				operationIDs[operationID] = location
+			}
+
+			if tags, _ := operation["tags"].([]interface{}); len(tags) == 0 {
+				report.add(SeverityWarning, "operation-tags", location, "operation has no tags; includeTags/excludeTags in sdk.yml cannot select it")
+			}
+
+			responses, _ := operation["responses"].(map[string]interface{})
+			if len(responses) == 0 {
+				report.add(SeverityError, "operation-responses", location, "operation must define at least one response")
+			}
+			for _, code := range sortedKeys(responses) {
+				if !statusCodePattern.MatchString(code) {
+					report.add(SeverityError, "response-code", location, "'%s' is not a valid response status code", code)
+				}
+			}
+
+			params := append(append([]map[string]interface{}{}, pathParams...), parameterList(doc, operation["parameters"])...)
+			validateParameters(location, path, params, report)
+		}
+	}
+}
+
+// validateParameters checks an operation's parameters, including those inherited from the path item
+func validateParameters(location, path string, params []map[string]interface{}, report *ValidationReport) {
+	declared := map[string]bool{}
+	seen := map[string]bool{}
+
+	for _, param := range params {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		if name == "" || in == "" {
+			report.add(SeverityError, "parameter", location, "parameters must have a name and an 'in' location")
+			continue
+		}
+		if !containsString(parameterLocations, in) {
+			report.add(SeverityError, "parameter", location, "parameter '%s' has invalid location '%s'", name, in)
+			continue
+		}
+		// Operation parameters override path-level ones with the same name and location
+		key := in + ":" + name
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if in == "path" {
+			declared[name] = true
+			if required, _ := param["required"].(bool); !required {
+				report.add(SeverityError, "path-parameter", location, "path parameter '%s' must be required", name)
+			}
+			if !strings.Contains(path, "{"+name+"}") {
+				report.add(SeverityError, "path-parameter", location, "path parameter '%s' does not appear in the path", name)
+			}
+		}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		if !declared[match[1]] {
+			report.add(SeverityError, "path-parameter", location, "path parameter '{%s}' is not declared", match[1])
+		}
+	}
+}
+
+// parameterList resolves a parameters array, following $refs
+func parameterList(doc map[string]interface{}, value interface{}) []map[string]interface{} {
+	items, _ := value.([]interface{})
+	params := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if param, ok := item.(map[string]interface{}); ok {
+			if resolved := ResolveRef(doc, param); resolved != nil {
+				params = append(params, resolved)
+			}
+		}
+	}
+	return params
+}
+
+// validateSchema checks schema types and array items, recursing into nested schemas
+func validateSchema(location string, schema map[string]interface{}, report *ValidationReport) {
+	if _, isRef := schema["$ref"]; isRef {
+		return
+	}
+
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	for _, t := range types {
+		if !containsString(schemaTypes, t) {
+			report.add(SeverityError, "schema-type", location, "'%s' is not a valid schema type", t)
+		}
+	}
+	if containsString(types, "array") && schema["items"] == nil {
+		report.add(SeverityError, "array-items", location, "array schemas must define items")
+	}
+
+	properties := getMap(schema, "properties")
+	for _, name := range sortedKeys(properties) {
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			validateSchema(location+"."+name, property, report)
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		validateSchema(location+"[]", items, report)
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		options, _ := schema[key].([]interface{})
+		for i, option := range options {
+			if option, ok := option.(map[string]interface{}); ok {
+				validateSchema(fmt.Sprintf("%s.%s[%d]", location, key, i), option, report)
+			}
+		}
+	}
+}
+
+// validateRefs reports local $refs that do not resolve
+func validateRefs(doc map[string]interface{}, location string, value interface{}, report *ValidationReport) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			if !strings.HasPrefix(ref, "#/") {
+				report.add(SeverityWarning, "external-ref", location, "external $ref '%s' is not resolved by SDK generation", ref)
+			} else if ResolveRef(doc, v) == nil {
+				report.add(SeverityError, "unresolved-ref", location, "$ref '%s' does not resolve", ref)
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			// Examples hold data, not schemas
+			if key == "example" || key == "examples" {
+				continue
+			}
+			validateRefs(doc, joinLocation(location, key), v[key], report)
+		}
+	case []interface{}:
+		for i, item := range v {
+			validateRefs(doc, fmt.Sprintf("%s[%d]", location, i), item, report)
+		}
+	}
+}
+
+// reportUnusedSchemas warns about component schemas nothing refers to
+func reportUnusedSchemas(doc, schemas map[string]interface{}, report *ValidationReport) {
+	used := map[string]bool{}
+	collectRefs(doc, used)
+	for _, name := range sortedKeys(schemas) {
+		if !used["#/components/schemas/"+name] {
+			report.add(SeverityWarning, "unused-schema", "components.schemas."+name, "schema is not referenced")
+		}
+	}
+}
+
+func collectRefs(value interface{}, refs map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			refs[ref] = true
+		}
+		for _, item := range v {
+			collectRefs(item, refs)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectRefs(item, refs)
+		}
+	}
+}
+
+func joinLocation(location, key string) string {
+	if location == "" {
+		return key
+	}
+	return location + "." + key
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
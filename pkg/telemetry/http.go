@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/blimu-dev/blimu-cli/pkg/metrics"
+	"github.com/blimu-dev/blimu-cli/pkg/transport"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
+)
+
+// tracingTransport wraps an http.RoundTripper with client spans and traceparent propagation
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// Transport wraps base so every request is recorded as a client span and carries a W3C
+// traceparent header. When tracing is disabled base is returned unchanged.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !Enabled() {
+		return base
+	}
+	return &tracingTransport{base: base}
+}
+
+// HTTPClient returns an http.Client that sends the User-Agent of the CLI, traces its requests,
+// records request metrics and logs requests with --trace-http
+func HTTPClient() *http.Client {
+	return HTTPClientFor(transport.Default())
+}
+
+// HTTPClientFor returns an http.Client like HTTPClient that sends its requests through base
+func HTTPClientFor(base http.RoundTripper) *http.Client {
+	return &http.Client{Transport: Transport(metrics.Transport(&userAgentTransport{base: traceTransport(base), userAgent: version.UserAgent()}))}
+}
+
+// userAgentTransport sets the User-Agent of the CLI on requests that have none of their own
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		// Clone before mutating headers, as required of RoundTrippers
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, span := startSpan(req.Context(), "HTTP "+req.Method, SpanKindClient)
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("url.full", req.URL.Redacted())
+	span.SetAttribute("server.address", req.URL.Hostname())
+
+	// Clone before mutating headers, as required of RoundTrippers
+	req = req.Clone(req.Context())
+	req.Header.Set("traceparent", span.TraceParent())
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.End(err)
+		return nil, err
+	}
+
+	span.SetAttribute("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 500 {
+		span.RecordError(errors.New(resp.Status))
+	}
+	span.End(nil)
+
+	return resp, nil
+}
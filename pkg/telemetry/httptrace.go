@@ -0,0 +1,242 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/logging"
+)
+
+// TraceEnv enables HTTP request tracing like --trace-http, e.g. BLIMU_TRACE=1 or BLIMU_TRACE=body
+const TraceEnv = "BLIMU_TRACE"
+
+// HTTPTraceMode selects how much of each request --trace-http logs
+type HTTPTraceMode int
+
+const (
+	// HTTPTraceOff logs nothing
+	HTTPTraceOff HTTPTraceMode = iota
+	// HTTPTraceBasic logs the method, URL, status and duration of every request
+	HTTPTraceBasic
+	// HTTPTraceHeaders also logs request and response headers, with credentials redacted
+	HTTPTraceHeaders
+	// HTTPTraceBody also logs request and response bodies, with secrets redacted
+	HTTPTraceBody
+)
+
+// maxTracedBody is the number of body bytes logged per request or response
+const maxTracedBody = 4096
+
+// redacted replaces credentials in traced headers and bodies
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged in clear
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// sensitiveFields are body fields never logged in clear, besides fields whose name contains
+// "token", "secret" or "password"
+var sensitiveFields = map[string]bool{
+	"api_key": true,
+	"apiKey":  true,
+}
+
+// sensitiveFormFields are the OAuth form fields never logged in clear
+var sensitiveFormFields = map[string]bool{
+	"code":          true,
+	"code_verifier": true,
+}
+
+var httpTraceMode HTTPTraceMode
+
+// ParseHTTPTraceMode parses a --trace-http or BLIMU_TRACE value: "", "0" or "false" is off, "1",
+// "true" or "basic" traces requests, and "headers" or "body" add more detail
+func ParseHTTPTraceMode(value string) (HTTPTraceMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false", "off":
+		return HTTPTraceOff, nil
+	case "1", "true", "on", "basic":
+		return HTTPTraceBasic, nil
+	case "headers":
+		return HTTPTraceHeaders, nil
+	case "body", "bodies", "full":
+		return HTTPTraceBody, nil
+	}
+	return HTTPTraceOff, fmt.Errorf("invalid HTTP trace mode '%s': use basic, headers or body", value)
+}
+
+// SetHTTPTrace sets what clients created afterwards by HTTPClient log of their requests
+func SetHTTPTrace(mode HTTPTraceMode) {
+	httpTraceMode = mode
+}
+
+// HTTPTraceFromEnv returns the trace mode of BLIMU_TRACE
+func HTTPTraceFromEnv() (HTTPTraceMode, error) {
+	mode, err := ParseHTTPTraceMode(os.Getenv(TraceEnv))
+	if err != nil {
+		return HTTPTraceOff, fmt.Errorf("invalid %s: %w", TraceEnv, err)
+	}
+	return mode, nil
+}
+
+// httpTraceTransport logs every request it sends
+type httpTraceTransport struct {
+	base http.RoundTripper
+	mode HTTPTraceMode
+}
+
+// traceTransport wraps base so requests are logged according to the --trace-http mode. When
+// tracing is off base is returned unchanged.
+func traceTransport(base http.RoundTripper) http.RoundTripper {
+	if httpTraceMode == HTTPTraceOff {
+		return base
+	}
+	return &httpTraceTransport{base: base, mode: httpTraceMode}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *httpTraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attrs := []any{"method", req.Method, "url", req.URL.Redacted()}
+	if t.mode >= HTTPTraceHeaders {
+		attrs = append(attrs, "request_headers", formatHeaders(req.Header))
+	}
+	if t.mode >= HTTPTraceBody && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// Clone before replacing the body, as required of RoundTrippers
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		attrs = append(attrs, "request_body", sanitizeBody(body, req.Header.Get("Content-Type")))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	attrs = append(attrs, "duration", time.Since(start).Round(time.Millisecond).String())
+	if err != nil {
+		logging.Logger().Info("HTTP request failed", append(attrs, "error", err.Error())...)
+		return nil, err
+	}
+
+	attrs = append(attrs, "status", resp.StatusCode)
+	requestID := resp.Header.Get("X-Request-Id")
+	if requestID == "" {
+		requestID = req.Header.Get("X-Request-Id")
+	}
+	if requestID != "" {
+		attrs = append(attrs, "request_id", requestID)
+	}
+	if t.mode >= HTTPTraceHeaders {
+		attrs = append(attrs, "response_headers", formatHeaders(resp.Header))
+	}
+	if t.mode >= HTTPTraceBody {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, "response_body", sanitizeBody(body, resp.Header.Get("Content-Type")))
+	}
+
+	logging.Logger().Info("HTTP request", attrs...)
+	return resp, nil
+}
+
+// formatHeaders returns headers as "Name: value; ..." in name order, with credentials redacted
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		parts = append(parts, name+": "+value)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// sanitizeBody returns a body for logging: JSON and form bodies with secret fields redacted,
+// truncated to maxTracedBody bytes
+func sanitizeBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	text := string(body)
+	switch {
+	case strings.Contains(contentType, "json"):
+		var value interface{}
+		if json.Unmarshal(body, &value) == nil {
+			if sanitized, err := json.Marshal(redactJSON(value)); err == nil {
+				text = string(sanitized)
+			}
+		}
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		text = redactForm(text)
+	default:
+		if !strings.HasPrefix(contentType, "text/") {
+			return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+		}
+	}
+
+	if len(text) > maxTracedBody {
+		text = text[:maxTracedBody] + fmt.Sprintf("... (%d bytes)", len(body))
+	}
+	return text
+}
+
+// redactJSON replaces the values of secret fields in a decoded JSON value
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return value
+}
+
+// redactForm replaces the values of secret fields in a URL-encoded form
+func redactForm(form string) string {
+	pairs := strings.Split(form, "&")
+	for i, pair := range pairs {
+		if key, _, ok := strings.Cut(pair, "="); ok && (sensitiveFormFields[key] || isSensitiveField(key)) {
+			pairs[i] = key + "=" + redacted
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func isSensitiveField(name string) bool {
+	lower := strings.ToLower(name)
+	return sensitiveFields[name] || strings.Contains(lower, "token") || strings.Contains(lower, "secret") || strings.Contains(lower, "password")
+}
@@ -0,0 +1,269 @@
+// Package telemetry records OpenTelemetry-compatible trace spans for CLI operations and exports
+// them over OTLP/HTTP (JSON encoding) when BLIMU_OTEL_ENDPOINT is set.
+//
+// The CLI is short-lived, so spans are buffered in memory and exported once by Shutdown.
+// When tracing is disabled every function is a cheap no-op.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EndpointEnv is the OTLP/HTTP collector endpoint (e.g. http://localhost:4318)
+	EndpointEnv = "BLIMU_OTEL_ENDPOINT"
+	// HeadersEnv holds extra export headers as comma-separated key=value pairs
+	HeadersEnv = "BLIMU_OTEL_HEADERS"
+	// TraceParentEnv lets automation pass a W3C traceparent so CLI spans join an existing trace
+	TraceParentEnv = "TRACEPARENT"
+
+	serviceName = "blimu-cli"
+	scopeName   = "github.com/blimu-dev/blimu-cli"
+)
+
+// SpanKind values from the OTLP specification
+const (
+	SpanKindInternal = 1
+	SpanKindClient   = 3
+)
+
+// Span status codes from the OTLP specification
+const (
+	statusUnset = 0
+	statusOK    = 1
+	statusError = 2
+)
+
+// Span is a single timed operation
+type Span struct {
+	traceID    string
+	spanID     string
+	parentID   string
+	name       string
+	kind       int
+	start      time.Time
+	end        time.Time
+	attributes map[string]interface{}
+	statusCode int
+	statusMsg  string
+	ended      bool
+	mu         sync.Mutex
+}
+
+type spanContextKey struct{}
+
+// tracer holds the process-wide tracing state
+type tracer struct {
+	mu            sync.Mutex
+	endpoint      string
+	headers       map[string]string
+	spans         []*Span
+	root          *Span
+	remoteTraceID string
+	remoteSpanID  string
+}
+
+var global *tracer
+
+// Init enables tracing when BLIMU_OTEL_ENDPOINT is set. It is safe to call more than once.
+func Init() {
+	endpoint := strings.TrimSpace(os.Getenv(EndpointEnv))
+	if endpoint == "" || global != nil {
+		return
+	}
+
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+
+	t := &tracer{
+		endpoint: endpoint,
+		headers:  parseHeaders(os.Getenv(HeadersEnv)),
+	}
+	t.remoteTraceID, t.remoteSpanID = parseTraceParent(os.Getenv(TraceParentEnv))
+	global = t
+}
+
+// Enabled reports whether spans are being recorded
+func Enabled() bool {
+	return global != nil
+}
+
+// StartRootSpan starts the span for the whole command. Spans started from contexts that carry
+// no span (e.g. context.Background()) become its children.
+func StartRootSpan(ctx context.Context, name string) (context.Context, *Span) {
+	ctx, span := StartSpan(ctx, name)
+	if global != nil && span != nil {
+		global.mu.Lock()
+		global.root = span
+		global.mu.Unlock()
+	}
+	return ctx, span
+}
+
+// StartSpan starts an internal span as a child of the span in ctx
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	return startSpan(ctx, name, SpanKindInternal)
+}
+
+func startSpan(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if global == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		spanID:     randomHex(8),
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+
+	if parent := parentSpan(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else if global.remoteTraceID != "" {
+		span.traceID = global.remoteTraceID
+		span.parentID = global.remoteSpanID
+	} else {
+		span.traceID = randomHex(16)
+	}
+
+	global.mu.Lock()
+	global.spans = append(global.spans, span)
+	global.mu.Unlock()
+
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// parentSpan returns the span carried by ctx, falling back to the root span
+func parentSpan(ctx context.Context) *Span {
+	if ctx != nil {
+		if span, ok := ctx.Value(spanContextKey{}).(*Span); ok {
+			return span
+		}
+	}
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	return global.root
+}
+
+// SetName renames the span, e.g. once the executed command is known
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name = name
+}
+
+// SetAttribute records an attribute on the span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+}
+
+// RecordError marks the span as failed
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusCode = statusError
+	s.statusMsg = err.Error()
+}
+
+// End finishes the span, recording err (if any) as its status
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.RecordError(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	if s.statusCode == statusUnset && err == nil {
+		s.statusCode = statusOK
+	}
+}
+
+// TraceParent returns the W3C traceparent header value for the span
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", s.traceID, s.spanID)
+}
+
+// Shutdown ends any open spans and exports everything recorded so far
+func Shutdown(ctx context.Context) error {
+	if global == nil {
+		return nil
+	}
+
+	global.mu.Lock()
+	spans := global.spans
+	global.spans = nil
+	global.mu.Unlock()
+
+	if len(spans) == 0 {
+		return nil
+	}
+
+	for _, span := range spans {
+		span.End(nil)
+	}
+
+	return export(ctx, global.endpoint, global.headers, spans)
+}
+
+// parseHeaders parses "key=value,key2=value2"
+func parseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(key) != "" {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		}
+	}
+	return headers
+}
+
+// parseTraceParent extracts the trace and parent span IDs from a W3C traceparent value
+func parseTraceParent(value string) (string, string) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", ""
+	}
+	return parts[1], parts[2]
+}
+
+// randomHex returns n random bytes hex-encoded
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to the clock just in case
+		return fmt.Sprintf("%0*x", n*2, time.Now().UnixNano())[:n*2]
+	}
+	return hex.EncodeToString(b)
+}
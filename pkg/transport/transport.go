@@ -0,0 +1,120 @@
+// Package transport builds the HTTP transport of every client of the CLI: proxies from
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY, custom TLS roots for private CAs and connection settings.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Options configures the transport
+type Options struct {
+	// CACert is a PEM file of certificates trusted in addition to the system roots
+	CACert string
+	// InsecureSkipVerify disables TLS certificate verification
+	InsecureSkipVerify bool
+	// ConnectTimeout limits establishing a connection (default 30s)
+	ConnectTimeout time.Duration
+	// KeepAlive is the interval of TCP keep-alive probes (default 30s); negative disables them
+	KeepAlive time.Duration
+	// MaxIdleConns is the number of idle connections kept open (default 100)
+	MaxIdleConns int
+}
+
+var (
+	mu         sync.Mutex
+	configured Options
+	fallback   http.RoundTripper = http.DefaultTransport
+)
+
+// Configure sets the transport returned by Default from the options
+func Configure(opts Options) error {
+	transport, err := New(opts)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	configured = opts
+	fallback = transport
+	return nil
+}
+
+// Configured returns the options of the last Configure call, e.g. to derive a transport with
+// other connection settings
+func Configured() Options {
+	mu.Lock()
+	defer mu.Unlock()
+	return configured
+}
+
+// Default returns the transport configured with Configure, or http.DefaultTransport
+func Default() http.RoundTripper {
+	mu.Lock()
+	defer mu.Unlock()
+	return fallback
+}
+
+// New returns a transport with the options applied. Like http.DefaultTransport it uses the
+// proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func New(opts Options) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if opts.ConnectTimeout != 0 || opts.KeepAlive != 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if opts.ConnectTimeout != 0 {
+			dialer.Timeout = opts.ConnectTimeout
+		}
+		if opts.KeepAlive != 0 {
+			dialer.KeepAlive = opts.KeepAlive
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if opts.MaxIdleConns != 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+	}
+
+	if opts.CACert == "" && !opts.InsecureSkipVerify {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.CACert != "" {
+		pool, err := certPool(opts.CACert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	// Opted into with insecure_skip_verify, e.g. to try a TLS-intercepting proxy
+	tlsConfig.InsecureSkipVerify = opts.InsecureSkipVerify
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// certPool returns the system roots plus the certificates of a PEM file
+func certPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
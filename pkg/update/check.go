@@ -0,0 +1,135 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/blimu-dev/blimu-cli/pkg/config"
+	"github.com/blimu-dev/blimu-cli/pkg/telemetry"
+	"github.com/blimu-dev/blimu-cli/pkg/version"
+)
+
+// CheckEnv turns the new-version check on (1) or off (0), overriding update_check in the CLI
+// config
+const CheckEnv = "BLIMU_UPDATE_CHECK"
+
+// checkInterval is how long the latest release found is trusted before GitHub is asked again
+const checkInterval = 24 * time.Hour
+
+// Bounds of the background check: how long it may take, and how long the CLI waits for it after
+// the command finished before leaving it to the next run
+const (
+	checkTimeout = 10 * time.Second
+	checkWait    = 500 * time.Millisecond
+)
+
+// checkCacheFile caches the latest release found in the cache directory
+const checkCacheFile = "update-check.json"
+
+// checkState is the cached result of the last check
+type checkState struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// CheckEnabled reports whether the new-version check is on: BLIMU_UPDATE_CHECK, else
+// update_check in the CLI config. It is off by default.
+func CheckEnabled() bool {
+	if value := os.Getenv(CheckEnv); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		return err == nil && enabled
+	}
+	cliConfig, err := config.LoadCLIConfig()
+	return err == nil && cliConfig.UpdateCheck
+}
+
+// StartCheck looks for a newer release in the background, at most once a day, and returns a
+// function that returns the notice to print once the command finished, or "" when the CLI is up
+// to date. Without the check enabled, or in development builds, it does nothing.
+func StartCheck() func() string {
+	none := func() string { return "" }
+	if !CheckEnabled() {
+		return none
+	}
+	current := version.Current()
+	if _, ok := version.Compare(current, current); !ok {
+		return none
+	}
+
+	state := loadCheckState()
+	if time.Since(state.CheckedAt) < checkInterval {
+		return func() string { return notice(current, state.Latest) }
+	}
+
+	done := make(chan string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+
+		release, err := LatestRelease(ctx, telemetry.HTTPClient())
+		if err != nil {
+			// Offline or rate limited: try again on a later run
+			done <- state.Latest
+			return
+		}
+		saveCheckState(checkState{CheckedAt: time.Now(), Latest: release.TagName})
+		done <- release.TagName
+	}()
+
+	return func() string {
+		select {
+		case latest := <-done:
+			return notice(current, latest)
+		case <-time.After(checkWait):
+			return notice(current, state.Latest)
+		}
+	}
+}
+
+// notice returns the one-line notice of latest when it is newer than current
+func notice(current, latest string) string {
+	if latest == "" {
+		return ""
+	}
+	if newer, ok := version.Compare(latest, current); !ok || newer <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("💡 Blimu CLI %s is available (you have %s), run 'blimu upgrade' to install it", latest, current)
+}
+
+func checkStatePath() (string, error) {
+	cacheDir, err := config.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, checkCacheFile), nil
+}
+
+// loadCheckState returns the cached result of the last check, empty when there is none
+func loadCheckState() checkState {
+	var state checkState
+	path, err := checkStatePath()
+	if err != nil {
+		return state
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+// saveCheckState caches the result of a check; failures only mean checking again next time
+func saveCheckState(state checkState) {
+	path, err := checkStatePath()
+	if err != nil {
+		return
+	}
+	if data, err := json.Marshal(state); err == nil {
+		os.WriteFile(path, data, 0644)
+	}
+}
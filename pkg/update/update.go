@@ -0,0 +1,262 @@
+// Package update finds releases of the CLI on GitHub and installs them over the running binary
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Repository is the GitHub repository the CLI is released from
+const Repository = "blimu-dev/blimu-cli"
+
+// ChecksumsAsset is the release asset listing the SHA-256 checksum of every binary, in the
+// format of sha256sum
+const ChecksumsAsset = "checksums.txt"
+
+// GitHubTokenEnv authenticates requests to GitHub, raising its rate limit
+const GitHubTokenEnv = "GITHUB_TOKEN"
+
+// apiURL is the GitHub API, a variable so that builds can point it at a mirror
+var apiURL = "https://api.github.com"
+
+// maxBinarySize bounds downloads, well above the size of a release binary
+const maxBinarySize = 200 << 20
+
+// Release is a GitHub release of the CLI
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	// Digest is the checksum GitHub computed on upload, e.g. "sha256:ab12..."; empty for
+	// releases published before GitHub recorded them
+	Digest string `json:"digest"`
+}
+
+// Asset returns the asset of the release with the given name
+func (r *Release) Asset(name string) (*Asset, bool) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// AssetName returns the name of the release binary for this OS and architecture, e.g.
+// "blimu-linux-amd64", as built by the release workflow
+func AssetName() string {
+	name := fmt.Sprintf("blimu-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// LatestRelease returns the latest published release
+func LatestRelease(ctx context.Context, client *http.Client) (*Release, error) {
+	return getRelease(ctx, client, fmt.Sprintf("%s/repos/%s/releases/latest", apiURL, Repository))
+}
+
+// GetRelease returns the release with the given tag, e.g. "v1.2.3"
+func GetRelease(ctx context.Context, client *http.Client, tag string) (*Release, error) {
+	return getRelease(ctx, client, fmt.Sprintf("%s/repos/%s/releases/tags/%s", apiURL, Repository, tag))
+}
+
+func getRelease(ctx context.Context, client *http.Client, url string) (*Release, error) {
+	resp, err := get(ctx, client, url, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &release, nil
+}
+
+// Download downloads the binary of the release for this OS and architecture and verifies it
+// against the release's checksums. Releases without a checksum of the binary are refused.
+func Download(ctx context.Context, client *http.Client, release *Release) ([]byte, error) {
+	name := AssetName()
+	asset, ok := release.Asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s (%s)", release.TagName, runtime.GOOS, runtime.GOARCH, name)
+	}
+
+	expected, err := expectedChecksum(ctx, client, release, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := download(ctx, client, asset.BrowserDownloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	sum := sha256.Sum256(binary)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, expected, actual)
+	}
+	return binary, nil
+}
+
+// expectedChecksum returns the SHA-256 of asset listed in the release's checksums file, or else
+// the digest GitHub recorded for it
+func expectedChecksum(ctx context.Context, client *http.Client, release *Release, asset *Asset) (string, error) {
+	if checksums, ok := release.Asset(ChecksumsAsset); ok {
+		data, err := download(ctx, client, checksums.BrowserDownloadURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+		}
+		if sum, ok := findChecksum(data, asset.Name); ok {
+			return sum, nil
+		}
+		return "", fmt.Errorf("%s of release %s does not list %s", ChecksumsAsset, release.TagName, asset.Name)
+	}
+
+	if sum, ok := strings.CutPrefix(asset.Digest, "sha256:"); ok && sum != "" {
+		return strings.ToLower(sum), nil
+	}
+	return "", fmt.Errorf("release %s has no checksum for %s, refusing to install it", release.TagName, asset.Name)
+}
+
+// findChecksum finds the checksum of name in sha256sum output ("<sum>  <name>", or "<sum> *<name>"
+// in binary mode)
+func findChecksum(data []byte, name string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	resp, err := get(ctx, client, url, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBinarySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBinarySize {
+		return nil, fmt.Errorf("larger than %d MB", maxBinarySize>>20)
+	}
+	return data, nil
+}
+
+// get sends a GET request, authenticated with GITHUB_TOKEN when it is set, and fails on statuses
+// other than 200
+func get(ctx context.Context, client *http.Client, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token := os.Getenv(GitHubTokenEnv); token != "" && strings.HasPrefix(url, apiURL) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("not found: %s", url)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return nil, fmt.Errorf("GitHub rate limit reached (status %d), set %s to raise it", resp.StatusCode, GitHubTokenEnv)
+	}
+	return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+}
+
+// ErrNotWritable is returned by Install when the running binary cannot be replaced by this user
+var ErrNotWritable = errors.New("the CLI binary is not writable")
+
+// Install replaces the running binary with binary and returns its path. The new binary is
+// written next to the old one and renamed over it, so an interrupted install leaves the old one
+// in place.
+func Install(binary []byte) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the CLI binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	mode := os.FileMode(0755)
+	if info, err := os.Stat(executable); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(executable), ".blimu-upgrade-*")
+	if err != nil {
+		if os.IsPermission(err) {
+			return executable, fmt.Errorf("%w: %s", ErrNotWritable, executable)
+		}
+		return executable, fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return executable, fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return executable, fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return executable, fmt.Errorf("failed to make the new binary executable: %w", err)
+	}
+
+	// Windows cannot replace a running executable, but it can rename it out of the way
+	if runtime.GOOS == "windows" {
+		old := executable + ".old"
+		os.Remove(old)
+		if err := os.Rename(executable, old); err != nil {
+			return executable, fmt.Errorf("failed to move the old binary aside: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmp.Name(), executable); err != nil {
+		if os.IsPermission(err) {
+			return executable, fmt.Errorf("%w: %s", ErrNotWritable, executable)
+		}
+		return executable, fmt.Errorf("failed to replace the binary: %w", err)
+	}
+	return executable, nil
+}
@@ -0,0 +1,157 @@
+// Package version identifies the build of the CLI
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Version is the release of the CLI, set at build time with
+// -ldflags "-X github.com/blimu-dev/blimu-cli/pkg/version.Version=v1.2.3"
+var Version = ""
+
+// Commit and Date are the git commit and build time of release builds, set at build time like
+// Version; builds from a git checkout take them from the build info instead
+var (
+	Commit = ""
+	Date   = ""
+)
+
+// Info describes the build of the CLI, for bug reports and for pinning toolchains in CI
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty"`
+	// Modified is set for builds of a checkout with uncommitted changes
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// Build returns the description of this build
+func Build() Info {
+	info := Info{
+		Version:   Current(),
+		Commit:    Commit,
+		BuildDate: Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	return info
+}
+
+// Dependency returns the version of the module at path this build was compiled with, "" when it
+// is not a dependency
+func Dependency(path string) string {
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range build.Deps {
+		if dep.Path == path {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// Current returns the release of the CLI: Version, or else the module version of go install
+// builds, or "dev"
+func Current() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// UserAgent is the User-Agent of the requests of the CLI, e.g. "blimu-cli/v1.2.3 (linux/amd64)"
+func UserAgent() string {
+	return fmt.Sprintf("blimu-cli/%s (%s/%s)", Current(), runtime.GOOS, runtime.GOARCH)
+}
+
+// Compare compares two releases like "v1.2.3" or "1.3.0-rc.1" and returns -1, 0 or 1. A
+// pre-release sorts before its release; ok is false when either is not a semantic version.
+func Compare(a, b string) (result int, ok bool) {
+	va, okA := parse(a)
+	vb, okB := parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := 0; i < 3; i++ {
+		if va.numbers[i] != vb.numbers[i] {
+			if va.numbers[i] < vb.numbers[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	switch {
+	case va.prerelease == vb.prerelease:
+		return 0, true
+	case va.prerelease == "":
+		return 1, true
+	case vb.prerelease == "":
+		return -1, true
+	case va.prerelease < vb.prerelease:
+		return -1, true
+	default:
+		return 1, true
+	}
+}
+
+// semver is a parsed semantic version; build metadata is ignored
+type semver struct {
+	numbers    [3]int
+	prerelease string
+}
+
+func parse(v string) (semver, bool) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	var parsed semver
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		v, parsed.prerelease = v[:i], v[i+1:]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		parsed.numbers[i] = n
+	}
+	return parsed, true
+}
@@ -0,0 +1,122 @@
+// Package watch reports changes to the files in a directory tree. It polls file sizes and
+// modification times, which works the same on every platform and for editors that save by
+// renaming a temporary file over the original.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultInterval is how often the directory is scanned
+const DefaultInterval = 500 * time.Millisecond
+
+// fileState is what a scan records about a file
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Snapshot is the state of every regular file under a directory, keyed by path
+type Snapshot map[string]fileState
+
+// Scan records the size and modification time of every regular file under dir. A missing
+// directory is an empty snapshot, so watching starts working once it is created.
+func Scan(dir string) (Snapshot, error) {
+	snapshot := Snapshot{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// Removed between listing and stat
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		snapshot[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return snapshot, err
+}
+
+// Changed returns the sorted paths added, modified or removed since previous
+func (s Snapshot) Changed(previous Snapshot) []string {
+	var changed []string
+	for path, state := range s {
+		if old, ok := previous[path]; !ok || old.size != state.size || !old.modTime.Equal(state.modTime) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := s[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Watch scans dir every interval and calls fn with the paths that changed. Changes are
+// collected until a scan finds nothing new, so a burst of saves results in a single call.
+// fn runs on the calling goroutine; scans pause while it runs. Watch returns when ctx is done
+// (with a nil error) or when a scan fails.
+func Watch(ctx context.Context, dir string, interval time.Duration, fn func(changed []string)) error {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	previous, err := Scan(dir)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	pending := map[string]bool{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := Scan(dir)
+		if err != nil {
+			return err
+		}
+		changed := current.Changed(previous)
+		previous = current
+
+		if len(changed) > 0 {
+			for _, path := range changed {
+				pending[path] = true
+			}
+			continue
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		paths := make([]string, 0, len(pending))
+		for path := range pending {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		pending = map[string]bool{}
+		fn(paths)
+	}
+}
@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"context"
@@ -34,9 +34,9 @@ func (s *ApiKeysService) ListWithContext(ctx context.Context, workspaceId string
 
 // List GET /v1/workspace/{workspaceId}/api-keys
 //
-// This is a convenience method that calls ListWithContext with context.Background().
+// This is a convenience method that calls ListWithContext with the client's default context (see WithContext).
 func (s *ApiKeysService) List(workspaceId string) (ApiKeyListDtoOutput, error) {
-	return s.ListWithContext(context.Background(), workspaceId)
+	return s.ListWithContext(s.client.defaultContext(), workspaceId)
 }
 
 // CreateWithContext POST /v1/workspace/{workspaceId}/api-keys
@@ -62,9 +62,9 @@ func (s *ApiKeysService) CreateWithContext(ctx context.Context, workspaceId stri
 
 // Create POST /v1/workspace/{workspaceId}/api-keys
 //
-// This is a convenience method that calls CreateWithContext with context.Background().
+// This is a convenience method that calls CreateWithContext with the client's default context (see WithContext).
 func (s *ApiKeysService) Create(workspaceId string, body ApiKeyCreateDto) (ApiKeyDtoOutput, error) {
-	return s.CreateWithContext(context.Background(), workspaceId, body)
+	return s.CreateWithContext(s.client.defaultContext(), workspaceId, body)
 }
 
 // DeleteWithContext DELETE /v1/workspace/{workspaceId}/api-keys/{id}
@@ -88,9 +88,9 @@ func (s *ApiKeysService) DeleteWithContext(ctx context.Context, workspaceId stri
 
 // Delete DELETE /v1/workspace/{workspaceId}/api-keys/{id}
 //
-// This is a convenience method that calls DeleteWithContext with context.Background().
+// This is a convenience method that calls DeleteWithContext with the client's default context (see WithContext).
 func (s *ApiKeysService) Delete(workspaceId string, id string) (interface{}, error) {
-	return s.DeleteWithContext(context.Background(), workspaceId, id)
+	return s.DeleteWithContext(s.client.defaultContext(), workspaceId, id)
 }
 
 // GetWithContext GET /v1/workspace/{workspaceId}/api-keys/{id}
@@ -116,7 +116,7 @@ func (s *ApiKeysService) GetWithContext(ctx context.Context, workspaceId string,
 
 // Get GET /v1/workspace/{workspaceId}/api-keys/{id}
 //
-// This is a convenience method that calls GetWithContext with context.Background().
+// This is a convenience method that calls GetWithContext with the client's default context (see WithContext).
 func (s *ApiKeysService) Get(workspaceId string, id string) (ApiKeyDtoOutput, error) {
-	return s.GetWithContext(context.Background(), workspaceId, id)
+	return s.GetWithContext(s.client.defaultContext(), workspaceId, id)
 }
@@ -1,5 +1,5 @@
-// Package blimu_platform provides a Go SDK for Blimu Platform
-package blimu_platform
+// Package platform is the Go client for the Blimu Platform API
+package platform
 
 import (
 	"bytes"
@@ -55,13 +55,53 @@ func WithBearer(token string) ClientOption {
 	}
 }
 
+// TokenSource supplies the bearer token of requests, e.g. refreshing it before it expires
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// WithTokenSource takes the bearer token of each request from ts instead of a fixed token, so
+// long-lived clients keep working past the expiry of the token they were created with
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithMiddleware wraps the HTTP transport of the client, e.g. to log or trace requests. The
+// first middleware is the outermost.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithRetry retries failed requests according to the policy
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// WithContext sets the context of the methods that take none, e.g. Resources.List, so that
+// cancelling it aborts their requests. Methods ending in WithContext use their own context.
+func WithContext(ctx context.Context) ClientOption {
+	return func(c *Client) {
+		c.ctx = ctx
+	}
+}
+
 // Client is the main client for the Blimu Platform API
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	headers    map[string]string
-	apiKey     string
-	bearer     string
+	baseURL     string
+	httpClient  *http.Client
+	headers     map[string]string
+	apiKey      string
+	bearer      string
+	tokenSource TokenSource
+	middleware  []Middleware
+	retry       *RetryPolicy
+	ctx         context.Context
 
 	// Services
 
@@ -84,6 +124,9 @@ func NewClient(opts ...ClientOption) *Client {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if len(c.middleware) > 0 {
+		c.httpClient = wrapHTTPClient(c.httpClient, c.middleware)
+	}
 
 	// Initialize services
 
@@ -97,6 +140,14 @@ func NewClient(opts ...ClientOption) *Client {
 	return c
 }
 
+// defaultContext returns the context of the methods that take none
+func (c *Client) defaultContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // request makes an HTTP request
 func (c *Client) request(ctx context.Context, method, path string, query url.Values, body interface{}, headers map[string]string) (*http.Response, error) {
 	// Build URL
@@ -110,17 +161,64 @@ func (c *Client) request(ctx context.Context, method, path string, query url.Val
 	}
 
 	// Prepare request body
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	// Every attempt carries the same request ID, so the platform logs can be correlated with the
+	// error the caller reports
+	requestID := headers[RequestIDHeader]
+	if requestID == "" {
+		requestID = c.headers[RequestIDHeader]
+	}
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	headers = withHeader(headers, RequestIDHeader, requestID)
+	headers = withHeader(headers, IdempotencyKeyHeader, idempotencyKey(ctx))
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		headers = withHeader(headers, "Authorization", "Bearer "+token)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, method, u.String(), jsonBody, body != nil, headers)
+		wait, retry := c.retry.next(ctx, method, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return nil, &NetworkError{Err: err, RequestID: requestID}
+			}
+			return resp, nil
+		}
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(attempt, wait, resp, err)
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+	}
+}
+
+// do sends one attempt of a request
+func (c *Client) do(ctx context.Context, method, target string, jsonBody []byte, hasBody bool, headers map[string]string) (*http.Response, error) {
+	var reqBody io.Reader
+	if hasBody {
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -134,7 +232,7 @@ func (c *Client) request(ctx context.Context, method, path string, query url.Val
 	}
 
 	// Set content type for JSON bodies
-	if body != nil {
+	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
@@ -146,13 +244,7 @@ func (c *Client) request(ctx context.Context, method, path string, query url.Val
 		req.Header.Set("Authorization", "Bearer "+c.bearer)
 	}
 
-	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-
-	return resp, nil
+	return c.httpClient.Do(req)
 }
 
 // decodeResponse decodes an HTTP response into the given interface
@@ -161,10 +253,7 @@ func (c *Client) decodeResponse(resp *http.Response, v interface{}) error {
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(body),
-		}
+		return newAPIError(resp, body)
 	}
 
 	if v == nil {
@@ -188,13 +277,3 @@ func (c *Client) decodeResponse(resp *http.Response, v interface{}) error {
 
 	return fmt.Errorf("unsupported content type: %s", contentType)
 }
-
-// APIError represents an API error response
-type APIError struct {
-	StatusCode int
-	Message    string
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
-}
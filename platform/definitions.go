@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"context"
@@ -36,9 +36,9 @@ func (s *DefinitionsService) GetWithContext(ctx context.Context, workspaceId str
 // Get GET /v1/workspace/{workspaceId}/environments/{environmentId}/definitions
 // Get environment definitions
 //
-// This is a convenience method that calls GetWithContext with context.Background().
+// This is a convenience method that calls GetWithContext with the client's default context (see WithContext).
 func (s *DefinitionsService) Get(workspaceId string, environmentId string) (DefinitionDtoOutput, error) {
-	return s.GetWithContext(context.Background(), workspaceId, environmentId)
+	return s.GetWithContext(s.client.defaultContext(), workspaceId, environmentId)
 }
 
 // UpdateWithContext PUT /v1/workspace/{workspaceId}/environments/{environmentId}/definitions
@@ -64,9 +64,9 @@ func (s *DefinitionsService) UpdateWithContext(ctx context.Context, workspaceId
 // Update PUT /v1/workspace/{workspaceId}/environments/{environmentId}/definitions
 // Update environment definitions
 //
-// This is a convenience method that calls UpdateWithContext with context.Background().
+// This is a convenience method that calls UpdateWithContext with the client's default context (see WithContext).
 func (s *DefinitionsService) Update(workspaceId string, environmentId string, body DefinitionUpdateDto) (interface{}, error) {
-	return s.UpdateWithContext(context.Background(), workspaceId, environmentId, body)
+	return s.UpdateWithContext(s.client.defaultContext(), workspaceId, environmentId, body)
 }
 
 // GetOpenApiWithContext GET /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/openapi
@@ -98,9 +98,9 @@ func (s *DefinitionsService) GetOpenApiWithContext(ctx context.Context, workspac
 //
 // Generates a custom OpenAPI specification from the environment's stored definitions in the database. The generated spec can be used to create type-safe SDKs.
 //
-// This is a convenience method that calls GetOpenApiWithContext with context.Background().
+// This is a convenience method that calls GetOpenApiWithContext with the client's default context (see WithContext).
 func (s *DefinitionsService) GetOpenApi(workspaceId string, environmentId string) (DefinitionGenerateSdkResponseDtoOutput, error) {
-	return s.GetOpenApiWithContext(context.Background(), workspaceId, environmentId)
+	return s.GetOpenApiWithContext(s.client.defaultContext(), workspaceId, environmentId)
 }
 
 // CreateOpenApiWithContext POST /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/openapi
@@ -132,9 +132,9 @@ func (s *DefinitionsService) CreateOpenApiWithContext(ctx context.Context, works
 //
 // Validates configuration and generates a custom OpenAPI specification tailored to the user's resource definitions. The generated spec can be used to create type-safe SDKs.
 //
-// This is a convenience method that calls CreateOpenApiWithContext with context.Background().
+// This is a convenience method that calls CreateOpenApiWithContext with the client's default context (see WithContext).
 func (s *DefinitionsService) CreateOpenApi(workspaceId string, environmentId string, body DefinitionGenerateSdkRequestDto) (DefinitionGenerateSdkResponseDtoOutput, error) {
-	return s.CreateOpenApiWithContext(context.Background(), workspaceId, environmentId, body)
+	return s.CreateOpenApiWithContext(s.client.defaultContext(), workspaceId, environmentId, body)
 }
 
 // ValidateWithContext POST /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/validate
@@ -166,7 +166,103 @@ func (s *DefinitionsService) ValidateWithContext(ctx context.Context, workspaceI
 //
 // Validates a complete Blimu configuration including resources, entitlements, features, and plans. Returns validation errors and optionally generates an OpenAPI spec if valid.
 //
-// This is a convenience method that calls ValidateWithContext with context.Background().
+// This is a convenience method that calls ValidateWithContext with the client's default context (see WithContext).
 func (s *DefinitionsService) Validate(workspaceId string, environmentId string, body DefinitionValidateRequestDto) (DefinitionValidateResponseDtoOutput, error) {
-	return s.ValidateWithContext(context.Background(), workspaceId, environmentId, body)
+	return s.ValidateWithContext(s.client.defaultContext(), workspaceId, environmentId, body)
+}
+
+// GetLockWithContext GET /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/lock
+// Get the advisory lock on environment definitions
+func (s *DefinitionsService) GetLockWithContext(ctx context.Context, workspaceId string, environmentId string) (DefinitionLockStatusDtoOutput, error) {
+	// Build path with parameters
+	path := fmt.Sprintf("/v1/workspace/%v/environments/%v/definitions/lock", workspaceId, environmentId)
+	var queryValues url.Values
+	// Make request
+	resp, err := s.client.request(ctx, "GET", path, queryValues, nil, nil)
+	if err != nil {
+		var zero DefinitionLockStatusDtoOutput
+		return zero, err
+	}
+	var result DefinitionLockStatusDtoOutput
+
+	if err := s.client.decodeResponse(resp, &result); err != nil {
+		var zero DefinitionLockStatusDtoOutput
+		return zero, err
+	}
+
+	return result, nil
+}
+
+// GetLock GET /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/lock
+// Get the advisory lock on environment definitions
+//
+// This is a convenience method that calls GetLockWithContext with the client's default context (see WithContext).
+func (s *DefinitionsService) GetLock(workspaceId string, environmentId string) (DefinitionLockStatusDtoOutput, error) {
+	return s.GetLockWithContext(s.client.defaultContext(), workspaceId, environmentId)
+}
+
+// LockWithContext PUT /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/lock
+// Lock environment definitions
+//
+// Takes the advisory lock on the environment's definitions. Fails with 409 Conflict when the definitions are already locked.
+func (s *DefinitionsService) LockWithContext(ctx context.Context, workspaceId string, environmentId string, body DefinitionLockDto) (DefinitionLockDtoOutput, error) {
+	// Build path with parameters
+	path := fmt.Sprintf("/v1/workspace/%v/environments/%v/definitions/lock", workspaceId, environmentId)
+	var queryValues url.Values
+	// Make request with body
+	resp, err := s.client.request(ctx, "PUT", path, queryValues, body, nil)
+	if err != nil {
+		var zero DefinitionLockDtoOutput
+		return zero, err
+	}
+	var result DefinitionLockDtoOutput
+
+	if err := s.client.decodeResponse(resp, &result); err != nil {
+		var zero DefinitionLockDtoOutput
+		return zero, err
+	}
+
+	return result, nil
+}
+
+// Lock PUT /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/lock
+// Lock environment definitions
+//
+// This is a convenience method that calls LockWithContext with the client's default context (see WithContext).
+func (s *DefinitionsService) Lock(workspaceId string, environmentId string, body DefinitionLockDto) (DefinitionLockDtoOutput, error) {
+	return s.LockWithContext(s.client.defaultContext(), workspaceId, environmentId, body)
+}
+
+// UnlockWithContext DELETE /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/lock
+// Unlock environment definitions
+//
+// Releases the advisory lock on the environment's definitions. With lockId, only that lock is released and 409 Conflict is returned if another lock is held.
+func (s *DefinitionsService) UnlockWithContext(ctx context.Context, workspaceId string, environmentId string, query *DefinitionsUnlockQuery) (interface{}, error) {
+	// Build path with parameters
+	path := fmt.Sprintf("/v1/workspace/%v/environments/%v/definitions/lock", workspaceId, environmentId)
+	// Convert query parameters
+	var queryValues url.Values
+	if query != nil {
+		queryValues = query.ToValues()
+	}
+	// Make request
+	resp, err := s.client.request(ctx, "DELETE", path, queryValues, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+
+	if err := s.client.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Unlock DELETE /v1/workspace/{workspaceId}/environments/{environmentId}/definitions/lock
+// Unlock environment definitions
+//
+// This is a convenience method that calls UnlockWithContext with the client's default context (see WithContext).
+func (s *DefinitionsService) Unlock(workspaceId string, environmentId string, query *DefinitionsUnlockQuery) (interface{}, error) {
+	return s.UnlockWithContext(s.client.defaultContext(), workspaceId, environmentId, query)
 }
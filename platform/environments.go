@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"context"
@@ -40,9 +40,9 @@ func (s *EnvironmentsService) ListWithContext(ctx context.Context, workspaceId s
 // List GET /v1/workspace/{workspaceId}/environments
 // List environments
 //
-// This is a convenience method that calls ListWithContext with context.Background().
+// This is a convenience method that calls ListWithContext with the client's default context (see WithContext).
 func (s *EnvironmentsService) List(workspaceId string, query *EnvironmentsListQuery) (EnvironmentListDtoOutput, error) {
-	return s.ListWithContext(context.Background(), workspaceId, query)
+	return s.ListWithContext(s.client.defaultContext(), workspaceId, query)
 }
 
 // CreateWithContext POST /v1/workspace/{workspaceId}/environments
@@ -70,9 +70,9 @@ func (s *EnvironmentsService) CreateWithContext(ctx context.Context, workspaceId
 // Create POST /v1/workspace/{workspaceId}/environments
 // Create a new environment
 //
-// This is a convenience method that calls CreateWithContext with context.Background().
+// This is a convenience method that calls CreateWithContext with the client's default context (see WithContext).
 func (s *EnvironmentsService) Create(workspaceId string, body EnvironmentCreateDto) (EnvironmentDtoOutput, error) {
-	return s.CreateWithContext(context.Background(), workspaceId, body)
+	return s.CreateWithContext(s.client.defaultContext(), workspaceId, body)
 }
 
 // DeleteWithContext DELETE /v1/workspace/{workspaceId}/environments/{environmentId}
@@ -98,9 +98,9 @@ func (s *EnvironmentsService) DeleteWithContext(ctx context.Context, workspaceId
 // Delete DELETE /v1/workspace/{workspaceId}/environments/{environmentId}
 // Delete an environment
 //
-// This is a convenience method that calls DeleteWithContext with context.Background().
+// This is a convenience method that calls DeleteWithContext with the client's default context (see WithContext).
 func (s *EnvironmentsService) Delete(workspaceId string, environmentId string) (interface{}, error) {
-	return s.DeleteWithContext(context.Background(), workspaceId, environmentId)
+	return s.DeleteWithContext(s.client.defaultContext(), workspaceId, environmentId)
 }
 
 // ReadWithContext GET /v1/workspace/{workspaceId}/environments/{environmentId}
@@ -128,9 +128,9 @@ func (s *EnvironmentsService) ReadWithContext(ctx context.Context, workspaceId s
 // Read GET /v1/workspace/{workspaceId}/environments/{environmentId}
 // Read an environment by ID
 //
-// This is a convenience method that calls ReadWithContext with context.Background().
+// This is a convenience method that calls ReadWithContext with the client's default context (see WithContext).
 func (s *EnvironmentsService) Read(workspaceId string, environmentId string) (EnvironmentWithDefinitionDtoOutput, error) {
-	return s.ReadWithContext(context.Background(), workspaceId, environmentId)
+	return s.ReadWithContext(s.client.defaultContext(), workspaceId, environmentId)
 }
 
 // UpdateWithContext PUT /v1/workspace/{workspaceId}/environments/{environmentId}
@@ -158,7 +158,7 @@ func (s *EnvironmentsService) UpdateWithContext(ctx context.Context, workspaceId
 // Update PUT /v1/workspace/{workspaceId}/environments/{environmentId}
 // Update an environment
 //
-// This is a convenience method that calls UpdateWithContext with context.Background().
+// This is a convenience method that calls UpdateWithContext with the client's default context (see WithContext).
 func (s *EnvironmentsService) Update(workspaceId string, environmentId string, body EnvironmentUpdateDto) (EnvironmentDtoOutput, error) {
-	return s.UpdateWithContext(context.Background(), workspaceId, environmentId, body)
+	return s.UpdateWithContext(s.client.defaultContext(), workspaceId, environmentId, body)
 }
@@ -0,0 +1,123 @@
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors an *APIError matches with errors.Is, by status code
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+	// ErrNetwork is matched by a *NetworkError
+	ErrNetwork = errors.New("network error")
+)
+
+// NetworkError is returned when a request got no response, e.g. the platform could not be
+// reached or the connection dropped
+type NetworkError struct {
+	Err error
+	// RequestID is the X-Request-Id the request was sent with
+	RequestID string
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("request failed: %v", e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrNetwork
+func (e *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+// APIError represents an API error response
+type APIError struct {
+	StatusCode int
+	// Message is the error message of a JSON error body, or the whole body (the status text when
+	// empty) otherwise
+	Message string
+	// Code is the machine-readable error code of a JSON error body, if any
+	Code string
+	// RequestID is the X-Request-Id of the response, or else of the request
+	RequestID string
+	// Body is the raw response body
+	Body string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+}
+
+// Is matches the sentinel error for the status code, e.g. errors.Is(err, platform.ErrNotFound)
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// newAPIError builds an APIError from an error response. JSON bodies of the form
+// {"message": "...", "error": "..."} (message may also be a list) provide Message and Code.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+		RequestID:  resp.Header.Get(RequestIDHeader),
+		Body:       string(body),
+	}
+	if apiErr.RequestID == "" && resp.Request != nil {
+		apiErr.RequestID = resp.Request.Header.Get(RequestIDHeader)
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	var parsed struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+		Code    string          `json:"code"`
+	}
+	if json.Unmarshal(body, &parsed) != nil {
+		return apiErr
+	}
+
+	var message string
+	var messages []string
+	switch {
+	case json.Unmarshal(parsed.Message, &message) == nil && message != "":
+		apiErr.Message = message
+	case json.Unmarshal(parsed.Message, &messages) == nil && len(messages) > 0:
+		apiErr.Message = strings.Join(messages, "; ")
+	}
+	apiErr.Code = parsed.Code
+	if apiErr.Code == "" {
+		apiErr.Code = parsed.Error
+	}
+	return apiErr
+}
@@ -0,0 +1,3 @@
+module github.com/blimu-dev/blimu-cli/platform
+
+go 1.25
@@ -0,0 +1,40 @@
+package platform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// IdempotencyKeyHeader is the header that lets the platform recognize a repeated request and
+// answer it with the outcome of the first one instead of acting twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context whose requests carry the Idempotency-Key header. Such
+// requests are retried after network errors and 502/503/504 responses whatever their method,
+// since repeating them has no additional effect.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKey returns the Idempotency-Key of the requests made with ctx, if any
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// IdempotencyKey derives an Idempotency-Key from the content of a request, e.g. its path and
+// body: the same content always gives the same key
+func IdempotencyKey(parts ...interface{}) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		// Values that cannot be encoded still separate the parts around them
+		data, _ := json.Marshal(part)
+		hash.Write(data)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
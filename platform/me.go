@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"context"
@@ -34,7 +34,7 @@ func (s *MeService) GetAccessWithContext(ctx context.Context) (UserAccessDtoOutp
 // GetAccess GET /v1/me/access
 // Get active resources for current user
 //
-// This is a convenience method that calls GetAccessWithContext with context.Background().
+// This is a convenience method that calls GetAccessWithContext with the client's default context (see WithContext).
 func (s *MeService) GetAccess() (UserAccessDtoOutput, error) {
-	return s.GetAccessWithContext(context.Background())
+	return s.GetAccessWithContext(s.client.defaultContext())
 }
@@ -0,0 +1,30 @@
+package platform
+
+import "net/http"
+
+// Middleware wraps the HTTP transport of a client. Every attempt of a request, including
+// retries, passes through it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper, for writing middleware inline
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// wrapHTTPClient returns a copy of httpClient whose transport is wrapped by the middleware, the
+// first one outermost. The original client is left unchanged.
+func wrapHTTPClient(httpClient *http.Client, middleware []Middleware) *http.Client {
+	wrapped := *httpClient
+	transport := wrapped.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	wrapped.Transport = transport
+	return &wrapped
+}
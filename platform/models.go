@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"fmt"
@@ -55,6 +55,25 @@ type DefinitionGenerateSdkResponseDtoOutput struct {
 	Success bool                     `json:"success"`
 }
 
+// DefinitionLockDto
+type DefinitionLockDto struct {
+	Reason string `json:"reason"`
+}
+
+// DefinitionLockDtoOutput
+type DefinitionLockDtoOutput struct {
+	Id       string  `json:"id"`
+	LockedAt string  `json:"lockedAt"`
+	LockedBy string  `json:"lockedBy"`
+	Reason   *string `json:"reason"`
+}
+
+// DefinitionLockStatusDtoOutput
+type DefinitionLockStatusDtoOutput struct {
+	Lock   *DefinitionLockDtoOutput `json:"lock"`
+	Locked bool                     `json:"locked"`
+}
+
 // DefinitionUpdateDto
 type DefinitionUpdateDto struct {
 	Entitlements map[string]interface{} `json:"entitlements"`
@@ -196,6 +215,26 @@ type UserResourceDtoOutput struct {
 
 // Query parameter structs for operations
 
+// DefinitionsUnlockQuery represents query parameters for Definitions.Unlock
+type DefinitionsUnlockQuery struct {
+	LockId *string `json:"lockId"`
+}
+
+// ToValues converts the query struct to url.Values
+func (q *DefinitionsUnlockQuery) ToValues() url.Values {
+	if q == nil {
+		return nil
+	}
+
+	values := make(url.Values)
+	// Handle optional lockId parameter
+	if q.LockId != nil {
+		values.Set("lockId", fmt.Sprintf("%v", *q.LockId))
+	}
+
+	return values
+}
+
 // EnvironmentsListQuery represents query parameters for Environments.List
 type EnvironmentsListQuery struct {
 	EnvironmentId *string `json:"environmentId"`
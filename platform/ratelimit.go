@@ -0,0 +1,74 @@
+package platform
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is the rate limit state reported by the headers of a response
+type RateLimit struct {
+	// Limit is the number of requests allowed per window, or -1 when not reported
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is the time until the window resets, or 0 when not reported
+	Reset time.Duration
+}
+
+// ParseRateLimit reads the rate limit state of a response from the X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset headers, or their RateLimit-* equivalents. Reset
+// may be a number of seconds or a Unix timestamp. ok is false when no remaining count is reported.
+func ParseRateLimit(header http.Header) (limit RateLimit, ok bool) {
+	remaining, ok := headerInt(header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !ok {
+		return RateLimit{}, false
+	}
+
+	limit = RateLimit{Limit: -1, Remaining: remaining}
+	if value, ok := headerInt(header, "X-RateLimit-Limit", "RateLimit-Limit"); ok {
+		limit.Limit = value
+	}
+	if reset, ok := headerInt(header, "X-RateLimit-Reset", "RateLimit-Reset"); ok && reset > 0 {
+		// Values past a year of seconds are Unix timestamps rather than delays
+		if reset > 365*24*60*60 {
+			limit.Reset = max(0, time.Until(time.Unix(int64(reset), 0)))
+		} else {
+			limit.Reset = time.Duration(reset) * time.Second
+		}
+	}
+	return limit, true
+}
+
+// RetryAfter returns the wait requested by the Retry-After header of a response, given as a
+// number of seconds or an HTTP date. ok is false when the header is absent or malformed.
+func RetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(0, time.Duration(seconds)*time.Second), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, date.Sub(now)), true
+	}
+	return 0, false
+}
+
+// headerInt returns the integer value of the first of the headers that is set
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+		// RateLimit-* values may carry parameters, e.g. "100;w=60"
+		value, _, _ = strings.Cut(value, ";")
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
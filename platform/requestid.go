@@ -0,0 +1,34 @@
+package platform
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// RequestIDHeader is the header identifying a request in the platform logs. The client sends a
+// generated ID with every request unless one is set with WithHeaders.
+const RequestIDHeader = "X-Request-Id"
+
+// newRequestID returns a random UUID (version 4)
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// withHeader returns a copy of headers with the header set
+func withHeader(headers map[string]string, name, value string) map[string]string {
+	if value == "" {
+		return headers
+	}
+	merged := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		merged[k] = v
+	}
+	merged[name] = value
+	return merged
+}
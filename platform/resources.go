@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"context"
@@ -40,9 +40,9 @@ func (s *ResourcesService) ListWithContext(ctx context.Context, workspaceId stri
 // List GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources
 // List resources for an environment
 //
-// This is a convenience method that calls ListWithContext with context.Background().
+// This is a convenience method that calls ListWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) List(workspaceId string, environmentId string, query *ResourcesListQuery) (ResourceListResponseDtoOutput, error) {
-	return s.ListWithContext(context.Background(), workspaceId, environmentId, query)
+	return s.ListWithContext(s.client.defaultContext(), workspaceId, environmentId, query)
 }
 
 // CreateWithContext POST /v1/workspaces/{workspaceId}/environments/{environmentId}/resources
@@ -70,9 +70,9 @@ func (s *ResourcesService) CreateWithContext(ctx context.Context, workspaceId st
 // Create POST /v1/workspaces/{workspaceId}/environments/{environmentId}/resources
 // Create a new resource
 //
-// This is a convenience method that calls CreateWithContext with context.Background().
+// This is a convenience method that calls CreateWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) Create(workspaceId string, environmentId string, body ResourceCreateDto) (ResourceDtoOutput, error) {
-	return s.CreateWithContext(context.Background(), workspaceId, environmentId, body)
+	return s.CreateWithContext(s.client.defaultContext(), workspaceId, environmentId, body)
 }
 
 // DeleteWithContext DELETE /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}
@@ -98,9 +98,9 @@ func (s *ResourcesService) DeleteWithContext(ctx context.Context, workspaceId st
 // Delete DELETE /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}
 // Delete a resource
 //
-// This is a convenience method that calls DeleteWithContext with context.Background().
+// This is a convenience method that calls DeleteWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) Delete(workspaceId string, environmentId string, resourceType string, resourceId string) (interface{}, error) {
-	return s.DeleteWithContext(context.Background(), workspaceId, environmentId, resourceType, resourceId)
+	return s.DeleteWithContext(s.client.defaultContext(), workspaceId, environmentId, resourceType, resourceId)
 }
 
 // GetWithContext GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}
@@ -128,9 +128,9 @@ func (s *ResourcesService) GetWithContext(ctx context.Context, workspaceId strin
 // Get GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}
 // Get a specific resource
 //
-// This is a convenience method that calls GetWithContext with context.Background().
+// This is a convenience method that calls GetWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) Get(workspaceId string, environmentId string, resourceType string, resourceId string) (ResourceDtoOutput, error) {
-	return s.GetWithContext(context.Background(), workspaceId, environmentId, resourceType, resourceId)
+	return s.GetWithContext(s.client.defaultContext(), workspaceId, environmentId, resourceType, resourceId)
 }
 
 // UpdateWithContext PUT /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}
@@ -158,9 +158,9 @@ func (s *ResourcesService) UpdateWithContext(ctx context.Context, workspaceId st
 // Update PUT /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}
 // Update a resource
 //
-// This is a convenience method that calls UpdateWithContext with context.Background().
+// This is a convenience method that calls UpdateWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) Update(workspaceId string, environmentId string, resourceType string, resourceId string, body ResourceUpdateDto) (ResourceDtoOutput, error) {
-	return s.UpdateWithContext(context.Background(), workspaceId, environmentId, resourceType, resourceId, body)
+	return s.UpdateWithContext(s.client.defaultContext(), workspaceId, environmentId, resourceType, resourceId, body)
 }
 
 // ListChildrenWithContext GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}/children
@@ -192,9 +192,9 @@ func (s *ResourcesService) ListChildrenWithContext(ctx context.Context, workspac
 // ListChildren GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}/children
 // List children resources for a specific resource
 //
-// This is a convenience method that calls ListChildrenWithContext with context.Background().
+// This is a convenience method that calls ListChildrenWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) ListChildren(workspaceId string, environmentId string, resourceType string, resourceId string, query *ResourcesListChildrenQuery) (ResourceListResponseDtoOutput, error) {
-	return s.ListChildrenWithContext(context.Background(), workspaceId, environmentId, resourceType, resourceId, query)
+	return s.ListChildrenWithContext(s.client.defaultContext(), workspaceId, environmentId, resourceType, resourceId, query)
 }
 
 // GetResourceUsersWithContext GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}/users
@@ -226,7 +226,7 @@ func (s *ResourcesService) GetResourceUsersWithContext(ctx context.Context, work
 // GetResourceUsers GET /v1/workspaces/{workspaceId}/environments/{environmentId}/resources/{resourceType}/{resourceId}/users
 // Get users with roles on a resource
 //
-// This is a convenience method that calls GetResourceUsersWithContext with context.Background().
+// This is a convenience method that calls GetResourceUsersWithContext with the client's default context (see WithContext).
 func (s *ResourcesService) GetResourceUsers(workspaceId string, environmentId string, resourceType string, resourceId string, query *ResourcesGetResourceUsersQuery) (ResourceUserListResponseDtoOutput, error) {
-	return s.GetResourceUsersWithContext(context.Background(), workspaceId, environmentId, resourceType, resourceId, query)
+	return s.GetResourceUsersWithContext(s.client.defaultContext(), workspaceId, environmentId, resourceType, resourceId, query)
 }
@@ -0,0 +1,122 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried. Network errors and 502, 503 and 504
+// responses are retried for idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) and requests
+// with an Idempotency-Key (see WithIdempotencyKey); 429 responses, which the server did not act
+// on, are retried for every method. A Retry-After header (or, for
+// 429 responses, an exhausted rate limit) makes the retry wait until the server is ready.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first; 1 or less disables retries
+	MaxAttempts int
+	// RateLimitAttempts is the number of attempts of rate limited (429) requests, when higher
+	// than MaxAttempts, so long operations pause and resume instead of failing
+	RateLimitAttempts int
+	// MinBackoff is the wait before the first retry; it doubles with every retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest wait a Retry-After header is honored for; responses asking
+	// to wait longer are returned instead. Zero honors any wait.
+	MaxRetryAfter time.Duration
+	// OnRetry, if set, is called before waiting for the next attempt, e.g. to report rate
+	// limiting. resp is nil when the attempt failed with err.
+	OnRetry func(attempt int, wait time.Duration, resp *http.Response, err error)
+}
+
+// DefaultRetryPolicy makes up to three attempts, waiting 250ms and then 500ms (with jitter), and
+// up to ten attempts of rate limited requests, honoring Retry-After waits of up to a minute
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       3,
+	RateLimitAttempts: 10,
+	MinBackoff:        250 * time.Millisecond,
+	MaxBackoff:        5 * time.Second,
+	MaxRetryAfter:     time.Minute,
+}
+
+// next reports whether an attempt's outcome is worth another attempt, and how long to wait
+// before it. A nil policy never retries.
+func (p *RetryPolicy) next(ctx context.Context, method string, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if !p.shouldRetry(ctx, method, attempt, resp, err) {
+		return 0, false
+	}
+
+	wait := p.backoff(attempt)
+	if resp == nil {
+		return wait, true
+	}
+	if retryAfter, ok := RetryAfter(resp.Header, time.Now()); ok {
+		if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+			return 0, false
+		}
+		return max(wait, retryAfter), true
+	}
+	if limit, ok := ParseRateLimit(resp.Header); ok && resp.StatusCode == http.StatusTooManyRequests && limit.Remaining == 0 {
+		if p.MaxRetryAfter > 0 && limit.Reset > p.MaxRetryAfter {
+			return 0, false
+		}
+		return max(wait, limit.Reset), true
+	}
+	return wait, true
+}
+
+// shouldRetry reports whether an attempt's outcome is worth another attempt
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) bool {
+	if p == nil || ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return attempt < p.MaxAttempts && idempotent(ctx, method) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return attempt < max(p.MaxAttempts, p.RateLimitAttempts)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return attempt < p.MaxAttempts && idempotent(ctx, method)
+	}
+	return false
+}
+
+// backoff returns the wait before the attempt after the given one, with up to 20% jitter
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinBackoff << (attempt - 1)
+	if wait <= 0 || (p.MaxBackoff > 0 && wait > p.MaxBackoff) {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait - time.Duration(rand.Int64N(int64(wait)/5+1))
+}
+
+// idempotent reports whether repeating a request with the method, or with an Idempotency-Key,
+// has no additional effect
+func idempotent(ctx context.Context, method string) bool {
+	if idempotencyKey(ctx) != "" {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// sleep waits for d or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
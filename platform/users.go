@@ -1,4 +1,4 @@
-package blimu_platform
+package platform
 
 import (
 	"context"
@@ -40,9 +40,9 @@ func (s *UsersService) ListWithContext(ctx context.Context, workspaceId string,
 // List GET /v1/workspaces/{workspaceId}/environments/{environmentId}/users
 // List users for an environment
 //
-// This is a convenience method that calls ListWithContext with context.Background().
+// This is a convenience method that calls ListWithContext with the client's default context (see WithContext).
 func (s *UsersService) List(workspaceId string, environmentId string, query *UsersListQuery) (UserListResponseDtoOutput, error) {
-	return s.ListWithContext(context.Background(), workspaceId, environmentId, query)
+	return s.ListWithContext(s.client.defaultContext(), workspaceId, environmentId, query)
 }
 
 // GetWithContext GET /v1/workspaces/{workspaceId}/environments/{environmentId}/users/{userId}
@@ -70,9 +70,9 @@ func (s *UsersService) GetWithContext(ctx context.Context, workspaceId string, e
 // Get GET /v1/workspaces/{workspaceId}/environments/{environmentId}/users/{userId}
 // Get user details by ID
 //
-// This is a convenience method that calls GetWithContext with context.Background().
+// This is a convenience method that calls GetWithContext with the client's default context (see WithContext).
 func (s *UsersService) Get(workspaceId string, environmentId string, userId string) (UserDtoOutput, error) {
-	return s.GetWithContext(context.Background(), workspaceId, environmentId, userId)
+	return s.GetWithContext(s.client.defaultContext(), workspaceId, environmentId, userId)
 }
 
 // GetUserResourcesWithContext GET /v1/workspaces/{workspaceId}/environments/{environmentId}/users/{userId}/resources
@@ -100,7 +100,7 @@ func (s *UsersService) GetUserResourcesWithContext(ctx context.Context, workspac
 // GetUserResources GET /v1/workspaces/{workspaceId}/environments/{environmentId}/users/{userId}/resources
 // Get user resource relationships
 //
-// This is a convenience method that calls GetUserResourcesWithContext with context.Background().
+// This is a convenience method that calls GetUserResourcesWithContext with the client's default context (see WithContext).
 func (s *UsersService) GetUserResources(workspaceId string, environmentId string, userId string) ([]UserResourceDtoOutput, error) {
-	return s.GetUserResourcesWithContext(context.Background(), workspaceId, environmentId, userId)
+	return s.GetUserResourcesWithContext(s.client.defaultContext(), workspaceId, environmentId, userId)
 }